@@ -103,6 +103,9 @@ class Web {
   ListObjects(args) {
     return this.makeCall('ListObjects', args)
   }
+  GetObjectPreview(args) {
+    return this.makeCall('GetObjectPreview', args)
+  }
   PresignedGet(args) {
     return this.makeCall('PresignedGet', args)
   }
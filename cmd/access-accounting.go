@@ -0,0 +1,284 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Prefix under which every node saves its accounting info.
+	accessAccountingPrefix = minioConfigPrefix + "/accounting"
+
+	// Interval at which the accounting info is persisted.
+	accessAccountingSaveInterval = 5 * time.Minute
+)
+
+// accessAccountingInfo is the accounting info persisted by each node.
+type accessAccountingInfo struct {
+	// Last time each access key successfully authenticated a request.
+	LastUsed map[string]time.Time `json:"lastUsed"`
+	// Number of anonymous requests allowed per bucket.
+	Anonymous map[string]uint64 `json:"anonymous"`
+}
+
+// merge merges o into a, keeping the most recent
+// timestamps and summing the anonymous counters.
+func (a *accessAccountingInfo) merge(o accessAccountingInfo) {
+	if a.LastUsed == nil {
+		a.LastUsed = make(map[string]time.Time, len(o.LastUsed))
+	}
+	if a.Anonymous == nil {
+		a.Anonymous = make(map[string]uint64, len(o.Anonymous))
+	}
+	for accessKey, t := range o.LastUsed {
+		if t.After(a.LastUsed[accessKey]) {
+			a.LastUsed[accessKey] = t
+		}
+	}
+	for bucket, count := range o.Anonymous {
+		a.Anonymous[bucket] += count
+	}
+}
+
+// accessAccounting tracks per access key last used time and
+// per bucket anonymous request counters on this node.
+type accessAccounting struct {
+	mu   sync.Mutex
+	info accessAccountingInfo
+
+	// Set once the previously persisted info is merged into info.
+	loaded bool
+}
+
+func newAccessAccounting() *accessAccounting {
+	return &accessAccounting{
+		info: accessAccountingInfo{
+			LastUsed:  make(map[string]time.Time),
+			Anonymous: make(map[string]uint64),
+		},
+	}
+}
+
+// recordAccessKey records a successfully authenticated request by accessKey.
+func (a *accessAccounting) recordAccessKey(accessKey string) {
+	if a == nil || accessKey == "" {
+		return
+	}
+	now := UTCNow()
+	a.mu.Lock()
+	a.info.LastUsed[accessKey] = now
+	a.mu.Unlock()
+}
+
+// recordAnonymous records an anonymous request allowed on bucket.
+func (a *accessAccounting) recordAnonymous(bucket string) {
+	if a == nil || bucket == "" {
+		return
+	}
+	a.mu.Lock()
+	a.info.Anonymous[bucket]++
+	a.mu.Unlock()
+}
+
+// forget drops the last used time of deleted credentials.
+func (a *accessAccounting) forget(accessKeys ...string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	for _, accessKey := range accessKeys {
+		delete(a.info.LastUsed, accessKey)
+	}
+	a.mu.Unlock()
+}
+
+// prune drops the last used time of every access key for which
+// exists returns false, such as rotated or expired credentials.
+// Nothing is dropped if exists cannot tell for one of the keys.
+func (a *accessAccounting) prune(exists func(accessKey string) (exists, ok bool)) {
+	// exists is called without holding the lock, since
+	// credentials are forgotten with the IAM lock held.
+	lastUsed := a.snapshot().LastUsed
+	stale := make(map[string]time.Time)
+	for accessKey, t := range lastUsed {
+		found, ok := exists(accessKey)
+		if !ok {
+			return
+		}
+		if !found {
+			stale[accessKey] = t
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for accessKey, t := range stale {
+		// Keep keys which were used again in the meantime.
+		if a.info.LastUsed[accessKey].Equal(t) {
+			delete(a.info.LastUsed, accessKey)
+		}
+	}
+}
+
+// load merges previously persisted accounting info into memory.
+func (a *accessAccounting) load(o accessAccountingInfo) {
+	a.mu.Lock()
+	a.info.merge(o)
+	a.loaded = true
+	a.mu.Unlock()
+}
+
+func (a *accessAccounting) isLoaded() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.loaded
+}
+
+// snapshot returns a copy of the current accounting info.
+func (a *accessAccounting) snapshot() accessAccountingInfo {
+	var info accessAccountingInfo
+	a.mu.Lock()
+	info.merge(a.info)
+	a.mu.Unlock()
+	return info
+}
+
+// accessAccountingPath returns the path of the accounting
+// info of this node in minioMetaBucket.
+func accessAccountingPath() string {
+	return pathJoin(accessAccountingPrefix, getSHA256Hash([]byte(GetLocalPeer(globalEndpoints)))+".json")
+}
+
+func loadAccessAccounting(ctx context.Context, objAPI ObjectLayer, configFile string) (accessAccountingInfo, error) {
+	var info accessAccountingInfo
+	data, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		return info, err
+	}
+	if err = json.Unmarshal(data, &info); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+func (a *accessAccounting) save(ctx context.Context, objAPI ObjectLayer) error {
+	data, err := json.Marshal(a.snapshot())
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, accessAccountingPath(), data)
+}
+
+// initAccessAccounting starts persisting the accounting info of this node.
+func initAccessAccounting(ctx context.Context, objAPI ObjectLayer) {
+	go globalAccessAccounting.run(ctx, objAPI)
+}
+
+// run periodically persists the accounting info of this node,
+// the function blocks until the context is canceled.
+func (a *accessAccounting) run(ctx context.Context, objAPI ObjectLayer) {
+	ticker := time.NewTicker(accessAccountingSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Previously saved info must be loaded before the
+			// first save, otherwise it would be overwritten.
+			if !a.isLoaded() {
+				info, err := loadAccessAccounting(ctx, objAPI, accessAccountingPath())
+				if err != nil && err != errConfigNotFound {
+					logger.LogIf(ctx, err)
+					continue
+				}
+				a.load(info)
+			}
+			a.prune(func(accessKey string) (bool, bool) {
+				if accessKey == globalActiveCred.AccessKey {
+					return true, true
+				}
+				return globalIAMSys.accessKeyExists(accessKey)
+			})
+			logger.LogIf(ctx, a.save(ctx, objAPI))
+		}
+	}
+}
+
+// getAccessAccountingInfo returns the accounting info of all the
+// nodes in the cluster, along with the unsaved info of this node.
+func getAccessAccountingInfo(ctx context.Context, objAPI ObjectLayer) (madmin.AccountingInfo, error) {
+	localPath := accessAccountingPath()
+	localLoaded := globalAccessAccounting.isLoaded()
+
+	var info accessAccountingInfo
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, accessAccountingPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return madmin.AccountingInfo{}, err
+		}
+		for _, obj := range res.Objects {
+			// Local node info is merged from memory below,
+			// once it was loaded.
+			if obj.Name == localPath && localLoaded {
+				continue
+			}
+			nodeInfo, err := loadAccessAccounting(ctx, objAPI, obj.Name)
+			if err != nil {
+				if err == errConfigNotFound {
+					continue
+				}
+				return madmin.AccountingInfo{}, err
+			}
+			info.merge(nodeInfo)
+		}
+		if !res.IsTruncated {
+			break
+		}
+		marker = res.NextMarker
+	}
+	info.merge(globalAccessAccounting.snapshot())
+
+	// Report credentials which were never used as well.
+	accessKeys := map[string]time.Time{
+		globalActiveCred.AccessKey: {},
+	}
+	users, err := globalIAMSys.ListUsers()
+	if err != nil {
+		return madmin.AccountingInfo{}, err
+	}
+	for accessKey := range users {
+		accessKeys[accessKey] = time.Time{}
+	}
+	for accessKey, t := range info.LastUsed {
+		accessKeys[accessKey] = t
+	}
+
+	return madmin.AccountingInfo{
+		AccessKeys:        accessKeys,
+		AnonymousRequests: info.Anonymous,
+	}, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccessAccounting(t *testing.T) {
+	a := newAccessAccounting()
+	a.recordAccessKey("user1")
+	a.recordAccessKey("")
+	a.recordAnonymous("bucket")
+	a.recordAnonymous("bucket")
+	a.recordAnonymous("")
+
+	old := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	a.load(accessAccountingInfo{
+		LastUsed:  map[string]time.Time{"user1": old, "user2": old},
+		Anonymous: map[string]uint64{"bucket": 3, "public": 1},
+	})
+	if !a.isLoaded() {
+		t.Fatal("expected accounting to be loaded")
+	}
+
+	info := a.snapshot()
+	if len(info.LastUsed) != 2 {
+		t.Fatalf("expected 2 access keys, got %d", len(info.LastUsed))
+	}
+	if !info.LastUsed["user1"].After(old) {
+		t.Errorf("expected most recent time to be kept for user1, got %v", info.LastUsed["user1"])
+	}
+	if !info.LastUsed["user2"].Equal(old) {
+		t.Errorf("expected %v for user2, got %v", old, info.LastUsed["user2"])
+	}
+	if info.Anonymous["bucket"] != 5 || info.Anonymous["public"] != 1 {
+		t.Errorf("unexpected anonymous counters %v", info.Anonymous)
+	}
+
+	// Snapshot must not share state with the accounting.
+	info.Anonymous["bucket"] = 0
+	if a.snapshot().Anonymous["bucket"] != 5 {
+		t.Error("snapshot modification must not change the accounting")
+	}
+
+	// Deleted credentials are forgotten.
+	a.forget("user2")
+	if _, ok := a.snapshot().LastUsed["user2"]; ok {
+		t.Error("expected user2 to be forgotten")
+	}
+
+	// Nothing is pruned while existence is unknown.
+	a.recordAccessKey("user3")
+	a.prune(func(accessKey string) (bool, bool) {
+		return accessKey == "user1", accessKey == "user1"
+	})
+	if len(a.snapshot().LastUsed) != 2 {
+		t.Errorf("expected no access key to be pruned, got %v", a.snapshot().LastUsed)
+	}
+	a.prune(func(accessKey string) (bool, bool) {
+		return accessKey == "user1", true
+	})
+	if info := a.snapshot(); len(info.LastUsed) != 1 || info.LastUsed["user1"].IsZero() {
+		t.Errorf("expected only user1 to be kept, got %v", info.LastUsed)
+	}
+
+	var nilAccounting *accessAccounting
+	nilAccounting.recordAccessKey("user1")
+	nilAccounting.recordAnonymous("bucket")
+	nilAccounting.forget("user1")
+}
@@ -0,0 +1,124 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/acme"
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// ACME account key, saved in the certs directory.
+	acmeAccountKeyFile = "acme-account.key"
+
+	// Interval at which the certificate is checked for renewal.
+	acmeRenewCheckInterval = 12 * time.Hour
+)
+
+// initACME obtains the server certificate from the ACME CA, if ACME
+// is enabled and the certificate is missing or about to expire, and
+// keeps renewing it in the background. Renewed certificates are
+// reloaded by the certificate manager without restarting the server.
+func initACME(ctx context.Context) error {
+	cfg, err := acme.LookupConfig()
+	if err != nil || !cfg.Enabled {
+		return err
+	}
+	client := acme.NewClient(cfg, filepath.Join(globalCertsDir.Get(), acmeAccountKeyFile), NewGatewayHTTPTransport())
+	if acmeNeedsRenewal(cfg) {
+		if err = acmeObtainCertificate(ctx, client); err != nil {
+			return err
+		}
+	}
+	go acmeRenewCertificate(ctx, cfg, client)
+	return nil
+}
+
+// acmeNeedsRenewal returns true if the server certificate is missing,
+// does not cover all the configured domains or expires soon.
+func acmeNeedsRenewal(cfg acme.Config) bool {
+	certs, err := config.ParsePublicCertFile(getPublicCertFile())
+	if err != nil || len(certs) == 0 {
+		return true
+	}
+	leaf := certs[0]
+	for _, domain := range cfg.Domains {
+		if leaf.VerifyHostname(domain) != nil {
+			return true
+		}
+	}
+	return UTCNow().Add(cfg.RenewBefore).After(leaf.NotAfter)
+}
+
+// acmeObtainCertificate obtains a new server certificate and
+// replaces the current private key and certificate files.
+func acmeObtainCertificate(ctx context.Context, client *acme.Client) error {
+	certPEM, keyPEM, err := client.Obtain(ctx)
+	if err != nil {
+		return err
+	}
+	// The private key is replaced first, the certificate manager
+	// keeps serving the old pair until the certificate matches.
+	if err = writeFileAtomic(getPrivateKeyFile(), keyPEM, 0600); err != nil {
+		return err
+	}
+	return writeFileAtomic(getPublicCertFile(), certPEM, 0644)
+}
+
+// acmeRenewCertificate periodically renews the server certificate,
+// the function blocks until the context is canceled.
+func acmeRenewCertificate(ctx context.Context, cfg acme.Config, client *acme.Client) {
+	ticker := time.NewTicker(acmeRenewCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !acmeNeedsRenewal(cfg) {
+				continue
+			}
+			err := acmeObtainCertificate(ctx, client)
+			if err == nil {
+				logger.Info("Renewed the TLS certificate for %v", cfg.Domains)
+			}
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
+// writeFileAtomic writes data to a temporary file next to
+// filename, which then replaces filename.
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmpFile := filename + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, filename); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	return nil
+}
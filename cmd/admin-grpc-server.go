@@ -0,0 +1,633 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/handlers"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/madmin/adminpb"
+	"github.com/minio/minio/pkg/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// errAdminGRPCWithoutTLS - the gRPC admin API carries configs and
+// secret keys in plain text, unlike the HTTP admin API which encrypts
+// them, it is only served over TLS.
+var errAdminGRPCWithoutTLS = errors.New("the admin gRPC API requires TLS, configure TLS certificates or unset " + config.EnvAdminGRPCAddr)
+
+// adminGRPCServer implements the admin API over gRPC, it shares the
+// authorization and the business logic with the HTTP admin API.
+type adminGRPCServer struct{}
+
+// newAdminGRPCServer returns a gRPC server serving the admin API over
+// TLS with the certificates returned by getCert.
+func newAdminGRPCServer(getCert func(*tls.ClientHelloInfo) (*tls.Certificate, error)) *grpc.Server {
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		GetCertificate: getCert,
+		MinVersion:     tls.VersionTLS12,
+	})))
+	adminpb.RegisterAdminServer(srv, adminGRPCServer{})
+	return srv
+}
+
+// startAdminGRPCServer serves the admin API over gRPC on addr until
+// ctx is canceled.
+func startAdminGRPCServer(ctx context.Context, addr string) error {
+	if globalTLSCerts == nil {
+		return errAdminGRPCWithoutTLS
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := newAdminGRPCServer(globalTLSCerts.GetCertificate)
+	go func() {
+		<-ctx.Done()
+		srv.Stop()
+	}()
+	go func() {
+		if err := srv.Serve(l); err != nil {
+			logger.LogIf(ctx, err)
+		}
+	}()
+	return nil
+}
+
+// newAdminGRPCRequest describes a gRPC call as an HTTP request, such
+// that the helpers of the HTTP admin API can be used for the call.
+func newAdminGRPCRequest(ctx context.Context, api string) *http.Request {
+	r := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: api},
+		Header: make(http.Header),
+		Host:   globalMinioAddr,
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, v := range md {
+		switch {
+		case k == ":authority":
+			r.Host = v[0]
+		case k == "authorization", strings.HasPrefix(k, ":"):
+		default:
+			r.Header[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		r.RemoteAddr = p.Addr.String()
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			r.TLS = &info.State
+		}
+	}
+	return r
+}
+
+// adminGRPCResponse is the response of a gRPC call as seen by the
+// audit log, nothing is written to it.
+type adminGRPCResponse struct {
+	header http.Header
+}
+
+func (w adminGRPCResponse) Header() http.Header         { return w.header }
+func (w adminGRPCResponse) Write(p []byte) (int, error) { return len(p), nil }
+func (w adminGRPCResponse) WriteHeader(statusCode int)  {}
+
+// newAdminGRPCContext returns the context of a gRPC call along with
+// the call described as an HTTP request, and the response writer
+// recording the outcome of the call for the audit log.
+func newAdminGRPCContext(ctx context.Context, api string) (context.Context, *http.Request, *logger.ResponseWriter) {
+	r := newAdminGRPCRequest(ctx, api)
+	reqInfo := &logger.ReqInfo{
+		DeploymentID: globalDeploymentID,
+		RemoteHost:   handlers.GetSourceIP(r),
+		Host:         getHostName(r),
+		UserAgent:    r.UserAgent(),
+		API:          api,
+	}
+	w := logger.NewResponseWriter(adminGRPCResponse{header: make(http.Header)})
+	return logger.SetReqInfo(ctx, reqInfo), r, w
+}
+
+// auditAdminGRPC logs a gRPC call to the audit targets once the call
+// returned the error err points to, same as the HTTP admin API does.
+func auditAdminGRPC(ctx context.Context, w *logger.ResponseWriter, r *http.Request, err *error) {
+	w.StatusCode = adminGRPCHTTPStatus(*err)
+	logger.AuditLog(ctx, w, r, r.URL.Path, nil)
+}
+
+// toAdminGRPCErr converts the error of an admin call to a gRPC status.
+func toAdminGRPCErr(ctx context.Context, err error) error {
+	return toAdminGRPCStatus(toAdminAPIErr(ctx, err))
+}
+
+// toAdminGRPCStatus converts an admin API error to a gRPC status.
+func toAdminGRPCStatus(apiErr APIError) error {
+	code := codes.Internal
+	switch apiErr.HTTPStatusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		code = codes.FailedPrecondition
+	case http.StatusNotImplemented:
+		code = codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	return status.Errorf(code, "%s: %s", apiErr.Code, apiErr.Description)
+}
+
+// adminGRPCHTTPStatus returns the HTTP status of the admin API error
+// converted to the gRPC status err by toAdminGRPCStatus.
+func adminGRPCHTTPStatus(err error) int {
+	switch status.Code(err) {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Canceled:
+		return 499
+	}
+	return http.StatusInternalServerError
+}
+
+// authenticate validates the JWT bearer token of a gRPC call and, when
+// action is set, that the caller is allowed to perform the action.
+func (s adminGRPCServer) authenticate(ctx context.Context, r *http.Request, action iampolicy.AdminAction) (cred auth.Credentials, owner bool, err error) {
+	if newObjectLayerFn() == nil || globalNotificationSys == nil {
+		return cred, false, toAdminGRPCStatus(errorCodes.ToAPIErr(ErrServerNotInitialized))
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return cred, false, status.Error(codes.Unauthenticated, errNoAuthToken.Error())
+	}
+	claims, owner, err := webTokenAuthenticate(strings.TrimPrefix(values[0], "Bearer "))
+	if err != nil {
+		return cred, false, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if owner {
+		cred = globalActiveCred
+	} else {
+		var ok bool
+		if cred, ok = globalIAMSys.GetUser(claims.AccessKey); !ok {
+			return cred, false, status.Error(codes.Unauthenticated, errInvalidAccessKeyID.Error())
+		}
+	}
+
+	reqInfo := logger.GetReqInfo(ctx)
+	reqInfo.AccessKey = cred.AccessKey
+	reqInfo.ParentUser = cred.ParentUser
+
+	if action != "" && !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Action:          iampolicy.Action(action),
+		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims.Map()),
+		IsOwner:         owner,
+		Claims:          claims.Map(),
+	}) {
+		return cred, owner, toAdminGRPCStatus(errorCodes.ToAPIErr(ErrAccessDenied))
+	}
+	return cred, owner, nil
+}
+
+// ServerInfo - returns the information about the deployment.
+func (s adminGRPCServer) ServerInfo(ctx context.Context, req *adminpb.ServerInfoRequest) (_ *adminpb.ServerInfoResponse, err error) {
+	ctx, r, w := newAdminGRPCContext(ctx, "ServerInfo")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	if _, _, err := s.authenticate(ctx, r, iampolicy.ServerInfoAdminAction); err != nil {
+		return nil, err
+	}
+
+	info := getServerInfo(ctx, r)
+	resp := &adminpb.ServerInfoResponse{
+		Mode:         string(info.Mode),
+		Domain:       info.Domain,
+		Region:       info.Region,
+		DeploymentId: info.DeploymentID,
+		BucketsCount: info.Buckets.Count,
+		ObjectsCount: info.Objects.Count,
+		UsageSize:    info.Usage.Size,
+	}
+	switch backend := info.Backend.(type) {
+	case madmin.ErasureBackend:
+		resp.Backend = &adminpb.Backend{
+			Type:             string(backend.Type),
+			OnlineDisks:      int64(backend.OnlineDisks),
+			OfflineDisks:     int64(backend.OfflineDisks),
+			StandardScData:   int64(backend.StandardSCData),
+			StandardScParity: int64(backend.StandardSCParity),
+			RrscData:         int64(backend.RRSCData),
+			RrscParity:       int64(backend.RRSCParity),
+		}
+	case madmin.FSBackend:
+		resp.Backend = &adminpb.Backend{Type: string(backend.Type)}
+	}
+	for _, server := range info.Servers {
+		props := &adminpb.ServerProperties{
+			State:    server.State,
+			Endpoint: server.Endpoint,
+			Uptime:   server.Uptime,
+			Version:  server.Version,
+			CommitId: server.CommitID,
+			Network:  server.Network,
+		}
+		for _, disk := range server.Disks {
+			props.Drives = append(props.Drives, &adminpb.Disk{
+				Endpoint:       disk.Endpoint,
+				RootDisk:       disk.RootDisk,
+				Path:           disk.DrivePath,
+				Healing:        disk.Healing,
+				State:          disk.State,
+				Uuid:           disk.UUID,
+				Model:          disk.Model,
+				TotalSpace:     disk.TotalSpace,
+				UsedSpace:      disk.UsedSpace,
+				AvailableSpace: disk.AvailableSpace,
+			})
+		}
+		resp.Servers = append(resp.Servers, props)
+	}
+	return resp, nil
+}
+
+// GetConfigKV - returns the configuration of a sub-system.
+func (s adminGRPCServer) GetConfigKV(ctx context.Context, req *adminpb.GetConfigKVRequest) (_ *adminpb.GetConfigKVResponse, err error) {
+	ctx, r, w := newAdminGRPCContext(ctx, "GetConfigKV")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	if _, _, err := s.authenticate(ctx, r, iampolicy.ConfigUpdateAdminAction); err != nil {
+		return nil, err
+	}
+
+	var buf = &bytes.Buffer{}
+	cw := config.NewConfigWriteTo(globalServerConfig, req.Key)
+	if _, err := cw.WriteTo(buf); err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	return &adminpb.GetConfigKVResponse{Config: buf.String()}, nil
+}
+
+// SetConfigKV - applies the configuration of a sub-system.
+func (s adminGRPCServer) SetConfigKV(ctx context.Context, req *adminpb.SetConfigKVRequest) (_ *adminpb.SetConfigKVResponse, err error) {
+	ctx, r, w := newAdminGRPCContext(ctx, "SetConfigKV")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	if _, _, err := s.authenticate(ctx, r, iampolicy.ConfigUpdateAdminAction); err != nil {
+		return nil, err
+	}
+
+	if len(req.Config) > maxEConfigJSONSize {
+		return nil, toAdminGRPCStatus(errorCodes.ToAPIErr(ErrAdminConfigTooLarge))
+	}
+
+	objectAPI := newObjectLayerFn()
+	kvBytes := []byte(req.Config)
+	cfg, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	dynamic, err := cfg.ReadConfig(bytes.NewReader(kvBytes))
+	if err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	if err = validateConfig(cfg, objectAPI.SetDriveCount()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Same as SetConfigKVHandler, keep the staged config and the
+	// config history consistent with the new server config.
+	if err = saveServerConfig(ctx, objectAPI, cfg); err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	if err = updateServerConfigStaged(ctx, objectAPI, kvBytes); err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	if err = saveServerConfigHistory(ctx, objectAPI, kvBytes); err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	if globalConfigEncrypted {
+		saveConfig(GlobalContext, objectAPI, backendEncryptedFile, backendEncryptedMigrationComplete)
+	}
+	if err = applyDynamicConfig(GlobalContext, cfg); err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	globalNotificationSys.SignalService(serviceReloadDynamic)
+
+	return &adminpb.SetConfigKVResponse{Dynamic: dynamic}, nil
+}
+
+// serviceAccountParent returns the user owning the service accounts of
+// the caller, the root user is not eligible for service accounts.
+func (s adminGRPCServer) serviceAccountParent(ctx context.Context, r *http.Request) (string, error) {
+	cred, owner, err := s.authenticate(ctx, r, "")
+	if err != nil {
+		return "", err
+	}
+	if owner {
+		return "", toAdminGRPCStatus(errorCodes.ToAPIErr(ErrAdminAccountNotEligible))
+	}
+	if cred.ParentUser != "" {
+		return cred.ParentUser, nil
+	}
+	return cred.AccessKey, nil
+}
+
+// AddServiceAccount - creates a service account for the caller.
+func (s adminGRPCServer) AddServiceAccount(ctx context.Context, req *adminpb.AddServiceAccountRequest) (_ *adminpb.AddServiceAccountResponse, err error) {
+	ctx, r, w := newAdminGRPCContext(ctx, "AddServiceAccount")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	parentUser, err := s.serviceAccountParent(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *iampolicy.Policy
+	if len(req.Policy) > 0 {
+		if policy, err = iampolicy.ParseConfig(bytes.NewReader(req.Policy)); err != nil {
+			return nil, toAdminGRPCErr(ctx, err)
+		}
+	}
+
+	newCred, err := globalIAMSys.NewServiceAccount(ctx, parentUser, policy)
+	if err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+
+	// Notify all other Minio peers to reload user the service account
+	for _, nerr := range globalNotificationSys.LoadServiceAccount(newCred.AccessKey) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	return &adminpb.AddServiceAccountResponse{
+		AccessKey: newCred.AccessKey,
+		SecretKey: newCred.SecretKey,
+	}, nil
+}
+
+// ListServiceAccounts - lists the service accounts of the caller.
+func (s adminGRPCServer) ListServiceAccounts(ctx context.Context, req *adminpb.ListServiceAccountsRequest) (_ *adminpb.ListServiceAccountsResponse, err error) {
+	ctx, r, w := newAdminGRPCContext(ctx, "ListServiceAccounts")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	parentUser, err := s.serviceAccountParent(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts, err := globalIAMSys.ListServiceAccounts(ctx, parentUser)
+	if err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	return &adminpb.ListServiceAccountsResponse{Accounts: serviceAccounts}, nil
+}
+
+// DeleteServiceAccount - removes a service account of the caller.
+func (s adminGRPCServer) DeleteServiceAccount(ctx context.Context, req *adminpb.DeleteServiceAccountRequest) (_ *adminpb.DeleteServiceAccountResponse, err error) {
+	ctx, r, w := newAdminGRPCContext(ctx, "DeleteServiceAccount")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	parentUser, err := s.serviceAccountParent(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AccessKey == "" {
+		return nil, toAdminGRPCStatus(errorCodes.ToAPIErr(ErrAdminInvalidArgument))
+	}
+
+	user, err := globalIAMSys.GetServiceAccountParent(ctx, req.AccessKey)
+	if err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	if parentUser != user || user == "" {
+		// Same as DeleteServiceAccount, do not disclose the
+		// service accounts of other users.
+		return nil, toAdminGRPCStatus(errorCodes.ToAPIErr(ErrServiceAccountNotFound))
+	}
+
+	if err = globalIAMSys.DeleteServiceAccount(ctx, req.AccessKey); err != nil {
+		return nil, toAdminGRPCErr(ctx, err)
+	}
+	return &adminpb.DeleteServiceAccountResponse{}, nil
+}
+
+// toHealResultPB converts a heal result to its protobuf message.
+func toHealResultPB(item madmin.HealResultItem) *adminpb.HealResultItem {
+	toDrivesPB := func(drives []madmin.HealDriveInfo) (pb []*adminpb.HealDriveInfo) {
+		for _, drive := range drives {
+			pb = append(pb, &adminpb.HealDriveInfo{
+				Uuid:     drive.UUID,
+				Endpoint: drive.Endpoint,
+				State:    drive.State,
+			})
+		}
+		return pb
+	}
+	return &adminpb.HealResultItem{
+		Type:         string(item.Type),
+		Bucket:       item.Bucket,
+		Object:       item.Object,
+		VersionId:    item.VersionID,
+		Detail:       item.Detail,
+		ParityBlocks: int64(item.ParityBlocks),
+		DataBlocks:   int64(item.DataBlocks),
+		DiskCount:    int64(item.DiskCount),
+		SetCount:     int64(item.SetCount),
+		Before:       toDrivesPB(item.Before.Drives),
+		After:        toDrivesPB(item.After.Drives),
+		ObjectSize:   item.ObjectSize,
+	}
+}
+
+// Heal - heals a bucket and, when asked for, the objects under a
+// prefix of the bucket. The heal runs as a heal sequence, same as with
+// the HTTP heal API, the results are streamed as soon as they are
+// available instead of being polled for.
+func (s adminGRPCServer) Heal(req *adminpb.HealRequest, stream adminpb.Admin_HealServer) (err error) {
+	ctx, r, w := newAdminGRPCContext(stream.Context(), "Heal")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	if _, _, err = s.authenticate(ctx, r, iampolicy.HealAdminAction); err != nil {
+		return err
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsErasure {
+		return toAdminGRPCStatus(errorCodes.ToAPIErr(ErrHealNotImplemented))
+	}
+	if req.Bucket == "" {
+		return toAdminGRPCStatus(errorCodes.ToAPIErr(ErrHealMissingBucket))
+	}
+	if isReservedOrInvalidBucket(req.Bucket, false) {
+		return toAdminGRPCStatus(errorCodes.ToAPIErr(ErrInvalidBucketName))
+	}
+
+	opts := madmin.HealOpts{
+		Recursive: req.Recursive,
+		DryRun:    req.DryRun,
+		Remove:    req.Remove,
+		Recreate:  req.Recreate,
+		ScanMode:  madmin.HealNormalScan,
+	}
+	switch req.ScanMode {
+	case "", "normal":
+	case "deep":
+		opts.ScanMode = madmin.HealDeepScan
+	default:
+		return toAdminGRPCStatus(errorCodes.ToAPIErr(ErrAdminInvalidArgument))
+	}
+
+	nh := newHealSequence(GlobalContext, req.Bucket, req.Prefix, handlers.GetSourceIP(r), opts, false)
+	if _, apiErr, errMsg := globalAllHealState.LaunchNewHealSequence(nh, newObjectLayerFn()); apiErr.Code != "" {
+		if errMsg != "" {
+			apiErr.Description = errMsg
+		}
+		return toAdminGRPCStatus(apiErr)
+	}
+	// The heal stops when the call is canceled.
+	defer nh.stop()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		healStatus := nh.popHealStatus()
+		for _, item := range healStatus.Items {
+			if err = stream.Send(toHealResultPB(item)); err != nil {
+				return err
+			}
+		}
+		switch healStatus.Summary {
+		case healFinishedStatus:
+			return nil
+		case healStoppedStatus:
+			return status.Error(codes.Aborted, healStatus.FailureDetail)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		}
+	}
+}
+
+// toTraceInfoPB converts a trace entry to its protobuf message.
+func toTraceInfoPB(info trace.Info) *adminpb.TraceInfo {
+	return &adminpb.TraceInfo{
+		NodeName:        info.NodeName,
+		FuncName:        info.FuncName,
+		Time:            info.ReqInfo.Time.UnixNano(),
+		Proto:           info.ReqInfo.Proto,
+		Method:          info.ReqInfo.Method,
+		Path:            info.ReqInfo.Path,
+		RawQuery:        info.ReqInfo.RawQuery,
+		Client:          info.ReqInfo.Client,
+		StatusCode:      int64(info.RespInfo.StatusCode),
+		InputBytes:      int64(info.CallStats.InputBytes),
+		OutputBytes:     int64(info.CallStats.OutputBytes),
+		Latency:         int64(info.CallStats.Latency),
+		TimeToFirstByte: int64(info.CallStats.TimeToFirstByte),
+	}
+}
+
+// Trace - streams the HTTP traces of all servers, same as TraceHandler.
+func (s adminGRPCServer) Trace(req *adminpb.TraceRequest, stream adminpb.Admin_TraceServer) (err error) {
+	ctx, r, w := newAdminGRPCContext(stream.Context(), "HTTPTrace")
+	defer auditAdminGRPC(ctx, w, r, &err)
+
+	if _, _, err := s.authenticate(ctx, r, iampolicy.TraceAdminAction); err != nil {
+		return err
+	}
+
+	// Trace Publisher and peer-trace-client uses nonblocking send and hence does not wait for slow receivers.
+	// Use buffered channel to take care of burst sends or slow stream.Send()
+	traceCh := make(chan interface{}, 4000)
+
+	peers, _ := newPeerRestClients(globalEndpoints)
+
+	globalHTTPTrace.Subscribe(traceCh, ctx.Done(), func(entry interface{}) bool {
+		return mustTrace(entry, req.All, req.ErrorsOnly)
+	})
+
+	for _, client := range peers {
+		if client == nil {
+			continue
+		}
+		client.Trace(traceCh, ctx.Done(), req.All, req.ErrorsOnly)
+	}
+
+	for {
+		select {
+		case entry := <-traceCh:
+			info, ok := entry.(trace.Info)
+			if !ok {
+				continue
+			}
+			if err = stream.Send(toTraceInfoPB(info)); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/madmin/adminpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminGRPCServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	// Configs and secret keys are sent in plain text, the server is
+	// not started without TLS.
+	if err = startAdminGRPCServer(ctx, "127.0.0.1:0"); err != errAdminGRPCWithoutTLS {
+		t.Fatalf("Expected %v, got %v", errAdminGRPCWithoutTLS, err)
+	}
+
+	certPEM, keyPEM, err := generateTLSCertKey("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(certPEM)
+
+	// Heals are run by the background heal routine.
+	globalBackgroundHealRoutine = newHealRoutine()
+	go globalBackgroundHealRoutine.run(ctx, adminTestBed.objLayer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newAdminGRPCServer(func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return &cert, nil
+	})
+	go srv.Serve(l)
+	defer srv.Stop()
+
+	dial := func(accessKey, secretKey string) adminpb.AdminClient {
+		conn, err := grpc.Dial(l.Addr().String(),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: rootCAs})),
+			grpc.WithPerRPCCredentials(adminpb.NewCredentials(accessKey, secretKey, true)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return adminpb.NewAdminClient(conn)
+	}
+
+	cred := globalActiveCred
+	client := dial(cred.AccessKey, cred.SecretKey)
+
+	info, err := client.ServerInfo(ctx, &adminpb.ServerInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode != string(madmin.ObjectLayerOnline) || info.Backend.GetType() != string(madmin.ErasureType) {
+		t.Errorf("Unexpected server info %v", info)
+	}
+
+	_, err = dial(cred.AccessKey, "wrong-secret-key").ServerInfo(ctx, &adminpb.ServerInfoRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected an unauthenticated error, got %v", err)
+	}
+
+	objLayer := adminTestBed.objLayer
+	if _, err = client.SetConfigKV(ctx, &adminpb.SetConfigKVRequest{Config: "region name=us-west-1"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := readServerConfig(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region := cfg[config.RegionSubSys][config.Default].Get(config.RegionName); region != "us-west-1" {
+		t.Errorf("Expected region us-west-1, got %q", region)
+	}
+	kv, err := client.GetConfigKV(ctx, &adminpb.GetConfigKVRequest{Key: config.RegionSubSys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(kv.Config, config.RegionSubSys) {
+		t.Errorf("Unexpected region config %q", kv.Config)
+	}
+
+	if err = objLayer.MakeBucketWithLocation(ctx, "mybucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hello")
+	_, err = objLayer.PutObject(ctx, "mybucket", "myobject", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := client.Heal(ctx, &adminpb.HealRequest{Bucket: "mybucket", Recursive: true, ScanMode: "deep"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []*adminpb.HealResultItem
+	for {
+		item, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Heal sequences also heal the backend metadata.
+		if item.Bucket == "mybucket" {
+			items = append(items, item)
+		}
+	}
+	if len(items) != 2 || items[0].Type != string(madmin.HealItemBucket) || items[1].Object != "myobject" {
+		t.Errorf("Unexpected heal results %v", items)
+	}
+
+	stream, err = client.Heal(ctx, &adminpb.HealRequest{Bucket: "mybucket", ScanMode: "bogus"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected an invalid argument error, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// StartBatchJobHandler - POST Start a batch job.
+// ----------
+// Starts a job copying the objects under a prefix to a bucket of this
+// or of a remote cluster. The job runs in the background on this node,
+// its progress is saved periodically and it resumes after a restart.
+func (a adminAPIHandlers) StartBatchJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, "StartBatchJob", mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.StartBatchJobAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if globalIsGateway {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if madmin.BatchJobType(vars["type"]) != madmin.BatchJobCopyType {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	// The job carries the credentials of the target.
+	reqBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	job := &batchJob{
+		Info: madmin.BatchJobInfo{
+			ID:        mustGetUUID(),
+			Type:      madmin.BatchJobCopyType,
+			Status:    madmin.BatchJobRunning,
+			Node:      GetLocalPeer(globalEndpoints),
+			StartTime: UTCNow(),
+		},
+	}
+	if err = json.Unmarshal(reqBytes, &job.Copy); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+	if err = job.validate(); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+		return
+	}
+
+	if _, err = objectAPI.GetBucketInfo(ctx, job.Copy.Source.Bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// The job reads the source, and writes copies made within this
+	// cluster, on behalf of the requester.
+	owner := cred.AccessKey == globalActiveCred.AccessKey
+	claims := mustGetClaimsFromToken(r)
+	isAllowed := func(action iampolicy.Action, bucket, prefix string) bool {
+		return globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     cred.AccessKey,
+			Action:          action,
+			BucketName:      bucket,
+			ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+			IsOwner:         owner,
+			ObjectName:      prefix,
+			Claims:          claims,
+		})
+	}
+	src, tgt := job.Copy.Source, job.Copy.Target
+	if !isAllowed(iampolicy.GetObjectAction, src.Bucket, src.Prefix) ||
+		(tgt.Endpoint == "" && !isAllowed(iampolicy.PutObjectAction, tgt.Bucket, tgt.Prefix)) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+	job.User = cred.AccessKey
+
+	// Verify the target bucket is reachable before the job is started.
+	client, err := newBatchJobCopyClient(job)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+		return
+	}
+	found, err := client.BucketExists(ctx, job.Copy.Target.Bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+		return
+	}
+	if !found {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, BucketNotFound{Bucket: job.Copy.Target.Bucket}), r.URL)
+		return
+	}
+
+	if err = saveBatchJob(ctx, objectAPI, job); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	globalBatchJobs.start(GlobalContext, objectAPI, job)
+
+	data, err := json.Marshal(job.info())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// BatchJobStatusHandler - GET Status of a batch job.
+// ----------
+// Returns the progress of a batch job as of its last checkpoint.
+func (a adminAPIHandlers) BatchJobStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BatchJobStatus")
+
+	defer logger.AuditLog(ctx, w, r, "BatchJobStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListBatchJobsAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	job, err := loadBatchJob(ctx, objectAPI, vars["id"])
+	if err != nil {
+		if err == errConfigNotFound {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminNoSuchBatchJob), r.URL)
+			return
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(job.info())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListBatchJobsHandler - GET List batch jobs.
+// ----------
+// Returns the progress of all batch jobs, including finished jobs.
+func (a adminAPIHandlers) ListBatchJobsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBatchJobs")
+
+	defer logger.AuditLog(ctx, w, r, "ListBatchJobs", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListBatchJobsAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	jobs, err := listBatchJobs(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	infos := make([]madmin.BatchJobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		infos = append(infos, job.info())
+	}
+
+	data, err := json.Marshal(infos)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// CancelBatchJobHandler - POST Cancel a batch job.
+// ----------
+// Cancels a running batch job, the objects copied so far are kept.
+func (a adminAPIHandlers) CancelBatchJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelBatchJob")
+
+	defer logger.AuditLog(ctx, w, r, "CancelBatchJob", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.CancelBatchJobAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := globalBatchJobs.cancel(ctx, objectAPI, vars["id"]); err != nil {
+		if err == errConfigNotFound {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminNoSuchBatchJob), r.URL)
+			return
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
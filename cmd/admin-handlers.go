@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
@@ -78,7 +79,7 @@ func updateServer(u *url.URL, sha256Sum []byte, lrTime time.Time, mode string) (
 func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ServerUpdate")
 
-	defer logger.AuditLog(w, r, "ServerUpdate", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "ServerUpdate", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerUpdateAdminAction)
 	if objectAPI == nil {
@@ -187,7 +188,7 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 func (a adminAPIHandlers) ServiceHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "Service")
 
-	defer logger.AuditLog(w, r, "Service", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "Service", mustGetClaimsFromToken(r))
 
 	vars := mux.Vars(r)
 	action := vars["action"]
@@ -284,7 +285,7 @@ type ServerInfo struct {
 func (a adminAPIHandlers) StorageInfoHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "StorageInfo")
 
-	defer logger.AuditLog(w, r, "StorageInfo", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "StorageInfo", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.StorageInfoAdminAction)
 	if objectAPI == nil {
@@ -327,7 +328,7 @@ func (a adminAPIHandlers) StorageInfoHandler(w http.ResponseWriter, r *http.Requ
 func (a adminAPIHandlers) DataUsageInfoHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "DataUsageInfo")
 
-	defer logger.AuditLog(w, r, "DataUsageInfo", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "DataUsageInfo", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DataUsageInfoAdminAction)
 	if objectAPI == nil {
@@ -349,6 +350,35 @@ func (a adminAPIHandlers) DataUsageInfoHandler(w http.ResponseWriter, r *http.Re
 	writeSuccessResponseJSON(w, dataUsageInfoJSON)
 }
 
+// AccountingInfoHandler - GET /minio/admin/v3/accounting
+// ----------
+// Get the last used time of every access key and the number
+// of anonymous requests allowed on every bucket.
+func (a adminAPIHandlers) AccountingInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AccountingInfo")
+
+	defer logger.AuditLog(ctx, w, r, "AccountingInfo", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AccountingInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	accountingInfo, err := getAccessAccountingInfo(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	accountingInfoJSON, err := json.Marshal(accountingInfo)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, accountingInfoJSON)
+}
+
 func lriToLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEntry {
 	entry := &madmin.LockEntry{
 		Timestamp:  l.Timestamp,
@@ -358,6 +388,7 @@ func lriToLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEn
 		Owner:      l.Owner,
 		ID:         l.UID,
 		Quorum:     l.Quorum,
+		Elapsed:    UTCNow().Sub(l.Timestamp),
 	}
 	if l.Writer {
 		entry.Type = "WRITE"
@@ -367,8 +398,9 @@ func lriToLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEn
 	return entry
 }
 
-func topLockEntries(peerLocks []*PeerLocks, stale bool) madmin.LockEntries {
+func topLockEntries(peerLocks []*PeerLocks, stale, queued bool) madmin.LockEntries {
 	entryMap := make(map[string]*madmin.LockEntry)
+	queuedMap := make(map[string]*madmin.LockEntry)
 	for _, peerLock := range peerLocks {
 		if peerLock == nil {
 			continue
@@ -382,32 +414,48 @@ func topLockEntries(peerLocks []*PeerLocks, stale bool) madmin.LockEntries {
 				}
 			}
 		}
-	}
-	var lockEntries madmin.LockEntries
-	for _, v := range entryMap {
-		if stale {
-			lockEntries = append(lockEntries, *v)
+		if !queued {
 			continue
 		}
-		if len(v.ServerList) >= v.Quorum {
+		// Waiting lock requests are only known by the node requesting them.
+		for k, v := range peerLock.Waiting {
+			for _, lockReqInfo := range v {
+				if _, ok := queuedMap[lockReqInfo.UID]; !ok {
+					entry := lriToLockEntry(lockReqInfo, k, peerLock.Addr)
+					entry.Queued = true
+					queuedMap[lockReqInfo.UID] = entry
+				}
+			}
+		}
+	}
+	var lockEntries madmin.LockEntries
+	for uid, v := range entryMap {
+		if stale || len(v.ServerList) >= v.Quorum {
 			lockEntries = append(lockEntries, *v)
+			// A lock request may be granted on some of the lockers
+			// while still waiting for quorum, report it only once.
+			delete(queuedMap, uid)
 		}
 	}
+	for _, v := range queuedMap {
+		lockEntries = append(lockEntries, *v)
+	}
 	sort.Sort(lockEntries)
 	return lockEntries
 }
 
 // PeerLocks holds server information result of one node
 type PeerLocks struct {
-	Addr  string
-	Locks map[string][]lockRequesterInfo
+	Addr    string
+	Locks   map[string][]lockRequesterInfo
+	Waiting map[string][]lockRequesterInfo
 }
 
 // TopLocksHandler Get list of locks in use
 func (a adminAPIHandlers) TopLocksHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "TopLocks")
 
-	defer logger.AuditLog(w, r, "TopLocks", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "TopLocks", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TopLocksAdminAction)
 	if objectAPI == nil {
@@ -423,11 +471,12 @@ func (a adminAPIHandlers) TopLocksHandler(w http.ResponseWriter, r *http.Request
 			return
 		}
 	}
-	stale := r.URL.Query().Get("stale") == "true" // list also stale locks
+	stale := r.URL.Query().Get("stale") == "true"   // list also stale locks
+	queued := r.URL.Query().Get("queued") == "true" // list also lock requests waiting to be granted
 
 	peerLocks := globalNotificationSys.GetLocks(ctx, r)
 
-	topLocks := topLockEntries(peerLocks, stale)
+	topLocks := topLockEntries(peerLocks, stale, queued)
 
 	// Marshal API response upto requested count.
 	if len(topLocks) > count && count > 0 {
@@ -453,32 +502,12 @@ type StartProfilingResult struct {
 	Error    string `json:"error"`
 }
 
-// StartProfilingHandler - POST /minio/admin/v3/profiling/start?profilerType={profilerType}
-// ----------
-// Enable server profiling
-func (a adminAPIHandlers) StartProfilingHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := newContext(r, w, "StartProfiling")
-
-	defer logger.AuditLog(w, r, "StartProfiling", mustGetClaimsFromToken(r))
-
-	// Validate request signature.
-	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.ProfilingAdminAction, "")
-	if adminAPIErr != ErrNone {
-		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
-		return
-	}
-
-	if globalNotificationSys == nil {
-		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
-		return
-	}
-
-	vars := mux.Vars(r)
-	profiles := strings.Split(vars["profilerType"], ",")
+// startClusterProfiling starts the given profilers on all peers and
+// locally, profilers of the same type already running are restarted.
+func startClusterProfiling(profiles []string) ([]NotificationPeerErr, error) {
 	thisAddr, err := xnet.ParseHost(GetLocalPeer(globalEndpoints))
 	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
+		return nil, err
 	}
 
 	globalProfilerMu.Lock()
@@ -517,6 +546,36 @@ func (a adminAPIHandlers) StartProfilingHandler(w http.ResponseWriter, r *http.R
 			})
 		}
 	}
+	return hostErrs, nil
+}
+
+// StartProfilingHandler - POST /minio/admin/v3/profiling/start?profilerType={profilerType}
+// ----------
+// Enable server profiling
+func (a adminAPIHandlers) StartProfilingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartProfiling")
+
+	defer logger.AuditLog(ctx, w, r, "StartProfiling", mustGetClaimsFromToken(r))
+
+	// Validate request signature.
+	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.ProfilingAdminAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
+		return
+	}
+
+	if globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	profiles := strings.Split(vars["profilerType"], ",")
+	hostErrs, err := startClusterProfiling(profiles)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
 
 	var startProfilingResult []StartProfilingResult
 
@@ -564,7 +623,7 @@ func (f dummyFileInfo) Sys() interface{}   { return f.sys }
 func (a adminAPIHandlers) DownloadProfilingHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "DownloadProfiling")
 
-	defer logger.AuditLog(w, r, "DownloadProfiling", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "DownloadProfiling", mustGetClaimsFromToken(r))
 
 	// Validate request signature.
 	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.ProfilingAdminAction, "")
@@ -584,6 +643,82 @@ func (a adminAPIHandlers) DownloadProfilingHandler(w http.ResponseWriter, r *htt
 	}
 }
 
+// maxProfileDuration is the longest duration a profile
+// requested through ProfileHandler may run for.
+const maxProfileDuration = 10 * time.Minute
+
+// parseProfileDuration parses the duration of a profiling request,
+// a missing duration defaults to one minute.
+func parseProfileDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return time.Minute, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 || duration > maxProfileDuration {
+		return 0, fmt.Errorf("profiling duration must be between 0s and %s", maxProfileDuration)
+	}
+	return duration, nil
+}
+
+// ProfileHandler - POST /minio/admin/v3/profile?profilerType={profilerType}&duration={duration}
+// ----------
+// Profile all nodes for the requested duration and download the
+// profiling information of all nodes in a zip format
+func (a adminAPIHandlers) ProfileHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Profile")
+
+	defer logger.AuditLog(ctx, w, r, "Profile", mustGetClaimsFromToken(r))
+
+	// Validate request signature.
+	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.ProfilingAdminAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
+		return
+	}
+
+	if globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	duration, err := parseProfileDuration(r.URL.Query().Get("duration"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidDuration, err), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	profiles := strings.Split(vars["profilerType"], ",")
+	hostErrs, err := startClusterProfiling(profiles)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	for _, nerr := range hostErrs {
+		if nerr.Err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nerr.Host.String())
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nerr.Err)
+		}
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// The client went away, stop all profilers
+		// and discard the collected profiles.
+		globalNotificationSys.DownloadProfilingData(GlobalContext, ioutil.Discard)
+	case <-timer.C:
+		if !globalNotificationSys.DownloadProfilingData(ctx, w) {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminProfilerNotEnabled), r.URL)
+		}
+	}
+}
+
 type healInitParams struct {
 	bucket, objPrefix     string
 	hs                    madmin.HealOpts
@@ -665,7 +800,7 @@ func extractHealInitParams(vars map[string]string, qParms url.Values, r io.Reade
 func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "Heal")
 
-	defer logger.AuditLog(w, r, "Heal", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "Heal", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
 	if objectAPI == nil {
@@ -876,7 +1011,7 @@ func getAggregatedBackgroundHealState(ctx context.Context) (madmin.BgHealState,
 func (a adminAPIHandlers) BackgroundHealStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "HealBackgroundStatus")
 
-	defer logger.AuditLog(w, r, "HealBackgroundStatus", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "HealBackgroundStatus", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
 	if objectAPI == nil {
@@ -1097,7 +1232,7 @@ func (a adminAPIHandlers) TraceHandler(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) ConsoleLogHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ConsoleLog")
 
-	defer logger.AuditLog(w, r, "ConsoleLog", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "ConsoleLog", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConsoleLogAdminAction)
 	if objectAPI == nil {
@@ -1168,7 +1303,7 @@ func (a adminAPIHandlers) ConsoleLogHandler(w http.ResponseWriter, r *http.Reque
 // KMSCreateKeyHandler - POST /minio/admin/v3/kms/key/create?key-id=<master-key-id>
 func (a adminAPIHandlers) KMSCreateKeyHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "KMSCreateKey")
-	defer logger.AuditLog(w, r, "KMSCreateKey", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "KMSCreateKey", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSCreateKeyAdminAction)
 	if objectAPI == nil {
@@ -1191,7 +1326,7 @@ func (a adminAPIHandlers) KMSCreateKeyHandler(w http.ResponseWriter, r *http.Req
 func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "KMSKeyStatus")
 
-	defer logger.AuditLog(w, r, "KMSKeyStatus", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "KMSKeyStatus", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSKeyStatusAdminAction)
 	if objectAPI == nil {
@@ -1264,7 +1399,7 @@ func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Req
 func (a adminAPIHandlers) HealthInfoHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "HealthInfo")
 
-	defer logger.AuditLog(w, r, "HealthInfo", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "HealthInfo", mustGetClaimsFromToken(r))
 
 	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealthInfoAdminAction)
 	if objectAPI == nil {
@@ -1436,13 +1571,56 @@ func (a adminAPIHandlers) HealthInfoHandler(w http.ResponseWriter, r *http.Reque
 
 }
 
+// ErasureBenchmarkHandler - POST /minio/admin/v3/erasure-benchmark
+// ----------
+// Runs an erasure coding benchmark on all servers and returns the
+// encode and decode throughput of each server.
+func (a adminAPIHandlers) ErasureBenchmarkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ErasureBenchmark")
+
+	defer logger.AuditLog(ctx, w, r, "ErasureBenchmark", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealthInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	p, err := parseErasureBenchmarkParams(r.URL.Query(), objectAPI.SetDriveCount())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminInvalidArgument, err), r.URL)
+		return
+	}
+
+	addr := r.Host
+	if globalIsDistErasure {
+		addr = GetLocalPeer(globalEndpoints)
+	}
+
+	// Run the local benchmark concurrently with the peers,
+	// so that all servers are measured under the same load.
+	localCh := make(chan madmin.ErasureBenchmarkResult, 1)
+	go func() {
+		localCh <- runErasureBenchmark(ctx, addr, p)
+	}()
+	peerResults := globalNotificationSys.ErasureBenchmark(ctx, p)
+	results := append([]madmin.ErasureBenchmarkResult{<-localCh}, peerResults...)
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, resultsJSON)
+}
+
 // BandwidthMonitorHandler - GET /minio/admin/v3/bandwidth
 // ----------
 // Get bandwidth consumption information
 func (a adminAPIHandlers) BandwidthMonitorHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "BandwidthMonitor")
 
-	defer logger.AuditLog(w, r, "BandwidthMonitor", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "BandwidthMonitor", mustGetClaimsFromToken(r))
 
 	// Validate request signature.
 	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.BandwidthMonitorAction, "")
@@ -1495,7 +1673,37 @@ func (a adminAPIHandlers) BandwidthMonitorHandler(w http.ResponseWriter, r *http
 func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ServerInfo")
 
-	defer logger.AuditLog(w, r, "ServerInfo", mustGetClaimsFromToken(r))
+	defer logger.AuditLog(ctx, w, r, "ServerInfo", mustGetClaimsFromToken(r))
+
+	// Validate request signature.
+	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.ServerInfoAdminAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
+		return
+	}
+
+	infoMsg := getServerInfo(ctx, r)
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(infoMsg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Reply with storage information (across nodes in a
+	// distributed setup) as json.
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// RequestsPoolInfoHandler - GET /minio/admin/v3/requests-pool
+// ----------
+// Get the occupancy, the queue wait times and the current limit
+// of the requests pool of all servers.
+func (a adminAPIHandlers) RequestsPoolInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RequestsPoolInfo")
+
+	defer logger.AuditLog(ctx, w, r, "RequestsPoolInfo", mustGetClaimsFromToken(r))
 
 	// Validate request signature.
 	_, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.ServerInfoAdminAction, "")
@@ -1504,6 +1712,36 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	local := globalAPIConfig.getRequestsPoolStats()
+	local.Endpoint = r.Host
+	if globalIsDistErasure {
+		local.Endpoint = GetLocalPeer(globalEndpoints)
+	}
+
+	info := madmin.RequestsPoolInfo{
+		Servers: []madmin.RequestsPoolStats{local},
+	}
+	if globalNotificationSys != nil {
+		for _, stats := range globalNotificationSys.RequestsPoolStats() {
+			if stats.Endpoint == "" {
+				continue
+			}
+			info.Servers = append(info.Servers, stats)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// getServerInfo returns the information about the deployment and all
+// its servers, it is shared by the HTTP and the gRPC admin API.
+func getServerInfo(ctx context.Context, r *http.Request) madmin.InfoMessage {
 	vault := fetchVaultStatus()
 
 	ldap := madmin.LDAP{}
@@ -1582,7 +1820,7 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 		Notifications: notifyTarget,
 	}
 
-	infoMsg := madmin.InfoMessage{
+	return madmin.InfoMessage{
 		Mode:         mode,
 		Domain:       domain,
 		Region:       globalServerRegion,
@@ -1595,17 +1833,6 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 		Backend:      backend,
 		Servers:      servers,
 	}
-
-	// Marshal API response
-	jsonBytes, err := json.Marshal(infoMsg)
-	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
-	}
-
-	// Reply with storage information (across nodes in a
-	// distributed setup) as json.
-	writeSuccessResponseJSON(w, jsonBytes)
 }
 
 func fetchLambdaInfo() []map[string][]madmin.TargetIDStatus {
@@ -27,6 +27,7 @@ import (
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/pkg/auth"
@@ -379,3 +380,79 @@ func TestExtractHealInitParams(t *testing.T) {
 	}
 
 }
+
+func TestTopLockEntries(t *testing.T) {
+	now := UTCNow()
+	held := lockRequesterInfo{Writer: true, UID: "held", Timestamp: now.Add(-time.Minute), Owner: "node1", Quorum: 2}
+	partial := lockRequesterInfo{UID: "partial", Timestamp: now, Owner: "node2", Quorum: 2}
+	waiting := lockRequesterInfo{Writer: true, UID: "waiting", Timestamp: now.Add(-time.Second), Owner: "node2"}
+
+	peerLocks := []*PeerLocks{
+		{
+			Addr:  "node1",
+			Locks: map[string][]lockRequesterInfo{"bucket/object": {held}},
+		},
+		{
+			Addr:  "node2",
+			Locks: map[string][]lockRequesterInfo{"bucket/object": {held}, "bucket/other": {partial}},
+			Waiting: map[string][]lockRequesterInfo{
+				"bucket/object": {waiting},
+				"bucket/other":  {partial},
+			},
+		},
+		nil,
+	}
+
+	testCases := []struct {
+		stale, queued bool
+		expectedUIDs  []string
+	}{
+		{false, false, []string{"held"}},
+		{true, false, []string{"held", "partial"}},
+		{false, true, []string{"held", "waiting", "partial"}},
+	}
+
+	for i, testCase := range testCases {
+		entries := topLockEntries(peerLocks, testCase.stale, testCase.queued)
+		if len(entries) != len(testCase.expectedUIDs) {
+			t.Fatalf("Test %d: expected %d entries, got %d", i+1, len(testCase.expectedUIDs), len(entries))
+		}
+		for j, entry := range entries {
+			if entry.ID != testCase.expectedUIDs[j] {
+				t.Errorf("Test %d: expected entry %d to be %s, got %s", i+1, j, testCase.expectedUIDs[j], entry.ID)
+			}
+			// Lock requests held below quorum are reported as queued.
+			if entry.Queued != (entry.ID == "waiting" || (entry.ID == "partial" && !testCase.stale)) {
+				t.Errorf("Test %d: unexpected queued status for %s", i+1, entry.ID)
+			}
+			if entry.ID == "held" && (len(entry.ServerList) != 2 || entry.Type != "WRITE" || entry.Elapsed < time.Minute) {
+				t.Errorf("Test %d: unexpected held lock entry %#v", i+1, entry)
+			}
+		}
+	}
+}
+
+func TestParseProfileDuration(t *testing.T) {
+	testCases := []struct {
+		value    string
+		duration time.Duration
+		success  bool
+	}{
+		{"", time.Minute, true},
+		{"30s", 30 * time.Second, true},
+		{"10m", 10 * time.Minute, true},
+		{"0s", 0, false},
+		{"-1s", 0, false},
+		{"11m", 0, false},
+		{"30", 0, false},
+	}
+	for i, testCase := range testCases {
+		duration, err := parseProfileDuration(testCase.value)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if duration != testCase.duration {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.duration, duration)
+		}
+	}
+}
@@ -312,32 +312,33 @@ func (ahs *allHealState) PopHealStatusJSON(hpath string,
 		return nil, ErrHealInvalidClientToken
 	}
 
+	jbytes, err := json.Marshal(h.popHealStatus())
+	if err != nil {
+		logger.LogIf(h.ctx, err)
+		return nil, ErrInternalError
+	}
+
+	return jbytes, ErrNone
+}
+
+// popHealStatus returns the status of the heal sequence along with the
+// heal results not consumed yet, the results are consumed by the call.
+func (h *healSequence) popHealStatus() healSequenceStatus {
 	// Take lock to access and update the heal-sequence
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
-	numItems := len(h.currentStatus.Items)
+	healStatus := h.currentStatus
 
 	// calculate index of most recently available heal result
 	// record.
-	lastResultIndex := h.lastSentResultIndex
-	if numItems > 0 {
-		lastResultIndex = h.currentStatus.Items[numItems-1].ResultIndex
-	}
-
-	h.lastSentResultIndex = lastResultIndex
-
-	jbytes, err := json.Marshal(h.currentStatus)
-	if err != nil {
-		h.currentStatus.Items = nil
-
-		logger.LogIf(h.ctx, err)
-		return nil, ErrInternalError
+	if numItems := len(healStatus.Items); numItems > 0 {
+		h.lastSentResultIndex = healStatus.Items[numItems-1].ResultIndex
 	}
 
 	h.currentStatus.Items = nil
 
-	return jbytes, ErrNone
+	return healStatus
 }
 
 // healSource denotes single entity and heal option.
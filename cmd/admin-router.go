@@ -56,11 +56,14 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 		// Info operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/info").HandlerFunc(httpTraceAll(adminAPI.ServerInfoHandler))
+		// Requests pool operations
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/requests-pool").HandlerFunc(httpTraceAll(adminAPI.RequestsPoolInfoHandler))
 
 		// StorageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/storageinfo").HandlerFunc(httpTraceAll(adminAPI.StorageInfoHandler))
 		// DataUsageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/datausageinfo").HandlerFunc(httpTraceAll(adminAPI.DataUsageInfoHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/accounting").HandlerFunc(httpTraceAll(adminAPI.AccountingInfoHandler))
 
 		if globalIsDistErasure || globalIsErasure {
 			/// Heal operations
@@ -74,18 +77,23 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 			/// Health operations
 
+			// Erasure coding benchmark
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/erasure-benchmark").HandlerFunc(httpTraceAll(adminAPI.ErasureBenchmarkHandler))
 		}
 
 		// Profiling operations
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/profiling/start").HandlerFunc(httpTraceAll(adminAPI.StartProfilingHandler)).
 			Queries("profilerType", "{profilerType:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/profiling/download").HandlerFunc(httpTraceAll(adminAPI.DownloadProfilingHandler))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/profile").HandlerFunc(httpTraceHdrs(adminAPI.ProfileHandler)).
+			Queries("profilerType", "{profilerType:.*}")
 
 		// Config KV operations.
 		if enableConfigOps {
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-config-kv").HandlerFunc(httpTraceHdrs(adminAPI.GetConfigKVHandler)).Queries("key", "{key:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/set-config-kv").HandlerFunc(httpTraceHdrs(adminAPI.SetConfigKVHandler))
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/del-config-kv").HandlerFunc(httpTraceHdrs(adminAPI.DelConfigKVHandler))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/resolved-config-kv").HandlerFunc(httpTraceAll(adminAPI.GetResolvedConfigKVHandler))
 		}
 
 		// Enable config help in all modes.
@@ -96,6 +104,11 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-config-history-kv").HandlerFunc(httpTraceAll(adminAPI.ListConfigHistoryKVHandler)).Queries("count", "{count:[0-9]+}")
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/clear-config-history-kv").HandlerFunc(httpTraceHdrs(adminAPI.ClearConfigHistoryKVHandler)).Queries("restoreId", "{restoreId:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/restore-config-history-kv").HandlerFunc(httpTraceHdrs(adminAPI.RestoreConfigHistoryKVHandler)).Queries("restoreId", "{restoreId:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/diff-config-history-kv").HandlerFunc(httpTraceAll(adminAPI.DiffConfigHistoryKVHandler)).Queries("restoreId", "{restoreId:.*}")
+
+			// Staged config operations.
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/staged-config-kv").HandlerFunc(httpTraceAll(adminAPI.GetStagedConfigKVHandler))
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion + "/staged-config-kv").HandlerFunc(httpTraceHdrs(adminAPI.DiscardStagedConfigKVHandler))
 		}
 
 		/// Config import/export bulk operations
@@ -153,6 +166,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			// List users
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-users").HandlerFunc(httpTraceHdrs(adminAPI.ListUsers))
 
+			// Reload IAM from the backend on all nodes
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/refresh-iam").HandlerFunc(httpTraceHdrs(adminAPI.RefreshIAM))
+
 			// User info
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/user-info").HandlerFunc(httpTraceHdrs(adminAPI.GetUserInfo)).Queries("accessKey", "{accessKey:.*}")
 
@@ -177,6 +193,59 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-quota").HandlerFunc(
 				httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler)).Queries("bucket", "{bucket:.*}")
 
+			// GetBucketLambdaConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-lambda").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketLambdaConfigHandler)).Queries("bucket", "{bucket:.*}")
+			// PutBucketLambdaConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-lambda").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketLambdaConfigHandler)).Queries("bucket", "{bucket:.*}")
+
+			// GetBucketPlacementConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-placement").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketPlacementConfigHandler)).Queries("bucket", "{bucket:.*}")
+			// PutBucketPlacementConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-placement").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketPlacementConfigHandler)).Queries("bucket", "{bucket:.*}")
+
+			// GetBucketReplicaConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-replica").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketReplicaConfigHandler)).Queries("bucket", "{bucket:.*}")
+			// PutBucketReplicaConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-replica").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketReplicaConfigHandler)).Queries("bucket", "{bucket:.*}")
+			// FailoverBucket
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/failover-bucket").HandlerFunc(
+				httpTraceHdrs(adminAPI.FailoverBucketHandler)).Queries("bucket", "{bucket:.*}")
+
+			// PurgeObjectVersions
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/purge-versions").HandlerFunc(
+				httpTraceHdrs(adminAPI.PurgeObjectVersionsHandler)).Queries("bucket", "{bucket:.*}", "prefix", "{prefix:.*}")
+
+			// ReplayEvents
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replay-events").HandlerFunc(
+				httpTraceHdrs(adminAPI.ReplayEventsHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}",
+				"start", "{start:.*}", "end", "{end:.*}")
+
+			// StartBatchJob
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/start-batch-job").HandlerFunc(
+				httpTraceHdrs(adminAPI.StartBatchJobHandler)).Queries("type", "{type:.*}")
+			// BatchJobStatus
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/batch-job-status").HandlerFunc(
+				httpTraceHdrs(adminAPI.BatchJobStatusHandler)).Queries("id", "{id:.*}")
+			// ListBatchJobs
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-batch-jobs").HandlerFunc(
+				httpTraceHdrs(adminAPI.ListBatchJobsHandler))
+			// CancelBatchJob
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/cancel-batch-job").HandlerFunc(
+				httpTraceHdrs(adminAPI.CancelBatchJobHandler)).Queries("id", "{id:.*}")
+
+			// ExportBucketMetadata
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/export-bucket-metadata").HandlerFunc(
+				httpTraceHdrs(adminAPI.ExportBucketMetadataHandler))
+			// ImportBucketMetadata
+			adminRouter.Methods(http.MethodPut).Path(adminVersion + "/import-bucket-metadata").HandlerFunc(
+				httpTraceHdrs(adminAPI.ImportBucketMetadataHandler))
+
 			// Bucket replication operations
 			// GetBucketTargetHandler
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-remote-targets").HandlerFunc(
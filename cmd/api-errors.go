@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -31,8 +32,10 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/config/dns"
 	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/cors"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	"github.com/minio/minio/pkg/bucket/replication"
 
@@ -131,6 +134,7 @@ const (
 	ErrSignatureDoesNotMatch
 	ErrMethodNotAllowed
 	ErrInvalidPart
+	ErrInvalidPartNumber
 	ErrInvalidPartOrder
 	ErrAuthorizationHeaderMalformed
 	ErrMalformedPOSTRequest
@@ -219,6 +223,9 @@ const (
 
 	// S3 extended errors.
 	ErrContentSHA256Mismatch
+	ErrContentChecksumMismatch
+	ErrInvalidChecksumTrailer
+	ErrInvalidAttributeName
 
 	// Add new extended error codes here.
 
@@ -235,8 +242,11 @@ const (
 	ErrServerNotInitialized
 	ErrOperationTimedOut
 	ErrClientDisconnected
+	ErrRequestTimeout
 	ErrOperationMaxedOut
 	ErrInvalidRequest
+	ErrAnonymousResponseHeaders
+	ErrInvalidResponseHeaderValue
 	// MinIO storage class error codes
 	ErrInvalidStorageClass
 	ErrBackendDown
@@ -262,6 +272,25 @@ const (
 	// Bucket Quota error codes
 	ErrAdminBucketQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
+	// Bucket object lambda error codes
+	ErrNoSuchLambdaConfiguration
+	ErrLambdaTransformFailed
+	// Bucket placement error codes
+	ErrNoSuchPlacementConfiguration
+	// Event journal error codes
+	ErrAdminEventJournalDisabled
+	// Batch job error codes
+	ErrAdminNoSuchBatchJob
+	// Archive extraction error codes
+	ErrExtractArchiveUnsupported
+	ErrExtractArchiveTooLarge
+	ErrExtractArchiveMalformed
+	// Append object error codes
+	ErrInvalidAppendOffset
+	ErrObjectNotAppendable
+	// Bucket replica error codes
+	ErrBucketReadOnlyReplica
+	ErrBucketNotReadOnlyReplica
 
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
@@ -597,6 +626,11 @@ var errorCodes = errorCodeMap{
 		Description:    "One or more of the specified parts could not be found.  The part may not have been uploaded, or the specified entity tag may not match the part's entity tag.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidPartNumber: {
+		Code:           "InvalidPartNumber",
+		Description:    "The requested partnumber is not satisfiable",
+		HTTPStatusCode: http.StatusRequestedRangeNotSatisfiable,
+	},
 	ErrInvalidPartOrder: {
 		Code:           "InvalidPartOrder",
 		Description:    "The list of parts was not in ascending order. The parts list must be specified in order by part number.",
@@ -1080,6 +1114,21 @@ var errorCodes = errorCodeMap{
 		Description:    "The provided 'x-amz-content-sha256' header does not match what was computed.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrContentChecksumMismatch: {
+		Code:           "BadDigest",
+		Description:    "The trailing 'x-amz-checksum' you specified did not match the calculated checksum.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksumTrailer: {
+		Code:           "InvalidRequest",
+		Description:    "The provided 'x-amz-trailer' header is missing or not a supported checksum.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidAttributeName: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid attribute name specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	/// MinIO extensions.
 	ErrStorageFull: {
@@ -1208,6 +1257,66 @@ var errorCodes = errorCodeMap{
 		Description:    "The quota configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrAdminNoSuchBatchJob: {
+		Code:           "XMinioAdminNoSuchBatchJob",
+		Description:    "The specified batch job does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminEventJournalDisabled: {
+		Code:           "XMinioAdminEventJournalDisabled",
+		Description:    "Events cannot be replayed, the event journal is not enabled",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchLambdaConfiguration: {
+		Code:           "XMinioNoSuchLambdaConfiguration",
+		Description:    "The object lambda configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrLambdaTransformFailed: {
+		Code:           "XMinioLambdaTransformFailed",
+		Description:    "The object lambda transformation webhook failed to process the object",
+		HTTPStatusCode: http.StatusBadGateway,
+	},
+	ErrExtractArchiveUnsupported: {
+		Code:           "XMinioExtractArchiveUnsupported",
+		Description:    "Only objects named *.tar, *.tar.gz, *.tgz or *.zip can be extracted",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrExtractArchiveTooLarge: {
+		Code:           "XMinioExtractArchiveTooLarge",
+		Description:    "Zip archives larger than 256MiB cannot be extracted, use a tar archive instead",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrExtractArchiveMalformed: {
+		Code:           "XMinioExtractArchiveMalformed",
+		Description:    "The archive is malformed and cannot be extracted",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidAppendOffset: {
+		Code:           "XMinioInvalidAppendOffset",
+		Description:    "The append offset does not match the current size of the object",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrObjectNotAppendable: {
+		Code:           "XMinioObjectNotAppendable",
+		Description:    "The object cannot be appended to",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBucketReadOnlyReplica: {
+		Code:           "XMinioBucketReadOnlyReplica",
+		Description:    "The bucket is a read-only replica, objects can only be written by replication",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrBucketNotReadOnlyReplica: {
+		Code:           "XMinioBucketNotReadOnlyReplica",
+		Description:    "The bucket is not a read-only replica",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchPlacementConfiguration: {
+		Code:           "XMinioNoSuchPlacementConfiguration",
+		Description:    "The placement configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1223,6 +1332,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Client disconnected before response was ready",
 		HTTPStatusCode: 499, // No official code, use nginx value.
 	},
+	ErrRequestTimeout: {
+		Code:           "RequestTimeout",
+		Description:    "Your socket connection to the server was not read from or written to within the timeout period.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrOperationMaxedOut: {
 		Code:           "SlowDown",
 		Description:    "A timeout exceeded while waiting to proceed with the request, please reduce your request rate",
@@ -1252,6 +1366,16 @@ var errorCodes = errorCodeMap{
 		Description:    "Invalid Request",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAnonymousResponseHeaders: {
+		Code:           "InvalidRequest",
+		Description:    "Request specific response headers cannot be used for anonymous GET requests.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidResponseHeaderValue: {
+		Code:           "InvalidArgument",
+		Description:    "Request specific response header values must not contain control characters.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrHealNotImplemented: {
 		Code:           "XMinioHealNotImplemented",
 		Description:    "This server does not implement heal functionality.",
@@ -1754,6 +1878,13 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		return ErrNone
 	}
 
+	// Reading a request body received below the minimum transfer
+	// rate fails with ErrRequestTooSlow once the read deadline of the
+	// connection expires, report it as a request timeout.
+	if errors.Is(err, xhttp.ErrRequestTooSlow) {
+		return ErrRequestTimeout
+	}
+
 	// Only return ErrClientDisconnected if the provided context is actually canceled.
 	// This way downstream context.Canceled will still report ErrOperationTimedOut
 	select {
@@ -1777,6 +1908,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrAdminNoSuchPolicy
 	case errSignatureMismatch:
 		apiErr = ErrSignatureDoesNotMatch
+	case errChecksumMismatch:
+		apiErr = ErrContentChecksumMismatch
 	case errInvalidRange:
 		apiErr = ErrInvalidRange
 	case errDataTooLarge:
@@ -1858,6 +1991,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrAllAccessDisabled
 	case IncompleteBody:
 		apiErr = ErrIncompleteBody
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
+	case InvalidAppendOffset:
+		apiErr = ErrInvalidAppendOffset
+	case ObjectNotAppendable:
+		apiErr = ErrObjectNotAppendable
 	case ObjectExistsAsDirectory:
 		apiErr = ErrObjectExistsAsDirectory
 	case PrefixAccessDenied:
@@ -1894,6 +2033,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchUpload
 	case InvalidPart:
 		apiErr = ErrInvalidPart
+	case InvalidPartNumber:
+		apiErr = ErrInvalidPartNumber
 	case InsufficientWriteQuorum:
 		apiErr = ErrSlowDown
 	case InsufficientReadQuorum:
@@ -1930,6 +2071,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
+	case BucketCorsNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
@@ -1960,6 +2103,14 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrReplicationSourceNotVersionedError
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
+	case BucketLambdaConfigNotFound:
+		apiErr = ErrNoSuchLambdaConfiguration
+	case LambdaTransformFailed:
+		apiErr = ErrLambdaTransformFailed
+	case BucketPlacementConfigNotFound:
+		apiErr = ErrNoSuchPlacementConfiguration
+	case BucketReadOnlyReplica:
+		apiErr = ErrBucketReadOnlyReplica
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -2070,6 +2221,12 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case cors.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case replication.Error:
 			apiErr = APIError{
 				Code:           "MalformedXML",
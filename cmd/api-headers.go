@@ -85,6 +85,13 @@ func setPartsCountHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
 	}
 }
 
+// Write the ETag of the requested part, if recorded for the object.
+func setPartETagHeader(w http.ResponseWriter, h http.Header, objInfo ObjectInfo, partNumber int) {
+	if etag := objInfo.GetActualPartETag(h, partNumber); etag != "" {
+		w.Header()[xhttp.ETag] = []string{"\"" + etag + "\""}
+	}
+}
+
 // Write object header
 func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSpec, opts ObjectOptions) (err error) {
 	// set common headers
@@ -157,6 +164,9 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 	}
 
 	if opts.PartNumber > 0 {
+		if err = checkPartNumber(objInfo, opts.PartNumber); err != nil {
+			return err
+		}
 		rs = partNumberToRangeSpec(objInfo, opts.PartNumber)
 	}
 
@@ -184,6 +194,7 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		ruleID, expiryTime := lc.PredictExpiryTime(lifecycle.ObjectOpts{
 			Name:         objInfo.Name,
 			UserTags:     objInfo.UserTags,
+			Size:         lifecycleObjectSize(objInfo),
 			VersionID:    objInfo.VersionID,
 			ModTime:      objInfo.ModTime,
 			IsLatest:     objInfo.IsLatest,
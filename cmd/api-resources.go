@@ -18,8 +18,12 @@ package cmd
 
 import (
 	"encoding/base64"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Parse bucket url queries
@@ -125,6 +129,57 @@ func getBucketMultipartResources(values url.Values) (prefix, keyMarker, uploadID
 	return
 }
 
+// Attributes which may be requested through 'x-amz-object-attributes'.
+const (
+	objectAttributesETag         = "ETag"
+	objectAttributesChecksum     = "Checksum"
+	objectAttributesObjectParts  = "ObjectParts"
+	objectAttributesStorageClass = "StorageClass"
+	objectAttributesObjectSize   = "ObjectSize"
+)
+
+// Parse object attributes headers
+func getObjectAttributesResources(header http.Header) (attributes map[string]bool, partNumberMarker, maxParts int, errCode APIErrorCode) {
+	var err error
+	errCode = ErrNone
+
+	attributes = make(map[string]bool)
+	for _, values := range header[xhttp.AmzObjectAttributes] {
+		for _, attribute := range strings.Split(values, ",") {
+			attribute = strings.TrimSpace(attribute)
+			switch attribute {
+			case objectAttributesETag, objectAttributesChecksum, objectAttributesObjectParts,
+				objectAttributesStorageClass, objectAttributesObjectSize:
+				attributes[attribute] = true
+			default:
+				errCode = ErrInvalidAttributeName
+				return
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		errCode = ErrInvalidAttributeName
+		return
+	}
+
+	if header.Get(xhttp.AmzMaxParts) != "" {
+		if maxParts, err = strconv.Atoi(header.Get(xhttp.AmzMaxParts)); err != nil || maxParts < 0 {
+			errCode = ErrInvalidMaxParts
+			return
+		}
+	} else {
+		maxParts = maxPartsList
+	}
+
+	if header.Get(xhttp.AmzPartNumberMarker) != "" {
+		if partNumberMarker, err = strconv.Atoi(header.Get(xhttp.AmzPartNumberMarker)); err != nil || partNumberMarker < 0 {
+			errCode = ErrInvalidPartNumberMarker
+			return
+		}
+	}
+	return
+}
+
 // Parse object url queries
 func getObjectResources(values url.Values) (uploadID string, partNumberMarker, maxParts int, encodingType string, errCode APIErrorCode) {
 	var err error
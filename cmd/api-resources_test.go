@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 )
@@ -219,3 +220,76 @@ func TestGetObjectsResources(t *testing.T) {
 		}
 	}
 }
+
+// Test getObjectAttributesResources.
+func TestGetObjectAttributesResources(t *testing.T) {
+	testCases := []struct {
+		header                     http.Header
+		attributes                 int
+		partNumberMarker, maxParts int
+		errCode                    APIErrorCode
+	}{
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes":  []string{"ETag, ObjectParts", "ObjectSize"},
+				"X-Amz-Part-Number-Marker": []string{"2"},
+				"X-Amz-Max-Parts":          []string{"100"},
+			},
+			attributes:       3,
+			partNumberMarker: 2,
+			maxParts:         100,
+			errCode:          ErrNone,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes": []string{"StorageClass"},
+			},
+			attributes: 1,
+			maxParts:   maxPartsList,
+			errCode:    ErrNone,
+		},
+		{
+			header:  http.Header{},
+			errCode: ErrInvalidAttributeName,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes": []string{"ETag,Owner"},
+			},
+			errCode: ErrInvalidAttributeName,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes": []string{"ETag"},
+				"X-Amz-Max-Parts":         []string{"-1"},
+			},
+			errCode: ErrInvalidMaxParts,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes":  []string{"ETag"},
+				"X-Amz-Part-Number-Marker": []string{"a"},
+			},
+			errCode: ErrInvalidPartNumberMarker,
+		},
+	}
+
+	for i, testCase := range testCases {
+		attributes, partNumberMarker, maxParts, errCode := getObjectAttributesResources(testCase.header)
+		if errCode != testCase.errCode {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.errCode, errCode)
+		}
+		if errCode != ErrNone {
+			continue
+		}
+		if len(attributes) != testCase.attributes {
+			t.Errorf("Test %d: Expected %d attributes, got %d", i+1, testCase.attributes, len(attributes))
+		}
+		if partNumberMarker != testCase.partNumberMarker {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.partNumberMarker, partNumberMarker)
+		}
+		if maxParts != testCase.maxParts {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.maxParts, maxParts)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"encoding/xml"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
@@ -28,9 +29,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/sio"
 )
 
 const (
@@ -156,6 +159,43 @@ type Part struct {
 	Size         int64
 }
 
+// ObjectAttributesChecksum - additional checksum of an object.
+type ObjectAttributesChecksum struct {
+	ChecksumCRC32  string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumSHA1   string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256,omitempty"`
+}
+
+// ObjectAttributesPart - part of a multipart object.
+type ObjectAttributesPart struct {
+	PartNumber int
+	Size       int64
+}
+
+// ObjectAttributesParts - parts of a multipart object.
+type ObjectAttributesParts struct {
+	PartsCount           int
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+	IsTruncated          bool
+
+	Parts []ObjectAttributesPart `xml:"Part"`
+}
+
+// GetObjectAttributesResponse - format for get object attributes response,
+// only the requested attributes are set.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse" json:"-"`
+
+	ETag         string                    `xml:"ETag,omitempty"`
+	Checksum     *ObjectAttributesChecksum `xml:"Checksum,omitempty"`
+	ObjectParts  *ObjectAttributesParts    `xml:"ObjectParts,omitempty"`
+	StorageClass string                    `xml:"StorageClass,omitempty"`
+	ObjectSize   *int64                    `xml:"ObjectSize,omitempty"`
+}
+
 // ListPartsResponse - format for list parts response.
 type ListPartsResponse struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListPartsResult" json:"-"`
@@ -301,6 +341,9 @@ type Object struct {
 
 	// UserMetadata user-defined metadata
 	UserMetadata StringMap `xml:"UserMetadata,omitempty"`
+
+	// UserTags URL encoded object tags
+	UserTags string `xml:"UserTags,omitempty"`
 }
 
 // CopyObjectResponse container returns ETag and LastModified of the successfully copied object
@@ -395,9 +438,13 @@ func getObjectLocation(r *http.Request, domains []string, bucket, object string)
 		Path:   path.Join(SlashSeparator, bucket, object),
 		Scheme: proto,
 	}
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
 	// If domain is set then we need to use bucket DNS style.
 	for _, domain := range domains {
-		if strings.HasPrefix(r.Host, bucket+"."+domain) {
+		if host == bucket+"."+domain {
 			u.Path = path.Join(SlashSeparator, object)
 			break
 		}
@@ -570,6 +617,7 @@ func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter,
 				}
 				content.UserMetadata[k] = v
 			}
+			content.UserTags = object.UserTags
 		}
 		contents = append(contents, content)
 	}
@@ -639,6 +687,9 @@ func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) Lis
 	listPartsResponse.Key = s3EncodeName(partsInfo.Object, encodingType)
 	listPartsResponse.UploadID = partsInfo.UploadID
 	listPartsResponse.StorageClass = globalMinioDefaultStorageClass
+	if sc := partsInfo.UserDefined[xhttp.AmzStorageClass]; sc != "" {
+		listPartsResponse.StorageClass = sc
+	}
 	listPartsResponse.Initiator.ID = globalMinioDefaultOwnerID
 	listPartsResponse.Owner.ID = globalMinioDefaultOwnerID
 
@@ -659,6 +710,83 @@ func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) Lis
 	return listPartsResponse
 }
 
+// generates GetObjectAttributesResponse for the requested attributes of the object,
+// the ETag and the sizes are reported as seen by clients, i.e. decrypted and
+// decompressed.
+func generateGetObjectAttributesResponse(objInfo ObjectInfo, h http.Header, attributes map[string]bool, partNumberMarker, maxParts int) (resp GetObjectAttributesResponse, err error) {
+	if attributes[objectAttributesETag] {
+		resp.ETag = objInfo.GetActualETag(h)
+	}
+
+	if attributes[objectAttributesChecksum] {
+		if c, checksum, ok := getObjectChecksum(objInfo.UserDefined); ok {
+			resp.Checksum = &ObjectAttributesChecksum{}
+			switch c {
+			case checksumCRC32:
+				resp.Checksum.ChecksumCRC32 = checksum
+			case checksumCRC32C:
+				resp.Checksum.ChecksumCRC32C = checksum
+			case checksumSHA1:
+				resp.Checksum.ChecksumSHA1 = checksum
+			case checksumSHA256:
+				resp.Checksum.ChecksumSHA256 = checksum
+			}
+		}
+	}
+
+	// Parts are only reported for multipart objects.
+	isMultipart := crypto.IsMultiPart(objInfo.UserDefined) || strings.Contains(objInfo.ETag, "-")
+	if attributes[objectAttributesObjectParts] && isMultipart {
+		parts := &ObjectAttributesParts{
+			PartsCount:       len(objInfo.Parts),
+			PartNumberMarker: partNumberMarker,
+			MaxParts:         maxParts,
+		}
+		for _, part := range objInfo.Parts {
+			if part.Number <= partNumberMarker {
+				continue
+			}
+			if len(parts.Parts) == maxParts {
+				parts.IsTruncated = true
+				break
+			}
+			size := part.Size
+			switch {
+			case objInfo.IsCompressed():
+				size = part.ActualSize
+			case crypto.IsEncrypted(objInfo.UserDefined):
+				decryptedSize, err := sio.DecryptedSize(uint64(part.Size))
+				if err != nil {
+					return resp, errObjectTampered
+				}
+				size = int64(decryptedSize)
+			}
+			parts.Parts = append(parts.Parts, ObjectAttributesPart{
+				PartNumber: part.Number,
+				Size:       size,
+			})
+			parts.NextPartNumberMarker = part.Number
+		}
+		resp.ObjectParts = parts
+	}
+
+	if attributes[objectAttributesStorageClass] {
+		resp.StorageClass = objInfo.StorageClass
+		if resp.StorageClass == "" {
+			resp.StorageClass = globalMinioDefaultStorageClass
+		}
+	}
+
+	if attributes[objectAttributesObjectSize] {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			return resp, err
+		}
+		resp.ObjectSize = &size
+	}
+	return resp, nil
+}
+
 // generates ListMultipartUploadsResponse for given bucket and ListMultipartsInfo.
 func generateListMultipartUploadsResponse(bucket string, multipartsInfo ListMultipartsInfo, encodingType string) ListMultipartUploadsResponse {
 	listMultipartUploadsResponse := ListMultipartUploadsResponse{}
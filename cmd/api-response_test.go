@@ -19,6 +19,8 @@ package cmd
 import (
 	"net/http"
 	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
 )
 
 // Tests object location.
@@ -97,6 +99,27 @@ func TestObjectLocation(t *testing.T) {
 			object:           "test/1.txt",
 			expectedLocation: "https://mybucket.mys3.bucket.org/test/1.txt",
 		},
+		// Server with several virtual domain names.
+		{
+			request: &http.Request{
+				Host:   "mybucket.internal.org:9000",
+				Header: map[string][]string{},
+			},
+			domains:          []string{"mys3.bucket.org", "internal.org"},
+			bucket:           "mybucket",
+			object:           "test/1.txt",
+			expectedLocation: "http://mybucket.internal.org:9000/test/1.txt",
+		},
+		{
+			request: &http.Request{
+				Host:   "mybucket.internal.org.example.com",
+				Header: map[string][]string{},
+			},
+			domains:          []string{"mys3.bucket.org", "internal.org"},
+			bucket:           "mybucket",
+			object:           "test/1.txt",
+			expectedLocation: "http://mybucket.internal.org.example.com/mybucket/test/1.txt",
+		},
 	}
 	for _, testCase := range testCases {
 		testCase := testCase
@@ -122,3 +145,76 @@ func TestGetURLScheme(t *testing.T) {
 		t.Errorf("Expected %s, got %s", httpsScheme, gotScheme)
 	}
 }
+
+// Tests generating the GetObjectAttributes response.
+func TestGenerateGetObjectAttributesResponse(t *testing.T) {
+	objInfo := ObjectInfo{
+		ETag: "e0bc4a3d82a5fe0d8f4d1f6cbf3cd9a8-3",
+		Size: 12,
+		Parts: []ObjectPartInfo{
+			{Number: 1, Size: 5},
+			{Number: 2, Size: 5},
+			{Number: 3, Size: 2},
+		},
+		UserDefined: map[string]string{
+			checksumCRC32.metadataKey(): "DUoRhQ==",
+		},
+	}
+	attributes := map[string]bool{
+		objectAttributesETag:         true,
+		objectAttributesChecksum:     true,
+		objectAttributesObjectParts:  true,
+		objectAttributesStorageClass: true,
+		objectAttributesObjectSize:   true,
+	}
+
+	resp, err := generateGetObjectAttributesResponse(objInfo, http.Header{}, attributes, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ETag != objInfo.ETag {
+		t.Errorf("Expected ETag %s, got %s", objInfo.ETag, resp.ETag)
+	}
+	if resp.Checksum == nil || resp.Checksum.ChecksumCRC32 != "DUoRhQ==" {
+		t.Errorf("Expected CRC32 checksum, got %#v", resp.Checksum)
+	}
+	if resp.StorageClass != globalMinioDefaultStorageClass {
+		t.Errorf("Expected storage class %s, got %s", globalMinioDefaultStorageClass, resp.StorageClass)
+	}
+	if resp.ObjectSize == nil || *resp.ObjectSize != 12 {
+		t.Errorf("Expected object size 12, got %v", resp.ObjectSize)
+	}
+	parts := resp.ObjectParts
+	if parts == nil {
+		t.Fatal("Expected object parts to be set")
+	}
+	if parts.PartsCount != 3 || !parts.IsTruncated || parts.NextPartNumberMarker != 2 {
+		t.Errorf("Unexpected object parts %#v", parts)
+	}
+	if len(parts.Parts) != 1 || parts.Parts[0].PartNumber != 2 || parts.Parts[0].Size != 5 {
+		t.Errorf("Unexpected parts %#v", parts.Parts)
+	}
+
+	// Parts and unrequested attributes are omitted for regular objects.
+	objInfo.ETag = "e0bc4a3d82a5fe0d8f4d1f6cbf3cd9a8"
+	resp, err = generateGetObjectAttributesResponse(objInfo, http.Header{}, map[string]bool{objectAttributesObjectParts: true}, 0, maxPartsList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ObjectParts != nil || resp.ETag != "" || resp.ObjectSize != nil {
+		t.Errorf("Unexpected response %#v", resp)
+	}
+
+	// The ETag of encrypted objects is reported decrypted.
+	objInfo.ETag = "9c7e3f4b1e60a6d2d0355c7bd06c3a31e0bc4a3d82a5fe0d8f4d1f6cbf3cd9a8"
+	objInfo.UserDefined = map[string]string{
+		crypto.MetaSealedKeySSEC: "sealed-key",
+	}
+	resp, err = generateGetObjectAttributesResponse(objInfo, http.Header{}, map[string]bool{objectAttributesETag: true}, 0, maxPartsList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ETag != "e0bc4a3d82a5fe0d8f4d1f6cbf3cd9a8" {
+		t.Errorf("Expected decrypted ETag, got %s", resp.ETag)
+	}
+}
@@ -165,6 +165,12 @@ func registerAPIRouter(router *mux.Router) {
 		// GetObjectLegalHold
 		bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobjectlegalhold", maxClients(httpTraceAll(api.GetObjectLegalHoldHandler)))).Queries("legal-hold", "")
+		// GetObjectAttributes
+		bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectattributes", maxClients(httpTraceAll(api.GetObjectAttributesHandler)))).Queries("attributes", "")
+		// GetObjectLambda
+		bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectlambda", maxClients(httpTraceHdrs(api.GetObjectLambdaHandler)))).Queries("lambda", "")
 		// GetObject
 		bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobject", maxClients(httpTraceHdrs(api.GetObjectHandler))))
@@ -178,6 +184,10 @@ func registerAPIRouter(router *mux.Router) {
 		bucket.Methods(http.MethodPut).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("putobjectlegalhold", maxClients(httpTraceAll(api.PutObjectLegalHoldHandler)))).Queries("legal-hold", "")
 
+		// AppendObject
+		bucket.Methods(http.MethodPut).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("appendobject", maxClients(httpTraceHdrs(api.AppendObjectHandler)))).Queries("append", "")
+
 		// PutObject
 		bucket.Methods(http.MethodPut).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("putobject", maxClients(httpTraceHdrs(api.PutObjectHandler))))
@@ -195,6 +205,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketLifecycle
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketlifecycle", maxClients(httpTraceAll(api.GetBucketLifecycleHandler)))).Queries("lifecycle", "")
+		// GetBucketCors
+		bucket.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketcors", maxClients(httpTraceAll(api.GetBucketCorsHandler)))).Queries("cors", "")
 		// GetBucketEncryption
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketencryption", maxClients(httpTraceAll(api.GetBucketEncryptionHandler)))).Queries("encryption", "")
@@ -211,6 +224,9 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketNotification
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketnotification", maxClients(httpTraceAll(api.GetBucketNotificationHandler)))).Queries("notification", "")
+		// GetBucketZip
+		bucket.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketzip", maxClients(httpTraceHdrs(api.GetBucketZipHandler)))).Queries("zip", "")
 		// ListenNotification
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("listennotification", maxClients(httpTraceAll(api.ListenNotificationHandler)))).Queries("events", "{events:.*}")
@@ -222,9 +238,6 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketACL -- this is a dummy call.
 		bucket.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketacl", maxClients(httpTraceAll(api.PutBucketACLHandler)))).Queries("acl", "")
-		// GetBucketCors - this is a dummy call.
-		bucket.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketcors", maxClients(httpTraceAll(api.GetBucketCorsHandler)))).Queries("cors", "")
 		// GetBucketWebsiteHandler - this is a dummy call.
 		bucket.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketwebsite", maxClients(httpTraceAll(api.GetBucketWebsiteHandler)))).Queries("website", "")
@@ -273,6 +286,10 @@ func registerAPIRouter(router *mux.Router) {
 			collectAPIStats("putbucketreplicationconfiguration", maxClients(httpTraceAll(api.PutBucketReplicationConfigHandler)))).Queries("replication", "")
 		// GetObjectRetention
 
+		// PutBucketCors
+		bucket.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketcors", maxClients(httpTraceAll(api.PutBucketCorsHandler)))).Queries("cors", "")
+
 		// PutBucketEncryption
 		bucket.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketencryption", maxClients(httpTraceAll(api.PutBucketEncryptionHandler)))).Queries("encryption", "")
@@ -314,6 +331,9 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketLifecycle
 		bucket.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketlifecycle", maxClients(httpTraceAll(api.DeleteBucketLifecycleHandler)))).Queries("lifecycle", "")
+		// DeleteBucketCors
+		bucket.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketcors", maxClients(httpTraceAll(api.DeleteBucketCorsHandler)))).Queries("cors", "")
 		// DeleteBucketEncryption
 		bucket.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketencryption", maxClients(httpTraceAll(api.DeleteBucketEncryptionHandler)))).Queries("encryption", "")
@@ -370,7 +390,7 @@ func corsHandler(handler http.Handler) http.Handler {
 		"*",
 	}
 
-	return cors.New(cors.Options{
+	globalCors := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			for _, allowedOrigin := range globalAPIConfig.getCorsAllowOrigins() {
 				if wildcard.MatchSimple(allowedOrigin, origin) {
@@ -392,4 +412,16 @@ func corsHandler(handler http.Handler) http.Handler {
 		ExposedHeaders:   commonS3Headers,
 		AllowCredentials: true,
 	}).Handler(handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Buckets with a CORS configuration are governed by
+		// their own rules instead of the global allowed origins.
+		if r.Header.Get(xhttp.Origin) != "" {
+			if config := getRequestCorsConfig(r); config != nil {
+				serveBucketCors(w, r, config, handler)
+				return
+			}
+		}
+		globalCors.ServeHTTP(w, r)
+	})
 }
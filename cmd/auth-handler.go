@@ -76,7 +76,7 @@ func isRequestPostPolicySignatureV4(r *http.Request) bool {
 
 // Verify if the request has AWS Streaming Signature Version '4'. This is only valid for 'PUT' operation.
 func isRequestSignStreamingV4(r *http.Request) bool {
-	return r.Header.Get(xhttp.AmzContentSha256) == streamingContentSHA256 &&
+	return isStreamingContentSHA256(r.Header.Get(xhttp.AmzContentSha256)) &&
 		r.Method == http.MethodPut
 }
 
@@ -148,9 +148,31 @@ func validateAdminSignature(ctx context.Context, r *http.Request, region string)
 		return cred, nil, owner, s3Err
 	}
 
+	setReqInfoIdentity(ctx, cred, claims)
 	return cred, claims, owner, ErrNone
 }
 
+// setReqInfoIdentity - records the authenticated identity of the request
+// in the request info, such that it is part of the audit log entries.
+func setReqInfoIdentity(ctx context.Context, cred auth.Credentials, claims map[string]interface{}) {
+	if cred.AccessKey == "" {
+		return
+	}
+	reqInfo := logger.GetReqInfo(ctx)
+	if reqInfo == nil {
+		return
+	}
+	reqInfo.AccessKey = cred.AccessKey
+	reqInfo.ParentUser = cred.ParentUser
+	reqInfo.PolicyName = getClaimsPolicyName(claims)
+}
+
+// getClaimsPolicyName - returns the policy claim of temporary credentials.
+func getClaimsPolicyName(claims map[string]interface{}) string {
+	policyName, _ := claims[iamPolicyClaimNameOpenID()].(string)
+	return policyName
+}
+
 // checkAdminRequestAuth checks for authentication and authorization for the incoming
 // request. It only accepts V2 and V4 requests. Presigned, JWT and anonymous requests
 // are automatically rejected.
@@ -334,8 +356,10 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		// Populate payload again to handle it in HTTP handler.
 		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
 	}
-	if cred.AccessKey != "" {
-		logger.GetReqInfo(ctx).AccessKey = cred.AccessKey
+	setReqInfoIdentity(ctx, cred, claims)
+
+	if s3Err = checkReplicaWriteAllowed(r, action, bucketName, objectName, cred, owner, claims); s3Err != ErrNone {
+		return cred.AccessKey, owner, s3Err
 	}
 
 	if action != policy.ListAllMyBucketsAction && cred.AccessKey == "" {
@@ -348,6 +372,7 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
+			globalAccessAccounting.recordAnonymous(bucketName)
 			// Request is allowed return the appropriate access key.
 			return cred.AccessKey, owner, ErrNone
 		}
@@ -363,6 +388,7 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 				IsOwner:         false,
 				ObjectName:      objectName,
 			}) {
+				globalAccessAccounting.recordAnonymous(bucketName)
 				// Request is allowed return the appropriate access key.
 				return cred.AccessKey, owner, ErrNone
 			}
@@ -634,9 +660,7 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 		return s3Err
 	}
 
-	if cred.AccessKey != "" {
-		logger.GetReqInfo(ctx).AccessKey = cred.AccessKey
-	}
+	setReqInfoIdentity(ctx, cred, claims)
 
 	// Do not check for PutObjectRetentionAction permission,
 	// if mode and retain until date are not set.
@@ -647,6 +671,10 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 		return ErrNone
 	}
 
+	if s3Err = checkReplicaWriteAllowed(r, policy.Action(action), bucketName, objectName, cred, owner, claims); s3Err != ErrNone {
+		return s3Err
+	}
+
 	if cred.AccessKey == "" {
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
@@ -656,6 +684,7 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
+			globalAccessAccounting.recordAnonymous(bucketName)
 			return ErrNone
 		}
 		return ErrAccessDenied
@@ -0,0 +1,429 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/bandwidth"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+const (
+	// Prefix under which the state of the batch jobs is saved.
+	batchJobsPrefix = minioConfigPrefix + "/batch-jobs"
+
+	// Interval at which the progress of a running job is saved,
+	// a job resumes from its last saved progress after a restart.
+	batchJobCheckpointInterval = 10 * time.Second
+
+	// Number of attempts to copy an object before it is skipped.
+	batchJobCopyAttempts = 3
+)
+
+var (
+	errBatchJobCanceled = errors.New("batch job was canceled")
+	errBatchJobSSEC     = errors.New("objects encrypted with SSE-C cannot be copied")
+)
+
+// batchJob is the saved state of a batch job, unlike the status
+// returned to clients it carries the credentials of the target.
+type batchJob struct {
+	Info madmin.BatchJobInfo `json:"info"`
+	Copy madmin.BatchJobCopy `json:"copy"`
+
+	// Access key of the user who started the job, copies within this
+	// cluster are made with the credentials of this user.
+	User string `json:"user"`
+
+	// Set when the job is canceled on another node than the
+	// one running it, the job stops at its next checkpoint.
+	Cancel bool `json:"cancel,omitempty"`
+}
+
+func batchJobPath(id string) string {
+	return pathJoin(batchJobsPrefix, id+".json")
+}
+
+// info returns the status of the job without the target secret key.
+func (job *batchJob) info() madmin.BatchJobInfo {
+	info := job.Info
+	copyJob := job.Copy
+	copyJob.Target.SecretKey = ""
+	info.Copy = &copyJob
+	return info
+}
+
+// sameTarget returns true if the objects are copied within this cluster.
+func (job *batchJob) sameTarget() bool {
+	if job.Copy.Target.Endpoint == "" {
+		return true
+	}
+	u, err := xnet.ParseHTTPURL(job.Copy.Target.Endpoint)
+	if err != nil {
+		return false
+	}
+	host := (*url.URL)(u)
+	same, _ := isLocalHost(host.Hostname(), host.Port(), globalMinioPort)
+	return same
+}
+
+// validate checks the job description before the job is started.
+func (job *batchJob) validate() error {
+	src, tgt := job.Copy.Source, job.Copy.Target
+	if src.Bucket == "" || tgt.Bucket == "" || job.Copy.BandwidthLimit < 0 {
+		return errInvalidArgument
+	}
+	if tgt.Endpoint != "" {
+		if _, err := xnet.ParseHTTPURL(tgt.Endpoint); err != nil {
+			return err
+		}
+	}
+	// Copies must not end up under the copied prefix, otherwise
+	// the job would copy its own copies.
+	if job.sameTarget() && src.Bucket == tgt.Bucket && strings.HasPrefix(tgt.Prefix, src.Prefix) {
+		return errInvalidArgument
+	}
+	return nil
+}
+
+func loadBatchJob(ctx context.Context, objAPI ObjectLayer, id string) (*batchJob, error) {
+	data, err := readConfig(ctx, objAPI, batchJobPath(id))
+	if err != nil {
+		return nil, err
+	}
+	job := &batchJob{}
+	if err = json.Unmarshal(data, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func saveBatchJob(ctx context.Context, objAPI ObjectLayer, job *batchJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, batchJobPath(job.Info.ID), data)
+}
+
+// updateBatchJob saves the progress of job, unless the job was canceled
+// in the meantime in which case the job is marked as canceled.
+func updateBatchJob(ctx context.Context, objAPI ObjectLayer, job *batchJob) (canceled bool, err error) {
+	lk := objAPI.NewNSLock(minioMetaBucket, batchJobPath(job.Info.ID))
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return false, err
+	}
+	defer lk.Unlock()
+
+	saved, err := loadBatchJob(ctx, objAPI, job.Info.ID)
+	if err != nil {
+		return false, err
+	}
+	if saved.Cancel {
+		job.Cancel = true
+		if job.Info.Status == madmin.BatchJobRunning {
+			job.Info.Status = madmin.BatchJobCanceled
+			job.Info.EndTime = UTCNow()
+		}
+	}
+	return job.Cancel, saveBatchJob(ctx, objAPI, job)
+}
+
+// listBatchJobs returns all saved batch jobs.
+func listBatchJobs(ctx context.Context, objAPI ObjectLayer) ([]*batchJob, error) {
+	var jobs []*batchJob
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, batchJobsPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			job, err := loadBatchJob(ctx, objAPI, strings.TrimSuffix(strings.TrimPrefix(obj.Name, batchJobsPrefix+SlashSeparator), ".json"))
+			if err != nil {
+				if err == errConfigNotFound {
+					continue
+				}
+				return nil, err
+			}
+			jobs = append(jobs, job)
+		}
+		if !res.IsTruncated {
+			return jobs, nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+// batchJobs tracks the batch jobs running on this node.
+type batchJobs struct {
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+}
+
+var globalBatchJobs = &batchJobs{running: make(map[string]context.CancelFunc)}
+
+// initBatchJobs resumes the jobs which were running on this node
+// when it was stopped.
+func initBatchJobs(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		jobs, err := listBatchJobs(ctx, objAPI)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		node := GetLocalPeer(globalEndpoints)
+		for _, job := range jobs {
+			if job.Info.Status != madmin.BatchJobRunning || job.Info.Node != node {
+				continue
+			}
+			if job.Cancel {
+				job.Info.Status = madmin.BatchJobCanceled
+				job.Info.EndTime = UTCNow()
+				logger.LogIf(ctx, saveBatchJob(ctx, objAPI, job))
+				continue
+			}
+			globalBatchJobs.start(ctx, objAPI, job)
+		}
+	}()
+}
+
+// start runs job in the background on this node.
+func (j *batchJobs) start(ctx context.Context, objAPI ObjectLayer, job *batchJob) {
+	ctx, cancel := context.WithCancel(ctx)
+	j.mu.Lock()
+	j.running[job.Info.ID] = cancel
+	j.mu.Unlock()
+
+	go func() {
+		defer func() {
+			j.mu.Lock()
+			delete(j.running, job.Info.ID)
+			j.mu.Unlock()
+			cancel()
+		}()
+
+		err := job.copy(ctx, objAPI, func() (bool, error) {
+			return updateBatchJob(ctx, objAPI, job)
+		})
+		switch {
+		case err == nil:
+			job.Info.Status = madmin.BatchJobComplete
+			job.Info.EndTime = UTCNow()
+		case err == errBatchJobCanceled || ctx.Err() != nil:
+			// Either canceled, or the server is stopping in which
+			// case the job resumes when the server is restarted.
+		default:
+			job.Info.Status = madmin.BatchJobFailed
+			job.Info.Error = err.Error()
+			job.Info.EndTime = UTCNow()
+		}
+		_, err = updateBatchJob(context.Background(), objAPI, job)
+		logger.LogIf(ctx, err)
+	}()
+}
+
+// cancel cancels the job with the given ID, if the job runs on another
+// node it stops at its next checkpoint.
+func (j *batchJobs) cancel(ctx context.Context, objAPI ObjectLayer, id string) error {
+	lk := objAPI.NewNSLock(minioMetaBucket, batchJobPath(id))
+	if err := lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return err
+	}
+	job, err := loadBatchJob(ctx, objAPI, id)
+	if err == nil && job.Info.Status == madmin.BatchJobRunning && !job.Cancel {
+		job.Cancel = true
+		err = saveBatchJob(ctx, objAPI, job)
+	}
+	lk.Unlock()
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	if cancel, ok := j.running[id]; ok {
+		cancel()
+	}
+	j.mu.Unlock()
+	return nil
+}
+
+// batchJobCredentials returns the current credentials of the user who
+// started a job, an error is returned if the user was since removed,
+// disabled or its temporary credentials expired.
+func batchJobCredentials(accessKey string) (auth.Credentials, error) {
+	if accessKey == "" {
+		return auth.Credentials{}, errNoSuchUser
+	}
+	if accessKey == globalActiveCred.AccessKey {
+		return globalActiveCred, nil
+	}
+	cred, ok := globalIAMSys.GetUser(accessKey)
+	if !ok {
+		return auth.Credentials{}, errNoSuchUser
+	}
+	return cred, nil
+}
+
+// newBatchJobCopyClient returns a client of the target cluster of a
+// copy job, objects copied within this cluster are written through
+// the S3 API of this node as the user who started the job, such that
+// they are handled like uploads of this user.
+func newBatchJobCopyClient(job *batchJob) (*miniogo.Core, error) {
+	tgt := job.Copy.Target
+	endpoint, secure := GetLocalPeer(globalEndpoints), globalIsTLS
+	region := globalServerRegion
+	var creds *credentials.Credentials
+	if tgt.Endpoint == "" {
+		cred, err := batchJobCredentials(job.User)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewStaticV4(cred.AccessKey, cred.SecretKey, cred.SessionToken)
+	} else {
+		u, err := xnet.ParseHTTPURL(tgt.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		endpoint, secure = u.Host, u.Scheme == "https"
+		creds = credentials.NewStaticV4(tgt.AccessKey, tgt.SecretKey, "")
+		region = tgt.Region
+	}
+	return miniogo.NewCore(endpoint, &miniogo.Options{
+		Creds:     creds,
+		Secure:    secure,
+		Region:    region,
+		Transport: newGatewayHTTPTransport(10 * time.Minute),
+	})
+}
+
+// copy copies the latest version of the objects of a copy job in
+// lexical order, starting after the last object processed. The
+// progress is saved every checkpoint interval with update, the last
+// object processed stops before the first failed object such that a
+// resumed job retries it.
+func (job *batchJob) copy(ctx context.Context, objAPI ObjectLayer, update func() (bool, error)) error {
+	src := job.Copy.Source
+
+	client, err := newBatchJobCopyClient(job)
+	if err != nil {
+		return err
+	}
+	throttle := bandwidth.NewThrottle(ctx, job.Copy.BandwidthLimit)
+
+	lastUpdate := time.Now()
+	marker := job.Info.LastObject
+	failed := false
+	for {
+		res, err := objAPI.ListObjects(ctx, src.Bucket, src.Prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range res.Objects {
+			size, err := job.copyObject(ctx, objAPI, client, throttle, oi)
+			switch {
+			case err == nil:
+				job.Info.Objects++
+				job.Info.Bytes += size
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case isErrObjectNotFound(err):
+				// Deleted since it was listed.
+			default:
+				logger.LogIf(ctx, err)
+				job.Info.Failed++
+				job.Info.Error = oi.Name + ": " + err.Error()
+				failed = true
+			}
+			if !failed {
+				job.Info.LastObject = oi.Name
+			}
+
+			if time.Since(lastUpdate) >= batchJobCheckpointInterval {
+				canceled, err := update()
+				if canceled {
+					return errBatchJobCanceled
+				}
+				logger.LogIf(ctx, err)
+				lastUpdate = time.Now()
+			}
+		}
+		if !res.IsTruncated {
+			return nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+// copyObject copies a single object to the target of the job, along
+// with its metadata, tags, retention and encryption. Encrypted objects
+// are re-encrypted by the target, since object keys are bound to the
+// name of the object.
+func (job *batchJob) copyObject(ctx context.Context, objAPI ObjectLayer, client *miniogo.Core, throttle *bandwidth.Throttle, oi ObjectInfo) (size int64, err error) {
+	if crypto.SSEC.IsEncrypted(oi.UserDefined) {
+		return 0, errBatchJobSSEC
+	}
+	src, tgt := job.Copy.Source, job.Copy.Target
+	object := tgt.Prefix + strings.TrimPrefix(oi.Name, src.Prefix)
+
+	for attempt := 0; attempt < batchJobCopyAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+		size, err = func() (int64, error) {
+			gr, err := objAPI.GetObjectNInfo(ctx, src.Bucket, oi.Name, nil, http.Header{}, readLock, ObjectOptions{})
+			if err != nil {
+				return 0, err
+			}
+			defer gr.Close()
+
+			objInfo := gr.ObjInfo
+			size, err := objInfo.GetActualSize()
+			if err != nil {
+				return 0, err
+			}
+			putOpts := putReplicationOpts(ctx, replication.Destination{}, objInfo)
+			// The copy is a new object, not a replica of the source.
+			putOpts.Internal = miniogo.AdvancedPutOptions{}
+			_, err = client.PutObject(ctx, tgt.Bucket, object, throttle.NewReader(gr), size, "", "", putOpts)
+			return size, err
+		}()
+		if err == nil || isErrObjectNotFound(err) {
+			return size, err
+		}
+	}
+	return 0, err
+}
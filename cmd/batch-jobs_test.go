@@ -0,0 +1,158 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestBatchJobValidate(t *testing.T) {
+	testCases := []struct {
+		copy    madmin.BatchJobCopy
+		success bool
+	}{
+		{madmin.BatchJobCopy{Source: madmin.BatchJobCopySource{Bucket: "src"}, Target: madmin.BatchJobCopyTarget{Bucket: "dst"}}, true},
+		{madmin.BatchJobCopy{Source: madmin.BatchJobCopySource{Bucket: "src", Prefix: "a/"}, Target: madmin.BatchJobCopyTarget{Bucket: "src", Prefix: "b/"}}, true},
+		// Copy into the source prefix.
+		{madmin.BatchJobCopy{Source: madmin.BatchJobCopySource{Bucket: "src"}, Target: madmin.BatchJobCopyTarget{Bucket: "src"}}, false},
+		{madmin.BatchJobCopy{Source: madmin.BatchJobCopySource{Bucket: "src", Prefix: "a/"}, Target: madmin.BatchJobCopyTarget{Bucket: "src", Prefix: "a/b/"}}, false},
+		// Missing buckets.
+		{madmin.BatchJobCopy{Source: madmin.BatchJobCopySource{Bucket: "src"}}, false},
+		{madmin.BatchJobCopy{Target: madmin.BatchJobCopyTarget{Bucket: "dst"}}, false},
+	}
+	for i, testCase := range testCases {
+		job := &batchJob{Copy: testCase.copy}
+		err := job.validate()
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestBatchJobCopy(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testBatchJobCopy, []string{"PutObject"})
+}
+
+func testBatchJobCopy(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(apiRouter)
+	defer server.Close()
+
+	targetBucket := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(ctx, targetBucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	objects := map[string][]byte{
+		"data/a.txt":     []byte("hello"),
+		"data/sub/b.txt": bytes.Repeat([]byte("b"), 1024),
+		"other/c.txt":    []byte("not copied"),
+	}
+	for name, data := range objects {
+		opts := ObjectOptions{UserDefined: map[string]string{
+			"X-Amz-Meta-Origin":    "batch",
+			xhttp.ContentType:      "text/plain",
+			xhttp.AmzObjectTagging: "k=v",
+		}}
+		_, err := obj.PutObject(ctx, bucketName, name, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	job := &batchJob{
+		Copy: madmin.BatchJobCopy{
+			Source: madmin.BatchJobCopySource{Bucket: bucketName, Prefix: "data/"},
+			Target: madmin.BatchJobCopyTarget{
+				Bucket:    targetBucket,
+				Prefix:    "copy/",
+				Endpoint:  server.URL,
+				AccessKey: credentials.AccessKey,
+				SecretKey: credentials.SecretKey,
+				Region:    globalMinioDefaultRegion,
+			},
+			BandwidthLimit: 1024 * 1024,
+		},
+	}
+	if err := job.copy(ctx, obj, func() (bool, error) { return false, nil }); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if job.Info.Objects != 2 || job.Info.Bytes != 5+1024 || job.Info.Failed != 0 {
+		t.Fatalf("%s: unexpected job progress %+v", instanceType, job.Info)
+	}
+	if job.Info.LastObject != "data/sub/b.txt" {
+		t.Errorf("%s: expected last object data/sub/b.txt, got %s", instanceType, job.Info.LastObject)
+	}
+
+	for name, target := range map[string]string{"data/a.txt": "copy/a.txt", "data/sub/b.txt": "copy/sub/b.txt"} {
+		var buf bytes.Buffer
+		if err := obj.GetObject(ctx, targetBucket, target, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %s: %v", instanceType, target, err)
+		}
+		if !bytes.Equal(buf.Bytes(), objects[name]) {
+			t.Errorf("%s: %s: content mismatch", instanceType, target)
+		}
+		oi, err := obj.GetObjectInfo(ctx, targetBucket, target, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s: %v", instanceType, target, err)
+		}
+		if oi.UserDefined["X-Amz-Meta-Origin"] != "batch" || oi.ContentType != "text/plain" {
+			t.Errorf("%s: %s: metadata not preserved %v", instanceType, target, oi.UserDefined)
+		}
+		if oi.UserTags != "k=v" {
+			t.Errorf("%s: %s: expected tags k=v, got %s", instanceType, target, oi.UserTags)
+		}
+	}
+	if _, err := obj.GetObjectInfo(ctx, targetBucket, "copy/c.txt", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Errorf("%s: expected objects outside the source prefix not to be copied, got %v", instanceType, err)
+	}
+
+	// Resuming a completed job doesn't copy anything again.
+	job.Info.Objects, job.Info.Bytes = 0, 0
+	if err := job.copy(ctx, obj, func() (bool, error) { return false, nil }); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if job.Info.Objects != 0 {
+		t.Errorf("%s: expected no objects to be copied on resume, got %d", instanceType, job.Info.Objects)
+	}
+}
+
+func TestBatchJobCredentials(t *testing.T) {
+	cred, err := batchJobCredentials(globalActiveCred.AccessKey)
+	if err != nil || cred.SecretKey != globalActiveCred.SecretKey {
+		t.Fatalf("expected the root credentials, got %v", err)
+	}
+	// Jobs never run without a user, nor as a user which is unknown.
+	for _, accessKey := range []string{"", "unknown-user"} {
+		if _, err = batchJobCredentials(accessKey); err != errNoSuchUser {
+			t.Errorf("%q: expected %v, got %v", accessKey, errNoSuchUser, err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
+	"github.com/minio/minio/pkg/bucket/policy"
+)
+
+const (
+	// CORS configuration file.
+	bucketCorsConfig = "cors.xml"
+)
+
+// PutBucketCorsHandler - This HTTP handler stores given bucket CORS configuration as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+func (api objectAPIHandlers) PutBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, "PutBucketCors", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	// PutBucketCors always needs a Content-Md5
+	if _, ok := r.Header[xhttp.ContentMD5]; !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentMD5), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketCorsAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := cors.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(bucket, bucketCorsConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketCorsHandler - This HTTP handler returns bucket CORS configuration.
+func (api objectAPIHandlers) GetBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, "GetBucketCors", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketCorsAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetCorsConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Write CORS configuration to client.
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketCorsHandler - This HTTP handler removes bucket CORS configuration.
+func (api objectAPIHandlers) DeleteBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, "DeleteBucketCors", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketCorsAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err := globalBucketMetadataSys.Update(bucket, bucketCorsConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Success.
+	writeSuccessNoContent(w)
+}
+
+// getRequestCorsConfig returns the CORS configuration of the bucket
+// addressed by the request, nil if the bucket has none. Only already
+// loaded bucket metadata is consulted, so that cross-origin requests
+// for unknown buckets never reach the backend.
+func getRequestCorsConfig(r *http.Request) *cors.Config {
+	if globalBucketMetadataSys == nil {
+		return nil
+	}
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil {
+		return nil
+	}
+	bucket, _ := path2BucketObject(resource)
+	if bucket == "" || isMinioMetaBucketName(bucket) {
+		return nil
+	}
+	return globalBucketMetadataSys.getLoadedCorsConfig(bucket)
+}
+
+// serveBucketCors evaluates a cross-origin request against the bucket
+// CORS rules. Preflight requests are answered directly, all other
+// requests are passed on to the handler with the matching rule's
+// response headers set.
+func serveBucketCors(w http.ResponseWriter, r *http.Request, config *cors.Config, handler http.Handler) {
+	origin := r.Header.Get(xhttp.Origin)
+	header := w.Header()
+
+	if r.Method == http.MethodOptions && r.Header.Get(xhttp.AccessControlRequestMethod) != "" {
+		header.Add(xhttp.Vary, xhttp.Origin)
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		var reqHeaders []string
+		for _, h := range strings.Split(r.Header.Get(xhttp.AccessControlRequestHeaders), ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				reqHeaders = append(reqHeaders, h)
+			}
+		}
+
+		rule := config.Match(origin, r.Header.Get(xhttp.AccessControlRequestMethod), reqHeaders)
+		if rule == nil {
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		header.Set(xhttp.AccessControlAllowOrigin, origin)
+		header.Set(xhttp.AccessControlAllowCredentials, "true")
+		header.Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+		if len(reqHeaders) > 0 {
+			header.Set(xhttp.AccessControlAllowHeaders, strings.Join(reqHeaders, ", "))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			header.Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	header.Add(xhttp.Vary, xhttp.Origin)
+	if rule := config.Match(origin, r.Method, nil); rule != nil {
+		header.Set(xhttp.AccessControlAllowOrigin, origin)
+		header.Set(xhttp.AccessControlAllowCredentials, "true")
+		if len(rule.ExposeHeaders) > 0 {
+			header.Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+		}
+	}
+	handler.ServeHTTP(w, r)
+}
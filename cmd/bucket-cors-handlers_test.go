@@ -0,0 +1,94 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+func TestServeBucketCors(t *testing.T) {
+	config := &cors.Config{
+		Rules: []cors.Rule{
+			{
+				AllowedOrigins: []string{"https://*.example.com"},
+				AllowedMethods: []string{http.MethodGet, http.MethodPut},
+				AllowedHeaders: []string{"x-amz-*", "Content-Type"},
+				ExposeHeaders:  []string{"ETag"},
+				MaxAgeSeconds:  300,
+			},
+		},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	testCases := []struct {
+		method        string
+		origin        string
+		reqMethod     string
+		reqHeaders    string
+		expectedCode  int
+		expectedAllow string
+		expectedAge   string
+		expectedExp   string
+	}{
+		// Preflight allowed by the rule.
+		{http.MethodOptions, "https://www.example.com", http.MethodPut, "X-Amz-Date, content-type", http.StatusOK, "https://www.example.com", "300", ""},
+		// Preflight with a method not allowed.
+		{http.MethodOptions, "https://www.example.com", http.MethodDelete, "", http.StatusForbidden, "", "", ""},
+		// Preflight with a header not allowed.
+		{http.MethodOptions, "https://www.example.com", http.MethodGet, "Authorization", http.StatusForbidden, "", "", ""},
+		// Preflight from an origin not allowed.
+		{http.MethodOptions, "https://example.org", http.MethodGet, "", http.StatusForbidden, "", "", ""},
+		// Actual request allowed by the rule.
+		{http.MethodGet, "https://www.example.com", "", "", http.StatusNoContent, "https://www.example.com", "", "ETag"},
+		// Actual request not allowed, served without CORS headers.
+		{http.MethodGet, "https://example.org", "", "", http.StatusNoContent, "", "", ""},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, "http://localhost:9000/bucket/object", nil)
+		req.Header.Set(xhttp.Origin, testCase.origin)
+		if testCase.reqMethod != "" {
+			req.Header.Set(xhttp.AccessControlRequestMethod, testCase.reqMethod)
+		}
+		if testCase.reqHeaders != "" {
+			req.Header.Set(xhttp.AccessControlRequestHeaders, testCase.reqHeaders)
+		}
+		rec := httptest.NewRecorder()
+		serveBucketCors(rec, req, config, handler)
+
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedCode, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlAllowOrigin); got != testCase.expectedAllow {
+			t.Errorf("Test %d: expected allowed origin %q, got %q", i+1, testCase.expectedAllow, got)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlMaxAge); got != testCase.expectedAge {
+			t.Errorf("Test %d: expected max age %q, got %q", i+1, testCase.expectedAge, got)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlExposeHeaders); got != testCase.expectedExp {
+			t.Errorf("Test %d: expected exposed headers %q, got %q", i+1, testCase.expectedExp, got)
+		}
+	}
+}
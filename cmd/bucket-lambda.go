@@ -0,0 +1,137 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	bucketLambdaConfigFile = "lambda.json"
+
+	// Default time allowed for the transformation webhook
+	// to start responding to an object lambda request.
+	defaultLambdaTimeout = 30 * time.Second
+)
+
+var (
+	globalLambdaClientOnce sync.Once
+	globalLambdaClient     *http.Client
+)
+
+// parseBucketLambda parses BucketLambda from json
+func parseBucketLambda(bucket string, data []byte) (lambdaCfg *madmin.BucketLambda, err error) {
+	lambdaCfg = &madmin.BucketLambda{}
+	if err = json.Unmarshal(data, lambdaCfg); err != nil {
+		return lambdaCfg, err
+	}
+	if !lambdaCfg.IsValid() {
+		return lambdaCfg, fmt.Errorf("Invalid lambda config %#v", lambdaCfg)
+	}
+	return
+}
+
+// getLambdaClient returns the HTTP client used to reach
+// transformation webhooks, shared across all buckets.
+func getLambdaClient() *http.Client {
+	globalLambdaClientOnce.Do(func() {
+		globalLambdaClient = &http.Client{
+			Transport: newCustomHTTPTransport(&tls.Config{
+				RootCAs: globalRootCAs,
+			}, defaultDialTimeout)(),
+		}
+	})
+	return globalLambdaClient
+}
+
+// lambdaResponse wraps the webhook response body, closing it
+// releases the underlying request context.
+type lambdaResponse struct {
+	*http.Response
+	cancel context.CancelFunc
+}
+
+// Close closes the response body and releases the request context.
+func (l *lambdaResponse) Close() error {
+	err := l.Body.Close()
+	l.cancel()
+	return err
+}
+
+// transformObject streams the object content to the transformation
+// webhook configured for the bucket, the returned response body is
+// the transformed object. The configured timeout only covers the time
+// until the webhook starts responding, the transformed stream itself
+// is proxied until the client or the webhook closes it.
+func transformObject(ctx context.Context, cfg *madmin.BucketLambda, objInfo ObjectInfo, body io.Reader) (*lambdaResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	req, err := http.NewRequest(http.MethodPost, cfg.Endpoint, body)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.ContentLength = objInfo.Size
+	if objInfo.ContentType != "" {
+		req.Header.Set(xhttp.ContentType, objInfo.ContentType)
+	}
+	if cfg.AuthToken != "" {
+		req.Header.Set(xhttp.Authorization, "Bearer "+cfg.AuthToken)
+	}
+	req.Header.Set(xhttp.MinIOLambdaBucket, objInfo.Bucket)
+	req.Header.Set(xhttp.MinIOLambdaObject, objInfo.Name)
+	if objInfo.VersionID != "" {
+		req.Header.Set(xhttp.MinIOLambdaVersionID, objInfo.VersionID)
+	}
+	req.Header.Set(xhttp.MinIOLambdaETag, "\""+objInfo.ETag+"\"")
+
+	timer := time.AfterFunc(cfg.TimeoutDuration(defaultLambdaTimeout), cancel)
+	resp, err := getLambdaClient().Do(req)
+	if !timer.Stop() {
+		// Timer already fired, request context is canceled.
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		return nil, OperationTimedOut{}
+	}
+	if err != nil {
+		cancel()
+		return nil, LambdaTransformFailed{Bucket: objInfo.Bucket, Object: objInfo.Name, Err: err}
+	}
+	if resp.StatusCode != http.StatusOK {
+		xhttp.DrainBody(resp.Body)
+		cancel()
+		return nil, LambdaTransformFailed{
+			Bucket: objInfo.Bucket,
+			Object: objInfo.Name,
+			Err:    fmt.Errorf("transformation webhook returned %s", resp.Status),
+		}
+	}
+	return &lambdaResponse{Response: resp, cancel: cancel}, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseBucketLambda(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr bool
+	}{
+		{`{}`, false},
+		{`{"endpoint":"http://localhost:8080/transform"}`, false},
+		{`{"endpoint":"https://localhost/transform","authToken":"token","timeout":10}`, false},
+		{`{"endpoint":"ftp://localhost/transform"}`, true},
+		{`{"endpoint":"http:///transform"}`, true},
+		{`{"endpoint":"http://localhost","timeout":-1}`, true},
+		{`{"endpoint":`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseBucketLambda("bucket", []byte(testCase.data))
+		if testCase.expectedErr != (err != nil) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestTransformObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(xhttp.Authorization) != "Bearer token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get(xhttp.MinIOLambdaObject) == "slow" {
+			time.Sleep(2 * time.Second)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set(xhttp.ContentType, "text/plain")
+		w.Write(bytes.ToUpper(data))
+	}))
+	defer server.Close()
+
+	objInfo := ObjectInfo{Bucket: "bucket", Name: "object", Size: 5, ETag: "etag"}
+
+	cfg := &madmin.BucketLambda{Endpoint: server.URL, AuthToken: "token"}
+	resp, err := transformObject(context.Background(), cfg, objInfo, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "HELLO" {
+		t.Errorf("expected transformed content 'HELLO', got %q", string(data))
+	}
+
+	cfg = &madmin.BucketLambda{Endpoint: server.URL}
+	if _, err = transformObject(context.Background(), cfg, objInfo, strings.NewReader("hello")); err == nil {
+		t.Error("expected transformation to fail without auth token")
+	} else if _, ok := err.(LambdaTransformFailed); !ok {
+		t.Errorf("expected LambdaTransformFailed, got %T", err)
+	}
+
+	objInfo.Name = "slow"
+	cfg = &madmin.BucketLambda{Endpoint: server.URL, AuthToken: "token", Timeout: 1}
+	if _, err = transformObject(context.Background(), cfg, objInfo, strings.NewReader("hello")); err == nil {
+		t.Error("expected transformation to time out")
+	} else if _, ok := err.(OperationTimedOut); !ok {
+		t.Errorf("expected OperationTimedOut, got %T", err)
+	}
+}
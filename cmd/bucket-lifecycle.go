@@ -129,6 +129,105 @@ func initBackgroundTransition(ctx context.Context, objectAPI ObjectLayer) {
 	}
 }
 
+type expiryTask struct {
+	objInfo ObjectInfo
+	lcOpts  lifecycle.ObjectOpts
+}
+
+type expiryState struct {
+	expiryCh chan expiryTask
+}
+
+// queueExpiryTask queues the expiry of an object version, it returns
+// false when the queue is full.
+func (es *expiryState) queueExpiryTask(oi ObjectInfo, lcOpts lifecycle.ObjectOpts) bool {
+	select {
+	case es.expiryCh <- expiryTask{objInfo: oi, lcOpts: lcOpts}:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	globalExpiryState *expiryState
+)
+
+func newExpiryState() *expiryState {
+	es := &expiryState{
+		expiryCh: make(chan expiryTask, 10000),
+	}
+	go func() {
+		<-GlobalContext.Done()
+		close(es.expiryCh)
+	}()
+	return es
+}
+
+// addWorker creates a new worker to process tasks
+func (es *expiryState) addWorker(ctx context.Context, objectAPI ObjectLayer) {
+	// Add a new worker.
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case t, ok := <-es.expiryCh:
+				if !ok {
+					return
+				}
+				if err := expireObjectVersion(ctx, objectAPI, t.objInfo, t.lcOpts); err != nil {
+					logger.LogIf(ctx, err)
+				}
+			}
+		}
+	}()
+}
+
+func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
+	if globalExpiryState == nil {
+		return
+	}
+
+	// Use as many expiry workers as transition workers.
+	for i := 0; i < globalTransitionConcurrent; i++ {
+		globalExpiryState.addWorker(ctx, objectAPI)
+	}
+}
+
+// expireObjectVersion deletes an object version expired by a lifecycle
+// rule and notifies the deletion.
+func expireObjectVersion(ctx context.Context, objectAPI ObjectLayer, oi ObjectInfo, lcOpts lifecycle.ObjectOpts) error {
+	if oi.TransitionStatus != "" {
+		// Notification is sent by deleteTransitionedObject.
+		return deleteTransitionedObject(ctx, objectAPI, oi.Bucket, oi.Name, lcOpts, lifecycle.DeleteVersionAction, false)
+	}
+
+	obj, err := objectAPI.DeleteObject(ctx, oi.Bucket, oi.Name, ObjectOptions{
+		VersionID: oi.VersionID,
+	})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	eventName := event.ObjectRemovedDelete
+	if obj.DeleteMarker {
+		eventName = event.ObjectRemovedDeleteMarkerCreated
+	}
+
+	// Notify object deleted event.
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: oi.Bucket,
+		Object:     obj,
+		Host:       "Internal: [ILM-EXPIRY]",
+	})
+	return nil
+}
+
 func validateLifecycleTransition(ctx context.Context, bucket string, lfc *lifecycle.Lifecycle) error {
 	for _, rule := range lfc.Rules {
 		if rule.Transition.StorageClass != "" {
@@ -310,6 +409,15 @@ func deleteTransitionedObject(ctx context.Context, objectAPI ObjectLayer, bucket
 	return nil
 }
 
+// lifecycleObjectSize returns the size lifecycle object size filters are
+// evaluated against, which is the size of the object as it was uploaded.
+func lifecycleObjectSize(oi ObjectInfo) int64 {
+	if size, err := oi.GetActualSize(); err == nil {
+		return size
+	}
+	return oi.Size
+}
+
 // transition object to target specified by the transition ARN. When an object is transitioned to another
 // storage specified by the transition ARN, the metadata is left behind on source cluster and original content
 // is moved to the transition tier. Note that in the case of encrypted objects, entire encrypted stream is moved
@@ -322,6 +430,7 @@ func transitionObject(ctx context.Context, objectAPI ObjectLayer, objInfo Object
 	lcOpts := lifecycle.ObjectOpts{
 		Name:     objInfo.Name,
 		UserTags: objInfo.UserTags,
+		Size:     lifecycleObjectSize(objInfo),
 	}
 	arn := getLifecycleTransitionTargetArn(ctx, lc, objInfo.Bucket, lcOpts)
 	if arn == nil {
@@ -396,6 +505,7 @@ func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs
 	arn := getLifecycleTransitionTargetArn(ctx, lc, bucket, lifecycle.ObjectOpts{
 		Name:         object,
 		UserTags:     oi.UserTags,
+		Size:         lifecycleObjectSize(oi),
 		ModTime:      oi.ModTime,
 		VersionID:    oi.VersionID,
 		DeleteMarker: oi.DeleteMarker,
@@ -602,20 +712,24 @@ var (
 )
 
 // parse x-amz-restore header from user metadata to get the status of ongoing request and expiry of restoration
-// if any. This header value is of format: ongoing-request=true|false, expires=time
+// if any. This header value is of format: ongoing-request=true|false, expiry-date=time, the expiry is only
+// present once the object is restored.
 func parseRestoreHeaderFromMeta(meta map[string]string) (ongoing bool, expiry time.Time, err error) {
 	restoreHdr, ok := meta[xhttp.AmzRestore]
 	if !ok {
 		return ongoing, expiry, errRestoreHDRMissing
 	}
 	rslc := strings.SplitN(restoreHdr, ",", 2)
-	if len(rslc) != 2 {
-		return ongoing, expiry, errRestoreHDRMalformed
-	}
 	rstatusSlc := strings.SplitN(rslc[0], "=", 2)
-	if len(rstatusSlc) != 2 {
+	if len(rstatusSlc) != 2 || strings.TrimSpace(rstatusSlc[0]) != "ongoing-request" {
 		return ongoing, expiry, errRestoreHDRMalformed
 	}
+	if len(rslc) == 1 {
+		if rstatusSlc[1] != "true" {
+			return ongoing, expiry, errRestoreHDRMalformed
+		}
+		return true, expiry, nil
+	}
 	rExpSlc := strings.SplitN(rslc[1], "=", 2)
 	if len(rExpSlc) != 2 {
 		return ongoing, expiry, errRestoreHDRMalformed
@@ -632,7 +746,14 @@ func parseRestoreHeaderFromMeta(meta map[string]string) (ongoing bool, expiry ti
 // storage class. When PostObjectRestore API is called, a temporary copy of the object
 // is restored locally to the bucket on source cluster until the restore expiry date.
 // The copy that was transitioned continues to reside in the transitioned tier.
-func restoreTransitionedObject(ctx context.Context, bucket, object string, objAPI ObjectLayer, objInfo ObjectInfo, rreq *RestoreObjectRequest, restoreExpiry time.Time) error {
+// If the restore fails the ongoing restore request is cleared, such that the
+// restore can be requested again.
+func restoreTransitionedObject(ctx context.Context, bucket, object string, objAPI ObjectLayer, objInfo ObjectInfo, rreq *RestoreObjectRequest, restoreExpiry time.Time) (err error) {
+	defer func() {
+		if err != nil {
+			clearRestoreRequest(ctx, bucket, object, objAPI, objInfo)
+		}
+	}()
 	var rs *HTTPRangeSpec
 	gr, err := getTransitionedObjectReader(ctx, bucket, object, rs, http.Header{}, objInfo, ObjectOptions{
 		VersionID: objInfo.VersionID})
@@ -647,9 +768,71 @@ func restoreTransitionedObject(ctx context.Context, bucket, object string, objAP
 	pReader := NewPutObjReader(hashReader, nil, nil)
 	opts := putRestoreOpts(bucket, object, rreq, objInfo)
 	opts.UserDefined[xhttp.AmzRestore] = fmt.Sprintf("ongoing-request=%t, expiry-date=%s", false, restoreExpiry.Format(http.TimeFormat))
-	if _, err := objAPI.PutObject(ctx, bucket, object, pReader, opts); err != nil {
+	if _, err = objAPI.PutObject(ctx, bucket, object, pReader, opts); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// clearRestoreRequest removes the restore request metadata of a transitioned object.
+func clearRestoreRequest(ctx context.Context, bucket, object string, objAPI ObjectLayer, objInfo ObjectInfo) {
+	metadata := cloneMSS(objInfo.UserDefined)
+	delete(metadata, xhttp.AmzRestore)
+	delete(metadata, xhttp.AmzRestoreExpiryDays)
+	delete(metadata, xhttp.AmzRestoreRequestDate)
+	objInfo.UserDefined = metadata
+	objInfo.metadataOnly = true
+	if _, err := objAPI.CopyObject(ctx, bucket, object, bucket, object, objInfo, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to clear restore request of %s/%s(%s): %w", bucket, object, objInfo.VersionID, err))
+	}
+}
+
+// Internal metadata of a multipart upload recording the bucket, object and
+// initiation time of the upload, which cannot be inferred from the hashed
+// upload path when evaluating the AbortIncompleteMultipartUpload action.
+const (
+	multipartUploadObjectKey    = ReservedMetadataPrefix + "multipart-object"
+	multipartUploadInitiatedKey = ReservedMetadataPrefix + "multipart-initiated"
+)
+
+// setMultipartUploadLifecycleMeta records in the metadata of a new
+// multipart upload what is needed to evaluate the lifecycle of the upload.
+func setMultipartUploadLifecycleMeta(meta map[string]string, bucket, object string, initiated time.Time) {
+	meta[multipartUploadObjectKey] = pathJoin(bucket, object)
+	meta[multipartUploadInitiatedKey] = initiated.UTC().Format(time.RFC3339Nano)
+}
+
+// removeMultipartUploadLifecycleMeta removes the multipart upload lifecycle
+// metadata, such that it is not saved with the completed object.
+func removeMultipartUploadLifecycleMeta(meta map[string]string) {
+	delete(meta, multipartUploadObjectKey)
+	delete(meta, multipartUploadInitiatedKey)
+}
+
+// isAbortableUpload returns true if the multipart upload with the given
+// metadata is to be aborted as per the AbortIncompleteMultipartUpload
+// lifecycle action of its bucket, uploads without the lifecycle metadata
+// are only removed by the stale uploads expiry.
+func isAbortableUpload(meta map[string]string) bool {
+	bucket, object := path2BucketObject(meta[multipartUploadObjectKey])
+	if bucket == "" || object == "" {
+		return false
+	}
+	initiated, err := time.Parse(time.RFC3339Nano, meta[multipartUploadInitiatedKey])
+	if err != nil {
+		return false
+	}
+	if globalLifecycleSys == nil {
+		return false
+	}
+	lc, err := globalLifecycleSys.Get(bucket)
+	if err != nil {
+		return false
+	}
+	return lc.AbortIncompleteUpload(object, initiated)
+}
@@ -0,0 +1,152 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseRestoreHeaderFromMeta(t *testing.T) {
+	expiry := time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		header  string
+		ongoing bool
+		expiry  time.Time
+		err     error
+	}{
+		{header: "ongoing-request=true", ongoing: true},
+		{header: "ongoing-request=false, expiry-date=" + expiry.Format(http.TimeFormat), expiry: expiry},
+		{header: "ongoing-request=false", err: errRestoreHDRMalformed},
+		{header: "expiry-date=" + expiry.Format(http.TimeFormat), err: errRestoreHDRMalformed},
+		{header: "ongoing-request=false, expiry-date", err: errRestoreHDRMalformed},
+		{err: errRestoreHDRMissing},
+	}
+	for i, tc := range testCases {
+		meta := map[string]string{}
+		if tc.header != "" {
+			meta[xhttp.AmzRestore] = tc.header
+		}
+		ongoing, exp, err := parseRestoreHeaderFromMeta(meta)
+		if err != tc.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.err, err)
+		}
+		if ongoing != tc.ongoing || !exp.Equal(tc.expiry) {
+			t.Fatalf("Test %d: expected (%t, %s), got (%t, %s)", i+1, tc.ongoing, tc.expiry, ongoing, exp)
+		}
+	}
+}
+
+// Tests that deleting a transitioned object removes its data from the
+// tier configured by a size-filtered transition rule.
+func TestDeleteTransitionedObjectSizeFilter(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		removed []string
+	)
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			removed = append(removed, r.URL.Path)
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer remote.Close()
+
+	u, err := url.Parse(remote.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clnt, err := miniogo.NewCore(u.Host, &miniogo.Options{
+		Creds:  credentials.NewStaticV4("access", "secretkey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	restoreBucketMetadataSys := globalBucketMetadataSys
+	restoreBucketTargetSys := globalBucketTargetSys
+	defer func() {
+		globalBucketMetadataSys = restoreBucketMetadataSys
+		globalBucketTargetSys = restoreBucketTargetSys
+		resetGlobalObjectAPI()
+	}()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalBucketTargetSys = NewBucketTargetSys()
+	setObjectLayer(obj)
+
+	bucket := "bucket"
+	lc, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration><Rule><Filter><ObjectSizeGreaterThan>1048576</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata(bucket)
+	meta.lifecycleConfig = lc
+	globalBucketMetadataSys.Set(bucket, meta)
+
+	arn := madmin.ARN{Type: madmin.ILMService, Region: "us-east-1", ID: "id", Bucket: "tier"}
+	globalBucketTargetSys.targetsMap[bucket] = []madmin.BucketTarget{{
+		SourceBucket: bucket,
+		TargetBucket: arn.Bucket,
+		Credentials:  &auth.Credentials{AccessKey: "access", SecretKey: "secretkey"},
+		Arn:          arn.String(),
+		Label:        "WARM",
+	}}
+	globalBucketTargetSys.arnRemotesMap[arn.String()] = clnt
+
+	oi := ObjectInfo{
+		Bucket:           bucket,
+		Name:             "large-object",
+		Size:             2 << 20,
+		TransitionStatus: lifecycle.TransitionComplete,
+	}
+	err = deleteTransitionedObject(context.Background(), obj, bucket, oi.Name, lifecycle.ObjectOpts{
+		Name:             oi.Name,
+		Size:             lifecycleObjectSize(oi),
+		TransitionStatus: oi.TransitionStatus,
+	}, lifecycle.DeleteAction, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(removed) != 1 || removed[0] != "/tier/large-object" {
+		t.Fatalf("expected transitioned data to be removed from the tier, removed %v", removed)
+	}
+}
@@ -0,0 +1,289 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// Maximum size of an encrypted bucket metadata bundle.
+const maxBucketMetadataBundleSize = 32 * humanize.MiByte
+
+// bucketMetadataBundleFiles are the bucket configurations which
+// are part of an exported bucket metadata bundle, in the order they
+// are applied on import.
+var bucketMetadataBundleFiles = []string{
+	objectLockConfig,
+	bucketVersioningConfig,
+	bucketPolicyConfig,
+	bucketNotificationConfig,
+	bucketLifecycleConfig,
+	bucketSSEConfig,
+	bucketTaggingConfig,
+	bucketQuotaConfigFile,
+	bucketTargetsFile,
+	bucketReplicationConfig,
+	bucketLambdaConfigFile,
+	bucketPlacementConfigFile,
+	bucketCorsConfig,
+}
+
+// bundleConfig returns the content of configFile as exported in a
+// bucket metadata bundle, nil is returned if the config is not set.
+func (b *BucketMetadata) bundleConfig(configFile string) ([]byte, error) {
+	switch configFile {
+	case bucketPolicyConfig:
+		return b.PolicyConfigJSON, nil
+	case bucketNotificationConfig:
+		return b.NotificationConfigXML, nil
+	case bucketLifecycleConfig:
+		return b.LifecycleConfigXML, nil
+	case bucketSSEConfig:
+		return b.EncryptionConfigXML, nil
+	case bucketTaggingConfig:
+		return b.TaggingConfigXML, nil
+	case bucketQuotaConfigFile:
+		return b.QuotaConfigJSON, nil
+	case objectLockConfig:
+		return b.ObjectLockConfigXML, nil
+	case bucketVersioningConfig:
+		return b.VersioningConfigXML, nil
+	case bucketReplicationConfig:
+		return b.ReplicationConfigXML, nil
+	case bucketTargetsFile:
+		// Bucket targets are stored encrypted, export them in plain
+		// text as the whole bundle is encrypted in transit.
+		if b.bucketTargetConfig == nil || len(b.bucketTargetConfig.Targets) == 0 {
+			return nil, nil
+		}
+		return json.Marshal(b.bucketTargetConfig)
+	case bucketLambdaConfigFile:
+		return b.LambdaConfigJSON, nil
+	case bucketPlacementConfigFile:
+		return b.PlacementConfigJSON, nil
+	case bucketCorsConfig:
+		return b.CorsConfigXML, nil
+	}
+	return nil, fmt.Errorf("Unknown bucket %s metadata config %s", b.Name, configFile)
+}
+
+// setBundleConfig sets configFile of an imported bucket metadata
+// bundle, configs have to be parsed with parseAllConfigs afterwards.
+func (b *BucketMetadata) setBundleConfig(configFile string, configData []byte) error {
+	switch configFile {
+	case bucketPolicyConfig:
+		b.PolicyConfigJSON = configData
+	case bucketNotificationConfig:
+		b.NotificationConfigXML = configData
+	case bucketLifecycleConfig:
+		b.LifecycleConfigXML = configData
+	case bucketSSEConfig:
+		b.EncryptionConfigXML = configData
+	case bucketTaggingConfig:
+		b.TaggingConfigXML = configData
+	case bucketQuotaConfigFile:
+		b.QuotaConfigJSON = configData
+	case objectLockConfig:
+		b.ObjectLockConfigXML = configData
+	case bucketVersioningConfig:
+		b.VersioningConfigXML = configData
+	case bucketReplicationConfig:
+		b.ReplicationConfigXML = configData
+	case bucketTargetsFile:
+		b.BucketTargetsConfigJSON = configData
+	case bucketLambdaConfigFile:
+		b.LambdaConfigJSON = configData
+	case bucketPlacementConfigFile:
+		b.PlacementConfigJSON = configData
+	case bucketCorsConfig:
+		b.CorsConfigXML = configData
+	default:
+		return fmt.Errorf("Unknown bucket %s metadata config %s", b.Name, configFile)
+	}
+	return nil
+}
+
+// writeBucketMetadataBundle adds the configs of the bucket to the
+// bundle, each config is a '<bucket>/<config file>' zip entry.
+func writeBucketMetadataBundle(zw *zip.Writer, meta *BucketMetadata) error {
+	for _, configFile := range bucketMetadataBundleFiles {
+		configData, err := meta.bundleConfig(configFile)
+		if err != nil {
+			return err
+		}
+		if len(configData) == 0 {
+			continue
+		}
+		header := &zip.FileHeader{
+			Name:     path.Join(meta.Name, configFile),
+			Method:   zip.Deflate,
+			Modified: UTCNow(),
+		}
+		zwriter, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err = zwriter.Write(configData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBucketMetadataBundle reads and validates all bucket configs of
+// a bucket metadata bundle, the bucket metadata is returned by bucket.
+func readBucketMetadataBundle(ctx context.Context, objAPI ObjectLayer, zr *zip.Reader) (map[string]*BucketMetadata, error) {
+	metas := make(map[string]*BucketMetadata)
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		bucket, configFile := path.Split(file.Name)
+		bucket = strings.TrimSuffix(bucket, SlashSeparator)
+		if !IsValidBucketName(bucket) || isMinioMetaBucketName(bucket) {
+			return nil, fmt.Errorf("Invalid bucket name %q in bucket metadata bundle", bucket)
+		}
+
+		meta, ok := metas[bucket]
+		if !ok {
+			m := newBucketMetadata(bucket)
+			meta = &m
+			metas[bucket] = meta
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		configData, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if err = meta.setBundleConfig(configFile, configData); err != nil {
+			return nil, err
+		}
+	}
+
+	for bucket, meta := range metas {
+		if err := meta.parseAllConfigs(ctx, objAPI); err != nil {
+			return nil, fmt.Errorf("Invalid bucket %s metadata: %w", bucket, err)
+		}
+	}
+	return metas, nil
+}
+
+// importBucketMetadataBundle applies the configs of all buckets of an
+// imported bucket metadata bundle, in the order of their names. Every
+// bucket is checked before any bucket is modified.
+func importBucketMetadataBundle(ctx context.Context, objAPI ObjectLayer, metas map[string]*BucketMetadata) error {
+	buckets := make([]string, 0, len(metas))
+	for bucket := range metas {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+
+	for _, bucket := range buckets {
+		if err := checkBucketMetadataImport(ctx, objAPI, metas[bucket]); err != nil {
+			return err
+		}
+	}
+	for _, bucket := range buckets {
+		if err := importBucketMetadata(ctx, objAPI, metas[bucket]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBucketMetadataImport verifies the configs of an imported bucket
+// metadata bundle which can only be validated against the running
+// server and the existing bucket.
+func checkBucketMetadataImport(ctx context.Context, objAPI ObjectLayer, meta *BucketMetadata) error {
+	if meta.notificationConfig != nil && len(meta.NotificationConfigXML) != 0 {
+		if err := meta.notificationConfig.Validate(globalServerRegion, globalNotificationSys.targetList); err != nil {
+			return err
+		}
+	}
+
+	// Object locking can only be enabled on bucket creation.
+	if meta.objectLockConfig != nil && meta.objectLockConfig.ToRetention().LockEnabled {
+		if _, err := objAPI.GetBucketInfo(ctx, meta.Name); err == nil {
+			if rcfg, _ := globalBucketObjectLockSys.Get(meta.Name); !rcfg.LockEnabled {
+				return BucketObjectLockConfigNotFound{Bucket: meta.Name}
+			}
+		}
+	}
+	return nil
+}
+
+// importBucketMetadata applies the configs of an imported bucket
+// metadata bundle to the bucket, the bucket is created if missing.
+func importBucketMetadata(ctx context.Context, objAPI ObjectLayer, meta *BucketMetadata) error {
+	_, err := objAPI.GetBucketInfo(ctx, meta.Name)
+	switch err.(type) {
+	case nil:
+	case BucketNotFound:
+		opts := BucketOptions{
+			Location:    globalServerRegion,
+			LockEnabled: meta.objectLockConfig != nil && meta.objectLockConfig.ToRetention().LockEnabled,
+		}
+		if err = objAPI.MakeBucketWithLocation(ctx, meta.Name, opts); err != nil {
+			return err
+		}
+		if globalDNSConfig != nil {
+			if err = globalDNSConfig.Put(meta.Name); err != nil {
+				objAPI.DeleteBucket(ctx, meta.Name, false)
+				return err
+			}
+		}
+	default:
+		return err
+	}
+
+	if meta.bucketTargetConfig != nil {
+		for i := range meta.bucketTargetConfig.Targets {
+			meta.bucketTargetConfig.Targets[i].SourceBucket = meta.Name
+		}
+	}
+
+	for _, configFile := range bucketMetadataBundleFiles {
+		configData, err := meta.bundleConfig(configFile)
+		if err != nil {
+			return err
+		}
+		if len(configData) == 0 {
+			continue
+		}
+		if err = globalBucketMetadataSys.Update(meta.Name, configFile, configData); err != nil {
+			return err
+		}
+		if configFile == bucketTargetsFile {
+			globalBucketTargetSys.UpdateAllTargets(meta.Name, meta.bucketTargetConfig)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestBucketMetadataBundle(t *testing.T) {
+	meta := newBucketMetadata("bucket")
+	meta.PolicyConfigJSON = []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`)
+	meta.LifecycleConfigXML = []byte(`<LifecycleConfiguration><Rule><ID>rule</ID><Status>Enabled</Status><Filter></Filter><Expiration><Days>3</Days></Expiration></Rule></LifecycleConfiguration>`)
+	meta.QuotaConfigJSON = []byte(`{"quota":1024,"quotatype":"hard"}`)
+	meta.CorsConfigXML = []byte(`<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`)
+	meta.bucketTargetConfig = &madmin.BucketTargets{
+		Targets: []madmin.BucketTarget{{
+			SourceBucket: "bucket",
+			Endpoint:     "replica:9000",
+			TargetBucket: "target",
+			Arn:          "arn:minio:replication::id:target",
+			Type:         madmin.ReplicationService,
+		}},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := writeBucketMetadataBundle(zw, &meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != 5 {
+		t.Fatalf("expected 5 configs in bundle, got %d", len(zr.File))
+	}
+
+	metas, err := readBucketMetadataBundle(context.Background(), nil, zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, ok := metas["bucket"]
+	if !ok || len(metas) != 1 {
+		t.Fatalf("unexpected buckets in bundle %v", metas)
+	}
+	if !bytes.Equal(imported.PolicyConfigJSON, meta.PolicyConfigJSON) ||
+		!bytes.Equal(imported.LifecycleConfigXML, meta.LifecycleConfigXML) ||
+		!bytes.Equal(imported.QuotaConfigJSON, meta.QuotaConfigJSON) ||
+		!bytes.Equal(imported.CorsConfigXML, meta.CorsConfigXML) {
+		t.Fatal("configs differ after import")
+	}
+	if imported.quotaConfig.Quota != 1024 || imported.lifecycleConfig == nil || imported.corsConfig == nil {
+		t.Fatal("configs were not parsed on import")
+	}
+	if len(imported.bucketTargetConfig.Targets) != 1 || imported.bucketTargetConfig.Targets[0].Arn != meta.bucketTargetConfig.Targets[0].Arn {
+		t.Fatalf("unexpected bucket targets %v", imported.bucketTargetConfig)
+	}
+}
+
+func TestReadBucketMetadataBundleInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "bucket/unknown.json", data: `{}`},
+		{name: "In_Valid/policy.json", data: `{}`},
+		{name: minioMetaBucket + "/quota.json", data: `{"quota":1024,"quotatype":"hard"}`},
+		{name: "bucket/lifecycle.xml", data: `<LifecycleConfiguration>`},
+		{name: "bucket/quota.json", data: `{"quota":"hard"}`},
+	}
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		f, err := zw.Create(testCase.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.Write([]byte(testCase.data)); err != nil {
+			t.Fatal(err)
+		}
+		if err = zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = readBucketMetadataBundle(context.Background(), nil, zr); err == nil {
+			t.Errorf("Test %d: expected %s to be rejected", i+1, testCase.name)
+		}
+	}
+}
+
+func TestImportBucketMetadataBundleInvalid(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	restoreBucketMetadataSys := globalBucketMetadataSys
+	restoreNotificationSys := globalNotificationSys
+	defer func() {
+		globalBucketMetadataSys = restoreBucketMetadataSys
+		globalNotificationSys = restoreNotificationSys
+		resetGlobalObjectAPI()
+	}()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalNotificationSys = NewNotificationSys(nil)
+	setObjectLayer(obj)
+
+	valid := newBucketMetadata("bucket-a")
+	valid.QuotaConfigJSON = []byte(`{"quota":1024,"quotatype":"hard"}`)
+	// The notification target of the second bucket doesn't exist.
+	invalid := newBucketMetadata("bucket-b")
+	invalid.NotificationConfigXML = []byte(`<NotificationConfiguration><QueueConfiguration><Queue>arn:minio:sqs::1:webhook</Queue><Event>s3:ObjectCreated:*</Event></QueueConfiguration></NotificationConfiguration>`)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, meta := range []*BucketMetadata{&valid, &invalid} {
+		if err = writeBucketMetadataBundle(zw, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	metas, err := readBucketMetadataBundle(ctx, obj, zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = importBucketMetadataBundle(ctx, obj, metas); err == nil {
+		t.Fatal("expected import of an unknown notification target to fail")
+	}
+	for _, bucket := range []string{"bucket-a", "bucket-b"} {
+		if _, err = obj.GetBucketInfo(ctx, bucket); !isErrBucketNotFound(err) {
+			t.Errorf("expected bucket %s to be left untouched, got %v", bucket, err)
+		}
+	}
+}
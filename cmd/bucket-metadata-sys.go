@@ -26,6 +26,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
@@ -153,6 +154,14 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 		meta.TaggingConfigXML = configData
 	case bucketQuotaConfigFile:
 		meta.QuotaConfigJSON = configData
+	case bucketLambdaConfigFile:
+		meta.LambdaConfigJSON = configData
+	case bucketPlacementConfigFile:
+		meta.PlacementConfigJSON = configData
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+	case bucketReplicaConfigFile:
+		meta.ReplicaConfigJSON = configData
 	case objectLockConfig:
 		if !globalIsErasure && !globalIsDistErasure {
 			return NotImplemented{}
@@ -239,6 +248,30 @@ func (sys *BucketMetadataSys) GetTaggingConfig(bucket string) (*tags.Tags, error
 	return meta.taggingConfig, nil
 }
 
+// GetCorsConfig returns configured bucket CORS config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(bucket string) (*cors.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketCorsNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.corsConfig == nil {
+		return nil, BucketCorsNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, nil
+}
+
+// getLoadedCorsConfig returns the CORS config of a bucket whose
+// metadata is already loaded, nil otherwise.
+func (sys *BucketMetadataSys) getLoadedCorsConfig(bucket string) *cors.Config {
+	sys.RLock()
+	defer sys.RUnlock()
+	return sys.metadataMap[bucket].corsConfig
+}
+
 // GetObjectLockConfig returns configured object lock config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetObjectLockConfig(bucket string) (*objectlock.Config, error) {
@@ -344,6 +377,36 @@ func (sys *BucketMetadataSys) GetQuotaConfig(bucket string) (*madmin.BucketQuota
 	return meta.quotaConfig, nil
 }
 
+// GetLambdaConfig returns configured bucket object lambda
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLambdaConfig(bucket string) (*madmin.BucketLambda, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.lambdaConfig, nil
+}
+
+// GetPlacementConfig returns configured bucket placement policy
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetPlacementConfig(bucket string) (*madmin.BucketPlacement, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.placementConfig, nil
+}
+
+// GetReplicaConfig returns the configured replica mode of the bucket
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetReplicaConfig(bucket string) (*madmin.BucketReplicaConfig, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.replicaConfig, nil
+}
+
 // GetReplicationConfig returns configured bucket replication config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
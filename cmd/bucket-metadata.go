@@ -31,6 +31,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
@@ -78,6 +79,10 @@ type BucketMetadata struct {
 	ReplicationConfigXML        []byte
 	BucketTargetsConfigJSON     []byte
 	BucketTargetsConfigMetaJSON []byte
+	LambdaConfigJSON            []byte
+	PlacementConfigJSON         []byte
+	CorsConfigXML               []byte
+	ReplicaConfigJSON           []byte
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig           *policy.Policy
@@ -91,6 +96,10 @@ type BucketMetadata struct {
 	replicationConfig      *replication.Config
 	bucketTargetConfig     *madmin.BucketTargets
 	bucketTargetConfigMeta map[string]string
+	lambdaConfig           *madmin.BucketLambda
+	placementConfig        *madmin.BucketPlacement
+	corsConfig             *cors.Config
+	replicaConfig          *madmin.BucketReplicaConfig
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -107,6 +116,9 @@ func newBucketMetadata(name string) BucketMetadata {
 		},
 		bucketTargetConfig:     &madmin.BucketTargets{},
 		bucketTargetConfigMeta: make(map[string]string),
+		lambdaConfig:           &madmin.BucketLambda{},
+		placementConfig:        &madmin.BucketPlacement{},
+		replicaConfig:          &madmin.BucketReplicaConfig{},
 	}
 }
 
@@ -247,6 +259,42 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	} else {
 		b.bucketTargetConfig = &madmin.BucketTargets{}
 	}
+
+	if len(b.LambdaConfigJSON) != 0 {
+		b.lambdaConfig, err = parseBucketLambda(b.Name, b.LambdaConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.lambdaConfig = &madmin.BucketLambda{}
+	}
+
+	if len(b.PlacementConfigJSON) != 0 {
+		b.placementConfig, err = parseBucketPlacement(b.Name, b.PlacementConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.placementConfig = &madmin.BucketPlacement{}
+	}
+
+	if len(b.CorsConfigXML) != 0 {
+		b.corsConfig, err = cors.ParseConfig(bytes.NewReader(b.CorsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.corsConfig = nil
+	}
+
+	if len(b.ReplicaConfigJSON) != 0 {
+		b.replicaConfig, err = parseBucketReplicaConfig(b.Name, b.ReplicaConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.replicaConfig = &madmin.BucketReplicaConfig{}
+	}
 	return nil
 }
 
@@ -0,0 +1,59 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const bucketPlacementConfigFile = "placement.json"
+
+// parseBucketPlacement parses BucketPlacement from json
+func parseBucketPlacement(bucket string, data []byte) (placementCfg *madmin.BucketPlacement, err error) {
+	placementCfg = &madmin.BucketPlacement{}
+	if err = json.Unmarshal(data, placementCfg); err != nil {
+		return placementCfg, err
+	}
+	if !placementCfg.IsValid() {
+		return placementCfg, fmt.Errorf("Invalid placement config %#v", placementCfg)
+	}
+	return
+}
+
+// getPlacementPool returns the server pool new objects of the given
+// name and size are pinned to by the bucket placement policy, if any.
+func getPlacementPool(ctx context.Context, bucket, object string, size int64) (pool int, ok bool) {
+	if globalBucketMetadataSys == nil || isMinioMetaBucketName(bucket) {
+		return -1, false
+	}
+	cfg, err := globalBucketMetadataSys.GetPlacementConfig(bucket)
+	if err != nil {
+		// A bucket without metadata has no placement policy, any other
+		// error means the policy could not be honored for this object.
+		if !errors.Is(err, errConfigNotFound) {
+			logger.LogIf(ctx, err)
+		}
+		return -1, false
+	}
+	return cfg.Pool(object, size)
+}
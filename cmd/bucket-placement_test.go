@@ -0,0 +1,147 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseBucketPlacement(t *testing.T) {
+	testCases := []struct {
+		data    string
+		wantErr bool
+	}{
+		{data: `{}`},
+		{data: `{"rules":[{"prefix":"videos/","pool":1}]}`},
+		{data: `{"rules":[{"minSize":1073741824,"pool":0}]}`},
+		{data: `{"rules":[{"prefix":"videos/","pool":-1}]}`, wantErr: true},
+		{data: `{"rules":[{"minSize":-1,"pool":0}]}`, wantErr: true},
+		{data: `{"rules":`, wantErr: true},
+	}
+	for i, testCase := range testCases {
+		_, err := parseBucketPlacement("bucket", []byte(testCase.data))
+		if (err != nil) != testCase.wantErr {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.wantErr, err)
+		}
+	}
+}
+
+func TestBucketPlacementPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var fsDirs []string
+	var endpointServerPools EndpointServerPools
+	for i := 0; i < 2; i++ {
+		dirs, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsDirs = append(fsDirs, dirs...)
+		endpointServerPools = append(endpointServerPools, mustGetZoneEndpoints(dirs...)...)
+	}
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(ctx, endpointServerPools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureServerPools)
+
+	// Placement is resolved through the global object layer and bucket
+	// metadata, and parity through the global storage class, so point
+	// them all at this two pool setup.
+	restoreStorageClass := globalStorageClass
+	restoreBucketMetadataSys := globalBucketMetadataSys
+	defer func() {
+		globalStorageClass = restoreStorageClass
+		globalBucketMetadataSys = restoreBucketMetadataSys
+		resetGlobalObjectAPI()
+	}()
+	globalStorageClass, err = storageclass.LookupConfig(nil, obj.SetDriveCount())
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := newBucketMetadata(bucket)
+	meta.placementConfig = &madmin.BucketPlacement{
+		Rules: []madmin.PlacementRule{
+			{Prefix: "pool1/", Pool: 1},
+			{MinSize: 1 << 20, Pool: 0},
+		},
+	}
+	globalBucketMetadataSys.Set(bucket, meta)
+
+	testCases := []struct {
+		object string
+		size   int
+		pool   int
+	}{
+		{object: "pool1/object1", size: 1024, pool: 1},
+		{object: "pool1/object2", size: 2 << 20, pool: 1},
+		{object: "large/object", size: 2 << 20, pool: 0},
+	}
+	for i, testCase := range testCases {
+		data := bytes.Repeat([]byte("a"), testCase.size)
+		if _, err = obj.PutObject(ctx, bucket, testCase.object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		for pool := range z.serverPools {
+			_, err = z.serverPools[pool].GetObjectInfo(ctx, bucket, testCase.object, ObjectOptions{})
+			if (err == nil) != (pool == testCase.pool) {
+				t.Fatalf("Test %d: expected object on pool %d, found on pool %d: %v", i+1, testCase.pool, pool, err)
+			}
+		}
+	}
+
+	// Multipart uploads are placed by their declared size, unsized
+	// uploads only by the rules without a minimum size.
+	uploadCases := []struct {
+		object       string
+		declaredSize int64
+		pool         int
+	}{
+		{object: "pool1/upload", pool: 1},
+		{object: "large/upload", declaredSize: 2 << 20, pool: 0},
+	}
+	for i, testCase := range uploadCases {
+		uploadID, err := obj.NewMultipartUpload(ctx, bucket, testCase.object, ObjectOptions{DeclaredSize: testCase.declaredSize})
+		if err != nil {
+			t.Fatalf("Upload %d: unexpected error %v", i+1, err)
+		}
+		for pool := range z.serverPools {
+			_, err = z.serverPools[pool].GetMultipartInfo(ctx, bucket, testCase.object, uploadID, ObjectOptions{})
+			if (err == nil) != (pool == testCase.pool) {
+				t.Fatalf("Upload %d: expected upload on pool %d, found on pool %d: %v", i+1, testCase.pool, pool, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// isPurgeLocked returns true if the object version may not be purged
+// at time now because of an active legal hold or retention, versions
+// under governance mode are purged when bypassGovernance is set.
+func isPurgeLocked(oi ObjectInfo, bypassGovernance bool, now time.Time) bool {
+	if oi.DeleteMarker {
+		return false
+	}
+
+	lhold := objectlock.GetObjectLegalHoldMeta(oi.UserDefined)
+	if lhold.Status.Valid() && lhold.Status == objectlock.LegalHoldOn {
+		return true
+	}
+
+	ret := objectlock.GetObjectRetentionMeta(oi.UserDefined)
+	switch ret.Mode {
+	case objectlock.RetCompliance:
+		return ret.RetainUntilDate.After(now)
+	case objectlock.RetGovernance:
+		return !bypassGovernance && ret.RetainUntilDate.After(now)
+	}
+	return false
+}
+
+// purgeObjectVersions permanently removes all versions and delete markers
+// of the objects under prefix, locked versions are skipped. deleted is
+// called for each removed version.
+func purgeObjectVersions(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, opts madmin.PurgeVersionsOpts, deleted func(ObjectInfo)) (result madmin.PurgeVersionsResult, err error) {
+	now, err := objectlock.UTCNowNTP()
+	if err != nil {
+		return result, err
+	}
+
+	hasLifecycleConfig := false
+	if _, err := globalBucketMetadataSys.GetLifecycleConfig(bucket); err == nil {
+		hasLifecycleConfig = true
+	}
+
+	delOpts := ObjectOptions{
+		Versioned:        globalBucketVersioningSys.Enabled(bucket),
+		VersionSuspended: globalBucketVersioningSys.Suspended(bucket),
+	}
+
+	// Versions left behind are listed again once the version marker
+	// was removed, locked versions are only counted the first time.
+	locked := make(map[ObjectToDelete]struct{})
+
+	var marker, versionIDMarker string
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, bucket, prefix, marker, versionIDMarker, "", maxObjectList)
+		if err != nil {
+			return result, err
+		}
+
+		objects := make([]ObjectToDelete, 0, len(loi.Objects))
+		objInfos := make([]ObjectInfo, 0, len(loi.Objects))
+		for _, oi := range loi.Objects {
+			if isPurgeLocked(oi, opts.BypassGovernance, now) {
+				version := ObjectToDelete{ObjectName: oi.Name, VersionID: oi.VersionID}
+				if _, ok := locked[version]; !ok {
+					locked[version] = struct{}{}
+					result.Locked++
+				}
+				continue
+			}
+			if opts.DryRun {
+				result.Deleted++
+				continue
+			}
+			// Always remove an explicit version, the null version
+			// included, such that no delete marker is created.
+			versionID := oi.VersionID
+			if versionID == "" {
+				versionID = nullVersionID
+			}
+			objects = append(objects, ObjectToDelete{
+				ObjectName: oi.Name,
+				VersionID:  versionID,
+			})
+			objInfos = append(objInfos, oi)
+		}
+
+		if len(objects) > 0 {
+			dobjects, errs := objAPI.DeleteObjects(ctx, bucket, objects, delOpts)
+			for i := range objects {
+				if errs[i] != nil {
+					if !isErrObjectNotFound(errs[i]) && !isErrVersionNotFound(errs[i]) {
+						result.Failed++
+					}
+					continue
+				}
+				result.Deleted++
+				if hasLifecycleConfig && dobjects[i].PurgeTransitioned == lifecycle.TransitionComplete {
+					// clean up transitioned tier
+					deleteTransitionedObject(ctx, objAPI, bucket, objects[i].ObjectName, lifecycle.ObjectOpts{
+						Name:      objects[i].ObjectName,
+						UserTags:  objInfos[i].UserTags,
+						Size:      lifecycleObjectSize(objInfos[i]),
+						VersionID: objInfos[i].VersionID,
+					}, lifecycle.DeleteVersionAction, true)
+				}
+				if deleted != nil {
+					deleted(objInfos[i])
+				}
+			}
+		}
+
+		if !loi.IsTruncated {
+			return result, nil
+		}
+		marker = loi.NextMarker
+		versionIDMarker = loi.NextVersionIDMarker
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestIsPurgeLocked(t *testing.T) {
+	now := time.Now().UTC()
+	future := now.Add(time.Hour).Format(time.RFC3339)
+	past := now.Add(-time.Hour).Format(time.RFC3339)
+
+	retention := func(mode, until string) ObjectInfo {
+		return ObjectInfo{UserDefined: map[string]string{
+			objectlock.AmzObjectLockMode:            mode,
+			objectlock.AmzObjectLockRetainUntilDate: until,
+		}}
+	}
+
+	testCases := []struct {
+		oi     ObjectInfo
+		bypass bool
+		locked bool
+	}{
+		{oi: ObjectInfo{}},
+		{oi: ObjectInfo{DeleteMarker: true}},
+		{oi: ObjectInfo{UserDefined: map[string]string{objectlock.AmzObjectLockLegalHold: "ON"}}, bypass: true, locked: true},
+		{oi: ObjectInfo{UserDefined: map[string]string{objectlock.AmzObjectLockLegalHold: "OFF"}}},
+		{oi: retention("COMPLIANCE", future), bypass: true, locked: true},
+		{oi: retention("COMPLIANCE", past)},
+		{oi: retention("GOVERNANCE", future), locked: true},
+		{oi: retention("GOVERNANCE", future), bypass: true},
+		{oi: retention("GOVERNANCE", past)},
+	}
+	for i, testCase := range testCases {
+		if locked := isPurgeLocked(testCase.oi, testCase.bypass, now); locked != testCase.locked {
+			t.Errorf("Test %d: expected locked %t, got %t", i+1, testCase.locked, locked)
+		}
+	}
+}
+
+func TestPurgeObjectVersions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	setObjectLayer(obj)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := newBucketMetadata(bucket)
+	meta.versioningConfig = &versioning.Versioning{Status: versioning.Enabled}
+	globalBucketMetadataSys.Set(bucket, meta)
+
+	put := func(object string, userDefined map[string]string) {
+		data := []byte("data")
+		opts := ObjectOptions{Versioned: true, UserDefined: userDefined}
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	put("prefix/object", nil)
+	put("prefix/object", nil)
+	put("prefix/locked", map[string]string{
+		objectlock.AmzObjectLockMode:            "COMPLIANCE",
+		objectlock.AmzObjectLockRetainUntilDate: time.Now().UTC().Add(time.Hour).Format(time.RFC3339),
+	})
+	put("other/object", nil)
+	if _, err = obj.DeleteObject(ctx, bucket, "prefix/object", ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := purgeObjectVersions(ctx, obj, bucket, "prefix/", madmin.PurgeVersionsOpts{DryRun: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (madmin.PurgeVersionsResult{Deleted: 3, Locked: 1}) {
+		t.Fatalf("unexpected dry run result %+v", result)
+	}
+
+	var deleted int
+	result, err = purgeObjectVersions(ctx, obj, bucket, "prefix/", madmin.PurgeVersionsOpts{}, func(ObjectInfo) { deleted++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (madmin.PurgeVersionsResult{Deleted: 3, Locked: 1}) || deleted != 3 {
+		t.Fatalf("unexpected result %+v, %d deleted", result, deleted)
+	}
+
+	loi, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 2 {
+		t.Fatalf("expected 2 versions left, got %d", len(loi.Objects))
+	}
+	for _, oi := range loi.Objects {
+		if oi.Name != "prefix/locked" && oi.Name != "other/object" {
+			t.Errorf("unexpected version left %s", oi.Name)
+		}
+	}
+}
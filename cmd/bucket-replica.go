@@ -0,0 +1,156 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const bucketReplicaConfigFile = "replica.json"
+
+var errBucketReplicationConfigured = errors.New("bucket replication is already configured, remove it to reverse the replication")
+
+// replicaWriteActions are the actions denied on read-only replica
+// buckets, unless the request is sent by replication.
+var replicaWriteActions = map[policy.Action]struct{}{
+	policy.PutObjectAction:                  {},
+	policy.DeleteObjectAction:               {},
+	policy.AbortMultipartUploadAction:       {},
+	policy.PutObjectRetentionAction:         {},
+	policy.PutObjectLegalHoldAction:         {},
+	policy.PutObjectTaggingAction:           {},
+	policy.DeleteObjectTaggingAction:        {},
+	policy.DeleteObjectVersionAction:        {},
+	policy.PutObjectVersionTaggingAction:    {},
+	policy.DeleteObjectVersionTaggingAction: {},
+}
+
+// parseBucketReplicaConfig parses BucketReplicaConfig from json
+func parseBucketReplicaConfig(bucket string, data []byte) (*madmin.BucketReplicaConfig, error) {
+	replicaCfg := &madmin.BucketReplicaConfig{}
+	if err := json.Unmarshal(data, replicaCfg); err != nil {
+		return replicaCfg, err
+	}
+	return replicaCfg, nil
+}
+
+// isBucketReadOnlyReplica returns true if the bucket is a read-only replica.
+func isBucketReadOnlyReplica(bucket string) bool {
+	if globalBucketMetadataSys == nil || bucket == "" || isMinioMetaBucketName(bucket) {
+		return false
+	}
+	cfg, err := globalBucketMetadataSys.GetReplicaConfig(bucket)
+	if err != nil {
+		return false
+	}
+	return cfg.ReadOnly
+}
+
+// checkReplicaWriteAllowed denies object writes to read-only replica
+// buckets. Only replication requests are allowed, they are marked with
+// the REPLICA replication status and sent by users allowed to perform
+// s3:ReplicateObject, or s3:ReplicateDelete for deletes.
+func checkReplicaWriteAllowed(r *http.Request, action policy.Action, bucket, object string,
+	cred auth.Credentials, owner bool, claims map[string]interface{}) APIErrorCode {
+	if _, ok := replicaWriteActions[action]; !ok || !isBucketReadOnlyReplica(bucket) {
+		return ErrNone
+	}
+	if cred.AccessKey == "" || r.Header.Get(xhttp.AmzBucketReplicationStatus) != replication.Replica.String() {
+		return ErrBucketReadOnlyReplica
+	}
+	var replicateAction iampolicy.Action = iampolicy.ReplicateObjectAction
+	if r.Method == http.MethodDelete {
+		replicateAction = iampolicy.ReplicateDeleteAction
+	}
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Action:          replicateAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+		ObjectName:      object,
+		IsOwner:         owner,
+		Claims:          claims,
+	}) {
+		return ErrBucketReadOnlyReplica
+	}
+	return ErrNone
+}
+
+// reverseBucketReplication adds target, the bucket of the former primary
+// cluster, as remote target of the bucket and configures the bucket to
+// replicate all objects and deletes to it. The ARN of the target is returned.
+func reverseBucketReplication(ctx context.Context, bucket string, target *madmin.BucketTarget) (string, error) {
+	if _, err := globalBucketMetadataSys.GetReplicationConfig(ctx, bucket); err == nil {
+		return "", errBucketReplicationConfigured
+	}
+
+	target.SourceBucket = bucket
+	target.Type = madmin.ReplicationService
+	target.Arn = globalBucketTargetSys.getRemoteARN(bucket, target)
+	if err := globalBucketTargetSys.SetTarget(ctx, bucket, target, false); err != nil {
+		return "", err
+	}
+	targets, err := globalBucketTargetSys.ListBucketTargets(ctx, bucket)
+	if err != nil {
+		return "", err
+	}
+	tgtBytes, err := json.Marshal(&targets)
+	if err != nil {
+		return "", err
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketTargetsFile, tgtBytes); err != nil {
+		return "", err
+	}
+
+	replicationCfg := &replication.Config{
+		RoleArn: target.Arn,
+		Rules: []replication.Rule{{
+			ID:                      "failover",
+			Status:                  replication.Enabled,
+			Priority:                1,
+			DeleteMarkerReplication: replication.DeleteMarkerReplication{Status: replication.Enabled},
+			DeleteReplication:       replication.DeleteReplication{Status: replication.Enabled},
+			Destination:             replication.Destination{Bucket: target.TargetBucket},
+		}},
+	}
+	sameTarget, err := validateReplicationDestination(ctx, bucket, replicationCfg)
+	if err != nil {
+		return "", err
+	}
+	if err = replicationCfg.Validate(bucket, sameTarget); err != nil {
+		return "", err
+	}
+	configData, err := xml.Marshal(replicationCfg)
+	if err != nil {
+		return "", err
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, configData); err != nil {
+		return "", err
+	}
+	return target.Arn, nil
+}
@@ -0,0 +1,168 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestBucketReadOnlyReplica(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testBucketReadOnlyReplica, []string{"PutObject", "GetObject", "DeleteObject"})
+}
+
+func testBucketReadOnlyReplica(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	setReadOnly := func(readOnly bool) {
+		meta, err := globalBucketMetadataSys.GetConfig(bucketName)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		meta.replicaConfig = &madmin.BucketReplicaConfig{ReadOnly: readOnly}
+		globalBucketMetadataSys.Set(bucketName, meta)
+	}
+	data := []byte("hello")
+	replica := map[string]string{xhttp.AmzBucketReplicationStatus: replication.Replica.String()}
+
+	testCases := []struct {
+		readOnly       bool
+		method         string
+		url            string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{false, http.MethodPut, getPutObjectURL("", bucketName, "object"), nil, http.StatusOK},
+		// Direct writes to a read-only replica are rejected.
+		{true, http.MethodPut, getPutObjectURL("", bucketName, "object"), nil, http.StatusForbidden},
+		{true, http.MethodPut, getPutObjectURL("", bucketName, "other"), nil, http.StatusForbidden},
+		{true, http.MethodDelete, getDeleteObjectURL("", bucketName, "object"), nil, http.StatusForbidden},
+		// Reads are allowed.
+		{true, http.MethodGet, getGetObjectURL("", bucketName, "object"), nil, http.StatusOK},
+		// Replication writes are allowed.
+		{true, http.MethodPut, getPutObjectURL("", bucketName, "replicated"), replica, http.StatusOK},
+		// Writable again.
+		{false, http.MethodDelete, getDeleteObjectURL("", bucketName, "object"), nil, http.StatusNoContent},
+	}
+	for i, testCase := range testCases {
+		setReadOnly(testCase.readOnly)
+
+		var body []byte
+		if testCase.method == http.MethodPut {
+			body = data
+		}
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, testCase.url, int64(len(body)), bytes.NewReader(body),
+			credentials.AccessKey, credentials.SecretKey, testCase.headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: failed to create request: %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: %s: expected status %d, got %d: %s", i+1, instanceType, testCase.expectedStatus, rec.Code, rec.Body.String())
+		}
+		if rec.Code == http.StatusForbidden && !bytes.Contains(rec.Body.Bytes(), []byte("XMinioBucketReadOnlyReplica")) {
+			t.Errorf("Test %d: %s: expected XMinioBucketReadOnlyReplica error, got %s", i+1, instanceType, rec.Body.String())
+		}
+	}
+	setReadOnly(false)
+}
+
+func TestFailoverBucketHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	bucket := "standby"
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	queryVal := url.Values{}
+	queryVal.Set("bucket", bucket)
+
+	getReadOnly := func() bool {
+		req, err := buildAdminRequest(queryVal, http.MethodGet, "/get-bucket-replica", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected get-bucket-replica to succeed, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var config madmin.BucketReplicaConfig
+		if err = json.NewDecoder(rec.Body).Decode(&config); err != nil {
+			t.Fatal(err)
+		}
+		return config.ReadOnly
+	}
+
+	configData := []byte(`{"readOnly":true}`)
+	req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-bucket-replica", int64(len(configData)), bytes.NewReader(configData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected set-bucket-replica to succeed, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !getReadOnly() {
+		t.Fatal("Expected bucket to be a read-only replica")
+	}
+
+	// Fail over without reversing the replication.
+	req, err = buildAdminRequest(queryVal, http.MethodPost, "/failover-bucket", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected failover-bucket to succeed, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if getReadOnly() {
+		t.Fatal("Expected bucket to be writable after failover")
+	}
+
+	// The bucket is not a replica anymore.
+	req, err = buildAdminRequest(queryVal, http.MethodPost, "/failover-bucket", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected failover-bucket of a writable bucket to fail with %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
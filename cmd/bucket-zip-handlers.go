@@ -0,0 +1,188 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// GetBucketZipHandler - GET Bucket?zip&prefix=<prefix>
+// ----------
+// This MinIO extension streams a zip archive of all the objects
+// under prefix, the archive is assembled while the objects are
+// read, no temporary files are used. Callers need s3:GetObjectZip
+// on the prefix, objects for which s3:GetObject is not allowed and
+// objects encrypted with SSE-C are left out of the archive.
+func (api objectAPIHandlers) GetBucketZipHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketZip")
+
+	defer logger.AuditLog(ctx, w, r, "GetBucketZip", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	prefix := r.URL.Query().Get("prefix")
+
+	accessKey, owner, s3Error := checkRequestAuthTypeToAccessKey(ctx, r, policy.GetObjectZipAction, bucket, prefix)
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	claims := mustGetClaimsFromToken(r)
+	isGetAllowed := func(object string) bool {
+		if accessKey == "" {
+			return globalPolicySys.IsAllowed(policy.Args{
+				Action:          policy.GetObjectAction,
+				BucketName:      bucket,
+				ConditionValues: getConditionValues(r, "", "", nil),
+				IsOwner:         false,
+				ObjectName:      object,
+			})
+		}
+		return globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     accessKey,
+			Action:          iampolicy.GetObjectAction,
+			BucketName:      bucket,
+			ConditionValues: getConditionValues(r, "", accessKey, claims),
+			IsOwner:         owner,
+			ObjectName:      object,
+			Claims:          claims,
+		})
+	}
+
+	getObjectNInfo := objectAPI.GetObjectNInfo
+	if api.CacheAPI() != nil {
+		getObjectNInfo = api.CacheAPI().GetObjectNInfo
+	}
+
+	// Names in the archive are relative to the parent "directory" of prefix.
+	baseDir := prefix[:strings.LastIndex(prefix, SlashSeparator)+1]
+	archiveName := bucket
+	if base := path.Base(strings.TrimSuffix(prefix, SlashSeparator)); prefix != "" && base != "." && base != SlashSeparator {
+		archiveName = base
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objInfoCh := make(chan ObjectInfo)
+	if err := objectAPI.Walk(ctx, bucket, prefix, objInfoCh, ObjectOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer func() {
+		// Stop the walk and unblock it if the archive was not completed.
+		cancel()
+		for range objInfoCh {
+		}
+	}()
+
+	w.Header().Set(xhttp.ContentType, "application/zip")
+	w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("attachment; filename=\"%s.zip\"", archiveName))
+	w.WriteHeader(http.StatusOK)
+
+	archive := zip.NewWriter(w)
+	defer archive.Close()
+
+	// zipit adds one object to the archive, errors are only returned
+	// when the archive cannot be written anymore.
+	zipit := func(obj ObjectInfo) error {
+		gr, err := getObjectNInfo(ctx, bucket, obj.Name, nil, r.Header, readLock, ObjectOptions{})
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return nil
+		}
+		defer gr.Close()
+
+		info := gr.ObjInfo
+		// For reporting, set the file size to the uncompressed size.
+		if info.Size, err = info.GetActualSize(); err != nil {
+			logger.LogIf(ctx, err)
+			return nil
+		}
+		header := &zip.FileHeader{
+			Name:     strings.TrimPrefix(info.Name, baseDir),
+			Method:   zip.Deflate,
+			Flags:    1 << 11,
+			Modified: info.ModTime,
+		}
+		if hasStringSuffixInSlice(info.Name, standardExcludeCompressExtensions) || hasPattern(standardExcludeCompressContentTypes, info.ContentType) {
+			// We strictly disable compression for standard extensions/content-types.
+			header.Method = zip.Store
+		}
+		writer, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(writer, gr); err != nil {
+			return err
+		}
+
+		// Notify object accessed via a GET request.
+		sendEvent(eventArgs{
+			EventName:    event.ObjectAccessedGet,
+			BucketName:   bucket,
+			Object:       info,
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         handlers.GetSourceIP(r),
+		})
+		return nil
+	}
+
+	for obj := range objInfoCh {
+		if obj.DeleteMarker || HasSuffix(obj.Name, SlashSeparator) {
+			continue
+		}
+		if crypto.SSEC.IsEncrypted(obj.UserDefined) || !isGetAllowed(obj.Name) {
+			continue
+		}
+		if err := zipit(obj); err != nil {
+			// The archive is truncated, the client notices
+			// since the zip central directory is missing.
+			logger.LogIf(ctx, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestGetBucketZipHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testGetBucketZipHandler, []string{"GetBucketZip"})
+}
+
+func testGetBucketZipHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objects := map[string][]byte{
+		"photos/2020/hawaii.jpg":   []byte("hawaii"),
+		"photos/2020/maldives.jpg": []byte("maldives"),
+		"photos/readme.txt":        []byte("readme"),
+		"docs/report.pdf":          []byte("report"),
+	}
+	for objectName, data := range objects {
+		_, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: failed to upload %s: %v", instanceType, objectName, err)
+		}
+	}
+
+	testCases := []struct {
+		prefix    string
+		accessKey string
+		secretKey string
+
+		expectedStatus  int
+		expectedEntries map[string]string
+	}{
+		{
+			prefix:          "photos/2020/",
+			accessKey:       credentials.AccessKey,
+			secretKey:       credentials.SecretKey,
+			expectedStatus:  http.StatusOK,
+			expectedEntries: map[string]string{"hawaii.jpg": "photos/2020/hawaii.jpg", "maldives.jpg": "photos/2020/maldives.jpg"},
+		},
+		{
+			prefix:         "photos/20",
+			accessKey:      credentials.AccessKey,
+			secretKey:      credentials.SecretKey,
+			expectedStatus: http.StatusOK,
+			expectedEntries: map[string]string{
+				"2020/hawaii.jpg":   "photos/2020/hawaii.jpg",
+				"2020/maldives.jpg": "photos/2020/maldives.jpg",
+			},
+		},
+		{
+			prefix:          "",
+			accessKey:       credentials.AccessKey,
+			secretKey:       credentials.SecretKey,
+			expectedStatus:  http.StatusOK,
+			expectedEntries: map[string]string{},
+		},
+		{
+			prefix:         "photos/",
+			accessKey:      "",
+			secretKey:      "",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+	for objectName := range objects {
+		testCases[2].expectedEntries[objectName] = objectName
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		reqURL := makeTestTargetURL("", bucketName, "", url.Values{"zip": {""}, "prefix": {testCase.prefix}})
+		var req *http.Request
+		var err error
+		if testCase.accessKey != "" {
+			req, err = newTestSignedRequestV4(http.MethodGet, reqURL, 0, nil, testCase.accessKey, testCase.secretKey, nil)
+		} else {
+			req, err = newTestRequest(http.MethodGet, reqURL, 0, nil)
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %s: failed to create request: %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Fatalf("Test %d: %s: expected status %d, got %d", i+1, instanceType, testCase.expectedStatus, rec.Code)
+		}
+		if testCase.expectedStatus != http.StatusOK {
+			continue
+		}
+
+		body := rec.Body.Bytes()
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("Test %d: %s: invalid zip archive: %v", i+1, instanceType, err)
+		}
+		if len(archive.File) != len(testCase.expectedEntries) {
+			t.Fatalf("Test %d: %s: expected %d entries, got %d", i+1, instanceType, len(testCase.expectedEntries), len(archive.File))
+		}
+		for _, f := range archive.File {
+			objectName, ok := testCase.expectedEntries[f.Name]
+			if !ok {
+				t.Fatalf("Test %d: %s: unexpected entry %s", i+1, instanceType, f.Name)
+			}
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, objects[objectName]) {
+				t.Errorf("Test %d: %s: entry %s has unexpected content", i+1, instanceType, f.Name)
+			}
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/gob"
 	"errors"
@@ -27,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -35,6 +37,7 @@ import (
 	"github.com/minio/cli"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/cmd/config"
+	xtls "github.com/minio/minio/cmd/config/identity/tls"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
@@ -57,6 +60,7 @@ func init() {
 
 	globalReplicationState = newReplicationState()
 	globalTransitionState = newTransitionState()
+	globalExpiryState = newExpiryState()
 
 	console.SetColor("Debug", color.New())
 
@@ -232,6 +236,26 @@ func handleCommonEnvVars() {
 		logger.Fatal(config.ErrInvalidFSOSyncValue(err), "Invalid MINIO_FS_OSYNC value in environment variable")
 	}
 
+	globalInternodeHTTP2, err = config.ParseBool(env.Get(config.EnvInternodeHTTP2, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidInternodeHTTP2Value(err), "Invalid MINIO_INTERNODE_HTTP2 value in environment variable")
+	}
+
+	globalDirectIO, err = config.ParseBool(env.Get(config.EnvDirectIO, config.EnableOn))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidDirectIOValue(err), "Invalid MINIO_DIRECT_IO value in environment variable")
+	}
+
+	globalAdminGRPCAddr = env.Get(config.EnvAdminGRPCAddr, "")
+	if globalAdminGRPCAddr != "" {
+		if _, _, err = net.SplitHostPort(globalAdminGRPCAddr); err != nil {
+			logger.Fatal(config.ErrInvalidAdminGRPCAddr(err), "Invalid MINIO_ADMIN_GRPC_ADDRESS value in environment variable")
+		}
+	}
+
+	globalSTSTLSConfig, err = xtls.LookupConfig(globalCertsCADir.Get())
+	logger.FatalIf(err, "Unable to initialize TLS client certificate authentication")
+
 	domains := env.Get(config.EnvDomain, "")
 	if len(domains) != 0 {
 		for _, domainName := range strings.Split(domains, config.ValueSeparator) {
@@ -239,8 +263,17 @@ func handleCommonEnvVars() {
 				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("Unknown value `%s`", domainName),
 					"Invalid MINIO_DOMAIN value in environment variable")
 			}
-			globalDomainNames = append(globalDomainNames, domainName)
+			if !contains(globalDomainNames, domainName) {
+				globalDomainNames = append(globalDomainNames, domainName)
+			}
 		}
+		// Bucket DNS style routes are matched in this order, the
+		// longest domains must be matched first so that a request
+		// to 'bucket.s3.example.com' uses 's3.example.com' rather
+		// than 'example.com'.
+		sort.SliceStable(globalDomainNames, func(i, j int) bool {
+			return len(globalDomainNames[i]) > len(globalDomainNames[j])
+		})
 	}
 
 	publicIPs := env.Get(config.EnvPublicIPs, "")
@@ -358,16 +391,36 @@ func getTLSConfig() (x509Certs []*x509.Certificate, manager *certs.Manager, secu
 	//
 	// Therefore, we read all filenames in the cert directory and check
 	// for each directory whether it contains a public.crt and private.key.
-	// If so, we try to add it to certificate manager.
+	// If so, we try to add it to certificate manager. Directories added
+	// while the server is running are picked up periodically.
+	loaded := make(map[string]string)
+	if err = addDomainCertificates(manager, loaded); err != nil {
+		return nil, nil, false, err
+	}
+	go watchDomainCertificates(GlobalContext, manager, loaded)
+
+	secureConn = true
+	return x509Certs, manager, secureConn, nil
+}
+
+// certsDirReloadInterval is the interval at which the certs
+// directory is scanned for newly added domain certificates.
+const certsDirReloadInterval = time.Minute
+
+// addDomainCertificates adds the certificates of all domain
+// directories under the certs directory, which were not added yet,
+// to manager. loaded holds the error of the last attempt to add each
+// domain directory, an empty string if the attempt succeeded.
+func addDomainCertificates(manager *certs.Manager, loaded map[string]string) error {
 	root, err := os.Open(globalCertsDir.Get())
 	if err != nil {
-		return nil, nil, false, err
+		return err
 	}
 	defer root.Close()
 
 	files, err := root.Readdir(-1)
 	if err != nil {
-		return nil, nil, false, err
+		return err
 	}
 	for _, file := range files {
 		// Ignore all
@@ -377,6 +430,10 @@ func getTLSConfig() (x509Certs []*x509.Certificate, manager *certs.Manager, secu
 		if file.Mode().IsRegular() || file.Name() == "CAs" || strings.HasPrefix(file.Name(), "..") {
 			continue
 		}
+		if lastErr, ok := loaded[file.Name()]; ok && lastErr == "" {
+			// Already added, changes are reloaded by the manager.
+			continue
+		}
 		if file.Mode()&os.ModeSymlink == os.ModeSymlink {
 			file, err = os.Stat(filepath.Join(root.Name(), file.Name()))
 			if err != nil {
@@ -396,10 +453,32 @@ func getTLSConfig() (x509Certs []*x509.Certificate, manager *certs.Manager, secu
 			continue
 		}
 		if err = manager.AddCertificate(certFile, keyFile); err != nil {
-			err = fmt.Errorf("Unable to load TLS certificate '%s,%s': %w", certFile, keyFile, err)
-			logger.LogIf(GlobalContext, err, logger.Minio)
+			// Only log once per distinct error, the directory
+			// is retried on every scan.
+			if loaded[file.Name()] != err.Error() {
+				logger.LogIf(GlobalContext, fmt.Errorf("Unable to load TLS certificate '%s,%s': %w", certFile, keyFile, err), logger.Minio)
+			}
+			loaded[file.Name()] = err.Error()
+			continue
+		}
+		loaded[file.Name()] = ""
+	}
+	return nil
+}
+
+// watchDomainCertificates periodically adds the certificates of
+// newly created domain directories under the certs directory to
+// manager, the function blocks until the context is canceled.
+func watchDomainCertificates(ctx context.Context, manager *certs.Manager, loaded map[string]string) {
+	ticker := time.NewTicker(certsDirReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logger.LogIf(ctx, addDomainCertificates(manager, loaded))
 		}
 	}
-	secureConn = true
-	return x509Certs, manager, secureConn, nil
 }
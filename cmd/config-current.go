@@ -29,15 +29,19 @@ import (
 	"github.com/minio/minio/cmd/config/crawler"
 	"github.com/minio/minio/cmd/config/dns"
 	"github.com/minio/minio/cmd/config/etcd"
+	"github.com/minio/minio/cmd/config/eventjournal"
 	"github.com/minio/minio/cmd/config/heal"
 	xldap "github.com/minio/minio/cmd/config/identity/ldap"
 	"github.com/minio/minio/cmd/config/identity/openid"
 	"github.com/minio/minio/cmd/config/notify"
 	"github.com/minio/minio/cmd/config/policy/opa"
 	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/config/tracing"
+	"github.com/minio/minio/cmd/config/usageexport"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/target/file"
 	"github.com/minio/minio/cmd/logger/target/http"
 	"github.com/minio/minio/pkg/env"
 	"github.com/minio/minio/pkg/madmin"
@@ -58,8 +62,13 @@ func initHelp() {
 		config.KmsKesSubSys:         crypto.DefaultKesKVS,
 		config.LoggerWebhookSubSys:  logger.DefaultKVS,
 		config.AuditWebhookSubSys:   logger.DefaultAuditKVS,
+		config.LoggerConsoleSubSys:  logger.DefaultConsoleKVS,
+		config.LoggerFileSubSys:     logger.DefaultFileKVS,
 		config.HealSubSys:           heal.DefaultKVS,
 		config.CrawlerSubSys:        crawler.DefaultKVS,
+		config.TracingSubSys:        tracing.DefaultKVS,
+		config.EventJournalSubSys:   eventjournal.DefaultKVS,
+		config.UsageExportSubSys:    usageexport.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -119,6 +128,26 @@ func initHelp() {
 			Key:         config.CrawlerSubSys,
 			Description: "manage crawling for usage calculation, lifecycle, healing and more",
 		},
+		config.HelpKV{
+			Key:         config.TracingSubSys,
+			Description: "export OpenTelemetry traces of requests to an OTLP collector",
+		},
+		config.HelpKV{
+			Key:         config.EventJournalSubSys,
+			Description: "keep bucket events for a replay to notification targets",
+		},
+		config.HelpKV{
+			Key:         config.UsageExportSubSys,
+			Description: "export bucket and prefix usage snapshots to a webhook or a bucket",
+		},
+		config.HelpKV{
+			Key:         config.LoggerConsoleSubSys,
+			Description: "manage the level and format of server logs printed on the console",
+		},
+		config.HelpKV{
+			Key:         config.LoggerFileSubSys,
+			Description: "write server logs to a local file",
+		},
 		config.HelpKV{
 			Key:             config.LoggerWebhookSubSys,
 			Description:     "send server logs to webhook endpoints",
@@ -200,6 +229,9 @@ func initHelp() {
 		config.CompressionSubSys:    compress.Help,
 		config.HealSubSys:           heal.Help,
 		config.CrawlerSubSys:        crawler.Help,
+		config.TracingSubSys:        tracing.Help,
+		config.EventJournalSubSys:   eventjournal.Help,
+		config.UsageExportSubSys:    usageexport.Help,
 		config.IdentityOpenIDSubSys: openid.Help,
 		config.IdentityLDAPSubSys:   xldap.Help,
 		config.PolicyOPASubSys:      opa.Help,
@@ -207,6 +239,8 @@ func initHelp() {
 		config.KmsKesSubSys:         crypto.HelpKes,
 		config.LoggerWebhookSubSys:  logger.Help,
 		config.AuditWebhookSubSys:   logger.HelpAudit,
+		config.LoggerConsoleSubSys:  logger.HelpConsole,
+		config.LoggerFileSubSys:     logger.HelpFile,
 		config.NotifyAMQPSubSys:     notify.HelpAMQP,
 		config.NotifyKafkaSubSys:    notify.HelpKafka,
 		config.NotifyMQTTSubSys:     notify.HelpMQTT,
@@ -279,6 +313,18 @@ func validateConfig(s config.Config, setDriveCount int) error {
 		return err
 	}
 
+	if _, err := tracing.LookupConfig(s[config.TracingSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err := eventjournal.LookupConfig(s[config.EventJournalSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err := usageexport.LookupConfig(s[config.UsageExportSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -408,6 +454,13 @@ func lookupConfigs(s config.Config, setDriveCount int) {
 							globalDomainNames, err))
 					}
 				}
+				if globalDNSConfig != nil && etcdCfg.DNSAddress != "" && globalDNSServer == nil {
+					globalDNSServer = dns.NewServer(globalDNSConfig, globalDomainNames, globalDomainIPs)
+					go func() {
+						logger.LogIf(ctx, fmt.Errorf("Unable to serve bucket DNS on %s: %w",
+							etcdCfg.DNSAddress, globalDNSServer.ListenAndServe(etcdCfg.DNSAddress)))
+					}()
+				}
 			}
 		}
 	}
@@ -506,6 +559,19 @@ func lookupConfigs(s config.Config, setDriveCount int) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize logger: %w", err))
 	}
 
+	logger.SetConsole(loggerCfg.Console.Enabled, loggerCfg.Console.Level, loggerCfg.Console.Format)
+
+	if loggerCfg.File.Enabled {
+		// Enable file logging
+		target, err := file.New(loggerCfg.File.Path, loggerCfg.File.Level, loggerCfg.File.Format)
+		if err == nil {
+			err = logger.AddTarget(target)
+		}
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to initialize file logger target: %w", err))
+		}
+	}
+
 	for k, l := range loggerCfg.HTTP {
 		if l.Enabled {
 			// Enable http logging
@@ -516,6 +582,10 @@ func lookupConfigs(s config.Config, setDriveCount int) {
 					http.WithAuthToken(l.AuthToken),
 					http.WithUserAgent(loggerUserAgent),
 					http.WithLogKind(string(logger.All)),
+					http.WithLevel(l.Level),
+					http.WithFormat(l.Format),
+					http.WithQueueDir(l.QueueDir),
+					http.WithQueueSize(l.QueueSize),
 					http.WithTransport(NewGatewayHTTPTransport()),
 				),
 			); err != nil {
@@ -534,6 +604,8 @@ func lookupConfigs(s config.Config, setDriveCount int) {
 					http.WithAuthToken(l.AuthToken),
 					http.WithUserAgent(loggerUserAgent),
 					http.WithLogKind(string(logger.All)),
+					http.WithQueueDir(l.QueueDir),
+					http.WithQueueSize(l.QueueSize),
 					http.WithTransport(NewGatewayHTTPTransport()),
 				),
 			); err != nil {
@@ -542,6 +614,24 @@ func lookupConfigs(s config.Config, setDriveCount int) {
 		}
 	}
 
+	tracingCfg, err := tracing.LookupConfig(s[config.TracingSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize tracing: %w", err))
+	}
+	initTracing(tracingCfg)
+
+	eventJournalCfg, err := eventjournal.LookupConfig(s[config.EventJournalSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize event journal: %w", err))
+	}
+	initEventJournal(GlobalContext, eventJournalCfg)
+
+	usageExportCfg, err := usageexport.LookupConfig(s[config.UsageExportSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize usage export: %w", err))
+	}
+	initUsageExport(usageExportCfg)
+
 	globalConfigTargetList, err = notify.GetNotificationTargets(GlobalContext, s, NewGatewayHTTPTransport(), false)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize notification target(s): %w", err))
@@ -58,3 +58,69 @@ func TestServerConfig(t *testing.T) {
 		t.Fatalf("Unable to initialize from updated config file %s", err)
 	}
 }
+
+func TestServerConfigStaged(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("Init Test config failed")
+	}
+
+	ctx := context.Background()
+	if err = saveServerConfig(ctx, objLayer, globalServerConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing staged, applying is a no-op.
+	if err = applyServerConfigStaged(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	staged := globalServerConfig.Clone()
+	config.SetRegion(staged, "us-west-1")
+	if err = saveServerConfigStaged(ctx, objLayer, staged); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes applied right away are kept on top of the staged config.
+	if err = updateServerConfigStaged(ctx, objLayer, []byte("api requests_max=100")); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := readServerConfig(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stagedCfg, err := readServerConfigStaged(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diffs := cfg.Diff(stagedCfg); len(diffs) != 2 {
+		t.Fatalf("Expected 2 staged changes, got %v", diffs)
+	}
+
+	if err = applyServerConfigStaged(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = readServerConfigStaged(ctx, objLayer); err != errConfigNotFound {
+		t.Fatalf("Expected staged config to be removed, got %v", err)
+	}
+	cfg, err = readServerConfig(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	region, err := config.LookupRegion(cfg[config.RegionSubSys][config.Default])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if region != "us-west-1" {
+		t.Errorf("Expecting staged region `us-west-1` found %s", region)
+	}
+	if v := cfg[config.APISubSys][config.Default].Get("requests_max"); v != "100" {
+		t.Errorf("Expecting requests_max `100` found %s", v)
+	}
+}
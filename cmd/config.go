@@ -27,6 +27,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -40,6 +41,9 @@ const (
 
 	// MinIO configuration file.
 	minioConfigFile = "config.json"
+
+	// Staged MinIO configuration file, applied on the next startup.
+	minioConfigStagedFile = "config.staged.json"
 )
 
 func listServerConfigHistory(ctx context.Context, objAPI ObjectLayer, withData bool, count int) (
@@ -156,6 +160,11 @@ func readServerConfig(ctx context.Context, objAPI ObjectLayer) (config.Config, e
 		return nil, err
 	}
 
+	return decodeServerConfig(configData)
+}
+
+func decodeServerConfig(configData []byte) (config.Config, error) {
+	var err error
 	if globalConfigEncrypted && !utf8.Valid(configData) {
 		configData, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(configData))
 		if err != nil {
@@ -176,6 +185,73 @@ func readServerConfig(ctx context.Context, objAPI ObjectLayer) (config.Config, e
 	return srvCfg.Merge(), nil
 }
 
+// readServerConfigStaged returns the staged config,
+// errConfigNotFound if no config is staged.
+func readServerConfigStaged(ctx context.Context, objAPI ObjectLayer) (config.Config, error) {
+	configData, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, minioConfigStagedFile))
+	if err != nil {
+		return nil, err
+	}
+	return decodeServerConfig(configData)
+}
+
+func saveServerConfigStaged(ctx context.Context, objAPI ObjectLayer, cfg config.Config) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, minioConfigStagedFile), data)
+}
+
+func delServerConfigStaged(ctx context.Context, objAPI ObjectLayer) error {
+	err := deleteConfig(ctx, objAPI, path.Join(minioConfigPrefix, minioConfigStagedFile))
+	if err == errConfigNotFound {
+		return nil
+	}
+	return err
+}
+
+// updateServerConfigStaged applies the config KV settings
+// to the staged config, if any.
+func updateServerConfigStaged(ctx context.Context, objAPI ObjectLayer, kv []byte) error {
+	cfg, err := readServerConfigStaged(ctx, objAPI)
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil
+		}
+		return err
+	}
+	if _, err = cfg.ReadConfig(bytes.NewReader(kv)); err != nil {
+		return err
+	}
+	return saveServerConfigStaged(ctx, objAPI, cfg)
+}
+
+// applyServerConfigStaged replaces the server config with the
+// staged config, if any, and removes the staged config.
+func applyServerConfigStaged(ctx context.Context, objAPI ObjectLayer) error {
+	cfg, err := readServerConfigStaged(ctx, objAPI)
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil
+		}
+		return err
+	}
+	if err = saveServerConfig(ctx, objAPI, cfg); err != nil {
+		return err
+	}
+	logger.Info("Applied the staged server config")
+	return delServerConfigStaged(ctx, objAPI)
+}
+
 // ConfigSys - config system.
 type ConfigSys struct{}
 
@@ -230,5 +306,10 @@ func initConfig(objAPI ObjectLayer) error {
 		return err
 	}
 
+	// Changes staged since the last startup are applied now.
+	if err := applyServerConfigStaged(GlobalContext, objAPI); err != nil {
+		return err
+	}
+
 	return loadConfig(objAPI)
 }
@@ -0,0 +1,130 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acme
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+	"golang.org/x/crypto/acme"
+)
+
+// ACME environment variables
+const (
+	EnvEnable              = "MINIO_ACME_ENABLE"
+	EnvDomains             = "MINIO_ACME_DOMAINS"
+	EnvEmail               = "MINIO_ACME_EMAIL"
+	EnvDirectoryURL        = "MINIO_ACME_DIRECTORY_URL"
+	EnvChallenge           = "MINIO_ACME_CHALLENGE"
+	EnvHTTPAddress         = "MINIO_ACME_HTTP_ADDRESS"
+	EnvDNSWebhookEndpoint  = "MINIO_ACME_DNS_WEBHOOK_ENDPOINT"
+	EnvDNSWebhookAuthToken = "MINIO_ACME_DNS_WEBHOOK_AUTH_TOKEN"
+	EnvRenewBefore         = "MINIO_ACME_RENEW_BEFORE"
+)
+
+// Supported ACME challenge types.
+const (
+	ChallengeHTTP01 = "http-01"
+	ChallengeDNS01  = "dns-01"
+)
+
+const (
+	defaultHTTPAddress = ":80"
+	defaultRenewBefore = 30 * 24 * time.Hour
+)
+
+// Config - ACME client configuration used to obtain and
+// renew the TLS certificate of the server.
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Domains the certificate is issued for.
+	Domains []string `json:"domains"`
+
+	// Contact email of the ACME account, optional.
+	Email string `json:"email"`
+
+	// Directory endpoint of the ACME CA.
+	DirectoryURL string `json:"directoryURL"`
+
+	// Challenge type used to prove control over the domains.
+	Challenge string `json:"challenge"`
+
+	// Address on which HTTP-01 challenges are served.
+	HTTPAddress string `json:"httpAddress"`
+
+	// Webhook presenting and removing DNS-01 challenge records.
+	DNSWebhookEndpoint  string `json:"dnsWebhookEndpoint"`
+	DNSWebhookAuthToken string `json:"-"`
+
+	// Certificates are renewed once they expire within RenewBefore.
+	RenewBefore time.Duration `json:"renewBefore"`
+}
+
+// LookupConfig - lookup the ACME configuration from the environment.
+// Unless MINIO_ACME_DOMAINS is set, the certificate is issued for
+// the domains set via MINIO_DOMAIN.
+func LookupConfig() (cfg Config, err error) {
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, config.EnableOff))
+	if err != nil {
+		return cfg, fmt.Errorf("Invalid %s value: %w", EnvEnable, err)
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+
+	for _, domain := range strings.Split(env.Get(EnvDomains, env.Get(config.EnvDomain, "")), config.ValueSeparator) {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			cfg.Domains = append(cfg.Domains, domain)
+		}
+	}
+	if len(cfg.Domains) == 0 {
+		return cfg, fmt.Errorf("ACME requires at least one domain, please set %s or %s", EnvDomains, config.EnvDomain)
+	}
+
+	cfg.Email = env.Get(EnvEmail, "")
+	cfg.DirectoryURL = env.Get(EnvDirectoryURL, acme.LetsEncryptURL)
+	cfg.Challenge = strings.ToLower(env.Get(EnvChallenge, ChallengeHTTP01))
+	switch cfg.Challenge {
+	case ChallengeHTTP01:
+		cfg.HTTPAddress = env.Get(EnvHTTPAddress, defaultHTTPAddress)
+	case ChallengeDNS01:
+		cfg.DNSWebhookEndpoint = env.Get(EnvDNSWebhookEndpoint, "")
+		if cfg.DNSWebhookEndpoint == "" {
+			return cfg, fmt.Errorf("ACME %s challenge requires %s to be set", ChallengeDNS01, EnvDNSWebhookEndpoint)
+		}
+		cfg.DNSWebhookAuthToken = env.Get(EnvDNSWebhookAuthToken, "")
+	default:
+		return cfg, fmt.Errorf("Invalid %s value '%s', must be one of [%s, %s]", EnvChallenge, cfg.Challenge, ChallengeHTTP01, ChallengeDNS01)
+	}
+
+	cfg.RenewBefore = defaultRenewBefore
+	if v := env.Get(EnvRenewBefore, ""); v != "" {
+		cfg.RenewBefore, err = time.ParseDuration(v)
+		if err != nil {
+			return cfg, fmt.Errorf("Invalid %s value: %w", EnvRenewBefore, err)
+		}
+		if cfg.RenewBefore <= 0 {
+			return cfg, errors.New("ACME renew before duration must be positive")
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,163 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acme
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"golang.org/x/crypto/acme"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		envs        map[string]string
+		expectedErr bool
+		expected    Config
+	}{
+		{
+			envs: map[string]string{},
+		},
+		{
+			envs:        map[string]string{EnvEnable: "on"},
+			expectedErr: true,
+		},
+		{
+			envs: map[string]string{EnvEnable: "on", config.EnvDomain: "example.com, example.org"},
+			expected: Config{
+				Enabled:      true,
+				Domains:      []string{"example.com", "example.org"},
+				DirectoryURL: acme.LetsEncryptURL,
+				Challenge:    ChallengeHTTP01,
+				HTTPAddress:  defaultHTTPAddress,
+				RenewBefore:  defaultRenewBefore,
+			},
+		},
+		{
+			envs:        map[string]string{EnvEnable: "on", EnvDomains: "example.com", EnvChallenge: ChallengeDNS01},
+			expectedErr: true,
+		},
+		{
+			envs: map[string]string{
+				EnvEnable:             "on",
+				EnvDomains:            "example.com,*.example.com",
+				EnvChallenge:          "DNS-01",
+				EnvDNSWebhookEndpoint: "http://localhost:8080/dns",
+				EnvRenewBefore:        "240h",
+			},
+			expected: Config{
+				Enabled:            true,
+				Domains:            []string{"example.com", "*.example.com"},
+				DirectoryURL:       acme.LetsEncryptURL,
+				Challenge:          ChallengeDNS01,
+				DNSWebhookEndpoint: "http://localhost:8080/dns",
+				RenewBefore:        240 * time.Hour,
+			},
+		},
+		{
+			envs:        map[string]string{EnvEnable: "on", EnvDomains: "example.com", EnvChallenge: "tls-alpn-01"},
+			expectedErr: true,
+		},
+		{
+			envs:        map[string]string{EnvEnable: "on", EnvDomains: "example.com", EnvRenewBefore: "-1h"},
+			expectedErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		for key, value := range testCase.envs {
+			os.Setenv(key, value)
+		}
+		cfg, err := LookupConfig()
+		for key := range testCase.envs {
+			os.Unsetenv(key)
+		}
+		if testCase.expectedErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		got, _ := json.Marshal(cfg)
+		expected, _ := json.Marshal(testCase.expected)
+		if string(got) != string(expected) {
+			t.Errorf("Test %d: expected %s, got %s", i+1, expected, got)
+		}
+	}
+}
+
+func TestDNSWebhookSolver(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &acme.Client{Key: key}
+	chal := &acme.Challenge{Type: ChallengeDNS01, Token: "token"}
+	value, err := client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests []dnsWebhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req dnsWebhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, req)
+	}))
+	defer server.Close()
+
+	s := &dnsWebhookSolver{endpoint: server.URL, authToken: "secret", client: server.Client()}
+	if err = s.present(context.Background(), client, "example.com", chal); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.cleanUp(context.Background(), client, "example.com", chal); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 webhook requests, got %d", len(requests))
+	}
+	for i, action := range []string{webhookActionPresent, webhookActionCleanUp} {
+		expected := dnsWebhookRequest{Action: action, Domain: "example.com", FQDN: "_acme-challenge.example.com.", Value: value}
+		if requests[i] != expected {
+			t.Errorf("expected %v, got %v", expected, requests[i])
+		}
+	}
+
+	s.authToken = "invalid"
+	if err = s.present(context.Background(), client, "example.com", chal); err == nil {
+		t.Error("expected unauthorized webhook request to fail")
+	}
+}
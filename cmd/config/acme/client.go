@@ -0,0 +1,189 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"golang.org/x/crypto/acme"
+)
+
+// Client - obtains certificates from an ACME CA.
+type Client struct {
+	cfg            Config
+	accountKeyFile string
+	transport      http.RoundTripper
+	solver         solver
+}
+
+// NewClient - initialize a new ACME client, the ACME account key
+// is loaded from accountKeyFile or generated and saved there on
+// first use.
+func NewClient(cfg Config, accountKeyFile string, transport http.RoundTripper) *Client {
+	c := &Client{
+		cfg:            cfg,
+		accountKeyFile: accountKeyFile,
+		transport:      transport,
+	}
+	switch cfg.Challenge {
+	case ChallengeDNS01:
+		c.solver = &dnsWebhookSolver{
+			endpoint:  cfg.DNSWebhookEndpoint,
+			authToken: cfg.DNSWebhookAuthToken,
+			client:    &http.Client{Transport: transport},
+		}
+	default:
+		c.solver = newHTTPSolver(cfg.HTTPAddress)
+	}
+	return c
+}
+
+// Obtain - obtains a new certificate for the configured domains
+// and returns the PEM encoded certificate chain and private key.
+func (c *Client) Obtain(ctx context.Context) (certPEM, keyPEM []byte, err error) {
+	accountKey, err := c.loadAccountKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	client := &acme.Client{
+		Key:          accountKey,
+		DirectoryURL: c.cfg.DirectoryURL,
+		HTTPClient:   &http.Client{Transport: c.transport},
+		UserAgent:    "MinIO",
+	}
+
+	account := &acme.Account{}
+	if c.cfg.Email != "" {
+		account.Contact = []string{"mailto:" + c.cfg.Email}
+	}
+	if _, err = client.Register(ctx, account, acme.AcceptTOS); err != nil && err != acme.ErrAccountAlreadyExists {
+		return nil, nil, fmt.Errorf("unable to register ACME account: %w", err)
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(c.cfg.Domains...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create ACME order: %w", err)
+	}
+	for _, authzURL := range order.AuthzURLs {
+		if err = c.authorize(ctx, client, authzURL); err != nil {
+			return nil, nil, err
+		}
+	}
+	if order, err = client.WaitOrder(ctx, order.URI); err != nil {
+		return nil, nil, fmt.Errorf("ACME order failed: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		DNSNames: c.cfg.Domains,
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to finalize ACME order: %w", err)
+	}
+	for _, der := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// authorize fulfills the challenge of the authorization at authzURL.
+func (c *Client) authorize(ctx context.Context, client *acme.Client, authzURL string) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return err
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, ch := range authz.Challenges {
+		if ch.Type == c.cfg.Challenge {
+			chal = ch
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("ACME CA offers no %s challenge for '%s'", c.cfg.Challenge, authz.Identifier.Value)
+	}
+
+	if err = c.solver.present(ctx, client, authz.Identifier.Value, chal); err != nil {
+		return fmt.Errorf("unable to present %s challenge for '%s': %w", chal.Type, authz.Identifier.Value, err)
+	}
+	defer c.solver.cleanUp(ctx, client, authz.Identifier.Value, chal)
+
+	if _, err = client.Accept(ctx, chal); err != nil {
+		return err
+	}
+	if _, err = client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("ACME authorization for '%s' failed: %w", authz.Identifier.Value, err)
+	}
+	return nil
+}
+
+// loadAccountKey loads the account key, a new key
+// is generated and saved if there is none yet.
+func (c *Client) loadAccountKey() (crypto.Signer, error) {
+	data, err := ioutil.ReadFile(c.accountKeyFile)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("invalid ACME account key: no PEM data found")
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	data = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	if err = ioutil.WriteFile(c.accountKeyFile, data, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"golang.org/x/crypto/acme"
+)
+
+// solver fulfills ACME challenges of a specific type.
+type solver interface {
+	present(ctx context.Context, client *acme.Client, domain string, chal *acme.Challenge) error
+	cleanUp(ctx context.Context, client *acme.Client, domain string, chal *acme.Challenge) error
+}
+
+// httpSolver serves HTTP-01 challenge responses, the listener
+// is only open while there are challenges to be answered.
+type httpSolver struct {
+	addr string
+
+	mu        sync.Mutex
+	responses map[string]string // Mapping: challenge path => key authorization
+	server    *http.Server
+}
+
+func newHTTPSolver(addr string) *httpSolver {
+	return &httpSolver{
+		addr:      addr,
+		responses: make(map[string]string),
+	}
+}
+
+func (s *httpSolver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	response, ok := s.responses[r.URL.Path]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(response))
+}
+
+func (s *httpSolver) present(ctx context.Context, client *acme.Client, domain string, chal *acme.Challenge) error {
+	response, err := client.HTTP01ChallengeResponse(chal.Token)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server == nil {
+		l, err := net.Listen("tcp", s.addr)
+		if err != nil {
+			return err
+		}
+		s.server = &http.Server{Handler: s}
+		go s.server.Serve(l)
+	}
+	s.responses[client.HTTP01ChallengePath(chal.Token)] = response
+	return nil
+}
+
+func (s *httpSolver) cleanUp(ctx context.Context, client *acme.Client, domain string, chal *acme.Challenge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.responses, client.HTTP01ChallengePath(chal.Token))
+	if len(s.responses) > 0 || s.server == nil {
+		return nil
+	}
+	err := s.server.Close()
+	s.server = nil
+	return err
+}
+
+// Webhook actions sent to the DNS-01 webhook.
+const (
+	webhookActionPresent = "present"
+	webhookActionCleanUp = "cleanup"
+)
+
+// dnsWebhookRequest is the request body of the DNS-01
+// webhook, asking to add or remove a TXT record.
+type dnsWebhookRequest struct {
+	Action string `json:"action"`
+	Domain string `json:"domain"`
+	FQDN   string `json:"fqdn"`
+	Value  string `json:"value"`
+}
+
+// dnsWebhookSolver fulfills DNS-01 challenges by having a webhook
+// manage the TXT records. The webhook is expected to respond once
+// the record is visible to the public DNS.
+type dnsWebhookSolver struct {
+	endpoint  string
+	authToken string
+	client    *http.Client
+}
+
+func (s *dnsWebhookSolver) do(ctx context.Context, client *acme.Client, action, domain string, chal *acme.Challenge) error {
+	value, err := client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(dnsWebhookRequest{
+		Action: action,
+		Domain: domain,
+		FQDN:   "_acme-challenge." + domain + ".",
+		Value:  value,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.authToken)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("DNS webhook '%s' returned '%s'", s.endpoint, resp.Status)
+	}
+	return nil
+}
+
+func (s *dnsWebhookSolver) present(ctx context.Context, client *acme.Client, domain string, chal *acme.Challenge) error {
+	return s.do(ctx, client, webhookActionPresent, domain, chal)
+}
+
+func (s *dnsWebhookSolver) cleanUp(ctx context.Context, client *acme.Client, domain string, chal *acme.Challenge) error {
+	return s.do(ctx, client, webhookActionCleanUp, domain, chal)
+}
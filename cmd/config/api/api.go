@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
 )
@@ -30,20 +31,32 @@ import (
 // API sub-system constants
 const (
 	apiRequestsMax             = "requests_max"
+	apiRequestsAutoTune        = "requests_auto_tune"
 	apiRequestsDeadline        = "requests_deadline"
 	apiClusterDeadline         = "cluster_deadline"
 	apiCorsAllowOrigin         = "cors_allow_origin"
 	apiRemoteTransportDeadline = "remote_transport_deadline"
 	apiListQuorum              = "list_quorum"
 	apiExtendListCacheLife     = "extend_list_cache_life"
+	apiStorageDeadline         = "storage_deadline"
+	apiMaxHeaderSize           = "max_header_size"
+	apiMaxBodySize             = "max_body_size"
+	apiMinTransferRate         = "min_transfer_rate"
+	apiMinTransferRateGrace    = "min_transfer_rate_grace"
 
 	EnvAPIRequestsMax             = "MINIO_API_REQUESTS_MAX"
+	EnvAPIRequestsAutoTune        = "MINIO_API_REQUESTS_AUTO_TUNE"
 	EnvAPIRequestsDeadline        = "MINIO_API_REQUESTS_DEADLINE"
 	EnvAPIClusterDeadline         = "MINIO_API_CLUSTER_DEADLINE"
 	EnvAPICorsAllowOrigin         = "MINIO_API_CORS_ALLOW_ORIGIN"
 	EnvAPIRemoteTransportDeadline = "MINIO_API_REMOTE_TRANSPORT_DEADLINE"
 	EnvAPIListQuorum              = "MINIO_API_LIST_QUORUM"
 	EnvAPIExtendListCacheLife     = "MINIO_API_EXTEND_LIST_CACHE_LIFE"
+	EnvAPIStorageDeadline         = "MINIO_API_STORAGE_DEADLINE"
+	EnvAPIMaxHeaderSize           = "MINIO_API_MAX_HEADER_SIZE"
+	EnvAPIMaxBodySize             = "MINIO_API_MAX_BODY_SIZE"
+	EnvAPIMinTransferRate         = "MINIO_API_MIN_TRANSFER_RATE"
+	EnvAPIMinTransferRateGrace    = "MINIO_API_MIN_TRANSFER_RATE_GRACE"
 	EnvAPISecureCiphers           = "MINIO_API_SECURE_CIPHERS"
 )
 
@@ -60,6 +73,10 @@ var (
 			Key:   apiRequestsMax,
 			Value: "0",
 		},
+		config.KV{
+			Key:   apiRequestsAutoTune,
+			Value: config.EnableOff,
+		},
 		config.KV{
 			Key:   apiRequestsDeadline,
 			Value: "10s",
@@ -84,18 +101,44 @@ var (
 			Key:   apiExtendListCacheLife,
 			Value: "0s",
 		},
+		config.KV{
+			Key:   apiStorageDeadline,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   apiMaxHeaderSize,
+			Value: "8KiB",
+		},
+		config.KV{
+			Key:   apiMaxBodySize,
+			Value: "5TiB",
+		},
+		config.KV{
+			Key:   apiMinTransferRate,
+			Value: "1KiB",
+		},
+		config.KV{
+			Key:   apiMinTransferRateGrace,
+			Value: "1m",
+		},
 	}
 )
 
 // Config storage class configuration
 type Config struct {
 	RequestsMax             int           `json:"requests_max"`
+	RequestsAutoTune        bool          `json:"requests_auto_tune"`
 	RequestsDeadline        time.Duration `json:"requests_deadline"`
 	ClusterDeadline         time.Duration `json:"cluster_deadline"`
 	CorsAllowOrigin         []string      `json:"cors_allow_origin"`
 	RemoteTransportDeadline time.Duration `json:"remote_transport_deadline"`
 	ListQuorum              string        `json:"list_strict_quorum"`
 	ExtendListLife          time.Duration `json:"extend_list_cache_life"`
+	StorageDeadline         time.Duration `json:"storage_deadline"`
+	MaxHeaderSize           int64         `json:"max_header_size"`
+	MaxBodySize             int64         `json:"max_body_size"`
+	MinTransferRate         int64         `json:"min_transfer_rate"`
+	MinTransferRateGrace    time.Duration `json:"min_transfer_rate_grace"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -144,6 +187,11 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		return cfg, errors.New("invalid API max requests value")
 	}
 
+	requestsAutoTune, err := config.ParseBool(env.Get(EnvAPIRequestsAutoTune, kvs.Get(apiRequestsAutoTune)))
+	if err != nil {
+		return cfg, err
+	}
+
 	requestsDeadline, err := time.ParseDuration(env.Get(EnvAPIRequestsDeadline, kvs.Get(apiRequestsDeadline)))
 	if err != nil {
 		return cfg, err
@@ -173,13 +221,64 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		return cfg, err
 	}
 
+	storageDeadline, err := time.ParseDuration(env.Get(EnvAPIStorageDeadline, kvs.Get(apiStorageDeadline)))
+	if err != nil {
+		return cfg, err
+	}
+
+	if storageDeadline < 0 {
+		return cfg, errors.New("invalid API storage deadline value")
+	}
+
+	maxHeaderSize, err := humanize.ParseBytes(env.Get(EnvAPIMaxHeaderSize, kvs.Get(apiMaxHeaderSize)))
+	if err != nil {
+		return cfg, err
+	}
+
+	if maxHeaderSize == 0 || maxHeaderSize > 1<<20 {
+		return cfg, errors.New("invalid API max header size value, must be between 1B and 1MiB")
+	}
+
+	maxBodySize, err := humanize.ParseBytes(env.Get(EnvAPIMaxBodySize, kvs.Get(apiMaxBodySize)))
+	if err != nil {
+		return cfg, err
+	}
+
+	if maxBodySize == 0 || maxBodySize > 5<<40 {
+		return cfg, errors.New("invalid API max body size value, must be between 1B and 5TiB")
+	}
+
+	minTransferRate, err := humanize.ParseBytes(env.Get(EnvAPIMinTransferRate, kvs.Get(apiMinTransferRate)))
+	if err != nil {
+		return cfg, err
+	}
+
+	if minTransferRate > 1<<30 {
+		return cfg, errors.New("invalid API min transfer rate value, must be at most 1GiB")
+	}
+
+	minTransferRateGrace, err := time.ParseDuration(env.Get(EnvAPIMinTransferRateGrace, kvs.Get(apiMinTransferRateGrace)))
+	if err != nil {
+		return cfg, err
+	}
+
+	if minTransferRateGrace < 0 {
+		return cfg, errors.New("invalid API min transfer rate grace value")
+	}
+
 	return Config{
 		RequestsMax:             requestsMax,
+		RequestsAutoTune:        requestsAutoTune,
 		RequestsDeadline:        requestsDeadline,
 		ClusterDeadline:         clusterDeadline,
 		CorsAllowOrigin:         corsAllowOrigin,
 		RemoteTransportDeadline: remoteTransportDeadline,
 		ListQuorum:              listQuorum,
 		ExtendListLife:          listLife,
+		StorageDeadline:         storageDeadline,
+		MaxHeaderSize:           int64(maxHeaderSize),
+		MaxBodySize:             int64(maxBodySize),
+		MinTransferRate:         int64(minTransferRate),
+		MinTransferRateGrace:    minTransferRateGrace,
 	}, nil
 }
@@ -27,6 +27,12 @@ var (
 			Optional:    true,
 			Type:        "number",
 		},
+		config.HelpKV{
+			Key:         apiRequestsAutoTune,
+			Description: `set to "on" to tune the concurrent requests limit based on memory usage and GC pauses when "requests_max" is not set, defaults to "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
 		config.HelpKV{
 			Key:         apiRequestsDeadline,
 			Description: `set the deadline for API requests waiting to be processed e.g. "1m"`,
@@ -45,5 +51,35 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         apiStorageDeadline,
+			Description: `set the deadline for drive read and stat operations after which a drive is treated as offline, disabled by default e.g. "30s"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiMaxHeaderSize,
+			Description: `set the maximum size of the HTTP headers of a request e.g. "8KiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         apiMaxBodySize,
+			Description: `set the maximum size of the body of a request, excluding form fields e.g. "5TiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         apiMinTransferRate,
+			Description: `set the minimum average rate at which request bodies must be received, "0" disables it e.g. "1KiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         apiMinTransferRateGrace,
+			Description: `set the time allowed before the minimum transfer rate is enforced e.g. "1m"`,
+			Optional:    true,
+			Type:        "duration",
+		},
 	}
 )
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/minio/minio-go/v7/pkg/set"
@@ -76,8 +77,13 @@ const (
 	KmsKesSubSys         = "kms_kes"
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
+	LoggerConsoleSubSys  = "logger_console"
+	LoggerFileSubSys     = "logger_file"
 	HealSubSys           = "heal"
 	CrawlerSubSys        = "crawler"
+	TracingSubSys        = "tracing"
+	EventJournalSubSys   = "event_journal"
+	UsageExportSubSys    = "usage_export"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -111,11 +117,16 @@ var SubSystems = set.CreateStringSet(
 	KmsKesSubSys,
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
+	LoggerConsoleSubSys,
+	LoggerFileSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
 	CrawlerSubSys,
 	HealSubSys,
+	TracingSubSys,
+	EventJournalSubSys,
+	UsageExportSubSys,
 	NotifyAMQPSubSys,
 	NotifyESSubSys,
 	NotifyKafkaSubSys,
@@ -152,6 +163,11 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	IdentityOpenIDSubSys,
 	HealSubSys,
 	CrawlerSubSys,
+	TracingSubSys,
+	EventJournalSubSys,
+	UsageExportSubSys,
+	LoggerConsoleSubSys,
+	LoggerFileSubSys,
 }...)
 
 // Constant separators
@@ -632,6 +648,52 @@ func (c Config) Clone() Config {
 	return cp
 }
 
+// Diff - returns all the keys whose values differ between c and o,
+// sorted by sub-system, target and key. A key missing from either
+// config is reported with an empty value.
+func (c Config) Diff(o Config) []madmin.ConfigKVDiff {
+	var diffs []madmin.ConfigKVDiff
+	compare := func(subSys, target string, oldKVS, newKVS KVS) {
+		keys := set.CreateStringSet(oldKVS.Keys()...).Union(set.CreateStringSet(newKVS.Keys()...))
+		for _, key := range keys.ToSlice() {
+			oldValue, oldOk := oldKVS.Lookup(key)
+			newValue, newOk := newKVS.Lookup(key)
+			if oldOk == newOk && oldValue == newValue {
+				continue
+			}
+			diffs = append(diffs, madmin.ConfigKVDiff{
+				SubSys: subSys,
+				Target: target,
+				Key:    key,
+				Old:    oldValue,
+				New:    newValue,
+			})
+		}
+	}
+	for subSys, tgtKV := range c {
+		for target, kvs := range tgtKV {
+			compare(subSys, target, kvs, o[subSys][target])
+		}
+	}
+	for subSys, tgtKV := range o {
+		for target, kvs := range tgtKV {
+			if _, ok := c[subSys][target]; !ok {
+				compare(subSys, target, nil, kvs)
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].SubSys != diffs[j].SubSys {
+			return diffs[i].SubSys < diffs[j].SubSys
+		}
+		if diffs[i].Target != diffs[j].Target {
+			return diffs[i].Target < diffs[j].Target
+		}
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs
+}
+
 // SetKVS - set specific key values per sub-system.
 func (c Config) SetKVS(s string, defaultKVS map[string]KVS) (dynamic bool, err error) {
 	if len(s) == 0 {
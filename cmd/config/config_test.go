@@ -131,3 +131,106 @@ func TestValidRegion(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigDiff(t *testing.T) {
+	oldCfg := Config{
+		"region": {Default: KVS{{Key: "name", Value: "us-east-1"}}},
+		"api":    {Default: KVS{{Key: "requests_max", Value: "0"}, {Key: "cors_allow_origin", Value: "*"}}},
+		"notify_webhook": {
+			"1": KVS{{Key: "endpoint", Value: "http://localhost:8080"}},
+		},
+	}
+	newCfg := Config{
+		"region": {Default: KVS{{Key: "name", Value: "us-east-1"}}},
+		"api":    {Default: KVS{{Key: "requests_max", Value: "100"}, {Key: "cors_allow_origin", Value: "*"}}},
+		"notify_webhook": {
+			"2": KVS{{Key: "endpoint", Value: "http://localhost:9090"}},
+		},
+	}
+
+	expected := []madmin.ConfigKVDiff{
+		{SubSys: "api", Target: Default, Key: "requests_max", Old: "0", New: "100"},
+		{SubSys: "notify_webhook", Target: "1", Key: "endpoint", Old: "http://localhost:8080"},
+		{SubSys: "notify_webhook", Target: "2", Key: "endpoint", New: "http://localhost:9090"},
+	}
+	diffs := oldCfg.Diff(newCfg)
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, diffs)
+	}
+	for i := range expected {
+		if diffs[i] != expected[i] {
+			t.Errorf("diff %d: expected %v, got %v", i, expected[i], diffs[i])
+		}
+	}
+	if diffs = oldCfg.Diff(oldCfg); len(diffs) != 0 {
+		t.Errorf("expected no diff, got %v", diffs)
+	}
+}
+
+func TestConfigResolve(t *testing.T) {
+	defaultKVS := DefaultKVS
+	defer func() { DefaultKVS = defaultKVS }()
+	DefaultKVS = map[string]KVS{
+		APISubSys:          {{Key: "requests_max", Value: "0"}, {Key: "cors_allow_origin", Value: "*"}},
+		StorageClassSubSys: {{Key: "standard", Value: ""}, {Key: "rrs", Value: "EC:2"}},
+	}
+
+	t.Setenv("MINIO_API_CORS_ALLOW_ORIGIN", "https://example.com")
+	cfg := Config{
+		APISubSys:          {Default: KVS{{Key: "requests_max", Value: "100"}, {Key: "cors_allow_origin", Value: "*"}}},
+		StorageClassSubSys: {Default: KVS{{Key: "standard", Value: "EC:4"}, {Key: "rrs", Value: "EC:2"}}},
+	}
+	running := Config{
+		APISubSys:          {Default: KVS{{Key: "requests_max", Value: "0"}, {Key: "cors_allow_origin", Value: "*"}}},
+		StorageClassSubSys: {Default: KVS{{Key: "standard", Value: ""}, {Key: "rrs", Value: "EC:2"}}},
+	}
+
+	expected := map[string]madmin.ResolvedConfigKV{
+		"api cors_allow_origin": {
+			SubSys: APISubSys, Target: Default, Key: "cors_allow_origin", Value: "https://example.com",
+			Origin: madmin.ConfigOriginEnv, EnvVar: "MINIO_API_CORS_ALLOW_ORIGIN",
+		},
+		"api requests_max": {
+			SubSys: APISubSys, Target: Default, Key: "requests_max", Value: "100",
+			Origin: madmin.ConfigOriginConfig,
+		},
+		"storage_class rrs": {
+			SubSys: StorageClassSubSys, Target: Default, Key: "rrs", Value: "EC:2",
+			Origin: madmin.ConfigOriginDefault,
+		},
+		"storage_class standard": {
+			SubSys: StorageClassSubSys, Target: Default, Key: "standard", Value: "EC:4",
+			Origin: madmin.ConfigOriginConfig, PendingRestart: true,
+		},
+	}
+	for _, kv := range cfg.Resolve(running) {
+		want, ok := expected[kv.SubSys+" "+kv.Key]
+		if !ok {
+			continue
+		}
+		delete(expected, kv.SubSys+" "+kv.Key)
+		if kv != want {
+			t.Errorf("expected %v, got %v", want, kv)
+		}
+	}
+	if len(expected) != 0 {
+		t.Errorf("keys not resolved: %v", expected)
+	}
+}
+
+func TestEnvNames(t *testing.T) {
+	testCases := []struct {
+		subSys, target, key string
+		expected            string
+	}{
+		{NotifyWebhookSubSys, Default, "endpoint", "MINIO_NOTIFY_WEBHOOK_ENDPOINT"},
+		{NotifyWebhookSubSys, "1", "endpoint", "MINIO_NOTIFY_WEBHOOK_ENDPOINT_1"},
+		{CompressionSubSys, Default, "extensions", "MINIO_COMPRESS_EXTENSIONS"},
+		{RegionSubSys, Default, RegionName, EnvRegion},
+	}
+	for _, testCase := range testCases {
+		if names := EnvNames(testCase.subSys, testCase.target, testCase.key); names[0] != testCase.expected {
+			t.Errorf("expected %s, got %v", testCase.expected, names)
+		}
+	}
+}
@@ -38,6 +38,9 @@ const (
 	EnvFSOSync         = "MINIO_FS_OSYNC"
 	EnvArgs            = "MINIO_ARGS"
 	EnvDNSWebhook      = "MINIO_DNS_WEBHOOK_ENDPOINT"
+	EnvInternodeHTTP2  = "MINIO_INTERNODE_HTTP2"
+	EnvDirectIO        = "MINIO_DIRECT_IO"
+	EnvAdminGRPCAddr   = "MINIO_ADMIN_GRPC_ADDRESS"
 
 	EnvUpdate = "MINIO_UPDATE"
 
@@ -0,0 +1,164 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dns
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	dns2 "github.com/miekg/dns"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// Server - a built-in DNS server answering queries on
+// '<bucket>.<domain>' with the records of the cluster which
+// owns the bucket, as found in the shared bucket DNS store.
+// This allows federated clusters to present a single
+// namespace without an external CoreDNS setup.
+type Server struct {
+	store       Store
+	domainNames []string
+	domainIPs   set.StringSet
+
+	servers []*dns2.Server
+}
+
+// NewServer - initialize a new bucket DNS server answering
+// for domainNames, queries on the domains themselves are
+// answered with domainIPs.
+func NewServer(store Store, domainNames []string, domainIPs set.StringSet) *Server {
+	return &Server{
+		store:       store,
+		domainNames: domainNames,
+		domainIPs:   domainIPs,
+	}
+}
+
+// ListenAndServe - serves DNS queries on addr over UDP and TCP,
+// the function blocks until the server is shutdown or fails.
+func (s *Server) ListenAndServe(addr string) error {
+	s.servers = []*dns2.Server{
+		{Addr: addr, Net: "udp", Handler: s},
+		{Addr: addr, Net: "tcp", Handler: s},
+	}
+	errCh := make(chan error, len(s.servers))
+	for _, srv := range s.servers {
+		go func(srv *dns2.Server) {
+			errCh <- srv.ListenAndServe()
+		}(srv)
+	}
+	err := <-errCh
+	s.Shutdown()
+	return err
+}
+
+// Shutdown - stops serving DNS queries.
+func (s *Server) Shutdown() {
+	for _, srv := range s.servers {
+		srv.Shutdown()
+	}
+}
+
+// ServeDNS - implements dns.Handler interface.
+func (s *Server) ServeDNS(w dns2.ResponseWriter, r *dns2.Msg) {
+	m := new(dns2.Msg)
+	m.SetReply(r)
+	m.Authoritative = true
+	for _, q := range r.Question {
+		answer, rcode := s.answer(q)
+		if rcode != dns2.RcodeSuccess {
+			m.SetRcode(r, rcode)
+			break
+		}
+		m.Answer = append(m.Answer, answer...)
+	}
+	w.WriteMsg(m)
+}
+
+// answer returns the records answering question q.
+func (s *Server) answer(q dns2.Question) ([]dns2.RR, int) {
+	if q.Qclass != dns2.ClassINET && q.Qclass != dns2.ClassANY {
+		return nil, dns2.RcodeRefused
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(q.Name, "."))
+
+	// The longest matching domain is the one the bucket is used with.
+	var domain string
+	for _, domainName := range s.domainNames {
+		if (name == domainName || strings.HasSuffix(name, "."+domainName)) && len(domainName) > len(domain) {
+			domain = domainName
+		}
+	}
+	if domain == "" {
+		return nil, dns2.RcodeRefused
+	}
+
+	var records []SrvRecord
+	if name == domain {
+		for ip := range s.domainIPs {
+			records = append(records, SrvRecord{Host: ip, TTL: defaultTTL})
+		}
+	} else {
+		var err error
+		records, err = s.store.Get(strings.TrimSuffix(name, "."+domain))
+		if err == ErrNoEntriesFound {
+			return nil, dns2.RcodeNameError
+		}
+		if err != nil {
+			return nil, dns2.RcodeServerFailure
+		}
+	}
+
+	var answer []dns2.RR
+	seen := set.NewStringSet()
+	for _, record := range records {
+		ip := net.ParseIP(record.Host)
+		if ip == nil || seen.Contains(record.Host) {
+			continue
+		}
+		seen.Add(record.Host)
+		ttl := record.TTL
+		if ttl == 0 {
+			ttl = defaultTTL
+		}
+		hdr := dns2.RR_Header{Name: q.Name, Class: dns2.ClassINET, Ttl: ttl}
+		switch {
+		case (q.Qtype == dns2.TypeA || q.Qtype == dns2.TypeANY) && ip.To4() != nil:
+			hdr.Rrtype = dns2.TypeA
+			answer = append(answer, &dns2.A{Hdr: hdr, A: ip.To4()})
+		case (q.Qtype == dns2.TypeAAAA || q.Qtype == dns2.TypeANY) && ip.To4() == nil:
+			hdr.Rrtype = dns2.TypeAAAA
+			answer = append(answer, &dns2.AAAA{Hdr: hdr, AAAA: ip})
+		case q.Qtype == dns2.TypeSRV && record.Port != "":
+			port, err := strconv.ParseUint(record.Port.String(), 10, 16)
+			if err != nil {
+				continue
+			}
+			hdr.Rrtype = dns2.TypeSRV
+			answer = append(answer, &dns2.SRV{
+				Hdr:      hdr,
+				Priority: uint16(record.Priority),
+				Weight:   uint16(record.Weight),
+				Port:     uint16(port),
+				Target:   dns2.Fqdn(q.Name),
+			})
+		}
+	}
+	return answer, dns2.RcodeSuccess
+}
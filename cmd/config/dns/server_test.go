@@ -0,0 +1,78 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dns
+
+import (
+	"testing"
+
+	dns2 "github.com/miekg/dns"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+type testStore map[string][]SrvRecord
+
+func (s testStore) Put(bucket string) error    { return nil }
+func (s testStore) Delete(bucket string) error { return nil }
+func (s testStore) DeleteRecord(record SrvRecord) error {
+	return nil
+}
+func (s testStore) List() (map[string][]SrvRecord, error) { return s, nil }
+func (s testStore) Close() error                          { return nil }
+func (s testStore) String() string                        { return "test" }
+func (s testStore) Get(bucket string) ([]SrvRecord, error) {
+	records, ok := s[bucket]
+	if !ok {
+		return nil, ErrNoEntriesFound
+	}
+	return records, nil
+}
+
+func TestServerAnswer(t *testing.T) {
+	store := testStore{
+		"bucket": {
+			{Host: "10.0.0.1", Port: "9000"},
+			{Host: "10.0.0.1", Port: "9000"},
+			{Host: "fd00::1", Port: "9000"},
+		},
+	}
+	s := NewServer(store, []string{"example.com", "s3.example.com"}, set.CreateStringSet("10.0.0.10"))
+
+	testCases := []struct {
+		name    string
+		qtype   uint16
+		rcode   int
+		answers int
+	}{
+		{"bucket.s3.example.com.", dns2.TypeA, dns2.RcodeSuccess, 1},
+		{"BUCKET.example.com.", dns2.TypeA, dns2.RcodeSuccess, 1},
+		{"bucket.example.com.", dns2.TypeAAAA, dns2.RcodeSuccess, 1},
+		{"bucket.example.com.", dns2.TypeSRV, dns2.RcodeSuccess, 2},
+		{"bucket.example.com.", dns2.TypeANY, dns2.RcodeSuccess, 2},
+		{"example.com.", dns2.TypeA, dns2.RcodeSuccess, 1},
+		{"other.example.com.", dns2.TypeA, dns2.RcodeNameError, 0},
+		{"bucket.example.org.", dns2.TypeA, dns2.RcodeRefused, 0},
+	}
+	for i, testCase := range testCases {
+		answer, rcode := s.answer(dns2.Question{Name: testCase.name, Qtype: testCase.qtype, Qclass: dns2.ClassINET})
+		if rcode != testCase.rcode {
+			t.Errorf("Test %d: expected rcode %d, got %d", i+1, testCase.rcode, rcode)
+		}
+		if len(answer) != testCase.answers {
+			t.Errorf("Test %d: expected %d answers, got %d", i+1, testCase.answers, len(answer))
+		}
+	}
+}
@@ -30,6 +30,24 @@ var (
 		"Can only accept `on` and `off` values. To enable O_SYNC for fs backend, set this value to `on`",
 	)
 
+	ErrInvalidInternodeHTTP2Value = newErrFn(
+		"Invalid internode HTTP/2 value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To use HTTP/2 between nodes without TLS, set this value to `on` on all nodes",
+	)
+
+	ErrInvalidAdminGRPCAddr = newErrFn(
+		"Invalid admin gRPC address",
+		"Please check the passed value",
+		"Address should be in `host:port` format, for example `:9443`",
+	)
+
+	ErrInvalidDirectIOValue = newErrFn(
+		"Invalid O_DIRECT value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To use disks which do not support O_DIRECT, set this value to `off`",
+	)
+
 	ErrInvalidDomainValue = newErrFn(
 		"Invalid domain value",
 		"Please check the passed value",
@@ -42,12 +42,14 @@ const (
 	CoreDNSPath   = "coredns_path"
 	ClientCert    = "client_cert"
 	ClientCertKey = "client_cert_key"
+	DNSAddress    = "dns_address"
 
 	EnvEtcdEndpoints     = "MINIO_ETCD_ENDPOINTS"
 	EnvEtcdPathPrefix    = "MINIO_ETCD_PATH_PREFIX"
 	EnvEtcdCoreDNSPath   = "MINIO_ETCD_COREDNS_PATH"
 	EnvEtcdClientCert    = "MINIO_ETCD_CLIENT_CERT"
 	EnvEtcdClientCertKey = "MINIO_ETCD_CLIENT_CERT_KEY"
+	EnvEtcdDNSAddress    = "MINIO_ETCD_DNS_ADDRESS"
 )
 
 // DefaultKVS - default KV settings for etcd.
@@ -73,6 +75,10 @@ var (
 			Key:   ClientCertKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   DNSAddress,
+			Value: "",
+		},
 	}
 )
 
@@ -81,6 +87,7 @@ type Config struct {
 	Enabled     bool   `json:"enabled"`
 	PathPrefix  string `json:"pathPrefix"`
 	CoreDNSPath string `json:"coreDNSPath"`
+	DNSAddress  string `json:"dnsAddress"`
 	clientv3.Config
 }
 
@@ -146,6 +153,7 @@ func LookupConfig(kvs config.KVS, rootCAs *x509.CertPool) (Config, error) {
 	cfg.DialKeepAliveTime = defaultDialKeepAlive
 	cfg.Endpoints = etcdEndpoints
 	cfg.CoreDNSPath = env.Get(EnvEtcdCoreDNSPath, kvs.Get(CoreDNSPath))
+	cfg.DNSAddress = env.Get(EnvEtcdDNSAddress, kvs.Get(DNSAddress))
 	// Default path prefix for all keys on etcd, other than CoreDNSPath.
 	cfg.PathPrefix = env.Get(EnvEtcdPathPrefix, kvs.Get(PathPrefix))
 	if etcdSecure {
@@ -50,6 +50,12 @@ var (
 			Optional:    true,
 			Type:        "path",
 		},
+		config.HelpKV{
+			Key:         DNSAddress,
+			Description: `serve the shared bucket DNS records on this address without CoreDNS e.g. ":53"`,
+			Optional:    true,
+			Type:        "address",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -0,0 +1,96 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventjournal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Event journal environment variables
+const (
+	Retention = "retention"
+
+	EnvEnable    = "MINIO_EVENT_JOURNAL_ENABLE"
+	EnvRetention = "MINIO_EVENT_JOURNAL_RETENTION"
+
+	minRetention = time.Minute
+)
+
+// Config represents the bucket event journal settings.
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Retention is the duration for which the events
+	// of all buckets are kept for a replay.
+	Retention time.Duration `json:"retention"`
+}
+
+var (
+	// DefaultKVS - default KV config for event journal settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Retention,
+			Value: "24h",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Retention,
+			Description: `duration for which bucket events are kept for a replay, defaults to '24h'`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.EventJournalSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		return cfg, fmt.Errorf("'event_journal:enable' value invalid: %w", err)
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+	cfg.Retention, err = time.ParseDuration(env.Get(EnvRetention, kvs.Get(Retention)))
+	if err != nil {
+		return cfg, fmt.Errorf("'event_journal:retention' value invalid: %w", err)
+	}
+	if cfg.Retention < minRetention {
+		return cfg, errors.New("'event_journal:retention' must be at least 1m")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tls
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/certs"
+	"github.com/minio/minio/pkg/env"
+)
+
+// TLS client certificate identity environment variables
+const (
+	EnvIdentityTLSEnable   = "MINIO_IDENTITY_TLS_ENABLE"
+	EnvIdentityTLSIdentity = "MINIO_IDENTITY_TLS_IDENTITY"
+)
+
+// Certificate fields which can be used as identity.
+const (
+	// IdentityCommonName uses the subject common name.
+	IdentityCommonName = "cn"
+
+	// IdentityDNSName uses the first DNS subject alternative name.
+	IdentityDNSName = "dns"
+)
+
+// Config - configuration of the STS API authenticating
+// clients via their mTLS client certificate.
+type Config struct {
+	Enabled bool `json:"enabled"`
+
+	// Certificate field which identifies the client, the
+	// identity is used as the name of the client policy.
+	Identity string `json:"identity"`
+
+	// CAs client certificates must be issued by.
+	ClientCAs *x509.CertPool `json:"-"`
+}
+
+// LookupConfig - lookup the TLS client certificate identity
+// configuration from the environment, client certificates
+// must be issued by one of the CAs in certsCADir.
+func LookupConfig(certsCADir string) (cfg Config, err error) {
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvIdentityTLSEnable, config.EnableOff))
+	if err != nil {
+		return cfg, fmt.Errorf("Invalid %s value: %w", EnvIdentityTLSEnable, err)
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+	cfg.Identity = strings.ToLower(env.Get(EnvIdentityTLSIdentity, IdentityCommonName))
+	switch cfg.Identity {
+	case IdentityCommonName, IdentityDNSName:
+	default:
+		return cfg, fmt.Errorf("Invalid %s value '%s', must be one of [%s, %s]",
+			EnvIdentityTLSIdentity, cfg.Identity, IdentityCommonName, IdentityDNSName)
+	}
+	cfg.ClientCAs, err = certs.GetClientCAs(certsCADir)
+	if err != nil {
+		return cfg, fmt.Errorf("Unable to load client CAs from '%s': %w", certsCADir, err)
+	}
+	return cfg, nil
+}
+
+// Verify - verifies the certificate chain presented by a client and
+// returns the identity and the expiry of the client certificate.
+func (cfg Config) Verify(chain []*x509.Certificate) (identity string, expiry time.Time, err error) {
+	if len(chain) == 0 {
+		return "", expiry, errors.New("no client certificate presented")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err = chain[0].Verify(x509.VerifyOptions{
+		Roots:         cfg.ClientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return "", expiry, err
+	}
+	identity, err = cfg.GetIdentity(chain[0])
+	return identity, chain[0].NotAfter, err
+}
+
+// GetIdentity - returns the identity of the client presenting cert.
+func (cfg Config) GetIdentity(cert *x509.Certificate) (string, error) {
+	var identity string
+	switch cfg.Identity {
+	case IdentityDNSName:
+		if len(cert.DNSNames) > 0 {
+			identity = cert.DNSNames[0]
+		}
+	default:
+		identity = cert.Subject.CommonName
+	}
+	if identity == "" {
+		return "", errors.New("client certificate has no identity, the " + cfg.Identity + " field is empty")
+	}
+	return identity, nil
+}
@@ -0,0 +1,117 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func newTestCert(t *testing.T, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	ca, caKey := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	client, _ := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "readonly"},
+		DNSNames:     []string{"app.example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(30 * time.Minute),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	server, _ := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "readonly"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	selfSigned, _ := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(4),
+		Subject:      pkix.Name{CommonName: "readwrite"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, nil, nil)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	testCases := []struct {
+		identity         string
+		chain            []*x509.Certificate
+		expectedIdentity string
+		expectedErr      bool
+	}{
+		{IdentityCommonName, []*x509.Certificate{client}, "readonly", false},
+		{IdentityDNSName, []*x509.Certificate{client}, "app.example.com", false},
+		{IdentityDNSName, []*x509.Certificate{server}, "", true},
+		{IdentityCommonName, []*x509.Certificate{selfSigned}, "", true},
+		{IdentityCommonName, nil, "", true},
+	}
+	for i, testCase := range testCases {
+		cfg := Config{Enabled: true, Identity: testCase.identity, ClientCAs: clientCAs}
+		identity, expiry, err := cfg.Verify(testCase.chain)
+		if testCase.expectedErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if identity != testCase.expectedIdentity {
+			t.Errorf("Test %d: expected identity %s, got %s", i+1, testCase.expectedIdentity, identity)
+		}
+		if !expiry.Equal(client.NotAfter) {
+			t.Errorf("Test %d: expected expiry %v, got %v", i+1, client.NotAfter, expiry)
+		}
+	}
+}
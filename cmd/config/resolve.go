@@ -0,0 +1,144 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/pkg/env"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// envNameOverrides - environment variables which do not follow
+// the MINIO_<SUB_SYS>_<KEY> convention, in order of precedence.
+var envNameOverrides = map[string]map[string][]string{
+	CredentialsSubSys: {
+		AccessKey: {EnvRootUser, EnvAccessKey},
+		SecretKey: {EnvRootPassword, EnvSecretKey},
+	},
+	RegionSubSys: {
+		RegionName: {EnvRegion, EnvRegionName},
+	},
+}
+
+// envSubSysOverrides - sub-systems whose environment
+// variables use a different prefix than their name.
+var envSubSysOverrides = map[string]string{
+	CompressionSubSys: "COMPRESS",
+}
+
+// EnvNames - returns the environment variables which override
+// key of the sub-system target, in order of precedence.
+func EnvNames(subSys, target, key string) []string {
+	if names, ok := envNameOverrides[subSys][key]; ok {
+		return names
+	}
+	prefix, ok := envSubSysOverrides[subSys]
+	if !ok {
+		prefix = strings.ToUpper(subSys)
+	}
+	name := EnvPrefix + prefix + EnvWordDelimiter + strings.ToUpper(key)
+	if target != Default {
+		name += Default + target
+	}
+	return []string{name}
+}
+
+// envTargets - returns the targets of subSys configured only
+// through environment variables, such as MINIO_NOTIFY_WEBHOOK_ENABLE_<target>.
+func envTargets(subSys string) []string {
+	if SubSystemsSingleTargets.Contains(subSys) {
+		return nil
+	}
+	enableEnv := EnvPrefix + strings.ToUpper(subSys) + EnvWordDelimiter + strings.ToUpper(Enable) + Default
+	var targets []string
+	for _, name := range env.List(enableEnv) {
+		if target := strings.TrimPrefix(name, enableEnv); target != "" {
+			targets = append(targets, strings.ToLower(target))
+		}
+	}
+	return targets
+}
+
+// Resolve - returns every key of every sub-system target along with
+// the value in effect and where it comes from, environment variables
+// take precedence over the stored config c which takes precedence over
+// the defaults. Stored values which differ from the running config and
+// cannot be applied dynamically are reported as pending a restart.
+func (c Config) Resolve(running Config) []madmin.ResolvedConfigKV {
+	var resolved []madmin.ResolvedConfigKV
+	for _, subSys := range SubSystems.ToSlice() {
+		defaultKVS := DefaultKVS[subSys]
+		targets := set.CreateStringSet(Default)
+		for target := range c[subSys] {
+			targets.Add(target)
+		}
+		for _, target := range envTargets(subSys) {
+			targets.Add(target)
+		}
+		for _, target := range targets.ToSlice() {
+			kvs := c[subSys][target]
+			keys := set.NewStringSet()
+			for _, kv := range defaultKVS {
+				keys.Add(kv.Key)
+			}
+			for _, kv := range kvs {
+				keys.Add(kv.Key)
+			}
+			keys.Remove(Comment)
+			for _, key := range keys.ToSlice() {
+				r := madmin.ResolvedConfigKV{
+					SubSys: subSys,
+					Target: target,
+					Key:    key,
+					Value:  defaultKVS.Get(key),
+					Origin: madmin.ConfigOriginDefault,
+				}
+				if stored, ok := kvs.Lookup(key); ok && stored != r.Value {
+					r.Value = stored
+					r.Origin = madmin.ConfigOriginConfig
+				}
+				for _, name := range EnvNames(subSys, target, key) {
+					if env.IsSet(name) {
+						r.Value = env.Get(name, "")
+						r.Origin = madmin.ConfigOriginEnv
+						r.EnvVar = name
+						break
+					}
+				}
+				if running != nil && r.Origin != madmin.ConfigOriginEnv && !SubSystemsDynamic.Contains(subSys) {
+					stored, _ := kvs.Lookup(key)
+					current, _ := running[subSys][target].Lookup(key)
+					r.PendingRestart = stored != current
+				}
+				resolved = append(resolved, r)
+			}
+		}
+	}
+	sort.Slice(resolved, func(i, j int) bool {
+		if resolved[i].SubSys != resolved[j].SubSys {
+			return resolved[i].SubSys < resolved[j].SubSys
+		}
+		if resolved[i].Target != resolved[j].Target {
+			return resolved[i].Target < resolved[j].Target
+		}
+		return resolved[i].Key < resolved[j].Key
+	})
+	return resolved
+}
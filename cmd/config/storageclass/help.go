@@ -23,13 +23,13 @@ var (
 	Help = config.HelpKVS{
 		config.HelpKV{
 			Key:         ClassStandard,
-			Description: `set the parity count for default standard storage class e.g. "EC:4"`,
+			Description: `set the parity count and optionally the erasure block size for default standard storage class e.g. "EC:4" or "EC:4:1MiB"`,
 			Optional:    true,
 			Type:        "string",
 		},
 		config.HelpKV{
 			Key:         ClassRRS,
-			Description: `set the parity count for reduced redundancy storage class e.g. "EC:2"`,
+			Description: `set the parity count and optionally the erasure block size for reduced redundancy storage class e.g. "EC:2"`,
 			Optional:    true,
 			Type:        "string",
 		},
@@ -39,6 +39,18 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         ClassDMAThreshold,
+			Description: `use O_DIRECT only for files of at least this size, defaults to "0" e.g. "1MiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         ClassCustom,
+			Description: `define custom storage classes with their own parity count and erasure block size e.g. "ARCHIVE=EC:6,FAST=EC:2:1MiB"`,
+			Optional:    true,
+			Type:        "csv",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
 )
@@ -47,12 +48,19 @@ const (
 	ClassRRS      = "rrs"
 	ClassDMA      = "dma"
 
+	ClassDMAThreshold = "dma_threshold"
+	ClassCustom       = "custom"
+
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
 	// Standard storage class environment variable
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
 	// DMA storage class environment variable
 	DMAEnv = "MINIO_STORAGE_CLASS_DMA"
+	// DMA threshold environment variable
+	DMAThresholdEnv = "MINIO_STORAGE_CLASS_DMA_THRESHOLD"
+	// Custom storage classes environment variable
+	CustomEnv = "MINIO_STORAGE_CLASS_CUSTOM"
 
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
@@ -65,6 +73,14 @@ const (
 
 	// Default DMA value
 	defaultDMA = DMAWrite
+
+	// Default DMA threshold, O_DIRECT is used for files of all sizes.
+	defaultDMAThreshold = "0"
+
+	// Erasure block size limits, the default block size of
+	// 10MiB is the largest block size supported.
+	minBlockSize = 64 * humanize.KiByte
+	maxBlockSize = 10 * humanize.MiByte
 )
 
 // DefaultKVS - default storage class config
@@ -82,6 +98,14 @@ var (
 			Key:   ClassDMA,
 			Value: defaultDMA,
 		},
+		config.KV{
+			Key:   ClassDMAThreshold,
+			Value: defaultDMAThreshold,
+		},
+		config.KV{
+			Key:   ClassCustom,
+			Value: "",
+		},
 	}
 )
 
@@ -89,6 +113,10 @@ var (
 type StorageClass struct {
 	Parity int
 	DMA    string
+
+	// Erasure block size of objects in this storage
+	// class, zero if the default block size is used.
+	BlockSize int64 `json:",omitempty"`
 }
 
 // Config storage class configuration
@@ -96,6 +124,14 @@ type Config struct {
 	Standard StorageClass `json:"standard"`
 	RRS      StorageClass `json:"rrs"`
 	DMA      StorageClass `json:"dma"`
+
+	// Custom storage classes defined by name, each
+	// with its own parity.
+	Custom map[string]StorageClass `json:"custom,omitempty"`
+
+	// Files smaller than DMAThreshold bytes are
+	// read and written without O_DIRECT.
+	DMAThreshold int64 `json:"-"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -115,6 +151,16 @@ func IsValid(sc string) bool {
 	return sc == RRS || sc == STANDARD || sc == DMA
 }
 
+// IsValid - returns true if input string is a valid storage
+// class kind supported, including the custom storage classes.
+func (sCfg Config) IsValid(sc string) bool {
+	if IsValid(sc) {
+		return true
+	}
+	_, ok := sCfg.Custom[sc]
+	return ok
+}
+
 // UnmarshalText unmarshals storage class from its textual form into
 // storageClass structure.
 func (sc *StorageClass) UnmarshalText(b []byte) error {
@@ -135,32 +181,50 @@ func (sc *StorageClass) UnmarshalText(b []byte) error {
 		return err
 	}
 	sc.Parity = s.Parity
+	sc.BlockSize = s.BlockSize
 	return nil
 }
 
 // MarshalText - marshals storage class string.
 func (sc *StorageClass) MarshalText() ([]byte, error) {
 	if sc.Parity != 0 {
-		return []byte(fmt.Sprintf("%s:%d", schemePrefix, sc.Parity)), nil
+		return []byte(sc.String()), nil
 	}
 	return []byte(sc.DMA), nil
 }
 
 func (sc *StorageClass) String() string {
 	if sc.Parity != 0 {
+		if sc.BlockSize != 0 {
+			return fmt.Sprintf("%s:%d:%s", schemePrefix, sc.Parity, formatBlockSize(sc.BlockSize))
+		}
 		return fmt.Sprintf("%s:%d", schemePrefix, sc.Parity)
 	}
 	return sc.DMA
 }
 
+// formatBlockSize returns the block size in the largest binary
+// unit it is a multiple of, e.g. "1MiB".
+func formatBlockSize(size int64) string {
+	switch {
+	case size%humanize.MiByte == 0:
+		return fmt.Sprintf("%dMiB", size/humanize.MiByte)
+	case size%humanize.KiByte == 0:
+		return fmt.Sprintf("%dKiB", size/humanize.KiByte)
+	}
+	return strconv.FormatInt(size, 10)
+}
+
 // Parses given storageClassEnv and returns a storageClass structure.
-// Supported Storage Class format is "Scheme:Number of parity disks".
+// Supported Storage Class format is "Scheme:Number of parity disks"
+// optionally followed by ":Erasure block size" e.g. "EC:4:1MiB".
 // Currently only supported scheme is "EC".
 func parseStorageClass(storageClassEnv string) (sc StorageClass, err error) {
 	s := strings.Split(storageClassEnv, ":")
 
-	// only two elements allowed in the string - "scheme" and "number of parity disks"
-	if len(s) > 2 {
+	// only three elements allowed in the string - "scheme", "number of
+	// parity disks" and the optional "erasure block size"
+	if len(s) > 3 {
 		return StorageClass{}, config.ErrStorageClassValue(nil).Msg("Too many sections in " + storageClassEnv)
 	} else if len(s) < 2 {
 		return StorageClass{}, config.ErrStorageClassValue(nil).Msg("Too few sections in " + storageClassEnv)
@@ -177,11 +241,82 @@ func parseStorageClass(storageClassEnv string) (sc StorageClass, err error) {
 		return StorageClass{}, config.ErrStorageClassValue(err)
 	}
 
+	var blockSize int64
+	if len(s) == 3 {
+		size, err := humanize.ParseBytes(s[2])
+		if err != nil {
+			return StorageClass{}, config.ErrStorageClassValue(err)
+		}
+		if size < minBlockSize || size > maxBlockSize {
+			return StorageClass{}, config.ErrStorageClassValue(nil).Msg(fmt.Sprintf("Erasure block size %s should be between %s and %s",
+				s[2], formatBlockSize(minBlockSize), formatBlockSize(maxBlockSize)))
+		}
+		blockSize = int64(size)
+	}
+
 	return StorageClass{
-		Parity: parityDisks,
+		Parity:    parityDisks,
+		BlockSize: blockSize,
 	}, nil
 }
 
+// Parses the custom storage classes given as a comma separated list
+// of "NAME=Scheme:Number of parity disks[:Erasure block size]" e.g.
+// "ARCHIVE=EC:6,FAST=EC:2:1MiB".
+func parseCustomStorageClasses(customEnv string) (map[string]StorageClass, error) {
+	custom := make(map[string]StorageClass)
+	for _, entry := range strings.Split(customEnv, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			return nil, config.ErrStorageClassValue(nil).Msg("Custom storage class must be of the form NAME=EC:N[:BLOCKSIZE], found " + entry)
+		}
+		name := strings.TrimSpace(kv[0])
+		if !isValidCustomName(name) {
+			return nil, config.ErrStorageClassValue(nil).Msg("Invalid custom storage class name " + name +
+				", only upper case letters, digits and '_' are allowed")
+		}
+		if IsValid(name) {
+			return nil, config.ErrStorageClassValue(nil).Msg("Custom storage class " + name + " is reserved")
+		}
+		if _, ok := custom[name]; ok {
+			return nil, config.ErrStorageClassValue(nil).Msg("Custom storage class " + name + " is defined more than once")
+		}
+		sc, err := parseStorageClass(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, err
+		}
+		custom[name] = sc
+	}
+	return custom, nil
+}
+
+func isValidCustomName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Validates the parity disks of a custom storage class.
+func validateCustomParity(name string, parity, setDriveCount int) error {
+	if parity < minParityDisks {
+		return fmt.Errorf("%s storage class parity %d should be greater than or equal to %d", name, parity, minParityDisks)
+	}
+	if parity > setDriveCount/2 {
+		return fmt.Errorf("%s storage class parity %d should be less than or equal to %d", name, parity, setDriveCount/2)
+	}
+	return nil
+}
+
 // Validates the parity disks.
 func validateParity(ssParity, rrsParity, setDriveCount int) (err error) {
 	if ssParity == 0 && rrsParity == 0 {
@@ -224,7 +359,9 @@ func validateParity(ssParity, rrsParity, setDriveCount int) (err error) {
 // If storage class is not set during startup, default values are returned
 // -- Default for Reduced Redundancy Storage class is, parity = 2 and data = N-Parity
 // -- Default for Standard Storage class is, parity = N/2, data = N/2
-// If storage class is empty
+// If storage class is one of the custom storage classes
+// -- its parity is returned
+// If storage class is empty or unknown
 // -- standard storage class is assumed and corresponding data and parity is returned
 func (sCfg Config) GetParityForSC(sc string) (parity int) {
 	switch strings.TrimSpace(sc) {
@@ -234,16 +371,45 @@ func (sCfg Config) GetParityForSC(sc string) (parity int) {
 			return defaultRRSParity
 		}
 		return sCfg.RRS.Parity
+	case STANDARD, "":
+		return sCfg.Standard.Parity
 	default:
+		if custom, ok := sCfg.Custom[strings.TrimSpace(sc)]; ok {
+			return custom.Parity
+		}
 		return sCfg.Standard.Parity
 	}
 }
 
+// GetBlockSizeForSC - returns the erasure block size configured for the
+// storage class, zero if the default block size should be used. Like
+// GetParityForSC an empty or unknown storage class is treated as the
+// standard storage class.
+func (sCfg Config) GetBlockSizeForSC(sc string) int64 {
+	switch strings.TrimSpace(sc) {
+	case RRS:
+		return sCfg.RRS.BlockSize
+	case STANDARD, "":
+		return sCfg.Standard.BlockSize
+	default:
+		if custom, ok := sCfg.Custom[strings.TrimSpace(sc)]; ok {
+			return custom.BlockSize
+		}
+		return sCfg.Standard.BlockSize
+	}
+}
+
 // GetDMA - returns DMA configuration.
 func (sCfg Config) GetDMA() string {
 	return sCfg.DMA.DMA
 }
 
+// GetDMAThreshold - returns the size in bytes at and above
+// which files are read and written with O_DIRECT.
+func (sCfg Config) GetDMAThreshold() int64 {
+	return sCfg.DMAThreshold
+}
+
 // Enabled returns if etcd is enabled.
 func Enabled(kvs config.KVS) bool {
 	ssc := kvs.Get(ClassStandard)
@@ -293,11 +459,33 @@ func LookupConfig(kvs config.KVS, setDriveCount int) (cfg Config, err error) {
 	}
 	cfg.DMA.DMA = dma
 
+	dmaThreshold := env.Get(DMAThresholdEnv, kvs.Get(ClassDMAThreshold))
+	if dmaThreshold == "" {
+		dmaThreshold = defaultDMAThreshold
+	}
+	threshold, err := humanize.ParseBytes(dmaThreshold)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid dma threshold %q: %w", dmaThreshold, err)
+	}
+	cfg.DMAThreshold = int64(threshold)
+
 	// Validation is done after parsing both the storage classes. This is needed because we need one
 	// storage class value to deduce the correct value of the other storage class.
 	if err = validateParity(cfg.Standard.Parity, cfg.RRS.Parity, setDriveCount); err != nil {
 		return Config{}, err
 	}
 
+	if custom := env.Get(CustomEnv, kvs.Get(ClassCustom)); custom != "" {
+		cfg.Custom, err = parseCustomStorageClasses(custom)
+		if err != nil {
+			return Config{}, err
+		}
+		for name, sc := range cfg.Custom {
+			if err = validateCustomParity(name, sc.Parity, setDriveCount); err != nil {
+				return Config{}, err
+			}
+		}
+	}
+
 	return cfg, nil
 }
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/minio/minio/cmd/config"
 )
 
 func TestParseStorageClass(t *testing.T) {
@@ -37,9 +39,18 @@ func TestParseStorageClass(t *testing.T) {
 		{"AB:4", StorageClass{
 			Parity: 4},
 			errors.New("Unsupported scheme AB. Supported scheme is EC")},
+		{"EC:4:1MiB", StorageClass{
+			Parity: 4, BlockSize: 1 << 20},
+			nil},
 		{"EC:4:5", StorageClass{
 			Parity: 4},
-			errors.New("Too many sections in EC:4:5")},
+			errors.New("Erasure block size 5 should be between 64KiB and 10MiB")},
+		{"EC:4:20MiB", StorageClass{
+			Parity: 4},
+			errors.New("Erasure block size 20MiB should be between 64KiB and 10MiB")},
+		{"EC:4:1MiB:5", StorageClass{
+			Parity: 4},
+			errors.New("Too many sections in EC:4:1MiB:5")},
 		{"EC:A", StorageClass{
 			Parity: 4},
 			errors.New(`strconv.Atoi: parsing "A": invalid syntax`)},
@@ -161,3 +172,118 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+// Test DMA threshold lookup with valid and invalid inputs
+func TestLookupConfigDMAThreshold(t *testing.T) {
+	tests := []struct {
+		threshold string
+		want      int64
+		wantErr   bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"1MiB", 1 << 20, false},
+		{"4096", 4096, false},
+		{"-1", 0, true},
+		{"invalid", 0, true},
+	}
+	for i, tt := range tests {
+		kvs := config.KVS{
+			config.KV{Key: ClassStandard, Value: ""},
+			config.KV{Key: ClassRRS, Value: "EC:2"},
+			config.KV{Key: ClassDMA, Value: DMAWrite},
+			config.KV{Key: ClassDMAThreshold, Value: tt.threshold},
+		}
+		cfg, err := LookupConfig(kvs, 4)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("Test %d, Expected error %t, got %v", i+1, tt.wantErr, err)
+		}
+		if err == nil && cfg.GetDMAThreshold() != tt.want {
+			t.Errorf("Test %d, Expected DMA threshold to be %d, got %d", i+1, tt.want, cfg.GetDMAThreshold())
+		}
+	}
+}
+
+// Test custom storage classes lookup with valid and invalid inputs
+func TestLookupConfigCustom(t *testing.T) {
+	tests := []struct {
+		custom  string
+		want    map[string]int
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"ARCHIVE=EC:6", map[string]int{"ARCHIVE": 6}, false},
+		{"ARCHIVE=EC:6, FAST_1=EC:2", map[string]int{"ARCHIVE": 6, "FAST_1": 2}, false},
+		{"ARCHIVE=EC:9", nil, true},
+		{"ARCHIVE=EC:1", nil, true},
+		{"ARCHIVE", nil, true},
+		{"archive=EC:4", nil, true},
+		{"STANDARD=EC:4", nil, true},
+		{"ARCHIVE=EC:4,ARCHIVE=EC:6", nil, true},
+		{"ARCHIVE=RS:4", nil, true},
+	}
+	for i, tt := range tests {
+		kvs := config.KVS{
+			config.KV{Key: ClassStandard, Value: ""},
+			config.KV{Key: ClassRRS, Value: "EC:2"},
+			config.KV{Key: ClassDMA, Value: DMAWrite},
+			config.KV{Key: ClassDMAThreshold, Value: ""},
+			config.KV{Key: ClassCustom, Value: tt.custom},
+		}
+		cfg, err := LookupConfig(kvs, 16)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("Test %d, Expected error %t, got %v", i+1, tt.wantErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if len(cfg.Custom) != len(tt.want) {
+			t.Fatalf("Test %d, Expected %d custom storage classes, got %d", i+1, len(tt.want), len(cfg.Custom))
+		}
+		for name, parity := range tt.want {
+			if !cfg.IsValid(name) {
+				t.Errorf("Test %d, Expected %s to be a valid storage class", i+1, name)
+			}
+			if got := cfg.GetParityForSC(name); got != parity {
+				t.Errorf("Test %d, Expected parity %d for %s, got %d", i+1, parity, name, got)
+			}
+		}
+		if cfg.GetParityForSC("UNKNOWN") != cfg.Standard.Parity {
+			t.Errorf("Test %d, Expected standard parity for unknown storage class", i+1)
+		}
+	}
+}
+
+// Test erasure block size lookup of the storage classes
+func TestLookupConfigBlockSize(t *testing.T) {
+	kvs := config.KVS{
+		config.KV{Key: ClassStandard, Value: "EC:4:1MiB"},
+		config.KV{Key: ClassRRS, Value: "EC:2"},
+		config.KV{Key: ClassDMA, Value: DMAWrite},
+		config.KV{Key: ClassDMAThreshold, Value: ""},
+		config.KV{Key: ClassCustom, Value: "ARCHIVE=EC:6:256KiB,FAST=EC:2"},
+	}
+	cfg, err := LookupConfig(kvs, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		sc   string
+		want int64
+	}{
+		{"", 1 << 20},
+		{STANDARD, 1 << 20},
+		{RRS, 0},
+		{"ARCHIVE", 256 << 10},
+		{"FAST", 0},
+		{"UNKNOWN", 1 << 20},
+	}
+	for i, tt := range tests {
+		if got := cfg.GetBlockSizeForSC(tt.sc); got != tt.want {
+			t.Errorf("Test %d, Expected block size %d for %q, got %d", i+1, tt.want, tt.sc, got)
+		}
+	}
+	if got := cfg.Standard.String(); got != "EC:4:1MiB" {
+		t.Errorf("Expected standard storage class to be EC:4:1MiB, got %s", got)
+	}
+}
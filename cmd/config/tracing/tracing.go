@@ -0,0 +1,131 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// Tracing environment variables
+const (
+	Endpoint    = "endpoint"
+	AuthToken   = "auth_token"
+	SampleRatio = "sample_ratio"
+
+	EnvEnable      = "MINIO_TRACING_ENABLE"
+	EnvEndpoint    = "MINIO_TRACING_ENDPOINT"
+	EnvAuthToken   = "MINIO_TRACING_AUTH_TOKEN"
+	EnvSampleRatio = "MINIO_TRACING_SAMPLE_RATIO"
+)
+
+// Config represents the OpenTelemetry tracing settings.
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Endpoint is the OTLP/HTTP traces endpoint of the collector.
+	Endpoint string `json:"endpoint"`
+	// AuthToken is sent as the bearer token of all export requests.
+	AuthToken string `json:"authToken"`
+	// SampleRatio is the fraction of the traces started by
+	// this cluster which are sampled, traces started by the
+	// client follow the sampling decision of the client.
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+var (
+	// DefaultKVS - default KV config for tracing settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   AuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   SampleRatio,
+			Value: "1",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `OTLP/HTTP traces endpoint e.g. "http://otel-collector:4318/v1/traces"`,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         AuthToken,
+			Description: `opaque string or JWT authorization token`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         SampleRatio,
+			Description: `fraction of the requests traced when the client did not send a trace context, defaults to '1'`,
+			Optional:    true,
+			Type:        "float",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.TracingSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		return cfg, fmt.Errorf("'tracing:enable' value invalid: %w", err)
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+	cfg.Endpoint = env.Get(EnvEndpoint, kvs.Get(Endpoint))
+	if cfg.Endpoint == "" {
+		return cfg, errors.New("'tracing:endpoint' is required to enable tracing")
+	}
+	if _, err = xnet.ParseHTTPURL(cfg.Endpoint); err != nil {
+		return cfg, fmt.Errorf("'tracing:endpoint' value invalid: %w", err)
+	}
+	cfg.AuthToken = env.Get(EnvAuthToken, kvs.Get(AuthToken))
+	cfg.SampleRatio, err = strconv.ParseFloat(env.Get(EnvSampleRatio, kvs.Get(SampleRatio)), 64)
+	if err != nil {
+		return cfg, fmt.Errorf("'tracing:sample_ratio' value invalid: %w", err)
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return cfg, errors.New("'tracing:sample_ratio' must be between 0 and 1")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,176 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package usageexport
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// Usage export environment variables
+const (
+	Interval  = "interval"
+	Endpoint  = "endpoint"
+	AuthToken = "auth_token"
+	Bucket    = "bucket"
+	Format    = "format"
+
+	EnvEnable    = "MINIO_USAGE_EXPORT_ENABLE"
+	EnvInterval  = "MINIO_USAGE_EXPORT_INTERVAL"
+	EnvEndpoint  = "MINIO_USAGE_EXPORT_ENDPOINT"
+	EnvAuthToken = "MINIO_USAGE_EXPORT_AUTH_TOKEN"
+	EnvBucket    = "MINIO_USAGE_EXPORT_BUCKET"
+	EnvFormat    = "MINIO_USAGE_EXPORT_FORMAT"
+
+	minInterval = time.Minute
+)
+
+// Supported snapshot formats.
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Config represents the usage export settings.
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Interval is the minimum duration between two snapshots,
+	// snapshots are only taken at the end of a crawl cycle.
+	Interval time.Duration `json:"interval"`
+	// Endpoint is the webhook the snapshots are posted to.
+	Endpoint string `json:"endpoint"`
+	// AuthToken is sent as the bearer token of all webhook requests.
+	AuthToken string `json:"authToken"`
+	// Bucket is the bucket the snapshots are written to.
+	Bucket string `json:"bucket"`
+	// Format is the format of the snapshots, json or csv.
+	Format string `json:"format"`
+}
+
+var (
+	// DefaultKVS - default KV config for usage export settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Interval,
+			Value: "1h",
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   AuthToken,
+			Value: "",
+		},
+		config.KV{
+			Key:   Bucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   Format,
+			Value: FormatJSON,
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Interval,
+			Description: `minimum duration between two usage snapshots, defaults to '1h'`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `webhook the usage snapshots are posted to e.g. "http://billing:8080/usage"`,
+			Optional:    true,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         AuthToken,
+			Description: `opaque string or JWT authorization token`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Bucket,
+			Description: `bucket the usage snapshots are written to`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Format,
+			Description: `format of the usage snapshots, 'json' or 'csv', defaults to 'json'`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.UsageExportSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		return cfg, fmt.Errorf("'usage_export:enable' value invalid: %w", err)
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+	cfg.Interval, err = time.ParseDuration(env.Get(EnvInterval, kvs.Get(Interval)))
+	if err != nil {
+		return cfg, fmt.Errorf("'usage_export:interval' value invalid: %w", err)
+	}
+	if cfg.Interval < minInterval {
+		return cfg, errors.New("'usage_export:interval' must be at least 1m")
+	}
+	cfg.Endpoint = env.Get(EnvEndpoint, kvs.Get(Endpoint))
+	cfg.Bucket = env.Get(EnvBucket, kvs.Get(Bucket))
+	if cfg.Endpoint == "" && cfg.Bucket == "" {
+		return cfg, errors.New("'usage_export:endpoint' or 'usage_export:bucket' is required to enable usage export")
+	}
+	if cfg.Endpoint != "" {
+		if _, err = xnet.ParseHTTPURL(cfg.Endpoint); err != nil {
+			return cfg, fmt.Errorf("'usage_export:endpoint' value invalid: %w", err)
+		}
+	}
+	cfg.AuthToken = env.Get(EnvAuthToken, kvs.Get(AuthToken))
+	cfg.Format = env.Get(EnvFormat, kvs.Get(Format))
+	switch cfg.Format {
+	case FormatJSON, FormatCSV:
+	default:
+		return cfg, fmt.Errorf("'usage_export:format' value invalid: %s, expected 'json' or 'csv'", cfg.Format)
+	}
+	return cfg, nil
+}
@@ -134,6 +134,9 @@ func runDataCrawler(ctx context.Context, objAPI ObjectLayer) {
 				if !isErrBucketNotFound(err) {
 					logger.LogIf(ctx, err)
 				}
+
+				// Export the usage of the completed cycle if due.
+				globalUsageExport.crawlDone(ctx, objAPI)
 			}
 		}
 	}
@@ -755,6 +758,7 @@ type sizeSummary struct {
 	pendingSize    int64
 	failedSize     int64
 	replicaSize    int64
+	versions       uint64
 }
 
 type getSizeFn func(item crawlItem) (sizeSummary, error)
@@ -776,6 +780,7 @@ type actionMeta struct {
 	oi               ObjectInfo
 	successorModTime time.Time // The modtime of the successor version
 	numVersions      int       // The number of versions of this object
+	newerNoncurrent  int       // The number of noncurrent versions newer than this version
 	bitRotScan       bool      // indicates if bitrot check was requested.
 }
 
@@ -823,6 +828,7 @@ func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta action
 		lifecycle.ObjectOpts{
 			Name:             i.objectPath(),
 			UserTags:         meta.oi.UserTags,
+			Size:             lifecycleObjectSize(meta.oi),
 			ModTime:          meta.oi.ModTime,
 			VersionID:        meta.oi.VersionID,
 			DeleteMarker:     meta.oi.DeleteMarker,
@@ -832,6 +838,8 @@ func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta action
 			RestoreOngoing:   meta.oi.RestoreOngoing,
 			RestoreExpires:   meta.oi.RestoreExpires,
 			TransitionStatus: meta.oi.TransitionStatus,
+
+			NewerNoncurrentVersions: meta.newerNoncurrent,
 		})
 	if i.debug {
 		if versionID != "" {
@@ -878,6 +886,7 @@ func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta action
 	lcOpts := lifecycle.ObjectOpts{
 		Name:             i.objectPath(),
 		UserTags:         obj.UserTags,
+		Size:             lifecycleObjectSize(obj),
 		ModTime:          obj.ModTime,
 		VersionID:        obj.VersionID,
 		DeleteMarker:     obj.DeleteMarker,
@@ -887,6 +896,8 @@ func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta action
 		RestoreOngoing:   obj.RestoreOngoing,
 		RestoreExpires:   obj.RestoreExpires,
 		TransitionStatus: obj.TransitionStatus,
+
+		NewerNoncurrentVersions: meta.newerNoncurrent,
 	}
 	action = i.lifeCycle.ComputeAction(lcOpts)
 	if i.debug {
@@ -921,6 +932,15 @@ func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta action
 				return size
 			}
 		}
+		if action == lifecycle.DeleteVersionAction && !obj.IsLatest {
+			// Noncurrent versions are expired by the background expiry
+			// workers, a version which could not be queued is looked at
+			// again by the next crawl.
+			if globalExpiryState.queueExpiryTask(obj, lcOpts) {
+				return 0
+			}
+			return size
+		}
 		opts.VersionID = obj.VersionID
 	case lifecycle.DeleteAction, lifecycle.DeleteRestoredAction:
 		opts.Versioned = globalBucketVersioningSys.Enabled(i.bucket)
@@ -52,6 +52,7 @@ type dataUsageEntry struct {
 	ReplicationFailedSize  uint64
 	ReplicaSize            uint64
 	Objects                uint64
+	Versions               uint64
 	ObjSizes               sizeHistogram
 	Children               dataUsageHashMap
 }
@@ -85,11 +86,13 @@ func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 	e.ReplicationFailedSize += uint64(summary.failedSize)
 	e.ReplicationPendingSize += uint64(summary.pendingSize)
 	e.ReplicaSize += uint64(summary.replicaSize)
+	e.Versions += summary.versions
 }
 
 // merge other data usage entry into this, excluding children.
 func (e *dataUsageEntry) merge(other dataUsageEntry) {
 	e.Objects += other.Objects
+	e.Versions += other.Versions
 	e.Size += other.Size
 	e.ReplicationPendingSize += other.ReplicationPendingSize
 	e.ReplicationFailedSize += other.ReplicationFailedSize
@@ -365,6 +368,7 @@ func (d *dataUsageCache) bucketsUsageInfo(buckets []BucketInfo) map[string]Bucke
 		dst[bucket.Name] = BucketUsageInfo{
 			Size:                   uint64(flat.Size),
 			ObjectsCount:           flat.Objects,
+			VersionsCount:          flat.Versions,
 			ReplicationPendingSize: flat.ReplicationPendingSize,
 			ReplicatedSize:         flat.ReplicatedSize,
 			ReplicationFailedSize:  flat.ReplicationFailedSize,
@@ -386,6 +390,7 @@ func (d *dataUsageCache) bucketUsageInfo(bucket string) BucketUsageInfo {
 	return BucketUsageInfo{
 		Size:                   uint64(flat.Size),
 		ObjectsCount:           flat.Objects,
+		VersionsCount:          flat.Versions,
 		ReplicationPendingSize: flat.ReplicationPendingSize,
 		ReplicatedSize:         flat.ReplicatedSize,
 		ReplicationFailedSize:  flat.ReplicationFailedSize,
@@ -512,7 +517,7 @@ func (d *dataUsageCache) save(ctx context.Context, store objectIO, name string)
 // dataUsageCacheVer indicates the cache version.
 // Bumping the cache version will drop data from previous versions
 // and write new data with the new version.
-const dataUsageCacheVer = 3
+const dataUsageCacheVer = 4
 
 // serialize the contents of the cache.
 func (d *dataUsageCache) serializeTo(dst io.Writer) error {
@@ -552,7 +557,7 @@ func (d *dataUsageCache) deserialize(r io.Reader) error {
 		return io.ErrUnexpectedEOF
 	}
 	switch b[0] {
-	case 1, 2:
+	case 1, 2, 3:
 		return errors.New("cache version deprecated (will autoupdate)")
 	case dataUsageCacheVer:
 	default:
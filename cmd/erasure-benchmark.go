@@ -0,0 +1,193 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/cpuid"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Default and maximum duration of an erasure benchmark.
+	defaultErasureBenchmarkDuration = 10 * time.Second
+	maxErasureBenchmarkDuration     = time.Minute
+)
+
+// erasureAcceleration returns the SIMD instruction set used for
+// Reed-Solomon coding on this CPU. Like the encoder, the fastest
+// instruction set supported is detected at runtime.
+func erasureAcceleration() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		switch {
+		case cpuid.CPU.AVX512F() && cpuid.CPU.AVX512BW():
+			return "AVX512"
+		case cpuid.CPU.AVX2():
+			return "AVX2"
+		case cpuid.CPU.SSSE3():
+			return "SSSE3"
+		}
+	case "arm64":
+		return "NEON"
+	case "ppc64le":
+		return "VSX"
+	}
+	return "none"
+}
+
+// erasureBenchmarkParams - parameters of an erasure benchmark.
+type erasureBenchmarkParams struct {
+	dataBlocks, parityBlocks int
+	blockSize                int64
+	duration                 time.Duration
+}
+
+// parseErasureBenchmarkParams parses the benchmark parameters from the
+// query values, missing values default to the standard storage class
+// of this deployment.
+func parseErasureBenchmarkParams(values url.Values, setDriveCount int) (p erasureBenchmarkParams, err error) {
+	p.parityBlocks = globalStorageClass.GetParityForSC("")
+	if p.parityBlocks == 0 {
+		p.parityBlocks = setDriveCount / 2
+	}
+	p.blockSize = globalStorageClass.GetBlockSizeForSC("")
+	if p.blockSize == 0 {
+		p.blockSize = blockSizeV1
+	}
+	p.duration = defaultErasureBenchmarkDuration
+
+	if v := values.Get("parity"); v != "" {
+		if p.parityBlocks, err = strconv.Atoi(v); err != nil {
+			return p, err
+		}
+	}
+	p.dataBlocks = setDriveCount - p.parityBlocks
+	if v := values.Get("data"); v != "" {
+		if p.dataBlocks, err = strconv.Atoi(v); err != nil {
+			return p, err
+		}
+	}
+	if p.dataBlocks <= 0 || p.parityBlocks <= 0 {
+		return p, errors.New("data and parity blocks must be greater than 0")
+	}
+	if v := values.Get("blockSize"); v != "" {
+		if p.blockSize, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return p, err
+		}
+	}
+	if p.blockSize <= 0 || p.blockSize > blockSizeV1 {
+		return p, fmt.Errorf("block size must be between 0 and %d", blockSizeV1)
+	}
+	if v := values.Get("duration"); v != "" {
+		if p.duration, err = time.ParseDuration(v); err != nil {
+			return p, err
+		}
+	}
+	if p.duration <= 0 || p.duration > maxErasureBenchmarkDuration {
+		return p, fmt.Errorf("duration must be between 0s and %s", maxErasureBenchmarkDuration)
+	}
+	return p, nil
+}
+
+// toValues returns the query values of the benchmark parameters.
+func (p erasureBenchmarkParams) toValues() url.Values {
+	values := make(url.Values)
+	values.Set("data", strconv.Itoa(p.dataBlocks))
+	values.Set("parity", strconv.Itoa(p.parityBlocks))
+	values.Set("blockSize", strconv.FormatInt(p.blockSize, 10))
+	values.Set("duration", p.duration.String())
+	return values
+}
+
+// runErasureBenchmark encodes and afterwards reconstructs random data
+// with the given erasure coding parameters, each for half of the
+// duration, and returns the throughput of this node.
+func runErasureBenchmark(ctx context.Context, addr string, p erasureBenchmarkParams) madmin.ErasureBenchmarkResult {
+	result := madmin.ErasureBenchmarkResult{
+		Addr:         addr,
+		Acceleration: erasureAcceleration(),
+		DataBlocks:   p.dataBlocks,
+		ParityBlocks: p.parityBlocks,
+		BlockSize:    p.blockSize,
+	}
+
+	erasure, err := NewErasure(ctx, p.dataBlocks, p.parityBlocks, p.blockSize)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	data := make([]byte, p.blockSize)
+	if _, err = rand.Read(data); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	shards, err := erasure.encoder().Split(data)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	// bench calls fn until half of the duration is over and
+	// returns the number of bytes of object data processed
+	// per second.
+	bench := func(fn func() error) (float64, error) {
+		var n int64
+		start := time.Now()
+		deadline := start.Add(p.duration / 2)
+		for time.Now().Before(deadline) {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			if err := fn(); err != nil {
+				return 0, err
+			}
+			n += p.blockSize
+		}
+		return float64(n) / time.Since(start).Seconds(), nil
+	}
+
+	result.EncodeThroughput, err = bench(func() error {
+		return erasure.encoder().Encode(shards)
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	// Reconstruct as many data blocks as there are parity
+	// blocks, the worst case of a degraded read.
+	result.DecodeThroughput, err = bench(func() error {
+		for i := 0; i < p.parityBlocks && i < p.dataBlocks; i++ {
+			shards[i] = shards[i][:0]
+		}
+		return erasure.encoder().ReconstructData(shards)
+	})
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
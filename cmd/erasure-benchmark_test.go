@@ -0,0 +1,70 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseErasureBenchmarkParams(t *testing.T) {
+	testCases := []struct {
+		values        url.Values
+		setDriveCount int
+		expected      erasureBenchmarkParams
+		shouldFail    bool
+	}{
+		{url.Values{}, 16, erasureBenchmarkParams{8, 8, blockSizeV1, defaultErasureBenchmarkDuration}, false},
+		{url.Values{"parity": {"4"}}, 16, erasureBenchmarkParams{12, 4, blockSizeV1, defaultErasureBenchmarkDuration}, false},
+		{url.Values{"data": {"6"}, "parity": {"2"}, "blockSize": {"1048576"}, "duration": {"1s"}}, 0, erasureBenchmarkParams{6, 2, 1 << 20, time.Second}, false},
+		{url.Values{"parity": {"0"}}, 16, erasureBenchmarkParams{}, true},
+		{url.Values{"data": {"x"}}, 16, erasureBenchmarkParams{}, true},
+		{url.Values{"blockSize": {"0"}}, 16, erasureBenchmarkParams{}, true},
+		{url.Values{"blockSize": {"104857600"}}, 16, erasureBenchmarkParams{}, true},
+		{url.Values{"duration": {"1h"}}, 16, erasureBenchmarkParams{}, true},
+	}
+	for i, testCase := range testCases {
+		p, err := parseErasureBenchmarkParams(testCase.values, testCase.setDriveCount)
+		if err != nil && !testCase.shouldFail {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if err == nil && testCase.shouldFail {
+			t.Errorf("Test %d: expected to fail but passed", i+1)
+		}
+		if err == nil && p != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, p)
+		}
+		if err == nil {
+			if q, err := parseErasureBenchmarkParams(p.toValues(), 0); err != nil || q != p {
+				t.Errorf("Test %d: params do not round trip: %v, %v", i+1, q, err)
+			}
+		}
+	}
+}
+
+func TestRunErasureBenchmark(t *testing.T) {
+	p := erasureBenchmarkParams{dataBlocks: 4, parityBlocks: 2, blockSize: 64 * 1024, duration: 100 * time.Millisecond}
+	result := runErasureBenchmark(context.Background(), "localhost:9000", p)
+	if result.Error != "" {
+		t.Fatal(result.Error)
+	}
+	if result.EncodeThroughput <= 0 || result.DecodeThroughput <= 0 {
+		t.Errorf("expected positive throughput, got %v and %v", result.EncodeThroughput, result.DecodeThroughput)
+	}
+}
@@ -117,7 +117,7 @@ func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 			if err != nil {
 				continue
 			}
-			if now.Sub(fi.ModTime) > expiry {
+			if now.Sub(fi.ModTime) > expiry || isAbortableUpload(fi.Metadata) {
 				er.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, fi.Erasure.DataBlocks+1)
 			}
 		}
@@ -248,6 +248,9 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 	dataBlocks := len(onlineDisks) - parityBlocks
 
 	fi := newFileInfo(object, dataBlocks, parityBlocks)
+	if blockSize := globalStorageClass.GetBlockSizeForSC(opts.UserDefined[xhttp.AmzStorageClass]); blockSize > 0 {
+		fi.Erasure.BlockSize = blockSize
+	}
 
 	// we now know the number of blocks this object needs for data and parity.
 	// establish the writeQuorum using this data
@@ -272,6 +275,7 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 	fi.DataDir = mustGetUUID()
 	fi.ModTime = UTCNow()
 	fi.Metadata = cloneMSS(opts.UserDefined)
+	setMultipartUploadLifecycleMeta(fi.Metadata, bucket, object, fi.ModTime)
 
 	uploadID := mustGetUUID()
 	uploadIDPath := er.getUploadIDDir(bucket, object, uploadID)
@@ -782,6 +786,7 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		// Add incoming parts.
 		fi.Parts[i] = ObjectPartInfo{
 			Number:     part.PartNumber,
+			ETag:       part.ETag,
 			Size:       currentFI.Parts[partIdx].Size,
 			ActualSize: currentFI.Parts[partIdx].ActualSize,
 		}
@@ -802,6 +807,7 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+	removeMultipartUploadLifecycleMeta(fi.Metadata)
 
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
@@ -839,6 +845,12 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	}
 	defer lk.Unlock()
 
+	if err = checkWritePreconditions(opts, func() (ObjectInfo, error) {
+		return er.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+	}); err != nil {
+		return oi, err
+	}
+
 	// Rename the multipart object to final location.
 	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath,
 		fi.DataDir, bucket, object, writeQuorum, nil); err != nil {
@@ -611,6 +611,9 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	partsMetadata := make([]FileInfo, len(storageDisks))
 
 	fi := newFileInfo(object, dataDrives, parityDrives)
+	if blockSize := globalStorageClass.GetBlockSizeForSC(opts.UserDefined[xhttp.AmzStorageClass]); blockSize > 0 {
+		fi.Erasure.BlockSize = blockSize
+	}
 
 	if opts.Versioned {
 		fi.VersionID = opts.VersionID
@@ -682,6 +685,12 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock()
 	}
 
+	if err := checkWritePreconditions(opts, func() (ObjectInfo, error) {
+		return er.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+	}); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
@@ -748,6 +757,181 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	return fi.ToObjectInfo(bucket, object), nil
 }
 
+// AppendObject - appends the incoming data as a new part to an existing
+// object, the offset must match the current size of the object.
+func (er erasureObjects) AppendObject(ctx context.Context, bucket, object string, r *PutObjReader, offset int64, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	defer ObjectPathUpdated(pathJoin(bucket, object))
+
+	data := r.Reader
+
+	// Validate input data size and it can never be less than zero.
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return ObjectInfo{}, toObjectErr(errInvalidArgument)
+	}
+
+	// Appends are serialized on the object lock, such that the offset
+	// validation and the metadata update are atomic.
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer lk.Unlock()
+	}
+
+	disks := er.getDisks()
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllFileInfo(ctx, disks, bucket, object, "")
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, er, metaArr, errs)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// List all online disks.
+	onlineDisks, modTime := listOnlineDisks(disks, metaArr, errs)
+
+	// Pick latest valid metadata.
+	fi, err := pickValidFileInfo(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	if fi.Deleted {
+		return ObjectInfo{}, toObjectErr(errFileNotFound, bucket, object)
+	}
+	if fi.DataDir == "" || fi.DataDir == legacyDataDir {
+		return ObjectInfo{}, ObjectNotAppendable{Bucket: bucket, Object: object}
+	}
+	if err = checkAppendObject(fi.ToObjectInfo(bucket, object), offset); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	onlineDisks, metaArr = shuffleDisksAndPartsMetadataByIndex(onlineDisks, metaArr, fi.Erasure.Distribution)
+
+	partID := 1
+	if len(fi.Parts) > 0 {
+		partID = fi.Parts[len(fi.Parts)-1].Number + 1
+	}
+	partSuffix := fmt.Sprintf("part.%d", partID)
+	tmpPart := mustGetUUID()
+	tmpPartPath := pathJoin(tmpPart, partSuffix)
+
+	// Delete the temporary object part. If AppendObject succeeds there would be nothing to delete.
+	defer er.deleteObject(context.Background(), minioMetaTmpBucket, tmpPart, writeQuorum)
+
+	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Fetch buffer for I/O, returns from the pool if not allocates a new one and returns.
+	var buffer []byte
+	switch size := data.Size(); {
+	case size == 0:
+		buffer = make([]byte, 1) // Allocate atleast a byte to reach EOF
+	case size == -1 || size >= fi.Erasure.BlockSize:
+		buffer = er.bp.Get()
+		defer er.bp.Put(buffer)
+	case size < fi.Erasure.BlockSize:
+		// No need to allocate fully fi.Erasure.BlockSize buffer if the incoming data is smaller.
+		buffer = make([]byte, size, 2*size+int64(fi.Erasure.ParityBlocks+fi.Erasure.DataBlocks-1))
+	}
+
+	if len(buffer) > int(fi.Erasure.BlockSize) {
+		buffer = buffer[:fi.Erasure.BlockSize]
+	}
+	writers := make([]io.Writer, len(onlineDisks))
+	for i, disk := range onlineDisks {
+		if disk == nil {
+			continue
+		}
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
+	}
+
+	n, err := erasure.Encode(ctx, data, writers, buffer, writeQuorum)
+	closeBitrotWriters(writers)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer bytes
+	// than specified in request header.
+	if n < data.Size() {
+		return ObjectInfo{}, IncompleteBody{Bucket: bucket, Object: object}
+	}
+
+	for i := range writers {
+		if writers[i] == nil {
+			onlineDisks[i] = nil
+		}
+	}
+
+	// Rename temporary part file to its final location.
+	partPath := pathJoin(object, fi.DataDir, partSuffix)
+	onlineDisks, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tmpPartPath, bucket, partPath, false, writeQuorum, nil)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	md5hex := r.MD5CurrentHexString()
+
+	// Add the appended part, the object ETag is chained to reflect the new content.
+	fi.AddObjectPart(partID, md5hex, n, data.ActualSize())
+	fi.Size += n
+	fi.ModTime = UTCNow()
+	etag := getAppendObjectETag(fi.Metadata["etag"], md5hex, len(fi.Parts))
+
+	for i, disk := range onlineDisks {
+		if disk == OfflineDisk {
+			continue
+		}
+		metaArr[i].Size = fi.Size
+		metaArr[i].ModTime = fi.ModTime
+		metaArr[i].Parts = fi.Parts
+		metaArr[i].Metadata["etag"] = etag
+		metaArr[i].Erasure.AddChecksumInfo(ChecksumInfo{
+			PartNumber: partID,
+			Algorithm:  DefaultBitrotAlgorithm,
+			Hash:       bitrotWriterSum(writers[i]),
+		})
+	}
+
+	tempObj := mustGetUUID()
+
+	// Write unique `xl.meta` for each disk.
+	if onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Atomically rename metadata from tmp location to destination for each disk.
+	if onlineDisks, err = renameFileInfo(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Whether a disk was initially or becomes offline
+	// during this append, send it to the MRF list.
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			continue
+		}
+		er.addPartial(bucket, object, fi.VersionID)
+		break
+	}
+
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			// Object info is the same in all disks, so we can pick
+			// the first meta from online disk
+			fi = metaArr[i]
+			break
+		}
+	}
+
+	return fi.ToObjectInfo(bucket, object), nil
+}
+
 func (er erasureObjects) deleteObjectVersion(ctx context.Context, bucket, object string, writeQuorum int, fi FileInfo) error {
 	defer ObjectPathUpdated(pathJoin(bucket, object))
 	disks := er.getDisks()
@@ -639,6 +639,24 @@ func testObjectQuorumFromMeta(obj ObjectLayer, instanceType string, dirs []strin
 		},
 	}
 
+	// Object for test case 8 - Custom StorageClass defined as Parity 4, MetaData in PutObject requesting the custom Class
+	object8 := "object8"
+	metadata8 := make(map[string]string)
+	metadata8["x-amz-storage-class"] = "ARCHIVE"
+	globalStorageClass = storageclass.Config{
+		Custom: map[string]storageclass.StorageClass{
+			"ARCHIVE": {Parity: 4},
+		},
+	}
+
+	_, err = obj.PutObject(ctx, bucket, object8, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{UserDefined: metadata8})
+	if err != nil {
+		t.Fatalf("Failed to putObject %v", err)
+	}
+
+	parts8, errs8 := readAllFileInfo(ctx, erasureDisks, bucket, object8, "")
+	parts8SC := globalStorageClass
+
 	tests := []struct {
 		parts               []FileInfo
 		errs                []error
@@ -654,6 +672,7 @@ func testObjectQuorumFromMeta(obj ObjectLayer, instanceType string, dirs []strin
 		{parts5, errs5, 14, 14, parts5SC, nil},
 		{parts6, errs6, 8, 9, parts6SC, nil},
 		{parts7, errs7, 14, 14, parts7SC, nil},
+		{parts8, errs8, 12, 12, parts8SC, nil},
 	}
 	for _, tt := range tests {
 		tt := tt
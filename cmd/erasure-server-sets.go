@@ -244,6 +244,23 @@ func (z *erasureServerPools) getZoneIdx(ctx context.Context, bucket, object stri
 		return i, nil
 	}
 
+	// New objects pinned by the bucket placement policy are only
+	// written to the pool of the policy.
+	placementPool, pinned := getPlacementPool(ctx, bucket, object, size)
+
+	// We don't know the exact size, so we ask for at least 1GiB file.
+	if size < 0 {
+		size = 1 << 30
+	}
+
+	if pinned && placementPool < len(z.serverPools) {
+		// We multiply the size by 2 to account for erasure coding.
+		if z.getServerPoolsAvailableSpace(ctx, size*2)[placementPool].Available == 0 {
+			return -1, toObjectErr(errDiskFull)
+		}
+		return placementPool, nil
+	}
+
 	// We multiply the size by 2 to account for erasure coding.
 	idx = z.getAvailableZoneIdx(ctx, size*2)
 	if idx < 0 {
@@ -433,7 +450,10 @@ func (z *erasureServerPools) CrawlAndGetDataUsage(ctx context.Context, bf *bloom
 // MakeBucketWithLocation - creates a new bucket across all serverPools simultaneously
 // even if one of the sets fail to create buckets, we proceed all the successful
 // operations.
-func (z *erasureServerPools) MakeBucketWithLocation(ctx context.Context, bucket string, opts BucketOptions) error {
+func (z *erasureServerPools) MakeBucketWithLocation(ctx context.Context, bucket string, opts BucketOptions) (err error) {
+	ctx, span := startObjectSpan(ctx, "MakeBucketWithLocation", bucket, "")
+	defer func() { endSpan(ctx, span, err) }()
+
 	g := errgroup.WithNErrs(len(z.serverPools))
 
 	// Create buckets in parallel across all sets.
@@ -471,6 +491,9 @@ func (z *erasureServerPools) MakeBucketWithLocation(ctx context.Context, bucket
 }
 
 func (z *erasureServerPools) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	ctx, span := startObjectSpan(ctx, "GetObjectNInfo", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
 		return nil, err
 	}
@@ -516,6 +539,9 @@ func (z *erasureServerPools) GetObject(ctx context.Context, bucket, object strin
 }
 
 func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "GetObjectInfo", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
 		return objInfo, err
 	}
@@ -539,7 +565,10 @@ func (z *erasureServerPools) GetObjectInfo(ctx context.Context, bucket, object s
 }
 
 // PutObject - writes an object to least used erasure pool.
-func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (_ ObjectInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "PutObject", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	// Validate put object input args.
 	if err := checkPutObjectArgs(ctx, bucket, object, z); err != nil {
 		return ObjectInfo{}, err
@@ -560,7 +589,42 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 	return z.serverPools[idx].PutObject(ctx, bucket, object, data, opts)
 }
 
+// AppendObject - appends data to an existing object in the pool it resides in.
+func (z *erasureServerPools) AppendObject(ctx context.Context, bucket string, object string, data *PutObjReader, offset int64, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "AppendObject", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
+	// Validate put object input args.
+	if err = checkPutObjectArgs(ctx, bucket, object, z); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	object = encodeDirObject(object)
+
+	if z.SingleZone() {
+		return z.serverPools[0].AppendObject(ctx, bucket, object, data, offset, opts)
+	}
+
+	for _, pool := range z.serverPools {
+		objInfo, err = pool.AppendObject(ctx, bucket, object, data, offset, opts)
+		if err != nil {
+			if isErrObjectNotFound(err) {
+				continue
+			}
+			return objInfo, err
+		}
+		return objInfo, nil
+	}
+	return objInfo, ObjectNotFound{
+		Bucket: bucket,
+		Object: object,
+	}
+}
+
 func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "DeleteObject", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
 		return objInfo, err
 	}
@@ -584,6 +648,9 @@ func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, ob
 }
 
 func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, objects []ObjectToDelete, opts ObjectOptions) ([]DeletedObject, []error) {
+	ctx, span := startObjectSpan(ctx, "DeleteObjects", bucket, "")
+	defer span.End()
+
 	derrs := make([]error, len(objects))
 	dobjects := make([]DeletedObject, len(objects))
 	objSets := set.NewStringSet()
@@ -621,6 +688,9 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 }
 
 func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "CopyObject", dstBucket, dstObject)
+	defer func() { endSpan(ctx, span, err) }()
+
 	srcObject = encodeDirObject(srcObject)
 	dstObject = encodeDirObject(dstObject)
 
@@ -685,7 +755,10 @@ func (z *erasureServerPools) ListObjectsV2(ctx context.Context, bucket, prefix,
 	return listObjectsV2Info, err
 }
 
-func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (_ ListObjectVersionsInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "ListObjectVersions", bucket, prefix)
+	defer func() { endSpan(ctx, span, err) }()
+
 	loi := ListObjectVersionsInfo{}
 	if marker == "" && versionMarker != "" {
 		return loi, NotImplemented{}
@@ -701,6 +774,15 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 		AskDisks:    globalAPIConfig.getListQuorum(),
 	}
 
+	// Resume within the versions of the marker object, one more
+	// entry is requested since the marker may have no versions left.
+	if versionMarker != "" {
+		opts.IncludeMarker = true
+		if maxKeys > 0 {
+			opts.Limit = maxKeys + 1
+		}
+	}
+
 	// Shortcut for APN/1.0 Veeam/1.0 Backup/10.0
 	// It requests unique blocks with a specific prefix.
 	// We skip scanning the parent directory for
@@ -715,7 +797,17 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 	if err != nil && err != io.EOF {
 		return loi, err
 	}
-	objects := merged.fileInfoVersions(bucket, prefix, delimiter, versionMarker)
+	// Collect one version past maxKeys to know if the listing is truncated
+	// instead of expanding all versions of all entries.
+	limit := maxKeys
+	if maxKeys > 0 {
+		limit = maxKeys + 1
+	}
+	if markerName, _ := parseMarker(marker); versionMarker != "" && (len(merged.o) == 0 || merged.o[0].name != markerName) {
+		// Marker object is gone, nothing to forward.
+		versionMarker = ""
+	}
+	objects := merged.fileInfoVersions(bucket, prefix, delimiter, versionMarker, limit)
 	loi.IsTruncated = err == nil && len(objects) > 0
 	if maxKeys > 0 && len(objects) > maxKeys {
 		objects = objects[:maxKeys]
@@ -736,7 +828,10 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 	return loi, nil
 }
 
-func (z *erasureServerPools) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsInfo, error) {
+func (z *erasureServerPools) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (_ ListObjectsInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "ListObjects", bucket, prefix)
+	defer func() { endSpan(ctx, span, err) }()
+
 	var loi ListObjectsInfo
 
 	merged, err := z.listPath(ctx, listPathOptions{
@@ -800,7 +895,10 @@ func (z *erasureServerPools) ListMultipartUploads(ctx context.Context, bucket, p
 }
 
 // Initiate a new multipart upload on a hashedSet based on object name.
-func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (string, error) {
+func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (_ string, err error) {
+	ctx, span := startObjectSpan(ctx, "NewMultipartUpload", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	if err := checkNewMultipartArgs(ctx, bucket, object, z); err != nil {
 		return "", err
 	}
@@ -809,8 +907,13 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 		return z.serverPools[0].NewMultipartUpload(ctx, bucket, object, opts)
 	}
 
-	// We don't know the exact size, so we ask for at least 1GiB file.
-	idx, err := z.getZoneIdx(ctx, bucket, object, opts, 1<<30)
+	// The size is only known if declared by the client, unsized uploads
+	// are placed as objects of unknown size.
+	size := int64(-1)
+	if opts.DeclaredSize > 0 {
+		size = opts.DeclaredSize
+	}
+	idx, err := z.getZoneIdx(ctx, bucket, object, opts, size)
 	if err != nil {
 		return "", err
 	}
@@ -829,7 +932,10 @@ func (z *erasureServerPools) CopyObjectPart(ctx context.Context, srcBucket, srcO
 }
 
 // PutObjectPart - writes part of an object to hashedSet based on the object name.
-func (z *erasureServerPools) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (PartInfo, error) {
+func (z *erasureServerPools) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (_ PartInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "PutObjectPart", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	if err := checkPutObjectPartArgs(ctx, bucket, object, z); err != nil {
 		return PartInfo{}, err
 	}
@@ -916,7 +1022,10 @@ func (z *erasureServerPools) ListObjectParts(ctx context.Context, bucket, object
 }
 
 // Aborts an in-progress multipart operation on hashedSet based on the object name.
-func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) error {
+func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) (err error) {
+	ctx, span := startObjectSpan(ctx, "AbortMultipartUpload", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	if err := checkAbortMultipartArgs(ctx, bucket, object, z); err != nil {
 		return err
 	}
@@ -946,6 +1055,9 @@ func (z *erasureServerPools) AbortMultipartUpload(ctx context.Context, bucket, o
 
 // CompleteMultipartUpload - completes a pending multipart transaction, on hashedSet based on object name.
 func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "CompleteMultipartUpload", bucket, object)
+	defer func() { endSpan(ctx, span, err) }()
+
 	if err = checkCompleteMultipartArgs(ctx, bucket, object, z); err != nil {
 		return objInfo, err
 	}
@@ -977,6 +1089,9 @@ func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket
 
 // GetBucketInfo - returns bucket info from one of the erasure coded serverPools.
 func (z *erasureServerPools) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
+	ctx, span := startObjectSpan(ctx, "GetBucketInfo", bucket, "")
+	defer func() { endSpan(ctx, span, err) }()
+
 	if z.SingleZone() {
 		bucketInfo, err = z.serverPools[0].GetBucketInfo(ctx, bucket)
 		if err != nil {
@@ -1034,7 +1149,10 @@ func (z *erasureServerPools) IsTaggingSupported() bool {
 // DeleteBucket - deletes a bucket on all serverPools simultaneously,
 // even if one of the serverPools fail to delete buckets, we proceed to
 // undo a successful operation.
-func (z *erasureServerPools) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+func (z *erasureServerPools) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) (err error) {
+	ctx, span := startObjectSpan(ctx, "DeleteBucket", bucket, "")
+	defer func() { endSpan(ctx, span, err) }()
+
 	if z.SingleZone() {
 		return z.serverPools[0].DeleteBucket(ctx, bucket, forceDelete)
 	}
@@ -1390,6 +1508,9 @@ func (z *erasureServerPools) getZoneAndSet(id string) (int, int, error) {
 // HealthOptions takes input options to return sepcific information
 type HealthOptions struct {
 	Maintenance bool
+	// ReadQuorum only verifies that this server reaches
+	// read quorum on all erasure sets.
+	ReadQuorum bool
 }
 
 // HealthResult returns the current state of the system, also
@@ -1407,6 +1528,10 @@ type HealthResult struct {
 // can be used to query scenarios if health may be lost
 // if this node is taken down by an external orchestrator.
 func (z *erasureServerPools) Health(ctx context.Context, opts HealthOptions) HealthResult {
+	if opts.ReadQuorum {
+		return z.readQuorumHealth(ctx)
+	}
+
 	erasureSetUpCount := make([][]int, len(z.serverPools))
 	for i := range z.serverPools {
 		erasureSetUpCount[i] = make([]int, len(z.serverPools[i].sets))
@@ -1494,6 +1619,38 @@ func (z *erasureServerPools) Health(ctx context.Context, opts HealthOptions) Hea
 	}
 }
 
+// readQuorumHealth - returns healthy if this server reaches
+// read quorum on all erasure sets of all pools.
+func (z *erasureServerPools) readQuorumHealth(ctx context.Context) HealthResult {
+	parityDrives := globalStorageClass.GetParityForSC(storageclass.STANDARD)
+	diskCount := z.SetDriveCount()
+	if parityDrives == 0 {
+		parityDrives = getDefaultParityBlocks(diskCount)
+	}
+	readQuorum := diskCount - parityDrives
+
+	for poolIdx, pool := range z.serverPools {
+		for setIdx, set := range pool.sets {
+			var online int
+			for _, disk := range set.getDisks() {
+				if disk != nil && disk.IsOnline() {
+					online++
+				}
+			}
+			if online < readQuorum {
+				logger.LogOnceIf(ctx, fmt.Errorf("Read quorum lost on pool: %d, set: %d, expected read quorum: %d, online drives: %d",
+					poolIdx, setIdx, readQuorum, online), "read-quorum-health")
+				return HealthResult{
+					Healthy: false,
+					ZoneID:  poolIdx,
+					SetID:   setIdx,
+				}
+			}
+		}
+	}
+	return HealthResult{Healthy: true}
+}
+
 // PutObjectTags - replace or add tags to an existing object
 func (z *erasureServerPools) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
 	object = encodeDirObject(object)
@@ -755,6 +755,11 @@ func (s *erasureSets) PutObject(ctx context.Context, bucket string, object strin
 	return s.getHashedSet(object).PutObject(ctx, bucket, object, data, opts)
 }
 
+// AppendObject - appends data to an object on hashedSet based on the object name.
+func (s *erasureSets) AppendObject(ctx context.Context, bucket string, object string, data *PutObjReader, offset int64, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return s.getHashedSet(object).AppendObject(ctx, bucket, object, data, offset, opts)
+}
+
 // GetObjectInfo - reads object metadata from the hashedSet based on the object name.
 func (s *erasureSets) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	return s.getHashedSet(object).GetObjectInfo(ctx, bucket, object, opts)
@@ -192,6 +192,9 @@ func getDisksInfo(disks []StorageAPI, endpoints []string) (disksInfo []madmin.Di
 			if info.Total > 0 {
 				di.Utilization = float64(info.Used / info.Total * 100)
 			}
+			if err == nil {
+				di.Metrics = info.Metrics.toAdmin()
+			}
 			disksInfo[index] = di
 			return err
 		}, index)
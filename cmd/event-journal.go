@@ -0,0 +1,259 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/config/eventjournal"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+)
+
+const (
+	// Prefix under which the journaled events of every bucket are saved.
+	eventJournalPrefix = bucketConfigPrefix + "/.events"
+
+	// Interval at which the pending events are saved.
+	eventJournalSaveInterval = 5 * time.Second
+
+	// Interval at which events older than the retention are removed.
+	eventJournalPurgeInterval = 10 * time.Minute
+
+	// Maximum number of pending events of this node, further
+	// events are dropped until the pending events are saved.
+	eventJournalMaxPending = 100000
+)
+
+// globalEventJournal is set when the event journal is enabled.
+var (
+	globalEventJournalEnabled int32
+	globalEventJournal        *eventJournal
+)
+
+// eventJournal keeps the events sent on this node for a replay, the
+// events are saved in batches per bucket, each batch is named after
+// the time of its first and last event.
+type eventJournal struct {
+	retention time.Duration
+
+	mu      sync.Mutex
+	pending map[string][]event.Event
+	count   int
+	dropped int
+}
+
+func newEventJournal(retention time.Duration) *eventJournal {
+	return &eventJournal{
+		retention: retention,
+		pending:   make(map[string][]event.Event),
+	}
+}
+
+// initEventJournal starts journaling the events sent on this node.
+func initEventJournal(ctx context.Context, cfg eventjournal.Config) {
+	if !cfg.Enabled || globalIsGateway || !atomic.CompareAndSwapInt32(&globalEventJournalEnabled, 0, 1) {
+		return
+	}
+	globalEventJournal = newEventJournal(cfg.Retention)
+	go globalEventJournal.run(ctx)
+}
+
+// add records ev to be saved with the next batch.
+func (j *eventJournal) add(ev event.Event) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.count >= eventJournalMaxPending {
+		j.dropped++
+		return
+	}
+	bucket := ev.S3.Bucket.Name
+	j.pending[bucket] = append(j.pending[bucket], ev)
+	j.count++
+}
+
+// eventJournalBatchPath returns the path of a batch of events of
+// bucket, zero padded times keep the batches sorted by time.
+func eventJournalBatchPath(bucket string, first, last time.Time) string {
+	return pathJoin(eventJournalPrefix, bucket,
+		fmt.Sprintf("%019d-%019d-%s.json", first.UnixNano(), last.UnixNano(), mustGetUUID()))
+}
+
+// parseEventJournalBatchPath returns the time of the first and
+// last event of the batch with the given path.
+func parseEventJournalBatchPath(batchPath string) (first, last time.Time, err error) {
+	tokens := strings.SplitN(strings.TrimSuffix(path.Base(batchPath), ".json"), "-", 3)
+	if len(tokens) != 3 {
+		return first, last, fmt.Errorf("invalid event journal batch %s", batchPath)
+	}
+	firstNano, err := strconv.ParseInt(tokens[0], 10, 64)
+	if err != nil {
+		return first, last, err
+	}
+	lastNano, err := strconv.ParseInt(tokens[1], 10, 64)
+	if err != nil {
+		return first, last, err
+	}
+	return time.Unix(0, firstNano).UTC(), time.Unix(0, lastNano).UTC(), nil
+}
+
+// eventTime returns the time of ev.
+func eventTime(ev event.Event) time.Time {
+	t, err := time.Parse(event.AMZTimeFormat, ev.EventTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// save saves the pending events, a batch per bucket.
+func (j *eventJournal) save(ctx context.Context, objAPI ObjectLayer) error {
+	j.mu.Lock()
+	pending, dropped := j.pending, j.dropped
+	j.pending, j.count, j.dropped = make(map[string][]event.Event), 0, 0
+	j.mu.Unlock()
+
+	if dropped > 0 {
+		logger.LogIf(ctx, fmt.Errorf("event journal is full, %d events were dropped", dropped))
+	}
+
+	for bucket, events := range pending {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		for _, ev := range events {
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		}
+		batchPath := eventJournalBatchPath(bucket, eventTime(events[0]), eventTime(events[len(events)-1]))
+		if err := saveConfig(ctx, objAPI, batchPath, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachEventJournalBatch calls fn with the path of all batches under prefix,
+// along with the time of their first and last event.
+func forEachEventJournalBatch(ctx context.Context, objAPI ObjectLayer, prefix string, fn func(batchPath string, first, last time.Time) error) error {
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, obj := range res.Objects {
+			first, last, err := parseEventJournalBatchPath(obj.Name)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			if err = fn(obj.Name, first, last); err != nil {
+				return err
+			}
+		}
+		if !res.IsTruncated {
+			return nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+// purge removes the batches of events older than the retention.
+func (j *eventJournal) purge(ctx context.Context, objAPI ObjectLayer) error {
+	expiry := UTCNow().Add(-j.retention)
+	return forEachEventJournalBatch(ctx, objAPI, eventJournalPrefix+SlashSeparator, func(batchPath string, first, last time.Time) error {
+		if !last.Before(expiry) {
+			return nil
+		}
+		if err := deleteConfig(ctx, objAPI, batchPath); err != nil && err != errConfigNotFound {
+			return err
+		}
+		return nil
+	})
+}
+
+// run periodically saves the pending events and removes expired
+// events, the function blocks until the context is canceled.
+func (j *eventJournal) run(ctx context.Context) {
+	saveTicker := time.NewTicker(eventJournalSaveInterval)
+	defer saveTicker.Stop()
+	purgeTicker := time.NewTicker(eventJournalPurgeInterval)
+	defer purgeTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-saveTicker.C:
+			if objAPI := newObjectLayerFn(); objAPI != nil {
+				logger.LogIf(ctx, j.save(ctx, objAPI))
+			}
+		case <-purgeTicker.C:
+			if objAPI := newObjectLayerFn(); objAPI != nil {
+				logger.LogIf(ctx, j.purge(ctx, objAPI))
+			}
+		}
+	}
+}
+
+// replay calls fn with the journaled events of bucket between start
+// and end, the batches saved by all nodes are replayed in the order of
+// their first event.
+func (j *eventJournal) replay(ctx context.Context, objAPI ObjectLayer, bucket string, start, end time.Time, fn func(event.Event) error) error {
+	prefix := pathJoin(eventJournalPrefix, bucket) + SlashSeparator
+	return forEachEventJournalBatch(ctx, objAPI, prefix, func(batchPath string, first, last time.Time) error {
+		if last.Before(start) || first.After(end) {
+			return nil
+		}
+		data, err := readConfig(ctx, objAPI, batchPath)
+		if err != nil {
+			if err == errConfigNotFound {
+				return nil
+			}
+			return err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+		for scanner.Scan() {
+			var ev event.Event
+			if err = json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+				return err
+			}
+			if t := eventTime(ev); t.Before(start) || t.After(end) {
+				continue
+			}
+			if err = fn(ev); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	})
+}
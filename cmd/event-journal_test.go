@@ -0,0 +1,97 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestEventJournal(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	newEvent := func(bucket, object string, t time.Time) event.Event {
+		ev := eventArgs{
+			EventName:  event.ObjectCreatedPut,
+			BucketName: bucket,
+			Object:     ObjectInfo{Name: object},
+		}.ToEvent(true)
+		ev.EventTime = t.Format(event.AMZTimeFormat)
+		return ev
+	}
+
+	// Save two batches of events, the first one outside of the
+	// replayed time range.
+	now := UTCNow()
+	j := newEventJournal(time.Hour)
+	j.add(newEvent("bucket", "old", now.Add(-2*time.Hour)))
+	j.add(newEvent("other", "object", now))
+	if err = j.save(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	j.add(newEvent("bucket", "new 1", now))
+	j.add(newEvent("bucket", "new 2", now.Add(time.Second)))
+	if err = j.save(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	replay := func() []string {
+		var keys []string
+		err := j.replay(ctx, objLayer, "bucket", now.Add(-time.Minute), now.Add(time.Minute), func(ev event.Event) error {
+			keys = append(keys, ev.S3.Object.Key)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keys
+	}
+	if keys := replay(); len(keys) != 2 || keys[0] != "new+1" || keys[1] != "new+2" {
+		t.Fatalf("unexpected replayed events %v", keys)
+	}
+
+	// Purging removes the expired batch only.
+	if err = j.purge(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	var batches int
+	err = forEachEventJournalBatch(ctx, objLayer, eventJournalPrefix+SlashSeparator, func(string, time.Time, time.Time) error {
+		batches++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batches != 2 {
+		t.Fatalf("expected 2 batches after purge, got %d", batches)
+	}
+	if keys := replay(); len(keys) != 2 {
+		t.Fatalf("unexpected replayed events after purge %v", keys)
+	}
+
+	var nilJournal *eventJournal
+	nilJournal.add(newEvent("bucket", "object", now))
+}
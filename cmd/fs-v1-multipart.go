@@ -232,7 +232,8 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 
 	// Initialize fs.json values.
 	fsMeta := newFSMetaV1()
-	fsMeta.Meta = opts.UserDefined
+	fsMeta.Meta = cloneMSS(opts.UserDefined)
+	setMultipartUploadLifecycleMeta(fsMeta.Meta, bucket, object, UTCNow())
 
 	fsMetaBytes, err := json.Marshal(fsMeta)
 	if err != nil {
@@ -630,6 +631,7 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 		fsMeta.Parts[i] = ObjectPartInfo{
 			Number:     part.PartNumber,
+			ETag:       part.ETag,
 			Size:       fi.Size(),
 			ActualSize: actualSize,
 		}
@@ -714,6 +716,13 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	}
 	defer destLock.Unlock()
 
+	if err = checkWritePreconditions(opts, func() (ObjectInfo, error) {
+		oi, err := fs.getObjectInfo(ctx, bucket, object)
+		return oi, toObjectErr(err, bucket, object)
+	}); err != nil {
+		return oi, err
+	}
+
 	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
 	fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
 	metaFile, err := fs.rwPool.Write(fsMetaPath)
@@ -759,8 +768,12 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		fsMeta.Meta = make(map[string]string)
 	}
 	fsMeta.Meta["etag"] = s3MD5
+	if opts.UserDefined["etag"] != "" { // preserve ETag if set
+		fsMeta.Meta["etag"] = opts.UserDefined["etag"]
+	}
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+	removeMultipartUploadLifecycleMeta(fsMeta.Meta)
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
@@ -845,6 +858,20 @@ func (fs *FSObjects) AbortMultipartUpload(ctx context.Context, bucket, object, u
 	return nil
 }
 
+// isAbortableUpload returns true if the upload saved at uploadIDDir is to
+// be aborted as per the lifecycle configuration of its bucket.
+func (fs *FSObjects) isAbortableUpload(uploadIDDir string) bool {
+	fsMetaBytes, err := ioutil.ReadFile(pathJoin(uploadIDDir, fs.metaJSONFile))
+	if err != nil {
+		return false
+	}
+	var fsMeta fsMetaV1
+	if err = json.Unmarshal(fsMetaBytes, &fsMeta); err != nil {
+		return false
+	}
+	return isAbortableUpload(fsMeta.Meta)
+}
+
 // Removes multipart uploads if any older than `expiry` duration
 // on all buckets for every `cleanupInterval`, this function is
 // blocking and should be run in a go-routine.
@@ -878,7 +905,7 @@ func (fs *FSObjects) cleanupStaleUploads(ctx context.Context, cleanupInterval, e
 					if err != nil {
 						continue
 					}
-					if now.Sub(fi.ModTime()) > expiry {
+					if now.Sub(fi.ModTime()) > expiry || fs.isAbortableUpload(pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID)) {
 						fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID))
 						// It is safe to ignore any directory not empty error (in case there were multiple uploadIDs on the same object)
 						fsRemoveDir(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry))
@@ -19,12 +19,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/minio/minio/pkg/bucket/lifecycle"
 )
 
 // Tests cleanup multipart uploads for filesystem backend.
@@ -71,6 +75,82 @@ func TestFSCleanupMultipartUploadsInRoutine(t *testing.T) {
 	}
 }
 
+// Tests cleanup of multipart uploads as per the AbortIncompleteMultipartUpload
+// lifecycle action for filesystem backend.
+func TestFSCleanupMultipartUploadsLifecycle(t *testing.T) {
+	// Prepare for tests
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+
+	newAllSubsystems()
+	setObjectLayer(obj)
+
+	bucketName := "bucket"
+
+	// Create a context we can cancel.
+	ctx, cancel := context.WithCancel(GlobalContext)
+	obj.MakeBucketWithLocation(ctx, bucketName, BucketOptions{})
+
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><Filter><Prefix>abort/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal("Unexpected err: ", err)
+	}
+	meta := newBucketMetadata(bucketName)
+	meta.lifecycleConfig = lc
+	globalBucketMetadataSys.Set(bucketName, meta)
+
+	// Uploads initiated 3 days ago, only the one under the
+	// rule prefix is to be aborted.
+	initiated := UTCNow().Add(-3 * 24 * time.Hour)
+	uploads := map[string]string{}
+	for _, objectName := range []string{"abort/object", "keep/object"} {
+		uploadID, err := obj.NewMultipartUpload(ctx, bucketName, objectName, ObjectOptions{UserDefined: map[string]string{}})
+		if err != nil {
+			t.Fatal("Unexpected err: ", err)
+		}
+		uploads[objectName] = uploadID
+
+		fsMetaPath := pathJoin(fs.getUploadIDDir(bucketName, objectName, uploadID), fs.metaJSONFile)
+		fsMeta := newFSMetaV1()
+		fsMeta.Meta = map[string]string{}
+		setMultipartUploadLifecycleMeta(fsMeta.Meta, bucketName, objectName, initiated)
+		fsMetaBytes, err := json.Marshal(fsMeta)
+		if err != nil {
+			t.Fatal("Unexpected err: ", err)
+		}
+		if err = ioutil.WriteFile(fsMetaPath, fsMetaBytes, 0644); err != nil {
+			t.Fatal("Unexpected err: ", err)
+		}
+	}
+
+	var cleanupWg sync.WaitGroup
+	cleanupWg.Add(1)
+	go func() {
+		defer cleanupWg.Done()
+		fs.cleanupStaleUploads(ctx, time.Millisecond, GlobalStaleUploadsExpiry*30)
+	}()
+
+	// Wait for 100ms such that - we have given enough time for
+	// cleanup routine to kick in. Flaky on slow systems...
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	cleanupWg.Wait()
+
+	if err = obj.AbortMultipartUpload(GlobalContext, bucketName, "abort/object", uploads["abort/object"], ObjectOptions{}); err != nil {
+		if _, ok := err.(InvalidUploadID); !ok {
+			t.Fatal("Unexpected err: ", err)
+		}
+	} else {
+		t.Error("Item was not cleaned up.")
+	}
+	if err = obj.AbortMultipartUpload(GlobalContext, bucketName, "keep/object", uploads["keep/object"], ObjectOptions{}); err != nil {
+		t.Fatal("Item was unexpectedly cleaned up: ", err)
+	}
+}
+
 // TestNewMultipartUploadFaultyDisk - test NewMultipartUpload with faulty disks
 func TestNewMultipartUploadFaultyDisk(t *testing.T) {
 	// Prepare for tests
@@ -27,6 +27,7 @@ import (
 	"os/user"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -355,10 +356,10 @@ func (fs *FSObjects) crawlBucket(ctx context.Context, bucket string, cache dataU
 		oi := fsMeta.ToObjectInfo(bucket, object, fi)
 		sz := item.applyActions(ctx, fs, actionMeta{oi: oi})
 		if sz >= 0 {
-			return sizeSummary{totalSize: sz}, nil
+			return sizeSummary{totalSize: sz, versions: 1}, nil
 		}
 
-		return sizeSummary{totalSize: fi.Size()}, nil
+		return sizeSummary{totalSize: fi.Size(), versions: 1}, nil
 	})
 
 	return cache, err
@@ -1108,6 +1109,13 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 		atomic.AddInt64(&fs.activeIOCount, -1)
 	}()
 
+	if err := checkWritePreconditions(opts, func() (ObjectInfo, error) {
+		oi, err := fs.getObjectInfo(ctx, bucket, object)
+		return oi, toObjectErr(err, bucket, object)
+	}); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	return fs.putObject(ctx, bucket, object, r, opts)
 }
 
@@ -1202,6 +1210,9 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	if err != nil {
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
+	// Metadata may be updated once the data is consumed,
+	// e.g. with a verified trailing checksum.
+	fsMeta.Meta = cloneMSS(opts.UserDefined)
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
 
 	// Should return IncompleteBody{} error when reader has fewer
@@ -1233,6 +1244,106 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	return fsMeta.ToObjectInfo(bucket, object, fi), nil
 }
 
+// AppendObject - appends the incoming data to the end of an existing
+// object, the offset must match the current size of the object.
+func (fs *FSObjects) AppendObject(ctx context.Context, bucket, object string, r *PutObjReader, offset int64, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if err = checkPutObjectArgs(ctx, bucket, object, fs); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	data := r.Reader
+
+	// Validate input data size and it can never be less than zero.
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return ObjectInfo{}, errInvalidArgument
+	}
+
+	// Lock the object.
+	lk := fs.NewNSLock(bucket, object)
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, err
+	}
+	defer lk.Unlock()
+	defer ObjectPathUpdated(path.Join(bucket, object))
+
+	atomic.AddInt64(&fs.activeIOCount, 1)
+	defer func() {
+		atomic.AddInt64(&fs.activeIOCount, -1)
+	}()
+
+	oi, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	if err = checkAppendObject(oi, offset); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	fsMeta := fsMetaV1{}
+	wlk, err := fs.rwPool.Write(fsMetaPath)
+	if err != nil {
+		wlk, err = fs.rwPool.Create(fsMetaPath)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	// Read objects' metadata in `fs.json`.
+	if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil {
+		// For any error to read fsMeta, set default ETag and proceed.
+		fsMeta = fs.defaultFsJSON(object)
+	}
+
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	f, err := os.OpenFile(fsObjPath, os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	bytesWritten, err := io.Copy(f, data)
+	if err == nil && bytesWritten < data.Size() {
+		// Should return IncompleteBody{} error when reader has fewer
+		// bytes than specified in request header.
+		err = IncompleteBody{Bucket: bucket, Object: object}
+	}
+	if err != nil {
+		// Discard the partially appended data.
+		logger.LogIf(ctx, f.Truncate(offset))
+		f.Close()
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	if err = f.Close(); err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	parts := 1
+	if i := strings.LastIndex(fsMeta.Meta["etag"], "-"); i >= 0 {
+		if n, perr := strconv.Atoi(fsMeta.Meta["etag"][i+1:]); perr == nil {
+			parts = n
+		}
+	}
+	fsMeta.Meta["etag"] = getAppendObjectETag(fsMeta.Meta["etag"], r.MD5CurrentHexString(), parts+1)
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Stat the file to fetch timestamp, size.
+	fi, err := fsStatFile(ctx, fsObjPath)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Success.
+	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+}
+
 // DeleteObjects - deletes an object from a bucket, this operation is destructive
 // and there are no rollbacks supported.
 func (fs *FSObjects) DeleteObjects(ctx context.Context, bucket string, objects []ObjectToDelete, opts ObjectOptions) ([]DeletedObject, []error) {
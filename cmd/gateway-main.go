@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/url"
@@ -177,6 +178,9 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	// Handle common command args.
 	handleCommonCmdArgs(ctx)
 
+	// Obtain or renew the TLS certificate via ACME, if enabled.
+	logger.FatalIf(initACME(GlobalContext), "Unable to obtain the TLS certificate via ACME")
+
 	// Check and load TLS certificates.
 	var err error
 	globalPublicCerts, globalTLSCerts, globalIsTLS, err = getTLSConfig()
@@ -283,6 +287,11 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	httpServer.BaseContext = func(listener net.Listener) context.Context {
 		return GlobalContext
 	}
+	if globalSTSTLSConfig.Enabled && httpServer.TLSConfig != nil {
+		// Client certificates are only requested, they are verified
+		// by the STS API. Clients without certificates are unaffected.
+		httpServer.TLSConfig.ClientAuth = tls.RequestClientCert
+	}
 	go func() {
 		globalHTTPServerErrorCh <- httpServer.Start()
 	}()
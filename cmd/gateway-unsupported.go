@@ -207,6 +207,12 @@ func (a GatewayUnsupported) GetMetrics(ctx context.Context) (*Metrics, error) {
 	return &Metrics{}, NotImplemented{}
 }
 
+// AppendObject - not implemented.
+func (a GatewayUnsupported) AppendObject(ctx context.Context, bucket, object string, data *PutObjReader, offset int64, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return objInfo, NotImplemented{}
+}
+
 // PutObjectTags - not implemented.
 func (a GatewayUnsupported) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
 	logger.LogIf(ctx, NotImplemented{})
@@ -45,8 +45,22 @@ const requestMaxBodySize = globalMaxObjectSize + requestFormDataSize
 
 func setRequestSizeLimitHandler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		maxBodySize := globalAPIConfig.getMaxBodySize()
+		if r.ContentLength > maxBodySize {
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL, guessIsBrowserReq(r))
+			return
+		}
 		// Restricting read data to a given maximum length
-		r.Body = http.MaxBytesReader(w, r.Body, requestMaxBodySize)
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		// Abort uploads from clients sending below the minimum
+		// transfer rate, which would otherwise hold a request
+		// slot for as long as they wish. Internode requests
+		// stream at a fraction of the client rate, they are
+		// never aborted.
+		if !guessIsRPCReq(r) {
+			minRate, grace := globalAPIConfig.getMinTransferRate()
+			r.Body = xhttp.NewMinRateBody(r, minRate, grace)
+		}
 		h.ServeHTTP(w, r)
 	})
 }
@@ -58,11 +72,11 @@ const (
 	maxUserDataSize = 2 * 1024
 )
 
-// ServeHTTP restricts the size of the http header to 8 KB and the size
-// of the user-defined metadata to 2 KB.
+// ServeHTTP restricts the size of the http header to 8 KB, unless
+// configured otherwise, and the size of the user-defined metadata to 2 KB.
 func setRequestHeaderSizeLimitHandler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if isHTTPHeaderSizeTooLarge(r.Header) {
+		if isHTTPHeaderSizeTooLarge(r.Header, int(globalAPIConfig.getMaxHeaderSize())) {
 			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrMetadataTooLarge), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -71,9 +85,9 @@ func setRequestHeaderSizeLimitHandler(h http.Handler) http.Handler {
 }
 
 // isHTTPHeaderSizeTooLarge returns true if the provided
-// header is larger than 8 KB or the user-defined metadata
+// header is larger than maxSize or the user-defined metadata
 // is larger than 2 KB.
-func isHTTPHeaderSizeTooLarge(header http.Header) bool {
+func isHTTPHeaderSizeTooLarge(header http.Header, maxSize int) bool {
 	var size, usersize int
 	for key := range header {
 		length := len(key) + len(header.Get(key))
@@ -84,7 +98,7 @@ func isHTTPHeaderSizeTooLarge(header http.Header) bool {
 				break
 			}
 		}
-		if usersize > maxUserDataSize || size > maxHeaderSize {
+		if usersize > maxUserDataSize || size > maxSize {
 			return true
 		}
 	}
@@ -149,8 +163,10 @@ func setRedirectHandler(h http.Handler) http.Handler {
 
 func setBrowserRedirectHandler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Re-direction is handled specifically for browser requests.
-		if globalBrowserEnabled && guessIsBrowserReq(r) {
+		// Re-direction is handled specifically for browser requests,
+		// bucket DNS style requests address a bucket and are not
+		// redirected to the browser.
+		if globalBrowserEnabled && guessIsBrowserReq(r) && !isVirtualHostReq(r, globalDomainNames) {
 			// Fetch the redirect location if any.
 			redirectLocation := getRedirectLocation(r.URL.Path)
 			if redirectLocation != "" {
@@ -358,7 +374,6 @@ func setTimeValidityHandler(h http.Handler) http.Handler {
 
 var supportedDummyBucketAPIs = map[string][]string{
 	"acl":            {http.MethodPut, http.MethodGet},
-	"cors":           {http.MethodGet},
 	"website":        {http.MethodGet, http.MethodDelete},
 	"logging":        {http.MethodGet},
 	"accelerate":     {http.MethodGet},
@@ -367,7 +382,6 @@ var supportedDummyBucketAPIs = map[string][]string{
 
 // List of not implemented bucket queries
 var notImplementedBucketResourceNames = map[string]struct{}{
-	"cors":           {},
 	"metrics":        {},
 	"website":        {},
 	"logging":        {},
@@ -644,15 +658,18 @@ func setBucketForwardingHandler(h http.Handler) http.Handler {
 	})
 }
 
-// customHeaderHandler sets x-amz-request-id header.
+// customHeaderHandler sets x-amz-request-id and x-amz-id-2 headers.
 // Previously, this value was set right before a response was sent to
 // the client. So, logger and Error response XML were not using this
 // value. This is set here so that this header can be logged as
 // part of the log entry, Error response XML and auditing.
 func addCustomHeaders(h http.Handler) http.Handler {
+	// x-amz-id-2 identifies the node which served the request.
+	hostID := getSHA256Hash([]byte(GetLocalPeer(globalEndpoints)))
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set custom headers such as x-amz-request-id for each request.
 		w.Header().Set(xhttp.AmzRequestID, mustGetRequestID(UTCNow()))
+		w.Header().Set(xhttp.AmzRequestHostID, hostID)
 		h.ServeHTTP(logger.NewResponseWriter(w), r)
 	})
 }
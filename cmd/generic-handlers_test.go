@@ -168,7 +168,7 @@ func generateHeader(size, usersize int) http.Header {
 
 func TestIsHTTPHeaderSizeTooLarge(t *testing.T) {
 	for i, test := range isHTTPHeaderSizeTooLargeTests {
-		if res := isHTTPHeaderSizeTooLarge(test.header); res != test.shouldFail {
+		if res := isHTTPHeaderSizeTooLarge(test.header, maxHeaderSize); res != test.shouldFail {
 			t.Errorf("Test %d: Expected %v got %v", i, res, test.shouldFail)
 		}
 	}
@@ -250,3 +250,25 @@ func TestSSETLSHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestAddCustomHeaders(t *testing.T) {
+	var requestID string
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		// The headers must be available to the handlers for logging and auditing.
+		requestID = w.Header().Get(xhttp.AmzRequestID)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	addCustomHeaders(okHandler).ServeHTTP(w, r)
+
+	if requestID == "" || w.Header().Get(xhttp.AmzRequestID) != requestID {
+		t.Errorf("Expected %s header %q, got %q", xhttp.AmzRequestID, requestID, w.Header().Get(xhttp.AmzRequestID))
+	}
+	// The host id is the same for all requests served by this node.
+	hostID := getSHA256Hash([]byte(GetLocalPeer(globalEndpoints)))
+	if w.Header().Get(xhttp.AmzRequestHostID) != hostID {
+		t.Errorf("Expected %s header %q, got %q", xhttp.AmzRequestHostID, hostID, w.Header().Get(xhttp.AmzRequestHostID))
+	}
+}
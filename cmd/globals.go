@@ -32,6 +32,7 @@ import (
 	"github.com/minio/minio/cmd/config/dns"
 	xldap "github.com/minio/minio/cmd/config/identity/ldap"
 	"github.com/minio/minio/cmd/config/identity/openid"
+	xtls "github.com/minio/minio/cmd/config/identity/tls"
 	"github.com/minio/minio/cmd/config/policy/opa"
 	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/cmd/crypto"
@@ -167,6 +168,9 @@ var (
 	globalLDAPConfig   xldap.Config
 	globalOpenIDConfig openid.Config
 
+	// STS authentication via TLS client certificates.
+	globalSTSTLSConfig xtls.Config
+
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 
@@ -221,6 +225,9 @@ var (
 	globalBucketQuotaSys      *BucketQuotaSys
 	globalBucketVersioningSys *BucketVersioningSys
 
+	// Per access key and anonymous request accounting of this node.
+	globalAccessAccounting = newAccessAccounting()
+
 	// Disk cache drives
 	globalCacheConfig cache.Config
 
@@ -237,6 +244,9 @@ var (
 	// Allocated DNS config wrapper over etcd client.
 	globalDNSConfig dns.Store
 
+	// Built-in bucket DNS server, if enabled.
+	globalDNSServer *dns.Server
+
 	// GlobalKMS initialized KMS configuration
 	GlobalKMS crypto.KMS
 
@@ -276,6 +286,15 @@ var (
 	// If writes to FS backend should be O_SYNC.
 	globalFSOSync bool
 
+	// If nodes talk HTTP/2 without TLS (h2c) to each other.
+	globalInternodeHTTP2 bool
+
+	// If erasure backend disks are read and written with O_DIRECT.
+	globalDirectIO = true
+
+	// Address of the gRPC admin API, disabled when empty.
+	globalAdminGRPCAddr string
+
 	globalProxyEndpoints []ProxyEndpoint
 
 	globalInternodeTransport http.RoundTripper
@@ -283,6 +302,9 @@ var (
 	globalProxyTransport http.RoundTripper
 
 	globalDNSCache *xhttp.DNSCache
+
+	// Declarative bootstrap config passed with '--config', if any.
+	globalBootstrapConfig *bootstrapConfig
 	// Add new variable global values here.
 )
 
@@ -0,0 +1,335 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// requestsPoolWaitSamples is the number of the most recent wait
+	// times used to compute the wait time percentiles.
+	requestsPoolWaitSamples = 1024
+
+	// requestsPoolTuneInterval is the interval between two tunings
+	// of the requests pool limit.
+	requestsPoolTuneInterval = 10 * time.Second
+
+	// requestsPoolMaxGCPause is the longest GC pause tolerated before
+	// the requests pool limit is lowered.
+	requestsPoolMaxGCPause = 100 * time.Millisecond
+)
+
+// requestsPool limits the number of concurrent requests. Unlike a
+// buffered channel its limit can be changed at any time, the requests
+// in flight count against the new limit such that lowering the limit
+// holds new requests back until enough requests are done.
+type requestsPool struct {
+	mu      sync.Mutex
+	limit   int
+	inUse   int
+	waiters []chan struct{}
+}
+
+func newRequestsPool(limit int) *requestsPool {
+	return &requestsPool{limit: limit}
+}
+
+// acquire waits for a slot, in the order of arrival, until deadline
+// fires or done is closed. It returns false if no slot was acquired.
+func (p *requestsPool) acquire(deadline <-chan time.Time, done <-chan struct{}) bool {
+	p.mu.Lock()
+	if p.inUse < p.limit && len(p.waiters) == 0 {
+		p.inUse++
+		p.mu.Unlock()
+		return true
+	}
+	ready := make(chan struct{})
+	p.waiters = append(p.waiters, ready)
+	p.mu.Unlock()
+
+	select {
+	case <-ready:
+		return true
+	case <-deadline:
+	case <-done:
+	}
+
+	p.mu.Lock()
+	for i, waiter := range p.waiters {
+		if waiter == ready {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			p.mu.Unlock()
+			return false
+		}
+	}
+	p.mu.Unlock()
+	// The slot was granted while giving up, hand it over.
+	p.release()
+	return false
+}
+
+// release frees a slot acquired with acquire.
+func (p *requestsPool) release() {
+	p.mu.Lock()
+	p.inUse--
+	p.grant()
+	p.mu.Unlock()
+}
+
+// setLimit changes the number of slots of the pool.
+func (p *requestsPool) setLimit(limit int) {
+	p.mu.Lock()
+	p.limit = limit
+	p.grant()
+	p.mu.Unlock()
+}
+
+// getLimit returns the number of slots of the pool.
+func (p *requestsPool) getLimit() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.limit
+}
+
+// grant hands the free slots over to the waiting requests, p.mu must
+// be held.
+func (p *requestsPool) grant() {
+	for p.inUse < p.limit && len(p.waiters) > 0 {
+		p.inUse++
+		close(p.waiters[0])
+		p.waiters[0] = nil
+		p.waiters = p.waiters[1:]
+	}
+}
+
+// requestsPoolStats tracks the occupancy of the requests pool, it is
+// updated without locks as it is updated by every request.
+type requestsPoolStats struct {
+	// Updated atomically, keep them first for 64-bit alignment.
+	inUse    int64
+	waiting  int64
+	waitSum  int64
+	served   uint64
+	rejected uint64
+
+	// The most recent samples[next % len(samples)] wait times.
+	next    uint64
+	samples [requestsPoolWaitSamples]int64
+}
+
+// queue is called when a request starts waiting for a slot.
+func (s *requestsPoolStats) queue() {
+	atomic.AddInt64(&s.waiting, 1)
+}
+
+// admit is called when a request got a slot after waiting.
+func (s *requestsPoolStats) admit(wait time.Duration) {
+	atomic.AddInt64(&s.waiting, -1)
+	atomic.AddInt64(&s.inUse, 1)
+	atomic.AddUint64(&s.served, 1)
+	s.addWait(wait)
+}
+
+// release is called when an admitted request is done.
+func (s *requestsPoolStats) release() {
+	atomic.AddInt64(&s.inUse, -1)
+}
+
+// reject is called when a request did not get a slot in time.
+func (s *requestsPoolStats) reject(wait time.Duration) {
+	atomic.AddInt64(&s.waiting, -1)
+	atomic.AddUint64(&s.rejected, 1)
+	s.addWait(wait)
+}
+
+// cancel is called when a request was canceled while waiting.
+func (s *requestsPoolStats) cancel() {
+	atomic.AddInt64(&s.waiting, -1)
+}
+
+func (s *requestsPoolStats) addWait(wait time.Duration) {
+	atomic.AddInt64(&s.waitSum, int64(wait))
+
+	n := atomic.AddUint64(&s.next, 1) - 1
+	atomic.StoreInt64(&s.samples[n%uint64(len(s.samples))], int64(wait))
+}
+
+// waitTime returns the percentiles of the most recent wait times, the
+// samples being recorded while they are read may be missed.
+func (s *requestsPoolStats) waitTime() (w madmin.RequestsPoolWaitTime) {
+	count := atomic.LoadUint64(&s.next)
+	if count > uint64(len(s.samples)) {
+		count = uint64(len(s.samples))
+	}
+	samples := make([]time.Duration, count)
+	for i := range samples {
+		samples[i] = time.Duration(atomic.LoadInt64(&s.samples[i]))
+	}
+
+	if len(samples) == 0 {
+		return w
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	percentile := func(p int) time.Duration {
+		return samples[(len(samples)-1)*p/100]
+	}
+	return madmin.RequestsPoolWaitTime{
+		P50: percentile(50),
+		P90: percentile(90),
+		P99: percentile(99),
+		Max: samples[len(samples)-1],
+	}
+}
+
+// requestsPoolSample is what the requests pool tuning is based on.
+type requestsPoolSample struct {
+	memUsed   uint64
+	gcPause   time.Duration
+	saturated bool
+}
+
+// nextRequestsMax returns the requests pool limit to use after observing
+// sample, memTotal is the memory available to the server. The limit is
+// lowered by a quarter under memory pressure or on long GC pauses and
+// raised by a quarter when requests wait for a slot while there is no
+// pressure, the limit is kept between minimum and maximum.
+func nextRequestsMax(current, minimum, maximum int, memTotal uint64, sample requestsPoolSample) int {
+	switch {
+	case sample.memUsed > memTotal/10*8 || sample.gcPause > requestsPoolMaxGCPause:
+		current -= current/4 + 1
+	case sample.saturated && sample.memUsed < memTotal/10*6 && sample.gcPause < requestsPoolMaxGCPause/4:
+		current += current/4 + 1
+	}
+	if current < minimum {
+		current = minimum
+	}
+	if current > maximum {
+		current = maximum
+	}
+	return current
+}
+
+// maxGCPause returns the longest GC pause since the GC cycle lastNumGC.
+func maxGCPause(ms *runtime.MemStats, lastNumGC uint32) (pause time.Duration) {
+	first := lastNumGC + 1
+	if ms.NumGC > uint32(len(ms.PauseNs)) && first < ms.NumGC-uint32(len(ms.PauseNs))+1 {
+		first = ms.NumGC - uint32(len(ms.PauseNs)) + 1
+	}
+	for n := first; n <= ms.NumGC; n++ {
+		// The pause of the GC cycle n is at PauseNs[(n+255)%256].
+		if p := time.Duration(ms.PauseNs[(n+uint32(len(ms.PauseNs))-1)%uint32(len(ms.PauseNs))]); p > pause {
+			pause = p
+		}
+	}
+	return pause
+}
+
+// autoTuneRequestsPool tunes the requests pool limit periodically,
+// when enabled, until ctx is canceled.
+func (t *apiConfig) autoTuneRequestsPool(ctx context.Context) {
+	ticker := time.NewTicker(requestsPoolTuneInterval)
+	defer ticker.Stop()
+
+	var lastNumGC uint32
+	var lastRejected uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !t.isRequestsAutoTune() {
+			continue
+		}
+
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		rejected := atomic.LoadUint64(&t.poolStats.rejected)
+		sample := requestsPoolSample{
+			memUsed:   ms.Sys - ms.HeapReleased,
+			gcPause:   maxGCPause(&ms, lastNumGC),
+			saturated: rejected > lastRejected || atomic.LoadInt64(&t.poolStats.waiting) > 0,
+		}
+		lastNumGC = ms.NumGC
+		lastRejected = rejected
+
+		t.tuneRequestsPool(sample)
+	}
+}
+
+// tuneRequestsPool applies the limit computed for sample.
+func (t *apiConfig) tuneRequestsPool(sample requestsPoolSample) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.requestsAutoTune {
+		return
+	}
+	t.lastPoolSample = sample
+
+	if t.requestsPool == nil {
+		return
+	}
+	current := t.requestsPool.getLimit()
+	minimum := t.requestsMaxBase / 8
+	if minimum < 1 {
+		minimum = 1
+	}
+	if limit := nextRequestsMax(current, minimum, 2*t.requestsMaxBase, t.memTotal, sample); limit != current {
+		t.requestsPool.setLimit(limit)
+	}
+}
+
+func (t *apiConfig) isRequestsAutoTune() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.requestsAutoTune
+}
+
+// getRequestsPoolStats returns the occupancy and the limit of the
+// requests pool of this server.
+func (t *apiConfig) getRequestsPoolStats() madmin.RequestsPoolStats {
+	t.mu.RLock()
+	stats := madmin.RequestsPoolStats{
+		AutoTune: t.requestsAutoTune,
+		MemUsed:  t.lastPoolSample.memUsed,
+		MemTotal: t.memTotal,
+		GCPause:  t.lastPoolSample.gcPause,
+	}
+	if t.requestsPool != nil {
+		stats.Limit = t.requestsPool.getLimit()
+	}
+	t.mu.RUnlock()
+
+	stats.InUse = atomic.LoadInt64(&t.poolStats.inUse)
+	stats.Waiting = atomic.LoadInt64(&t.poolStats.waiting)
+	stats.Served = atomic.LoadUint64(&t.poolStats.served)
+	stats.Rejected = atomic.LoadUint64(&t.poolStats.rejected)
+	stats.WaitTime = t.poolStats.waitTime()
+	return stats
+}
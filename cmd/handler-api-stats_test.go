@@ -0,0 +1,151 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRequestsPool(t *testing.T) {
+	p := newRequestsPool(2)
+	for i := 0; i < 2; i++ {
+		if !p.acquire(nil, nil) {
+			t.Fatal("Expected a free slot")
+		}
+	}
+
+	// Requests in flight count against a lowered limit.
+	p.setLimit(1)
+	p.release()
+	if p.acquire(time.After(10*time.Millisecond), nil) {
+		t.Fatal("Expected no slot while a request is still in flight")
+	}
+
+	// Waiting requests are admitted when the limit is raised, or when
+	// a request gives up its slot.
+	admitted := make(chan bool, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			admitted <- p.acquire(time.After(10*time.Second), nil)
+		}()
+	}
+	p.setLimit(2)
+	if !<-admitted {
+		t.Fatal("Expected a slot once the limit was raised")
+	}
+	p.release()
+	if !<-admitted {
+		t.Fatal("Expected a slot once a request was done")
+	}
+
+	done := make(chan struct{})
+	close(done)
+	if p.acquire(nil, done) {
+		t.Fatal("Expected no slot for a canceled request")
+	}
+	p.release()
+	p.release()
+	if p.inUse != 0 || len(p.waiters) != 0 {
+		t.Errorf("Unexpected %d slots in use and %d waiters", p.inUse, len(p.waiters))
+	}
+}
+
+func TestRequestsPoolStatsWaitTime(t *testing.T) {
+	var s requestsPoolStats
+	if w := s.waitTime(); w.Max != 0 {
+		t.Fatalf("Expected no wait time, got %v", w)
+	}
+
+	// Older samples than the last requestsPoolWaitSamples are dropped.
+	for i := 0; i < requestsPoolWaitSamples; i++ {
+		s.queue()
+		s.admit(time.Hour)
+		s.release()
+	}
+	for i := 1; i <= requestsPoolWaitSamples; i++ {
+		s.queue()
+		if i%2 == 0 {
+			s.admit(time.Duration(i) * time.Millisecond)
+			s.release()
+		} else {
+			s.reject(time.Duration(i) * time.Millisecond)
+		}
+	}
+	s.queue()
+	s.cancel()
+
+	w := s.waitTime()
+	if w.P50 != 512*time.Millisecond || w.P90 != 921*time.Millisecond ||
+		w.P99 != 1013*time.Millisecond || w.Max != 1024*time.Millisecond {
+		t.Errorf("Unexpected wait time %v", w)
+	}
+	if s.inUse != 0 || s.waiting != 0 || s.served != 3*requestsPoolWaitSamples/2 || s.rejected != requestsPoolWaitSamples/2 {
+		t.Errorf("Unexpected stats in use %d, waiting %d, served %d, rejected %d", s.inUse, s.waiting, s.served, s.rejected)
+	}
+}
+
+func TestNextRequestsMax(t *testing.T) {
+	const memTotal = 100 << 30
+	testCases := []struct {
+		current  int
+		sample   requestsPoolSample
+		expected int
+	}{
+		// No pressure and no waiting requests.
+		{100, requestsPoolSample{memUsed: 10 << 30}, 100},
+		// Requests are waiting without any pressure.
+		{100, requestsPoolSample{memUsed: 10 << 30, saturated: true}, 126},
+		// Bounded by the maximum.
+		{190, requestsPoolSample{memUsed: 10 << 30, saturated: true}, 200},
+		// Requests are waiting but the memory usage is already high.
+		{100, requestsPoolSample{memUsed: 70 << 30, saturated: true}, 100},
+		// Memory pressure.
+		{100, requestsPoolSample{memUsed: 90 << 30}, 74},
+		// Long GC pauses.
+		{100, requestsPoolSample{memUsed: 10 << 30, gcPause: time.Second, saturated: true}, 74},
+		// Bounded by the minimum.
+		{12, requestsPoolSample{memUsed: 90 << 30}, 10},
+	}
+	for i, testCase := range testCases {
+		if limit := nextRequestsMax(testCase.current, 10, 200, memTotal, testCase.sample); limit != testCase.expected {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.expected, limit)
+		}
+	}
+}
+
+func TestMaxGCPause(t *testing.T) {
+	var ms runtime.MemStats
+	ms.NumGC = 300
+	for n := uint32(1); n <= ms.NumGC; n++ {
+		ms.PauseNs[(n+255)%256] = uint64(n)
+	}
+	if pause := maxGCPause(&ms, 0); pause != 300 {
+		t.Errorf("Expected 300, got %d", pause)
+	}
+	ms.PauseNs[(298+255)%256] = 1000
+	if pause := maxGCPause(&ms, 297); pause != 1000 {
+		t.Errorf("Expected 1000, got %d", pause)
+	}
+	if pause := maxGCPause(&ms, 298); pause != 300 {
+		t.Errorf("Expected 300, got %d", pause)
+	}
+	if pause := maxGCPause(&ms, 300); pause != 0 {
+		t.Errorf("Expected no pause, got %d", pause)
+	}
+}
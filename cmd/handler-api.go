@@ -27,15 +27,28 @@ import (
 )
 
 type apiConfig struct {
+	// Updated without holding mu, keep it first for 64-bit alignment.
+	poolStats requestsPoolStats
+
 	mu sync.RWMutex
 
 	requestsDeadline time.Duration
-	requestsPool     chan struct{}
+	requestsPool     *requestsPool
+	requestsAutoTune bool
+	requestsMaxBase  int
+	memTotal         uint64
+	lastPoolSample   requestsPoolSample
 	clusterDeadline  time.Duration
 	listQuorum       int
 	extendListLife   time.Duration
+	storageDeadline  time.Duration
 	corsAllowOrigins []string
-	setDriveCount    int
+
+	maxHeaderSize        int64
+	maxBodySize          int64
+	minTransferRate      int64
+	minTransferRateGrace time.Duration
+	setDriveCount        int
 }
 
 func (t *apiConfig) init(cfg api.Config, setDriveCount int) {
@@ -46,14 +59,16 @@ func (t *apiConfig) init(cfg api.Config, setDriveCount int) {
 	t.corsAllowOrigins = cfg.CorsAllowOrigin
 	t.setDriveCount = setDriveCount
 
+	stats, err := sys.GetStats()
+	if err != nil {
+		logger.LogIf(GlobalContext, err)
+		// Default to 16 GiB, not critical.
+		stats.TotalRAM = 16 << 30
+	}
+	t.memTotal = stats.TotalRAM
+
 	var apiRequestsMaxPerNode int
 	if cfg.RequestsMax <= 0 {
-		stats, err := sys.GetStats()
-		if err != nil {
-			logger.LogIf(GlobalContext, err)
-			// Default to 16 GiB, not critical.
-			stats.TotalRAM = 16 << 30
-		}
 		// max requests per node is calculated as
 		// total_ram / ram_per_request
 		// ram_per_request is 4MiB * setDriveCount + 2 * 10MiB (default erasure block size)
@@ -64,17 +79,24 @@ func (t *apiConfig) init(cfg api.Config, setDriveCount int) {
 			apiRequestsMaxPerNode /= len(globalEndpoints.Hostnames())
 		}
 	}
-	if cap(t.requestsPool) < apiRequestsMaxPerNode {
-		// Only replace if needed.
-		// Existing requests will use the previous limit,
-		// but new requests will use the new limit.
-		// There will be a short overlap window,
-		// but this shouldn't last long.
-		t.requestsPool = make(chan struct{}, apiRequestsMaxPerNode)
+	if t.requestsPool == nil {
+		t.requestsPool = newRequestsPool(apiRequestsMaxPerNode)
+	} else {
+		// Existing requests count against the new limit.
+		t.requestsPool.setLimit(apiRequestsMaxPerNode)
 	}
+	// The limit computed from the RAM is the starting point
+	// of the tuning, an explicit limit is never tuned.
+	t.requestsAutoTune = cfg.RequestsAutoTune && cfg.RequestsMax <= 0
+	t.requestsMaxBase = apiRequestsMaxPerNode
 	t.requestsDeadline = cfg.RequestsDeadline
 	t.listQuorum = cfg.GetListQuorum()
 	t.extendListLife = cfg.ExtendListLife
+	t.storageDeadline = cfg.StorageDeadline
+	t.maxHeaderSize = cfg.MaxHeaderSize
+	t.maxBodySize = cfg.MaxBodySize
+	t.minTransferRate = cfg.MinTransferRate
+	t.minTransferRateGrace = cfg.MinTransferRateGrace
 }
 
 func (t *apiConfig) getListQuorum() int {
@@ -98,6 +120,42 @@ func (t *apiConfig) getExtendListLife() time.Duration {
 	return t.extendListLife
 }
 
+func (t *apiConfig) getStorageDeadline() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.storageDeadline
+}
+
+func (t *apiConfig) getMaxHeaderSize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.maxHeaderSize <= 0 {
+		return maxHeaderSize
+	}
+
+	return t.maxHeaderSize
+}
+
+func (t *apiConfig) getMaxBodySize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.maxBodySize <= 0 {
+		return requestMaxBodySize
+	}
+
+	return t.maxBodySize + requestFormDataSize
+}
+
+func (t *apiConfig) getMinTransferRate() (int64, time.Duration) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.minTransferRate, t.minTransferRateGrace
+}
+
 func (t *apiConfig) getCorsAllowOrigins() []string {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -118,7 +176,7 @@ func (t *apiConfig) getClusterDeadline() time.Duration {
 	return t.clusterDeadline
 }
 
-func (t *apiConfig) getRequestsPool() (chan struct{}, time.Duration) {
+func (t *apiConfig) getRequestsPool() (*requestsPool, time.Duration) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
@@ -141,18 +199,28 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 		deadlineTimer := time.NewTimer(deadline)
 		defer deadlineTimer.Stop()
 
-		select {
-		case pool <- struct{}{}:
-			defer func() { <-pool }()
+		stats := &globalAPIConfig.poolStats
+		stats.queue()
+		start := time.Now()
+
+		switch {
+		case pool.acquire(deadlineTimer.C, r.Context().Done()):
+			stats.admit(time.Since(start))
+			defer func() {
+				pool.release()
+				stats.release()
+			}()
 			f.ServeHTTP(w, r)
-		case <-deadlineTimer.C:
+		case r.Context().Err() != nil:
+			stats.cancel()
+			return
+		default:
+			stats.reject(time.Since(start))
 			// Send a http timeout message
 			writeErrorResponse(r.Context(), w,
 				errorCodes.ToAPIErr(ErrOperationMaxedOut),
 				r.URL, guessIsBrowserReq(r))
 			return
-		case <-r.Context().Done():
-			return
 		}
 	}
 }
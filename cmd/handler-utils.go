@@ -226,13 +226,23 @@ func extractReqParams(r *http.Request) map[string]string {
 	region := globalServerRegion
 	cred := getReqAccessCred(r, region)
 
-	// Success.
-	return map[string]string{
+	reqParams := map[string]string{
 		"region":          region,
 		"accessKey":       cred.AccessKey,
 		"sourceIPAddress": handlers.GetSourceIP(r),
 		// Add more fields here.
 	}
+	if cred.ParentUser != "" {
+		reqParams["parentUser"] = cred.ParentUser
+	}
+	if cred.SessionToken != "" {
+		if policyName := getClaimsPolicyName(mustGetClaimsFromToken(r)); policyName != "" {
+			reqParams["policyName"] = policyName
+		}
+	}
+
+	// Success.
+	return reqParams
 }
 
 // Extract response elements to be sent with event notifiation.
@@ -242,6 +252,7 @@ func extractRespElements(w http.ResponseWriter) map[string]string {
 	}
 	return map[string]string{
 		"requestId":      w.Header().Get(xhttp.AmzRequestID),
+		"requestHostId":  w.Header().Get(xhttp.AmzRequestHostID),
 		"content-length": w.Header().Get(xhttp.ContentLength),
 		// Add more fields here.
 	}
@@ -399,6 +410,9 @@ func getResource(path string, host string, domains []string) (string, error) {
 			return "", err
 		}
 	}
+	// With several domains, say 'example.com' and 's3.example.com',
+	// the longest matching domain is the one the bucket is used with.
+	var bucket string
 	for _, domain := range domains {
 		if host == minioReservedBucket+"."+domain {
 			continue
@@ -406,10 +420,21 @@ func getResource(path string, host string, domains []string) (string, error) {
 		if !strings.HasSuffix(host, "."+domain) {
 			continue
 		}
-		bucket := strings.TrimSuffix(host, "."+domain)
-		return SlashSeparator + pathJoin(bucket, path), nil
+		if b := strings.TrimSuffix(host, "."+domain); bucket == "" || len(b) < len(bucket) {
+			bucket = b
+		}
+	}
+	if bucket == "" {
+		return path, nil
 	}
-	return path, nil
+	return SlashSeparator + pathJoin(bucket, path), nil
+}
+
+// isVirtualHostReq returns true if the request addresses
+// a bucket in bucket DNS style on one of the domains.
+func isVirtualHostReq(r *http.Request, domains []string) bool {
+	resource, err := getResource(r.URL.Path, r.Host, domains)
+	return err == nil && resource != r.URL.Path
 }
 
 var regexVersion = regexp.MustCompile(`(\w\d+)`)
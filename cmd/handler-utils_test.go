@@ -221,6 +221,10 @@ func TestGetResource(t *testing.T) {
 		{"/a/b/c", "test.mydomain.com", []string{"mydomain.com"}, "/test/a/b/c"},
 		{"/a/b/c", "test.mydomain.com", []string{"notmydomain.com"}, "/a/b/c"},
 		{"/a/b/c", "test.mydomain.com", nil, "/a/b/c"},
+		{"/a/b/c", "test.mydomain.com:9000", []string{"otherdomain.com", "mydomain.com"}, "/test/a/b/c"},
+		{"/a/b/c", "test.s3.mydomain.com", []string{"mydomain.com", "s3.mydomain.com"}, "/test/a/b/c"},
+		{"/a/b/c", "test.s3.mydomain.com", []string{"s3.mydomain.com", "mydomain.com"}, "/test/a/b/c"},
+		{"/a/b/c", "minio.mydomain.com", []string{"mydomain.com"}, "/a/b/c"},
 	}
 	for i, test := range testCases {
 		gotResource, err := getResource(test.p, test.host, test.domains)
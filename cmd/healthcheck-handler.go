@@ -64,13 +64,39 @@ func ClusterCheckHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, http.StatusOK, nil, mimeNone)
 }
 
-// ReadinessCheckHandler Checks if the process is up. Always returns success.
+// ReadinessCheckHandler returns if the server is ready for requests, i.e.
+// if IAM is loaded and the server reaches read quorum on all erasure sets.
 func ReadinessCheckHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReadinessCheckHandler")
+
 	if shouldProxy() {
 		// Service not initialized yet
 		w.Header().Set(xhttp.MinIOServerStatus, unavailable)
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
+
+	// Gateways have no erasure sets, and IAM is optional.
+	if globalIsGateway {
+		writeResponse(w, http.StatusOK, nil, mimeNone)
+		return
+	}
+
+	if !globalIAMSys.Loaded() {
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
 	}
 
+	objLayer := newObjectLayerFn()
+
+	ctx, cancel := context.WithTimeout(ctx, globalAPIConfig.getClusterDeadline())
+	defer cancel()
+
+	result := objLayer.Health(ctx, HealthOptions{ReadQuorum: true})
+	if !result.Healthy {
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
 	writeResponse(w, http.StatusOK, nil, mimeNone)
 }
 
@@ -0,0 +1,102 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadQuorumHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	xl := z.serverPools[0].sets[0]
+
+	if result := obj.Health(ctx, HealthOptions{ReadQuorum: true}); !result.Healthy {
+		t.Fatal("expected read quorum with all drives online")
+	}
+
+	// For a 16 disk setup, read quorum is 8.
+	erasureDisks := xl.getDisks()
+	xl.getDisks = func() []StorageAPI {
+		disks := make([]StorageAPI, len(erasureDisks))
+		copy(disks, erasureDisks)
+		for i := range disks[:8] {
+			disks[i] = nil
+		}
+		return disks
+	}
+	if result := obj.Health(ctx, HealthOptions{ReadQuorum: true}); !result.Healthy {
+		t.Fatal("expected read quorum with 8 drives online")
+	}
+
+	xl.getDisks = func() []StorageAPI {
+		disks := make([]StorageAPI, len(erasureDisks))
+		copy(disks, erasureDisks)
+		for i := range disks[:9] {
+			disks[i] = nil
+		}
+		return disks
+	}
+	if result := obj.Health(ctx, HealthOptions{ReadQuorum: true}); result.Healthy {
+		t.Fatal("expected read quorum to be lost with 7 drives online")
+	}
+}
+
+func TestReadinessCheckHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	newAllSubsystems()
+	setObjectLayer(obj)
+	globalIAMSys.InitStore(obj)
+
+	ready := func() int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, healthCheckPathPrefix+healthCheckReadinessPath, nil)
+		ReadinessCheckHandler(rec, req)
+		return rec.Code
+	}
+
+	// Not ready until IAM is loaded.
+	if code := ready(); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d before IAM is loaded, got %d", http.StatusServiceUnavailable, code)
+	}
+
+	globalIAMSys.Init(ctx, obj)
+	if code := ready(); code != http.StatusOK {
+		t.Fatalf("expected %d once IAM is loaded, got %d", http.StatusOK, code)
+	}
+}
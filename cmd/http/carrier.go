@@ -0,0 +1,33 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import "net/http"
+
+// HeaderCarrier adapts http.Header to carry the trace
+// context of OpenTelemetry spans across requests.
+type HeaderCarrier http.Header
+
+// Get returns the first value of the header key.
+func (h HeaderCarrier) Get(key string) string {
+	return http.Header(h).Get(key)
+}
+
+// Set sets the header key to value.
+func (h HeaderCarrier) Set(key, value string) {
+	http.Header(h).Set(key, value)
+}
@@ -41,6 +41,20 @@ const (
 	Range              = "Range"
 )
 
+// Standard CORS HTTP header constants
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+)
+
 // Non standard S3 HTTP response constants
 const (
 	XCache       = "X-Cache"
@@ -107,6 +121,18 @@ const (
 	AmzCredential           = "X-Amz-Credential"
 	AmzSecurityToken        = "X-Amz-Security-Token"
 	AmzDecodedContentLength = "X-Amz-Decoded-Content-Length"
+	AmzTrailer              = "X-Amz-Trailer"
+
+	// GetObjectAttributes related headers.
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
+
+	// Additional checksums sent as trailers of aws-chunked payloads.
+	AmzChecksumCRC32  = "X-Amz-Checksum-Crc32"
+	AmzChecksumCRC32C = "X-Amz-Checksum-Crc32c"
+	AmzChecksumSHA1   = "X-Amz-Checksum-Sha1"
+	AmzChecksumSHA256 = "X-Amz-Checksum-Sha256"
 
 	AmzMetaUnencryptedContentLength = "X-Amz-Meta-X-Amz-Unencrypted-Content-Length"
 	AmzMetaUnencryptedContentMD5    = "X-Amz-Meta-X-Amz-Unencrypted-Content-Md5"
@@ -132,6 +158,9 @@ const (
 	// Response request id.
 	AmzRequestID = "x-amz-request-id"
 
+	// Response host id, set to the deployment id.
+	AmzRequestHostID = "x-amz-id-2"
+
 	// Deployment id.
 	MinioDeploymentID = "x-minio-deployment-id"
 
@@ -163,6 +192,26 @@ const (
 	MinIODeleteReplicationStatus = "X-Minio-Replication-Delete-Status"
 	// Header indicates delete-marker replication status.
 	MinIODeleteMarkerReplicationStatus = "X-Minio-Replication-DeleteMarker-Status"
+
+	// Extract the uploaded tar or zip archive into individual objects.
+	MinIOExtract = "X-Minio-Extract"
+	// Number of objects extracted from the uploaded archive.
+	MinIOExtractedObjects = "X-Minio-Extracted-Objects"
+
+	// Offset at which an append object request adds data, the new
+	// size of the object is returned in the response.
+	MinIOAppendOffset = "X-Minio-Append-Offset"
+
+	// Total size of the object declared when initiating a multipart
+	// upload, the upload is placed on a server pool of the bucket
+	// placement policy as an object of that size.
+	MinIOObjectSize = "X-Minio-Object-Size"
+
+	// Headers sent to the object lambda transformation webhook.
+	MinIOLambdaBucket    = "X-Minio-Lambda-Bucket"
+	MinIOLambdaObject    = "X-Minio-Lambda-Object"
+	MinIOLambdaVersionID = "X-Minio-Lambda-Version-Id"
+	MinIOLambdaETag      = "X-Minio-Lambda-Etag"
 )
 
 // Common http query params S3 API
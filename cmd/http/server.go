@@ -33,6 +33,8 @@ import (
 	"github.com/minio/minio/cmd/config/api"
 	"github.com/minio/minio/pkg/certs"
 	"github.com/minio/minio/pkg/env"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 const (
@@ -50,6 +52,7 @@ type Server struct {
 	http.Server
 	Addrs           []string      // addresses on which the server listens for new connection.
 	ShutdownTimeout time.Duration // timeout used for graceful server shutdown.
+	EnableH2C       bool          // accept HTTP/2 without TLS.
 	listenerMutex   sync.Mutex    // to guard 'listener' field.
 	listener        *httpListener // HTTP listener for all 'Addrs' field.
 	inShutdown      uint32        // indicates whether the server is in shutdown or not
@@ -101,8 +104,15 @@ func (srv *Server) Start() (err error) {
 		handler.ServeHTTP(w, r)
 	})
 
+	var serveHandler http.Handler = wrappedHandler
+	if tlsConfig == nil && srv.EnableH2C {
+		// Accept HTTP/2 without TLS, requests which are
+		// not HTTP/2 are served by wrappedHandler as is.
+		serveHandler = h2c.NewHandler(wrappedHandler, &http2.Server{})
+	}
+
 	srv.listenerMutex.Lock()
-	srv.Handler = wrappedHandler
+	srv.Handler = serveHandler
 	srv.listener = listener
 	srv.listenerMutex.Unlock()
 
@@ -208,6 +218,7 @@ func NewServer(addrs []string, handler http.Handler, getCert certs.GetCertificat
 	httpServer.Handler = handler
 	httpServer.TLSConfig = tlsConfig
 	httpServer.MaxHeaderBytes = DefaultMaxHeaderBytes
+	httpServer.ConnContext = connContext
 
 	return httpServer
 }
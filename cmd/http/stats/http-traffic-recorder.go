@@ -53,6 +53,17 @@ func (w *OutgoingTrafficMeter) Write(p []byte) (n int, err error) {
 	return n, err
 }
 
+// ReadFrom calls the underlying ReadFrom, if any, and counts the output bytes.
+func (w *OutgoingTrafficMeter) ReadFrom(r io.Reader) (n int64, err error) {
+	rf, ok := w.ResponseWriter.(io.ReaderFrom)
+	if !ok {
+		return io.Copy(struct{ io.Writer }{w}, r)
+	}
+	n, err = rf.ReadFrom(r)
+	w.countBytes += int(n)
+	return n, err
+}
+
 // Flush calls the underlying Flush.
 func (w *OutgoingTrafficMeter) Flush() {
 	w.ResponseWriter.(http.Flusher).Flush()
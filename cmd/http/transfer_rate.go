@@ -0,0 +1,101 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrRequestTooSlow - the request body was received below the
+// minimum transfer rate.
+var ErrRequestTooSlow = errors.New("request body was received below the minimum transfer rate")
+
+type connContextKey struct{}
+
+// connContext stores the connection of a request in its context,
+// the connection is used to bound the reads of slow request bodies.
+func connContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, c)
+}
+
+// minRateBody is a request body which must be received at a minimum
+// average transfer rate once the grace period is over, reads which
+// would lower the rate below the minimum are aborted.
+type minRateBody struct {
+	io.ReadCloser
+	conn    net.Conn
+	start   time.Time
+	grace   time.Duration
+	minRate int64
+	read    int64
+	err     error
+}
+
+// NewMinRateBody - wraps the body of r so that reading it fails with
+// ErrRequestTooSlow when it is received below minRate bytes per second
+// after the grace period. The body is returned as is if the minimum
+// rate is disabled or the connection of the request is not known.
+func NewMinRateBody(r *http.Request, minRate int64, grace time.Duration) io.ReadCloser {
+	if minRate <= 0 || r.ProtoMajor != 1 || r.Body == nil || r.Body == http.NoBody {
+		return r.Body
+	}
+	// The deadline of HTTP/2 connections is shared by all their streams.
+	conn, ok := r.Context().Value(connContextKey{}).(net.Conn)
+	if !ok {
+		return r.Body
+	}
+	return &minRateBody{
+		ReadCloser: r.Body,
+		conn:       conn,
+		start:      time.Now(),
+		grace:      grace,
+		minRate:    minRate,
+	}
+}
+
+// deadline returns the time by which the next bytes must be received.
+func (b *minRateBody) deadline() time.Time {
+	elapsed := time.Duration(b.read/b.minRate)*time.Second +
+		time.Duration(b.read%b.minRate)*time.Second/time.Duration(b.minRate)
+	return b.start.Add(b.grace + elapsed)
+}
+
+func (b *minRateBody) Read(p []byte) (n int, err error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	deadline := b.deadline()
+	if time.Now().After(deadline) {
+		b.err = ErrRequestTooSlow
+		return 0, b.err
+	}
+	b.conn.SetReadDeadline(deadline)
+	n, err = b.ReadCloser.Read(p)
+	b.conn.SetReadDeadline(time.Time{})
+	b.read += int64(n)
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		b.err = ErrRequestTooSlow
+		return n, b.err
+	}
+	return n, err
+}
@@ -0,0 +1,68 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMinRateBody(t *testing.T) {
+	errCh := make(chan error, 1)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := ioutil.ReadAll(NewMinRateBody(r, 1024, 100*time.Millisecond))
+		errCh <- err
+	}))
+	ts.Config.ConnContext = connContext
+	ts.Start()
+	defer ts.Close()
+
+	testCases := []struct {
+		body        string
+		length      int
+		expectedErr error
+	}{
+		// Body received at once.
+		{body: "hello", length: 5, expectedErr: nil},
+		// Client stalls after sending a few bytes.
+		{body: "hello", length: 1 << 20, expectedErr: ErrRequestTooSlow},
+	}
+
+	for i, testCase := range testCases {
+		conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(conn, "PUT / HTTP/1.1\r\nHost: %s\r\nContent-Length: %d\r\n\r\n%s",
+			ts.Listener.Addr(), testCase.length, testCase.body)
+
+		select {
+		case err = <-errCh:
+			if err != testCase.expectedErr {
+				t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("Test %d: the request body was not aborted", i+1)
+		}
+		conn.Close()
+	}
+}
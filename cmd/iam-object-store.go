@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"path"
 	"strings"
 	"sync"
@@ -473,26 +474,7 @@ func (iamOS *IAMObjectStore) loadAll(ctx context.Context, sys *IAMSys) error {
 	}
 
 	// purge any expired entries which became expired now.
-	var expiredEntries []string
-	for k, v := range sys.iamUsersMap {
-		if v.IsExpired() {
-			delete(sys.iamUsersMap, k)
-			delete(sys.iamUserPolicyMap, k)
-			expiredEntries = append(expiredEntries, k)
-			// Deleting on the disk is taken care of in the next cycle
-		}
-	}
-
-	for _, v := range sys.iamUsersMap {
-		if v.IsServiceAccount() {
-			for _, accessKey := range expiredEntries {
-				if v.ParentUser == accessKey {
-					_ = iamOS.deleteUserIdentity(ctx, v.AccessKey, srvAccUser)
-					delete(sys.iamUsersMap, v.AccessKey)
-				}
-			}
-		}
-	}
+	iamOS.purgeExpiredUsers(ctx, sys)
 
 	for k, v := range iamGroupPolicyMap {
 		sys.iamGroupPolicyMap[k] = v
@@ -597,12 +579,86 @@ func listIAMConfigItems(ctx context.Context, objAPI ObjectLayer, pathPrefix stri
 	return ch
 }
 
+// listIAMConfigVersions returns the ETag and modification time of all
+// the IAM configuration objects, keyed by their object names.
+func listIAMConfigVersions(ctx context.Context, objAPI ObjectLayer) (map[string]string, error) {
+	versions := make(map[string]string)
+
+	objInfoCh := make(chan ObjectInfo)
+	if err := objAPI.Walk(ctx, minioMetaBucket, iamConfigPrefix+SlashSeparator, objInfoCh, ObjectOptions{}); err != nil {
+		return nil, err
+	}
+
+	for obj := range objInfoCh {
+		versions[obj.Name] = fmt.Sprintf("%s-%d", obj.ETag, obj.ModTime.UnixNano())
+	}
+
+	return versions, nil
+}
+
+// purgeExpiredUsers removes the expired temporary users and the service
+// accounts of those users from memory and from the backend disks.
+// iamOS.lock() is held by caller.
+func (iamOS *IAMObjectStore) purgeExpiredUsers(ctx context.Context, sys *IAMSys) {
+	var expiredEntries []string
+	for k, v := range sys.iamUsersMap {
+		if v.IsExpired() {
+			delete(sys.iamUsersMap, k)
+			delete(sys.iamUserPolicyMap, k)
+			expiredEntries = append(expiredEntries, k)
+			_ = iamOS.deleteUserIdentity(ctx, k, stsUser)
+			_ = iamOS.deleteMappedPolicy(ctx, k, stsUser, false)
+		}
+	}
+
+	for _, v := range sys.iamUsersMap {
+		if v.IsServiceAccount() {
+			for _, accessKey := range expiredEntries {
+				if v.ParentUser == accessKey {
+					_ = iamOS.deleteUserIdentity(ctx, v.AccessKey, srvAccUser)
+					delete(sys.iamUsersMap, v.AccessKey)
+				}
+			}
+		}
+	}
+}
+
+// watch periodically lists the IAM configuration objects and only
+// reloads the entries which were added, modified or removed since
+// the last listing, instead of reloading all the entries.
 func (iamOS *IAMObjectStore) watch(ctx context.Context, sys *IAMSys) {
-	// Refresh IAMSys.
+	versions, err := listIAMConfigVersions(ctx, iamOS.objAPI)
+	if err != nil {
+		logger.LogIf(ctx, err)
+	}
+
 	for {
-		time.Sleep(globalRefreshIAMInterval)
-		if err := iamOS.loadAll(ctx, sys); err != nil {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(globalRefreshIAMInterval):
+		}
+
+		current, err := listIAMConfigVersions(ctx, iamOS.objAPI)
+		if err != nil {
 			logger.LogIf(ctx, err)
+			continue
 		}
+
+		iamOS.lock()
+		for name, version := range current {
+			if oldVersion, ok := versions[name]; !ok || oldVersion != version {
+				sys.reloadFromKey(ctx, name, false)
+			}
+		}
+		for name := range versions {
+			if _, ok := current[name]; !ok {
+				sys.reloadFromKey(ctx, name, true)
+			}
+		}
+		iamOS.purgeExpiredUsers(ctx, sys)
+		iamOS.unlock()
+
+		versions = current
 	}
 }
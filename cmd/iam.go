@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -222,6 +223,9 @@ type IAMSys struct {
 	// Persistence layer for IAM subsystem
 	store         IAMStorageAPI
 	storeFallback bool
+
+	// Set once all users, groups and policies are loaded.
+	loaded bool
 }
 
 // IAMUserType represents a user type inside MinIO server
@@ -411,6 +415,116 @@ func (sys *IAMSys) LoadServiceAccount(accessKey string) error {
 	return nil
 }
 
+// Refresh - reloads all users, groups, policies and policy mappings
+// from the backend disks or etcd, this is useful when the IAM
+// entities were changed out-of-band.
+func (sys *IAMSys) Refresh(ctx context.Context) error {
+	if !sys.Initialized() {
+		return errServerNotInitialized
+	}
+
+	return sys.store.loadAll(ctx, sys)
+}
+
+// reloadFromKey - reloads (or removes when deleted is true) the IAM
+// entity stored at the given key into the in-memory maps. This is
+// used by the watchers of the IAM stores to apply incremental changes
+// instead of reloading everything. sys.store.lock() is held by caller.
+func (sys *IAMSys) reloadFromKey(ctx context.Context, key string, deleted bool) {
+	usersPrefix := strings.HasPrefix(key, iamConfigUsersPrefix)
+	groupsPrefix := strings.HasPrefix(key, iamConfigGroupsPrefix)
+	stsPrefix := strings.HasPrefix(key, iamConfigSTSPrefix)
+	srvAccPrefix := strings.HasPrefix(key, iamConfigServiceAccountsPrefix)
+	policyPrefix := strings.HasPrefix(key, iamConfigPoliciesPrefix)
+	policyDBUsersPrefix := strings.HasPrefix(key, iamConfigPolicyDBUsersPrefix)
+	policyDBSTSUsersPrefix := strings.HasPrefix(key, iamConfigPolicyDBSTSUsersPrefix)
+	policyDBGroupsPrefix := strings.HasPrefix(key, iamConfigPolicyDBGroupsPrefix)
+
+	switch {
+	case !deleted:
+		switch {
+		case usersPrefix:
+			accessKey := path.Dir(strings.TrimPrefix(key,
+				iamConfigUsersPrefix))
+			sys.store.loadUser(ctx, accessKey, regularUser, sys.iamUsersMap)
+		case stsPrefix:
+			accessKey := path.Dir(strings.TrimPrefix(key,
+				iamConfigSTSPrefix))
+			sys.store.loadUser(ctx, accessKey, stsUser, sys.iamUsersMap)
+		case srvAccPrefix:
+			accessKey := path.Dir(strings.TrimPrefix(key,
+				iamConfigServiceAccountsPrefix))
+			sys.store.loadUser(ctx, accessKey, srvAccUser, sys.iamUsersMap)
+		case groupsPrefix:
+			group := path.Dir(strings.TrimPrefix(key,
+				iamConfigGroupsPrefix))
+			sys.store.loadGroup(ctx, group, sys.iamGroupsMap)
+			gi := sys.iamGroupsMap[group]
+			sys.removeGroupFromMembershipsMap(group)
+			sys.updateGroupMembershipsMap(group, &gi)
+		case policyPrefix:
+			policyName := path.Dir(strings.TrimPrefix(key,
+				iamConfigPoliciesPrefix))
+			sys.store.loadPolicyDoc(ctx, policyName, sys.iamPolicyDocsMap)
+		case policyDBUsersPrefix:
+			policyMapFile := strings.TrimPrefix(key,
+				iamConfigPolicyDBUsersPrefix)
+			user := strings.TrimSuffix(policyMapFile, ".json")
+			sys.store.loadMappedPolicy(ctx, user, regularUser, false, sys.iamUserPolicyMap)
+		case policyDBSTSUsersPrefix:
+			policyMapFile := strings.TrimPrefix(key,
+				iamConfigPolicyDBSTSUsersPrefix)
+			user := strings.TrimSuffix(policyMapFile, ".json")
+			sys.store.loadMappedPolicy(ctx, user, stsUser, false, sys.iamUserPolicyMap)
+		case policyDBGroupsPrefix:
+			policyMapFile := strings.TrimPrefix(key,
+				iamConfigPolicyDBGroupsPrefix)
+			user := strings.TrimSuffix(policyMapFile, ".json")
+			sys.store.loadMappedPolicy(ctx, user, regularUser, true, sys.iamGroupPolicyMap)
+		}
+	default:
+		switch {
+		case usersPrefix:
+			accessKey := path.Dir(strings.TrimPrefix(key,
+				iamConfigUsersPrefix))
+			delete(sys.iamUsersMap, accessKey)
+		case stsPrefix:
+			accessKey := path.Dir(strings.TrimPrefix(key,
+				iamConfigSTSPrefix))
+			delete(sys.iamUsersMap, accessKey)
+		case srvAccPrefix:
+			accessKey := path.Dir(strings.TrimPrefix(key,
+				iamConfigServiceAccountsPrefix))
+			delete(sys.iamUsersMap, accessKey)
+		case groupsPrefix:
+			group := path.Dir(strings.TrimPrefix(key,
+				iamConfigGroupsPrefix))
+			sys.removeGroupFromMembershipsMap(group)
+			delete(sys.iamGroupsMap, group)
+			delete(sys.iamGroupPolicyMap, group)
+		case policyPrefix:
+			policyName := path.Dir(strings.TrimPrefix(key,
+				iamConfigPoliciesPrefix))
+			delete(sys.iamPolicyDocsMap, policyName)
+		case policyDBUsersPrefix:
+			policyMapFile := strings.TrimPrefix(key,
+				iamConfigPolicyDBUsersPrefix)
+			user := strings.TrimSuffix(policyMapFile, ".json")
+			delete(sys.iamUserPolicyMap, user)
+		case policyDBSTSUsersPrefix:
+			policyMapFile := strings.TrimPrefix(key,
+				iamConfigPolicyDBSTSUsersPrefix)
+			user := strings.TrimSuffix(policyMapFile, ".json")
+			delete(sys.iamUserPolicyMap, user)
+		case policyDBGroupsPrefix:
+			policyMapFile := strings.TrimPrefix(key,
+				iamConfigPolicyDBGroupsPrefix)
+			user := strings.TrimSuffix(policyMapFile, ".json")
+			delete(sys.iamGroupPolicyMap, user)
+		}
+	}
+}
+
 // Perform IAM configuration migration.
 func (sys *IAMSys) doIAMConfigMigration(ctx context.Context) error {
 	return sys.store.migrateBackendFormat(ctx)
@@ -538,9 +652,24 @@ func (sys *IAMSys) Init(ctx context.Context, objAPI ObjectLayer) {
 
 	// Invalidate the old cred always, even upon error to avoid any leakage.
 	globalOldCred = auth.Credentials{}
+
+	sys.Lock()
+	sys.loaded = true
+	sys.Unlock()
+
 	go sys.store.watch(ctx, sys)
 }
 
+// Loaded - returns true once all users, groups and policies are loaded.
+func (sys *IAMSys) Loaded() bool {
+	if sys == nil {
+		return false
+	}
+	sys.Lock()
+	defer sys.Unlock()
+	return sys.loaded
+}
+
 // DeletePolicy - deletes a canned policy from backend or etcd.
 func (sys *IAMSys) DeletePolicy(policyName string) error {
 	if !sys.Initialized() {
@@ -691,6 +820,7 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 			if u.ParentUser == accessKey {
 				_ = sys.store.deleteUserIdentity(context.Background(), u.AccessKey, srvAccUser)
 				delete(sys.iamUsersMap, u.AccessKey)
+				globalAccessAccounting.forget(u.AccessKey)
 			}
 		}
 	}
@@ -705,6 +835,7 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 
 	delete(sys.iamUsersMap, accessKey)
 	delete(sys.iamUserPolicyMap, accessKey)
+	globalAccessAccounting.forget(accessKey)
 
 	return err
 }
@@ -1088,6 +1219,7 @@ func (sys *IAMSys) DeleteServiceAccount(ctx context.Context, accessKey string) e
 	}
 
 	delete(sys.iamUsersMap, accessKey)
+	globalAccessAccounting.forget(accessKey)
 	return nil
 }
 
@@ -1156,6 +1288,24 @@ func (sys *IAMSys) SetUserSecretKey(accessKey string, secretKey string) error {
 	return nil
 }
 
+// accessKeyExists returns whether accessKey belongs to a credential
+// which exists and did not expire, ok is false as long as the IAM
+// data is not fully loaded and the answer is unknown.
+func (sys *IAMSys) accessKeyExists(accessKey string) (exists, ok bool) {
+	if !sys.Initialized() {
+		return false, false
+	}
+
+	sys.store.rlock()
+	defer sys.store.runlock()
+
+	if sys.storeFallback {
+		return false, false
+	}
+	cred, found := sys.iamUsersMap[accessKey]
+	return found && !cred.IsExpired(), true
+}
+
 // GetUser - get user credentials
 func (sys *IAMSys) GetUser(accessKey string) (cred auth.Credentials, ok bool) {
 	if !sys.Initialized() {
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -37,6 +38,9 @@ type lockRequesterInfo struct {
 	Owner string
 	// Quorum represents the quorum required for this lock to be active.
 	Quorum int
+	// TimeLastRefresh is the time of the last lease refresh by the owner,
+	// a lock is expired once it is not refreshed anymore.
+	TimeLastRefresh time.Time
 }
 
 // isWriteLock returns whether the lock is a write or read lock.
@@ -44,10 +48,27 @@ func isWriteLock(lri []lockRequesterInfo) bool {
 	return len(lri) == 1 && lri[0].Writer
 }
 
+// Waiting write lock requests which are not retried for this long
+// are dropped from the queue, i.e requests of crashed nodes.
+const lockQueueValidity = 5 * time.Second
+
+// queuedWriter is a write lock request waiting for a resource.
+type queuedWriter struct {
+	uid      string
+	owner    string
+	deadline time.Time
+	lastSeen time.Time
+}
+
 // localLocker implements Dsync.NetLocker
 type localLocker struct {
 	mutex   sync.Mutex
 	lockMap map[string][]lockRequesterInfo
+
+	// Write lock requests waiting per resource, ordered by deadline.
+	// Read locks are not granted while writers are waiting, this
+	// prevents writers from starving under read lock contention.
+	writersQueue map[string][]queuedWriter
 }
 
 func (l *localLocker) String() string {
@@ -76,28 +97,130 @@ func (l *localLocker) canTakeLock(resources ...string) bool {
 	return noLkCnt == len(resources)
 }
 
+// pruneWritersQueue drops the waiting writers of resource
+// which were abandoned. Caller must hold 'l.mutex' lock.
+func (l *localLocker) pruneWritersQueue(resource string, now time.Time) {
+	queue := l.writersQueue[resource]
+	n := 0
+	for _, w := range queue {
+		if now.After(w.deadline) || now.Sub(w.lastSeen) > lockQueueValidity {
+			continue
+		}
+		queue[n] = w
+		n++
+	}
+	if n == 0 {
+		delete(l.writersQueue, resource)
+		return
+	}
+	l.writersQueue[resource] = queue[:n]
+}
+
+// isFirstWriter returns true if args is the first waiting writer,
+// or no writers are waiting, on all resources.
+// Caller must hold 'l.mutex' lock.
+func (l *localLocker) isFirstWriter(args dsync.LockArgs) bool {
+	for _, resource := range args.Resources {
+		queue := l.writersQueue[resource]
+		if len(queue) > 0 && (queue[0].uid != args.UID || queue[0].owner != args.Owner) {
+			return false
+		}
+	}
+	return true
+}
+
+// queueWriter adds args to the waiting writers of all resources or
+// refreshes it if already waiting. Caller must hold 'l.mutex' lock.
+func (l *localLocker) queueWriter(args dsync.LockArgs, now time.Time) {
+	if l.writersQueue == nil {
+		l.writersQueue = make(map[string][]queuedWriter)
+	}
+	for _, resource := range args.Resources {
+		queue := l.writersQueue[resource]
+		found := false
+		for i := range queue {
+			if queue[i].uid == args.UID && queue[i].owner == args.Owner {
+				queue[i].lastSeen = now
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		queue = append(queue, queuedWriter{
+			uid:      args.UID,
+			owner:    args.Owner,
+			deadline: args.Deadline,
+			lastSeen: now,
+		})
+		// Order by deadline, which is the same on all the lockers,
+		// so that all of them serve waiting writers in the same order.
+		sort.SliceStable(queue, func(i, j int) bool {
+			if queue[i].deadline.Equal(queue[j].deadline) {
+				return queue[i].uid < queue[j].uid
+			}
+			return queue[i].deadline.Before(queue[j].deadline)
+		})
+		l.writersQueue[resource] = queue
+	}
+}
+
+// dequeueWriter removes args from the waiting writers of all
+// resources, returns true if args was waiting on any of them.
+// Caller must hold 'l.mutex' lock.
+func (l *localLocker) dequeueWriter(args dsync.LockArgs) (dequeued bool) {
+	for _, resource := range args.Resources {
+		queue := l.writersQueue[resource]
+		for i := range queue {
+			if queue[i].uid == args.UID && queue[i].owner == args.Owner {
+				queue = append(queue[:i], queue[i+1:]...)
+				dequeued = true
+				break
+			}
+		}
+		if len(queue) == 0 {
+			delete(l.writersQueue, resource)
+		} else {
+			l.writersQueue[resource] = queue
+		}
+	}
+	return dequeued
+}
+
 func (l *localLocker) Lock(ctx context.Context, args dsync.LockArgs) (reply bool, err error) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
-	if !l.canTakeLock(args.Resources...) {
-		// Not all locks can be taken on resources,
-		// reject it completely.
+	now := UTCNow()
+	for _, resource := range args.Resources {
+		l.pruneWritersQueue(resource, now)
+	}
+
+	if !l.canTakeLock(args.Resources...) || !l.isFirstWriter(args) {
+		// Not all locks can be taken on resources, reject it
+		// completely and wait for our turn. Requests without
+		// a deadline come from older clients, they do not wait.
+		if !args.Deadline.IsZero() {
+			l.queueWriter(args, now)
+		}
 		return false, nil
 	}
+	l.dequeueWriter(args)
 
 	// No locks held on the all resources, so claim write
 	// lock on all resources at once.
 	for _, resource := range args.Resources {
 		l.lockMap[resource] = []lockRequesterInfo{
 			{
-				Writer:        true,
-				Source:        args.Source,
-				Owner:         args.Owner,
-				UID:           args.UID,
-				Timestamp:     UTCNow(),
-				TimeLastCheck: UTCNow(),
-				Quorum:        args.Quorum,
+				Writer:          true,
+				Source:          args.Source,
+				Owner:           args.Owner,
+				UID:             args.UID,
+				Timestamp:       now,
+				TimeLastCheck:   now,
+				TimeLastRefresh: now,
+				Quorum:          args.Quorum,
 			},
 		}
 	}
@@ -148,16 +271,23 @@ func (l *localLocker) removeEntry(name string, args dsync.LockArgs, lri *[]lockR
 func (l *localLocker) RLock(ctx context.Context, args dsync.LockArgs) (reply bool, err error) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
+	now := UTCNow()
 	lrInfo := lockRequesterInfo{
-		Writer:        false,
-		Source:        args.Source,
-		Owner:         args.Owner,
-		UID:           args.UID,
-		Timestamp:     UTCNow(),
-		TimeLastCheck: UTCNow(),
-		Quorum:        args.Quorum,
+		Writer:          false,
+		Source:          args.Source,
+		Owner:           args.Owner,
+		UID:             args.UID,
+		Timestamp:       now,
+		TimeLastCheck:   now,
+		TimeLastRefresh: now,
+		Quorum:          args.Quorum,
 	}
 	resource := args.Resources[0]
+	l.pruneWritersQueue(resource, now)
+	if len(l.writersQueue[resource]) > 0 {
+		// Writers are waiting, let the current readers drain.
+		return false, nil
+	}
 	if lri, ok := l.lockMap[resource]; ok {
 		if reply = !isWriteLock(lri); reply {
 			// Unless there is a write lock
@@ -237,6 +367,72 @@ func (l *localLocker) Expired(ctx context.Context, args dsync.LockArgs) (expired
 	}
 }
 
+// Refresh extends the lease of the lock held by args on all resources.
+func (l *localLocker) Refresh(ctx context.Context, args dsync.LockArgs) (refreshed bool, err error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		now := UTCNow()
+		for _, resource := range args.Resources {
+			lri, ok := l.lockMap[resource]
+			if !ok {
+				return false, nil
+			}
+			found := false
+			for i := range lri {
+				if lri[i].UID == args.UID && lri[i].Owner == args.Owner {
+					lri[i].TimeLastRefresh = now
+					found = true
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+// Withdraw removes the write lock request args, which was
+// not granted, from the waiting writers of all resources.
+func (l *localLocker) Withdraw(args dsync.LockArgs) (withdrawn bool, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.dequeueWriter(args), nil
+}
+
+// expireLeases removes all the locks whose lease was not refreshed
+// within the given duration, since their owners are gone.
+func (l *localLocker) expireLeases(leaseDuration time.Duration) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := UTCNow()
+	for resource, lri := range l.lockMap {
+		n := 0
+		for _, entry := range lri {
+			if now.Sub(entry.TimeLastRefresh) > leaseDuration {
+				continue
+			}
+			lri[n] = entry
+			n++
+		}
+		if n == 0 {
+			delete(l.lockMap, resource)
+		} else {
+			l.lockMap[resource] = lri[:n]
+		}
+	}
+}
+
 // Similar to removeEntry but only removes an entry only if the lock entry exists in map.
 // Caller must hold 'l.mutex' lock.
 func (l *localLocker) removeEntryIfExists(nlrip nameLockRequesterInfoPair) {
@@ -254,6 +450,7 @@ func (l *localLocker) removeEntryIfExists(nlrip nameLockRequesterInfoPair) {
 
 func newLocker() *localLocker {
 	return &localLocker{
-		lockMap: make(map[string][]lockRequesterInfo),
+		lockMap:      make(map[string][]lockRequesterInfo),
+		writersQueue: make(map[string][]queuedWriter),
 	}
 }
@@ -0,0 +1,132 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/dsync"
+)
+
+func TestLocalLockerWriterFairness(t *testing.T) {
+	l := newLocker()
+	ctx := context.Background()
+	deadline := UTCNow().Add(time.Minute)
+
+	reader := dsync.LockArgs{UID: "reader", Owner: "owner", Resources: []string{"resource"}, Deadline: deadline}
+	if ok, _ := l.RLock(ctx, reader); !ok {
+		t.Fatal("expected read lock to be granted")
+	}
+
+	// Writers wait for the read lock, the one with the later deadline queues second.
+	late := dsync.LockArgs{UID: "late", Owner: "owner", Resources: []string{"resource"}, Deadline: deadline.Add(time.Second)}
+	early := dsync.LockArgs{UID: "early", Owner: "owner", Resources: []string{"resource"}, Deadline: deadline}
+	if ok, _ := l.Lock(ctx, late); ok {
+		t.Fatal("expected write lock to wait for readers")
+	}
+	if ok, _ := l.Lock(ctx, early); ok {
+		t.Fatal("expected write lock to wait for readers")
+	}
+
+	// New readers must not starve the waiting writers.
+	newReader := dsync.LockArgs{UID: "new-reader", Owner: "owner", Resources: []string{"resource"}, Deadline: deadline}
+	if ok, _ := l.RLock(ctx, newReader); ok {
+		t.Fatal("expected read lock to wait for queued writers")
+	}
+
+	if _, err := l.RUnlock(reader); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writers are served in deadline order.
+	if ok, _ := l.Lock(ctx, late); ok {
+		t.Fatal("expected writer with later deadline to wait for its turn")
+	}
+	if ok, _ := l.Lock(ctx, early); !ok {
+		t.Fatal("expected writer with earliest deadline to be granted")
+	}
+	if _, err := l.Unlock(early); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := l.Lock(ctx, late); !ok {
+		t.Fatal("expected queued writer to be granted")
+	}
+
+	// Refresh keeps the lease alive, until the lock is released.
+	if ok, _ := l.Refresh(ctx, late); !ok {
+		t.Fatal("expected lock to be refreshed")
+	}
+	l.expireLeases(time.Minute)
+	if ok, _ := l.Refresh(ctx, late); !ok {
+		t.Fatal("expected refreshed lock not to expire")
+	}
+	l.expireLeases(-time.Second)
+	if ok, _ := l.Refresh(ctx, late); ok {
+		t.Fatal("expected lock with an expired lease to be removed")
+	}
+	if ok, _ := l.RLock(ctx, newReader); !ok {
+		t.Fatal("expected read lock to be granted after the lease expired")
+	}
+}
+
+func TestLocalLockerAbandonedWriter(t *testing.T) {
+	l := newLocker()
+	ctx := context.Background()
+
+	reader := dsync.LockArgs{UID: "reader", Owner: "owner", Resources: []string{"resource"}}
+	if ok, _ := l.RLock(ctx, reader); !ok {
+		t.Fatal("expected read lock to be granted")
+	}
+
+	// A writer whose deadline passed does not block readers anymore.
+	writer := dsync.LockArgs{UID: "writer", Owner: "owner", Resources: []string{"resource"}, Deadline: UTCNow().Add(10 * time.Millisecond)}
+	if ok, _ := l.Lock(ctx, writer); ok {
+		t.Fatal("expected write lock to wait for readers")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if ok, _ := l.RLock(ctx, dsync.LockArgs{UID: "reader2", Owner: "owner", Resources: []string{"resource"}}); !ok {
+		t.Fatal("expected read lock to be granted once the writer was abandoned")
+	}
+}
+
+func TestLocalLockerWithdrawWriter(t *testing.T) {
+	l := newLocker()
+	ctx := context.Background()
+
+	reader := dsync.LockArgs{UID: "reader", Owner: "owner", Resources: []string{"resource"}}
+	if ok, _ := l.RLock(ctx, reader); !ok {
+		t.Fatal("expected read lock to be granted")
+	}
+
+	writer := dsync.LockArgs{UID: "writer", Owner: "owner", Resources: []string{"resource"}, Deadline: UTCNow().Add(time.Minute)}
+	if ok, _ := l.Lock(ctx, writer); ok {
+		t.Fatal("expected write lock to wait for readers")
+	}
+
+	// A withdrawn writer does not block readers anymore.
+	if ok, err := l.Withdraw(writer); err != nil || !ok {
+		t.Fatalf("expected waiting writer to be withdrawn, got %v, %v", ok, err)
+	}
+	if ok, _ := l.Withdraw(writer); ok {
+		t.Fatal("expected writer not to be waiting anymore")
+	}
+	if ok, _ := l.RLock(ctx, dsync.LockArgs{UID: "reader2", Owner: "owner", Resources: []string{"resource"}}); !ok {
+		t.Fatal("expected read lock to be granted once the writer was withdrawn")
+	}
+}
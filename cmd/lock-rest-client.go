@@ -93,6 +93,9 @@ func (client *lockRESTClient) restCall(ctx context.Context, call string, args ds
 	values.Set(lockRESTOwner, args.Owner)
 	values.Set(lockRESTSource, args.Source)
 	values.Set(lockRESTQuorum, strconv.Itoa(args.Quorum))
+	if !args.Deadline.IsZero() {
+		values.Set(lockRESTDeadline, strconv.FormatInt(args.Deadline.UnixNano(), 10))
+	}
 	var buffer bytes.Buffer
 	for _, resource := range args.Resources {
 		buffer.WriteString(resource)
@@ -103,7 +106,7 @@ func (client *lockRESTClient) restCall(ctx context.Context, call string, args ds
 	switch err {
 	case nil:
 		return true, nil
-	case errLockConflict, errLockNotExpired:
+	case errLockConflict, errLockNotExpired, errLockNotFound:
 		return false, nil
 	default:
 		return false, err
@@ -135,6 +138,16 @@ func (client *lockRESTClient) Expired(ctx context.Context, args dsync.LockArgs)
 	return client.restCall(ctx, lockRESTMethodExpired, args)
 }
 
+// Refresh calls refresh REST API to extend the lease of the lock.
+func (client *lockRESTClient) Refresh(ctx context.Context, args dsync.LockArgs) (refreshed bool, err error) {
+	return client.restCall(ctx, lockRESTMethodRefresh, args)
+}
+
+// Withdraw calls withdraw REST API to drop a waiting write lock request.
+func (client *lockRESTClient) Withdraw(args dsync.LockArgs) (withdrawn bool, err error) {
+	return client.restCall(context.Background(), lockRESTMethodWithdraw, args)
+}
+
 func newLockAPI(endpoint Endpoint) dsync.NetLocker {
 	if endpoint.IsLocal {
 		return globalLockServer
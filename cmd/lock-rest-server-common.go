@@ -21,18 +21,20 @@ import (
 )
 
 const (
-	lockRESTVersion       = "v4" // Add Quorum query param
+	lockRESTVersion       = "v5" // Add Deadline query param, Refresh and Withdraw calls
 	lockRESTVersionPrefix = SlashSeparator + lockRESTVersion
 	lockRESTPrefix        = minioReservedBucketPath + "/lock"
 )
 
 const (
-	lockRESTMethodHealth  = "/health"
-	lockRESTMethodLock    = "/lock"
-	lockRESTMethodRLock   = "/rlock"
-	lockRESTMethodUnlock  = "/unlock"
-	lockRESTMethodRUnlock = "/runlock"
-	lockRESTMethodExpired = "/expired"
+	lockRESTMethodHealth   = "/health"
+	lockRESTMethodLock     = "/lock"
+	lockRESTMethodRLock    = "/rlock"
+	lockRESTMethodUnlock   = "/unlock"
+	lockRESTMethodRUnlock  = "/runlock"
+	lockRESTMethodExpired  = "/expired"
+	lockRESTMethodRefresh  = "/refresh"
+	lockRESTMethodWithdraw = "/withdraw"
 
 	// lockRESTOwner represents owner UUID
 	lockRESTOwner = "owner"
@@ -47,10 +49,14 @@ const (
 	// Quroum value to be saved along lock requester info, useful
 	// in verifying stale locks
 	lockRESTQuorum = "quorum"
+
+	// Deadline of the lock request, used to order waiting writers.
+	lockRESTDeadline = "deadline"
 )
 
 var (
 	errLockConflict       = errors.New("lock conflict")
 	errLockNotExpired     = errors.New("lock not expired")
+	errLockNotFound       = errors.New("lock not found")
 	errLockNotInitialized = errors.New("lock not initialized")
 )
@@ -36,6 +36,10 @@ const (
 
 	// Lock validity check interval.
 	lockValidityCheckInterval = 5 * time.Second
+
+	// Locks whose lease was not refreshed by their owner for
+	// this long are considered held by a crashed node.
+	lockLeaseDuration = 6 * dsync.DRWMutexRefreshInterval
 )
 
 // To abstract a node over network.
@@ -75,6 +79,14 @@ func getLockArgs(r *http.Request) (args dsync.LockArgs, err error) {
 		Quorum: quorum,
 	}
 
+	if deadline := r.URL.Query().Get(lockRESTDeadline); deadline != "" {
+		nsec, err := strconv.ParseInt(deadline, 10, 64)
+		if err != nil {
+			return args, err
+		}
+		args.Deadline = time.Unix(0, nsec)
+	}
+
 	var resources []string
 	bio := bufio.NewScanner(r.Body)
 	for bio.Scan() {
@@ -208,6 +220,48 @@ func (l *lockRESTServer) ExpiredHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// RefreshHandler - refreshes the lease of an acquired lock.
+func (l *lockRESTServer) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	if !l.IsValid(w, r) {
+		l.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	args, err := getLockArgs(r)
+	if err != nil {
+		l.writeErrorResponse(w, err)
+		return
+	}
+
+	refreshed, err := l.ll.Refresh(r.Context(), args)
+	if err == nil && !refreshed {
+		err = errLockNotFound
+	}
+	if err != nil {
+		l.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// WithdrawHandler - drops a waiting write lock request.
+func (l *lockRESTServer) WithdrawHandler(w http.ResponseWriter, r *http.Request) {
+	if !l.IsValid(w, r) {
+		l.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	args, err := getLockArgs(r)
+	if err != nil {
+		l.writeErrorResponse(w, err)
+		return
+	}
+
+	if _, err = l.ll.Withdraw(args); err != nil {
+		l.writeErrorResponse(w, err)
+		return
+	}
+}
+
 // nameLockRequesterInfoPair is a helper type for lock maintenance
 type nameLockRequesterInfoPair struct {
 	name string
@@ -244,6 +298,9 @@ func getLongLivedLocks(interval time.Duration) []nameLockRequesterInfoPair {
 //
 // We will ignore the error, and we will retry later to get a resolve on this lock
 func lockMaintenance(ctx context.Context, interval time.Duration) error {
+	// Purge locks held by crashed nodes, which stopped refreshing them.
+	globalLockServer.expireLeases(lockLeaseDuration)
+
 	objAPI := newObjectLayerFn()
 	if objAPI == nil {
 		return nil
@@ -360,6 +417,8 @@ func registerLockRESTHandlers(router *mux.Router) {
 	subrouter.Methods(http.MethodPost).Path(lockRESTVersionPrefix + lockRESTMethodUnlock).HandlerFunc(httpTraceHdrs(lockServer.UnlockHandler))
 	subrouter.Methods(http.MethodPost).Path(lockRESTVersionPrefix + lockRESTMethodRUnlock).HandlerFunc(httpTraceHdrs(lockServer.RUnlockHandler))
 	subrouter.Methods(http.MethodPost).Path(lockRESTVersionPrefix + lockRESTMethodExpired).HandlerFunc(httpTraceAll(lockServer.ExpiredHandler))
+	subrouter.Methods(http.MethodPost).Path(lockRESTVersionPrefix + lockRESTMethodRefresh).HandlerFunc(httpTraceAll(lockServer.RefreshHandler))
+	subrouter.Methods(http.MethodPost).Path(lockRESTVersionPrefix + lockRESTMethodWithdraw).HandlerFunc(httpTraceHdrs(lockServer.WithdrawHandler))
 
 	globalLockServer = lockServer.ll
 
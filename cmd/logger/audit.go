@@ -18,6 +18,7 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -80,6 +81,24 @@ func (lrw *ResponseWriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
+// ReadFrom - calls the underlying ReadFrom when the response body
+// is not logged, so that the data can be sent with sendfile.
+func (lrw *ResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	rf, ok := lrw.ResponseWriter.(io.ReaderFrom)
+	if !ok || (lrw.LogErrBody && lrw.StatusCode >= http.StatusBadRequest) || lrw.LogAllBody {
+		return io.Copy(struct{ io.Writer }{lrw}, r)
+	}
+	if !lrw.headersLogged {
+		lrw.WriteHeader(http.StatusOK)
+	}
+	if lrw.TimeToFirstByte == 0 {
+		lrw.TimeToFirstByte = time.Now().UTC().Sub(lrw.StartTime)
+	}
+	n, err := rf.ReadFrom(r)
+	lrw.bytesWritten += int(n)
+	return n, err
+}
+
 // Write the headers into the given buffer
 func (lrw *ResponseWriter) writeHeaders(w io.Writer, statusCode int, headers http.Header) {
 	n, _ := fmt.Fprintf(w, "%d %s\n", statusCode, http.StatusText(statusCode))
@@ -125,7 +144,7 @@ func (lrw *ResponseWriter) Size() int {
 }
 
 // AuditLog - logs audit logs to all audit targets.
-func AuditLog(w http.ResponseWriter, r *http.Request, api string, reqClaims map[string]interface{}, filterKeys ...string) {
+func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, api string, reqClaims map[string]interface{}, filterKeys ...string) {
 	// Fast exit if there is not audit target configured
 	if len(AuditTargets) == 0 {
 		return
@@ -158,6 +177,15 @@ func AuditLog(w http.ResponseWriter, r *http.Request, api string, reqClaims map[
 		delete(entry.ReqHeader, filterKey)
 		delete(entry.RespHeader, filterKey)
 	}
+	if reqInfo := GetReqInfo(ctx); reqInfo != nil {
+		entry.AccessKey = reqInfo.AccessKey
+		entry.ParentUser = reqInfo.ParentUser
+		entry.PolicyName = reqInfo.PolicyName
+	}
+	if entry.AccessKey == "" {
+		// Browser requests only carry the access key in the claims.
+		entry.AccessKey, _ = reqClaims["accessKey"].(string)
+	}
 	entry.API.Name = api
 	entry.API.Bucket = bucket
 	entry.API.Object = object
@@ -17,6 +17,8 @@
 package logger
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/minio/minio/cmd/config"
@@ -25,7 +27,17 @@ import (
 
 // Console logger target
 type Console struct {
-	Enabled bool `json:"enabled"`
+	Enabled bool   `json:"enabled"`
+	Level   Level  `json:"level"`
+	Format  string `json:"format"`
+}
+
+// File logger target
+type File struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+	Level   Level  `json:"level"`
+	Format  string `json:"format"`
 }
 
 // HTTP logger target
@@ -33,11 +45,16 @@ type HTTP struct {
 	Enabled   bool   `json:"enabled"`
 	Endpoint  string `json:"endpoint"`
 	AuthToken string `json:"authToken"`
+	Level     Level  `json:"level"`
+	Format    string `json:"format"`
+	QueueDir  string `json:"queueDir"`
+	QueueSize int    `json:"queueSize"`
 }
 
-// Config console and http logger targets
+// Config console, file and http logger targets
 type Config struct {
 	Console Console         `json:"console"`
+	File    File            `json:"file"`
 	HTTP    map[string]HTTP `json:"http"`
 	Audit   map[string]HTTP `json:"audit"`
 }
@@ -46,14 +63,40 @@ type Config struct {
 const (
 	Endpoint  = "endpoint"
 	AuthToken = "auth_token"
+	LogLevel  = "level"
+	LogFormat = "format"
+	QueueDir  = "queue_dir"
+	QueueSize = "queue_size"
+	Path      = "path"
 
 	EnvLoggerWebhookEnable    = "MINIO_LOGGER_WEBHOOK_ENABLE"
 	EnvLoggerWebhookEndpoint  = "MINIO_LOGGER_WEBHOOK_ENDPOINT"
 	EnvLoggerWebhookAuthToken = "MINIO_LOGGER_WEBHOOK_AUTH_TOKEN"
+	EnvLoggerWebhookLevel     = "MINIO_LOGGER_WEBHOOK_LEVEL"
+	EnvLoggerWebhookFormat    = "MINIO_LOGGER_WEBHOOK_FORMAT"
+	EnvLoggerWebhookQueueDir  = "MINIO_LOGGER_WEBHOOK_QUEUE_DIR"
+	EnvLoggerWebhookQueueSize = "MINIO_LOGGER_WEBHOOK_QUEUE_SIZE"
 
 	EnvAuditWebhookEnable    = "MINIO_AUDIT_WEBHOOK_ENABLE"
 	EnvAuditWebhookEndpoint  = "MINIO_AUDIT_WEBHOOK_ENDPOINT"
 	EnvAuditWebhookAuthToken = "MINIO_AUDIT_WEBHOOK_AUTH_TOKEN"
+	EnvAuditWebhookQueueDir  = "MINIO_AUDIT_WEBHOOK_QUEUE_DIR"
+	EnvAuditWebhookQueueSize = "MINIO_AUDIT_WEBHOOK_QUEUE_SIZE"
+
+	EnvLoggerConsoleEnable = "MINIO_LOGGER_CONSOLE_ENABLE"
+	EnvLoggerConsoleLevel  = "MINIO_LOGGER_CONSOLE_LEVEL"
+	EnvLoggerConsoleFormat = "MINIO_LOGGER_CONSOLE_FORMAT"
+
+	EnvLoggerFileEnable = "MINIO_LOGGER_FILE_ENABLE"
+	EnvLoggerFilePath   = "MINIO_LOGGER_FILE_PATH"
+	EnvLoggerFileLevel  = "MINIO_LOGGER_FILE_LEVEL"
+	EnvLoggerFileFormat = "MINIO_LOGGER_FILE_FORMAT"
+)
+
+// Default size of the log queue of http targets.
+const (
+	defaultQueueLen  = 10000
+	defaultQueueSize = "10000"
 )
 
 // Inject into config package.
@@ -77,6 +120,22 @@ var (
 			Key:   AuthToken,
 			Value: "",
 		},
+		config.KV{
+			Key:   LogLevel,
+			Value: "error",
+		},
+		config.KV{
+			Key:   LogFormat,
+			Value: FormatJSON,
+		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueSize,
+			Value: defaultQueueSize,
+		},
 	}
 	DefaultAuditKVS = config.KVS{
 		config.KV{
@@ -91,15 +150,135 @@ var (
 			Key:   AuthToken,
 			Value: "",
 		},
+		config.KV{
+			Key:   QueueDir,
+			Value: "",
+		},
+		config.KV{
+			Key:   QueueSize,
+			Value: defaultQueueSize,
+		},
+	}
+	DefaultConsoleKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOn,
+		},
+		config.KV{
+			Key:   LogLevel,
+			Value: "info",
+		},
+		config.KV{
+			Key:   LogFormat,
+			Value: FormatText,
+		},
+	}
+	DefaultFileKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Path,
+			Value: "",
+		},
+		config.KV{
+			Key:   LogLevel,
+			Value: "info",
+		},
+		config.KV{
+			Key:   LogFormat,
+			Value: FormatText,
+		},
 	}
 )
 
+// parseLevelFormat parses the level and format of a target,
+// empty values are replaced by the given defaults.
+func parseLevelFormat(level, format string, defaultLevel Level, defaultFormat string) (lvl Level, f string, err error) {
+	lvl, f = defaultLevel, defaultFormat
+	if level != "" {
+		if lvl, err = ParseLevel(level); err != nil {
+			return lvl, f, err
+		}
+	}
+	if format != "" {
+		if f, err = ParseFormat(format); err != nil {
+			return lvl, f, err
+		}
+	}
+	return lvl, f, nil
+}
+
+// parseQueueSize parses the log queue size of a http target.
+func parseQueueSize(queueSize string) (int, error) {
+	if queueSize == "" {
+		queueSize = defaultQueueSize
+	}
+	size, err := strconv.Atoi(queueSize)
+	if err != nil {
+		return 0, err
+	}
+	if size <= 0 {
+		return 0, fmt.Errorf("invalid log queue size '%s', must be a positive number", queueSize)
+	}
+	return size, nil
+}
+
+// lookupConsoleConfig - lookup the console target config, override with ENVs if set.
+func lookupConsoleConfig(kvs config.KVS) (Console, error) {
+	cfg := Console{Enabled: true, Level: InformationLvl, Format: FormatText}
+	if err := config.CheckValidKeys(config.LoggerConsoleSubSys, kvs, DefaultConsoleKVS); err != nil {
+		return cfg, err
+	}
+	if enable := env.Get(EnvLoggerConsoleEnable, kvs.Get(config.Enable)); enable != "" {
+		enabled, err := config.ParseBool(enable)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Enabled = enabled
+	}
+	var err error
+	cfg.Level, cfg.Format, err = parseLevelFormat(env.Get(EnvLoggerConsoleLevel, kvs.Get(LogLevel)),
+		env.Get(EnvLoggerConsoleFormat, kvs.Get(LogFormat)), InformationLvl, FormatText)
+	return cfg, err
+}
+
+// lookupFileConfig - lookup the file target config, override with ENVs if set.
+func lookupFileConfig(kvs config.KVS) (File, error) {
+	cfg := File{Level: InformationLvl, Format: FormatText}
+	if err := config.CheckValidKeys(config.LoggerFileSubSys, kvs, DefaultFileKVS); err != nil {
+		return cfg, err
+	}
+	enable := env.Get(EnvLoggerFileEnable, kvs.Get(config.Enable))
+	if enable == "" {
+		return cfg, nil
+	}
+	enabled, err := config.ParseBool(enable)
+	if err != nil || !enabled {
+		return cfg, err
+	}
+	cfg.Path = env.Get(EnvLoggerFilePath, kvs.Get(Path))
+	if cfg.Path == "" {
+		return cfg, config.Errorf("'%s' must be set to enable the file logger", Path)
+	}
+	cfg.Level, cfg.Format, err = parseLevelFormat(env.Get(EnvLoggerFileLevel, kvs.Get(LogLevel)),
+		env.Get(EnvLoggerFileFormat, kvs.Get(LogFormat)), InformationLvl, FormatText)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Enabled = true
+	return cfg, nil
+}
+
 // NewConfig - initialize new logger config.
 func NewConfig() Config {
 	cfg := Config{
 		// Console logging is on by default
 		Console: Console{
 			Enabled: true,
+			Level:   InformationLvl,
+			Format:  FormatText,
 		},
 		HTTP:  make(map[string]HTTP),
 		Audit: make(map[string]HTTP),
@@ -142,8 +321,11 @@ func lookupLegacyConfig() (Config, error) {
 			continue
 		}
 		cfg.HTTP[target] = HTTP{
-			Enabled:  true,
-			Endpoint: endpoint,
+			Enabled:   true,
+			Endpoint:  endpoint,
+			Level:     ErrorLvl,
+			Format:    FormatJSON,
+			QueueSize: defaultQueueLen,
 		}
 	}
 
@@ -168,8 +350,10 @@ func lookupLegacyConfig() (Config, error) {
 			continue
 		}
 		cfg.Audit[target] = HTTP{
-			Enabled:  true,
-			Endpoint: endpoint,
+			Enabled:   true,
+			Endpoint:  endpoint,
+			Format:    FormatJSON,
+			QueueSize: defaultQueueLen,
 		}
 	}
 
@@ -185,6 +369,16 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		return cfg, err
 	}
 
+	cfg.Console, err = lookupConsoleConfig(scfg[config.LoggerConsoleSubSys][config.Default])
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.File, err = lookupFileConfig(scfg[config.LoggerFileSubSys][config.Default])
+	if err != nil {
+		return cfg, err
+	}
+
 	envs := env.List(EnvLoggerWebhookEndpoint)
 	var loggerTargets []string
 	for _, k := range envs {
@@ -228,10 +422,32 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if target != config.Default {
 			authTokenEnv = EnvLoggerWebhookAuthToken + config.Default + target
 		}
+		levelEnv := EnvLoggerWebhookLevel
+		formatEnv := EnvLoggerWebhookFormat
+		queueDirEnv := EnvLoggerWebhookQueueDir
+		queueSizeEnv := EnvLoggerWebhookQueueSize
+		if target != config.Default {
+			levelEnv = EnvLoggerWebhookLevel + config.Default + target
+			formatEnv = EnvLoggerWebhookFormat + config.Default + target
+			queueDirEnv = EnvLoggerWebhookQueueDir + config.Default + target
+			queueSizeEnv = EnvLoggerWebhookQueueSize + config.Default + target
+		}
+		level, format, err := parseLevelFormat(env.Get(levelEnv, ""), env.Get(formatEnv, ""), ErrorLvl, FormatJSON)
+		if err != nil {
+			return cfg, err
+		}
+		queueSize, err := parseQueueSize(env.Get(queueSizeEnv, ""))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.HTTP[target] = HTTP{
 			Enabled:   true,
 			Endpoint:  env.Get(endpointEnv, ""),
 			AuthToken: env.Get(authTokenEnv, ""),
+			Level:     level,
+			Format:    format,
+			QueueDir:  env.Get(queueDirEnv, ""),
+			QueueSize: queueSize,
 		}
 	}
 
@@ -257,10 +473,23 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if target != config.Default {
 			authTokenEnv = EnvAuditWebhookAuthToken + config.Default + target
 		}
+		queueDirEnv := EnvAuditWebhookQueueDir
+		queueSizeEnv := EnvAuditWebhookQueueSize
+		if target != config.Default {
+			queueDirEnv = EnvAuditWebhookQueueDir + config.Default + target
+			queueSizeEnv = EnvAuditWebhookQueueSize + config.Default + target
+		}
+		queueSize, err := parseQueueSize(env.Get(queueSizeEnv, ""))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.Audit[target] = HTTP{
 			Enabled:   true,
 			Endpoint:  env.Get(endpointEnv, ""),
 			AuthToken: env.Get(authTokenEnv, ""),
+			Format:    FormatJSON,
+			QueueDir:  env.Get(queueDirEnv, ""),
+			QueueSize: queueSize,
 		}
 	}
 
@@ -285,10 +514,22 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if !enabled {
 			continue
 		}
+		level, format, err := parseLevelFormat(kv.Get(LogLevel), kv.Get(LogFormat), ErrorLvl, FormatJSON)
+		if err != nil {
+			return cfg, err
+		}
+		queueSize, err := parseQueueSize(kv.Get(QueueSize))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.HTTP[starget] = HTTP{
 			Enabled:   true,
 			Endpoint:  kv.Get(Endpoint),
 			AuthToken: kv.Get(AuthToken),
+			Level:     level,
+			Format:    format,
+			QueueDir:  kv.Get(QueueDir),
+			QueueSize: queueSize,
 		}
 	}
 
@@ -313,10 +554,17 @@ func LookupConfig(scfg config.Config) (Config, error) {
 		if !enabled {
 			continue
 		}
+		queueSize, err := parseQueueSize(kv.Get(QueueSize))
+		if err != nil {
+			return cfg, err
+		}
 		cfg.Audit[starget] = HTTP{
 			Enabled:   true,
 			Endpoint:  kv.Get(Endpoint),
 			AuthToken: kv.Get(AuthToken),
+			Format:    FormatJSON,
+			QueueDir:  kv.Get(QueueDir),
+			QueueSize: queueSize,
 		}
 	}
 
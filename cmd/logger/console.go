@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/minio/cmd/logger/message/log"
@@ -35,9 +36,42 @@ type Logger interface {
 	pretty(msg string, args ...interface{})
 }
 
+// Settings of the console target applied from the server config,
+// the --json and --quiet command line flags take precedence.
+var (
+	consoleDisabled int32
+	consoleJSON     int32
+	consoleLevel    = int32(InformationLvl)
+)
+
+// SetConsole - applies the settings of the console target, a disabled
+// console only prints the startup and fatal messages.
+func SetConsole(enabled bool, level Level, format string) {
+	var disabled, json int32
+	if !enabled {
+		disabled = 1
+	}
+	if format == FormatJSON {
+		json = 1
+	}
+	atomic.StoreInt32(&consoleDisabled, disabled)
+	atomic.StoreInt32(&consoleJSON, json)
+	atomic.StoreInt32(&consoleLevel, int32(level))
+}
+
+// IsConsoleEnabled - returns true if log entries are printed on the console.
+func IsConsoleEnabled() bool {
+	return atomic.LoadInt32(&consoleDisabled) == 0
+}
+
+// consoleAccepts returns true if the console prints the entries of level lvl.
+func consoleAccepts(lvl Level) bool {
+	return IsConsoleEnabled() && int32(lvl) >= atomic.LoadInt32(&consoleLevel)
+}
+
 func consoleLog(console Logger, msg string, args ...interface{}) {
 	switch {
-	case jsonFlag:
+	case IsJSON():
 		// Strip escape control characters from json message
 		msg = ansiRE.ReplaceAllLiteralString(msg, "")
 		console.json(msg, args...)
@@ -163,7 +197,19 @@ func (i infoMsg) pretty(msg string, args ...interface{}) {
 
 // Info :
 func Info(msg string, data ...interface{}) {
-	consoleLog(info, msg+"\n", data...)
+	if consoleAccepts(InformationLvl) {
+		consoleLog(info, msg+"\n", data...)
+	}
+	if Disable {
+		return
+	}
+	sendToTargets(log.Entry{
+		DeploymentID: globalDeploymentID,
+		Level:        InformationLvl.String(),
+		LogKind:      string(Minio),
+		Message:      ansiRE.ReplaceAllLiteralString(fmt.Sprintf(msg, data...), ""),
+		Time:         time.Now().UTC().Format(time.RFC3339Nano),
+	}, string(Minio), InformationLvl)
 }
 
 var startupMessage startUpMsg
@@ -0,0 +1,85 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio/cmd/logger/message/log"
+)
+
+// FormatEntry - formats a log entry as a single line in the given
+// format, entries other than log.Entry are always JSON formatted.
+func FormatEntry(e interface{}, format string) ([]byte, error) {
+	entry, ok := e.(log.Entry)
+	if !ok || format != FormatText {
+		return json.Marshal(e)
+	}
+
+	var b strings.Builder
+	b.WriteString(entry.Time)
+	b.WriteString(" " + entry.Level)
+	if entry.DeploymentID != "" {
+		b.WriteString(" deploymentid=" + entry.DeploymentID)
+	}
+	if entry.API != nil {
+		b.WriteString(" api=" + entry.API.Name)
+		if entry.API.Args != nil {
+			if entry.API.Args.Bucket != "" {
+				b.WriteString(" bucket=" + entry.API.Args.Bucket)
+			}
+			if entry.API.Args.Object != "" {
+				b.WriteString(" object=" + entry.API.Args.Object)
+			}
+		}
+	}
+	if entry.RequestID != "" {
+		b.WriteString(" requestid=" + entry.RequestID)
+	}
+	if entry.RemoteHost != "" {
+		b.WriteString(" remotehost=" + entry.RemoteHost)
+	}
+	if entry.Host != "" {
+		b.WriteString(" host=" + entry.Host)
+	}
+	if entry.UserAgent != "" {
+		b.WriteString(fmt.Sprintf(" useragent=%q", entry.UserAgent))
+	}
+	if entry.Message != "" {
+		b.WriteString(fmt.Sprintf(" message=%q", strings.TrimSpace(entry.Message)))
+	}
+	if entry.Trace != nil {
+		b.WriteString(fmt.Sprintf(" error=%q", entry.Trace.Message))
+		keys := make([]string, 0, len(entry.Trace.Variables))
+		for key := range entry.Trace.Variables {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if value := entry.Trace.Variables[key]; value != "" {
+				b.WriteString(fmt.Sprintf(" %s=%q", key, value))
+			}
+		}
+		if len(entry.Trace.Source) > 0 {
+			b.WriteString(" source=" + entry.Trace.Source[0])
+		}
+	}
+	return []byte(b.String()), nil
+}
@@ -32,6 +32,30 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         LogLevel,
+			Description: `minimum level of the logged entries, "info" or "error", defaults to "error"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         LogFormat,
+			Description: `format of the logged entries, "json" or "text", defaults to "json"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for undelivered log entries e.g. '/home/logs'`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueSize,
+			Description: `maximum number of log entries buffered in memory and in the queue dir, defaults to '10000'`,
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -52,6 +76,65 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         QueueDir,
+			Description: `staging dir for undelivered log entries e.g. '/home/logs'`,
+			Optional:    true,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         QueueSize,
+			Description: `maximum number of log entries buffered in memory and in the queue dir, defaults to '10000'`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpConsole = config.HelpKVS{
+		config.HelpKV{
+			Key:         LogLevel,
+			Description: `minimum level of the logged entries, "info" or "error", defaults to "info"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         LogFormat,
+			Description: `format of the logged entries, "json" or "text", defaults to "text"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+
+	HelpFile = config.HelpKVS{
+		config.HelpKV{
+			Key:         Path,
+			Description: `path of the log file e.g. "/var/log/minio/server.log"`,
+			Type:        "path",
+		},
+		config.HelpKV{
+			Key:         LogLevel,
+			Description: `minimum level of the logged entries, "info" or "error", defaults to "info"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         LogFormat,
+			Description: `format of the logged entries, "json" or "text", defaults to "text"`,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
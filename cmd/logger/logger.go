@@ -28,6 +28,7 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/highwayhash"
@@ -88,6 +89,17 @@ var matchingFuncNames = [...]string{
 	// add more here ..
 }
 
+// ParseLevel - parses the minimum level of the entries sent to a target.
+func ParseLevel(level string) (Level, error) {
+	switch strings.ToUpper(level) {
+	case InformationLvl.String():
+		return InformationLvl, nil
+	case ErrorLvl.String():
+		return ErrorLvl, nil
+	}
+	return 0, fmt.Errorf("unknown log level '%s', expected 'info' or 'error'", level)
+}
+
 func (level Level) String() string {
 	var lvlStr string
 	switch level {
@@ -126,9 +138,10 @@ func EnableAnonymous() {
 	anonFlag = true
 }
 
-// IsJSON - returns true if jsonFlag is true
+// IsJSON - returns true if jsonFlag is true or
+// the console target is configured with JSON format.
 func IsJSON() bool {
-	return jsonFlag
+	return jsonFlag || atomic.LoadInt32(&consoleJSON) == 1
 }
 
 // IsQuiet - returns true if quietFlag is true
@@ -380,9 +393,7 @@ func logIf(ctx context.Context, err error, errKind ...interface{}) {
 	}
 
 	// Iterate over all logger targets to send the log entry
-	for _, t := range Targets {
-		t.Send(entry, entry.LogKind)
-	}
+	sendToTargets(entry, entry.LogKind, ErrorLvl)
 }
 
 // ErrCritical is the value panic'd whenever CriticalIf is called.
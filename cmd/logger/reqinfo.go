@@ -44,6 +44,8 @@ type ReqInfo struct {
 	BucketName   string   // Bucket name
 	ObjectName   string   // Object name
 	AccessKey    string   // Access Key
+	ParentUser   string   // Parent user of temporary credentials and service accounts
+	PolicyName   string   // Policy claim of temporary credentials
 	tags         []KeyVal // Any additional info not accommodated by above fields
 	sync.RWMutex
 }
@@ -48,6 +48,9 @@ func (c *Target) String() string {
 
 // Send log message 'e' to console
 func (c *Target) Send(e interface{}, logKind string) error {
+	if !logger.IsConsoleEnabled() {
+		return nil
+	}
 	entry, ok := e.(log.Entry)
 	if !ok {
 		return fmt.Errorf("Uexpected log entry structure %#v", e)
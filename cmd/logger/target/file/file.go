@@ -0,0 +1,137 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package file
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// Interval at which the written log entries are flushed to the file.
+const flushInterval = time.Second
+
+// Target implements logger.Target and appends the json or text
+// format of a log entry as a line of the configured file. An
+// internal buffer of logs is maintained but when the buffer is
+// full, new logs are just ignored and an error is returned to
+// the caller.
+type Target struct {
+	// Channel of log entries
+	logCh chan interface{}
+
+	path   string
+	level  logger.Level
+	format string
+}
+
+// Endpoint returns the path of the log file
+func (t *Target) Endpoint() string {
+	return t.path
+}
+
+func (t *Target) String() string {
+	return "file"
+}
+
+// Level returns the minimum level of the log entries written to the file.
+func (t *Target) Level() logger.Level {
+	return t.level
+}
+
+// Validate validates the file target, the log file is created if needed.
+func (t *Target) Validate() error {
+	if t.path == "" {
+		return errors.New("log file path is not set")
+	}
+	f, err := t.open()
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (t *Target) open() (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0700); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(t.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+}
+
+func (t *Target) startFileLogger(f *os.File) {
+	// Create a routine which writes the logs received from an
+	// internal channel, the writes are flushed periodically.
+	go func() {
+		w := bufio.NewWriter(f)
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case entry := <-t.logCh:
+				line, err := logger.FormatEntry(entry, t.format)
+				if err != nil {
+					continue
+				}
+				line = append(line, '\n')
+				if _, err = w.Write(line); err != nil {
+					logger.LogOnceIf(context.Background(), err, t.path)
+				}
+			case <-ticker.C:
+				if err := w.Flush(); err != nil {
+					logger.LogOnceIf(context.Background(), err, t.path)
+				}
+			}
+		}
+	}()
+}
+
+// New initializes a new logger target which appends
+// the logs of level or above to the file at path.
+func New(path string, level logger.Level, format string) (*Target, error) {
+	t := &Target{
+		logCh:  make(chan interface{}, 10000),
+		path:   path,
+		level:  level,
+		format: format,
+	}
+	if path == "" {
+		return nil, errors.New("log file path is not set")
+	}
+	f, err := t.open()
+	if err != nil {
+		return nil, err
+	}
+	t.startFileLogger(f)
+	return t, nil
+}
+
+// Send log message 'e' to the file target.
+func (t *Target) Send(entry interface{}, errKind string) error {
+	select {
+	case t.logCh <- entry:
+	default:
+		// log channel is full, do not wait and return
+		// an error immediately to the caller
+		return errors.New("log buffer full")
+	}
+	return nil
+}
@@ -19,7 +19,6 @@ package http
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -30,15 +29,32 @@ import (
 	"github.com/minio/minio/cmd/logger"
 )
 
-// Target implements logger.Target and sends the json
-// format of a log entry to the configured http endpoint.
-// An internal buffer of logs is maintained but when the
-// buffer is full, new logs are just ignored and an error
-// is returned to the caller.
+// Default number of log entries buffered in memory and on disk.
+const defaultQueueSize = 10000
+
+// Interval at which the log entries queued on disk are resent.
+const replayInterval = 10 * time.Second
+
+// Target implements logger.Target and sends the json or
+// text format of a log entry to the configured http endpoint.
+// An internal buffer of logs is maintained, when the buffer
+// is full or the endpoint is unreachable logs are queued in
+// the configured queue directory and sent later. Without a
+// queue directory new logs are just ignored and an error is
+// returned to the caller.
 type Target struct {
 	// Channel of log entries
 	logCh chan interface{}
 
+	// Minimum level and format of the log entries sent
+	level  logger.Level
+	format string
+
+	// Directory and maximum number of queued log entries
+	queueDir  string
+	queueSize int
+	store     *queueStore
+
 	name string
 	// HTTP(s) endpoint
 	endpoint string
@@ -100,54 +116,121 @@ func (h *Target) Validate() error {
 	return nil
 }
 
+// Level returns the minimum level of the log entries sent to the target.
+func (h *Target) Level() logger.Level {
+	return h.level
+}
+
+// contentType returns the content type of the formatted log entries.
+func (h *Target) contentType() string {
+	if h.format == logger.FormatText {
+		return "text/plain"
+	}
+	return "application/json"
+}
+
+// post sends a formatted log entry to the endpoint.
+func (h *Target) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, h.contentType())
+
+	// Set user-agent to indicate MinIO release
+	// version to the configured log endpoint
+	req.Header.Set("User-Agent", h.userAgent)
+
+	if h.authToken != "" {
+		req.Header.Set("Authorization", h.authToken)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s returned '%w', please check your endpoint configuration",
+			h.endpoint, err)
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusForbidden:
+			return fmt.Errorf("%s returned '%s', please check if your auth token is correctly set",
+				h.endpoint, resp.Status)
+		}
+		return fmt.Errorf("%s returned '%s', please check your endpoint configuration",
+			h.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// queue stores a formatted log entry on disk to be sent later.
+func (h *Target) queue(body []byte) error {
+	if h.store == nil {
+		return errors.New("log buffer full")
+	}
+	return h.store.put(body)
+}
+
+// replay sends the log entries queued on disk, oldest first, it
+// stops at the first failure to keep the entries in order.
+func (h *Target) replay() {
+	names, err := h.store.list()
+	if err != nil {
+		logger.LogOnceIf(context.Background(), err, h.queueDir)
+		return
+	}
+	for _, name := range names {
+		body, err := h.store.get(name)
+		if err != nil {
+			logger.LogOnceIf(context.Background(), err, h.queueDir)
+			h.store.del(name)
+			continue
+		}
+		if err = h.post(body); err != nil {
+			return
+		}
+		h.store.del(name)
+	}
+}
+
 func (h *Target) startHTTPLogger() {
-	// Create a routine which sends json logs received
+	// Create a routine which sends logs received
 	// from an internal channel.
 	go func() {
 		for entry := range h.logCh {
-			logJSON, err := json.Marshal(&entry)
-			if err != nil {
-				continue
-			}
-
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			req, err := http.NewRequestWithContext(ctx, http.MethodPost,
-				h.endpoint, bytes.NewReader(logJSON))
+			body, err := logger.FormatEntry(entry, h.format)
 			if err != nil {
-				cancel()
 				continue
 			}
-			req.Header.Set(xhttp.ContentType, "application/json")
-
-			// Set user-agent to indicate MinIO release
-			// version to the configured log endpoint
-			req.Header.Set("User-Agent", h.userAgent)
-
-			if h.authToken != "" {
-				req.Header.Set("Authorization", h.authToken)
+			if err = h.post(body); err != nil {
+				logger.LogOnceIf(context.Background(), err, h.endpoint)
+				// Keep the entry on disk when a queue
+				// directory is configured.
+				if h.store != nil {
+					logger.LogOnceIf(context.Background(), h.queue(body), h.queueDir)
+				}
 			}
+		}
+	}()
 
-			resp, err := h.client.Do(req)
-			cancel()
-			if err != nil {
-				logger.LogIf(ctx, fmt.Errorf("%s returned '%w', please check your endpoint configuration\n",
-					h.endpoint, err))
-				continue
-			}
+	if h.store == nil {
+		return
+	}
 
-			// Drain any response.
-			xhttp.DrainBody(resp.Body)
-
-			if resp.StatusCode != http.StatusOK {
-				switch resp.StatusCode {
-				case http.StatusForbidden:
-					logger.LogIf(ctx, fmt.Errorf("%s returned '%s', please check if your auth token is correctly set",
-						h.endpoint, resp.Status))
-				default:
-					logger.LogIf(ctx, fmt.Errorf("%s returned '%s', please check your endpoint configuration",
-						h.endpoint, resp.Status))
-				}
-			}
+	// Create a routine which resends the logs
+	// queued on disk.
+	go func() {
+		ticker := time.NewTicker(replayInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			h.replay()
 		}
 	}()
 }
@@ -190,6 +273,36 @@ func WithAuthToken(authToken string) Option {
 	}
 }
 
+// WithLevel sets the minimum level of the log entries sent to target.
+func WithLevel(level logger.Level) Option {
+	return func(t *Target) {
+		t.level = level
+	}
+}
+
+// WithFormat sets the format, json or text, of the log entries sent to target.
+func WithFormat(format string) Option {
+	return func(t *Target) {
+		t.format = format
+	}
+}
+
+// WithQueueDir adds a directory where log entries are
+// queued when they cannot be sent right away.
+func WithQueueDir(queueDir string) Option {
+	return func(t *Target) {
+		t.queueDir = queueDir
+	}
+}
+
+// WithQueueSize sets the maximum number of log entries
+// buffered in memory and in the queue directory.
+func WithQueueSize(queueSize int) Option {
+	return func(t *Target) {
+		t.queueSize = queueSize
+	}
+}
+
 // WithTransport adds a custom transport with custom timeouts and tuning.
 func WithTransport(transport *http.Transport) Option {
 	return func(t *Target) {
@@ -203,7 +316,9 @@ func WithTransport(transport *http.Transport) Option {
 // sends log over http to the specified endpoint
 func New(opts ...Option) *Target {
 	h := &Target{
-		logCh: make(chan interface{}, 10000),
+		level:     logger.ErrorLvl,
+		format:    logger.FormatJSON,
+		queueSize: defaultQueueSize,
 	}
 
 	// Loop through each option
@@ -213,6 +328,21 @@ func New(opts ...Option) *Target {
 		opt(h)
 	}
 
+	if h.queueSize <= 0 {
+		h.queueSize = defaultQueueSize
+	}
+	h.logCh = make(chan interface{}, h.queueSize)
+
+	if h.queueDir != "" {
+		store, err := newQueueStore(h.queueDir, h.queueSize)
+		if err != nil {
+			// Log entries are still sent but not queued.
+			logger.LogIf(context.Background(), err)
+		} else {
+			h.store = store
+		}
+	}
+
 	h.startHTTPLogger()
 	return h
 }
@@ -226,9 +356,17 @@ func (h *Target) Send(entry interface{}, errKind string) error {
 	select {
 	case h.logCh <- entry:
 	default:
-		// log channel is full, do not wait and return
-		// an error immediately to the caller
-		return errors.New("log buffer full")
+		// log channel is full, do not wait, queue the
+		// entry on disk or return an error immediately
+		// to the caller
+		if h.store == nil {
+			return errors.New("log buffer full")
+		}
+		body, err := logger.FormatEntry(entry, h.format)
+		if err != nil {
+			return err
+		}
+		return h.queue(body)
 	}
 
 	return nil
@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/log"
+)
+
+func TestTargetQueueDir(t *testing.T) {
+	var (
+		online int32
+		mu     sync.Mutex
+		bodies []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&online) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	queueDir, err := ioutil.TempDir("", "minio-log-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(queueDir)
+
+	h := New(
+		WithEndpoint(ts.URL),
+		WithLogKind(string(logger.All)),
+		WithFormat(logger.FormatText),
+		WithTransport(&http.Transport{}),
+		WithQueueDir(queueDir),
+		WithQueueSize(2),
+	)
+	if h.Level() != logger.ErrorLvl {
+		t.Fatalf("expected default level %s, got %s", logger.ErrorLvl, h.Level())
+	}
+
+	waitQueued := func(n int) {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			names, err := h.store.list()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(names) == n {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d queued entries, got %d", n, len(names))
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// Only two entries fit in the queue directory.
+	for i, msg := range []string{"first", "second", "third"} {
+		if err = h.Send(log.Entry{Level: "ERROR", Message: msg}, string(logger.Minio)); err != nil {
+			t.Fatal(err)
+		}
+		if i < 2 {
+			waitQueued(i + 1)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	waitQueued(2)
+
+	atomic.StoreInt32(&online, 1)
+	h.replay()
+
+	names, err := h.store.list()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Fatalf("expected an empty queue after replay, got %d entries", len(names))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{` ERROR message="first"`, ` ERROR message="second"`}
+	if len(bodies) != len(expected) {
+		t.Fatalf("expected %d entries sent, got %d", len(expected), len(bodies))
+	}
+	for i := range expected {
+		if bodies[i] != expected[i] {
+			t.Errorf("entry %d: expected %q, got %q", i, expected[i], bodies[i])
+		}
+	}
+}
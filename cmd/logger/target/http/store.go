@@ -0,0 +1,118 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const storeExt = ".log"
+
+// errStoreFull is returned when the queue directory holds
+// the maximum number of log entries.
+var errStoreFull = errors.New("log queue directory is full")
+
+// queueStore persists the log entries which could not be sent
+// immediately, each entry is stored as a file of the directory
+// named so that entries are replayed in order.
+type queueStore struct {
+	sync.Mutex
+	dir   string
+	limit int
+	count int
+	seq   uint64
+}
+
+// newQueueStore - opens the queue directory dir holding
+// at most limit entries, entries already stored are kept.
+func newQueueStore(dir string, limit int) (*queueStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	q := &queueStore{dir: dir, limit: limit}
+	names, err := q.list()
+	if err != nil {
+		return nil, err
+	}
+	q.count = len(names)
+	return q, nil
+}
+
+// put stores a formatted log entry.
+func (q *queueStore) put(body []byte) error {
+	q.Lock()
+	defer q.Unlock()
+	if q.count >= q.limit {
+		return errStoreFull
+	}
+	q.seq++
+	name := fmt.Sprintf("%020d-%08d%s", time.Now().UnixNano(), q.seq, storeExt)
+	tmp := filepath.Join(q.dir, "."+name)
+	if err := ioutil.WriteFile(tmp, body, 0600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(q.dir, name)); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	q.count++
+	return nil
+}
+
+// list returns the names of the stored entries, oldest first.
+func (q *queueStore) list() ([]string, error) {
+	entries, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Mode().IsRegular() && !strings.HasPrefix(name, ".") && strings.HasSuffix(name, storeExt) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// get returns a stored entry.
+func (q *queueStore) get(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(q.dir, name))
+}
+
+// del removes a stored entry once sent.
+func (q *queueStore) del(name string) error {
+	q.Lock()
+	defer q.Unlock()
+	if err := os.Remove(filepath.Join(q.dir, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if q.count > 0 {
+		q.count--
+	}
+	return nil
+}
@@ -16,6 +16,11 @@
 
 package logger
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Target is the entity that we will receive
 // a single log entry and Send it to the log target
 //   e.g. Send the log to a http server
@@ -26,6 +31,46 @@ type Target interface {
 	Send(entry interface{}, errKind string) error
 }
 
+// LevelTarget is implemented by the targets which receive the log
+// entries of a configured minimum level, targets which do not
+// implement it only receive the error entries.
+type LevelTarget interface {
+	Target
+	Level() Level
+}
+
+// Log entry formats supported by the targets.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ParseFormat - parses a log entry format.
+func ParseFormat(format string) (string, error) {
+	switch f := strings.ToLower(format); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown log format '%s', expected '%s' or '%s'", format, FormatText, FormatJSON)
+}
+
+// acceptsLevel returns true if t receives the entries of level lvl.
+func acceptsLevel(t Target, lvl Level) bool {
+	if lt, ok := t.(LevelTarget); ok {
+		return lvl >= lt.Level()
+	}
+	return lvl >= ErrorLvl
+}
+
+// sendToTargets sends entry to all logger targets accepting lvl.
+func sendToTargets(entry interface{}, logKind string, lvl Level) {
+	for _, t := range Targets {
+		if acceptsLevel(t, lvl) {
+			t.Send(entry, logKind)
+		}
+	}
+}
+
 // Targets is the set of enabled loggers
 var Targets = []Target{}
 
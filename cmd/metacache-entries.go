@@ -306,10 +306,16 @@ func (m *metaCacheEntriesSorted) iterate(fn func(entry metaCacheEntry) (cont boo
 
 // fileInfoVersions converts the metadata to FileInfoVersions where possible.
 // Metadata that cannot be decoded is skipped.
-func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, afterV string) (versions []ObjectInfo) {
+// Versions of the first entry up to and including afterV are skipped,
+// at most limit versions and prefixes are returned if limit > 0.
+func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, afterV string, limit int) (versions []ObjectInfo) {
 	versions = make([]ObjectInfo, 0, m.len())
 	prevPrefix := ""
 	for _, entry := range m.o {
+		if limit > 0 && len(versions) >= limit {
+			// Remaining entries are listed on the next page.
+			break
+		}
 		if entry.isObject() {
 			if delimiter != "" {
 				idx := strings.Index(strings.TrimPrefix(entry.name, prefix), delimiter)
@@ -337,6 +343,9 @@ func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, aft
 			}
 			if err == nil {
 				for _, version := range fiv.Versions {
+					if limit > 0 && len(versions) >= limit {
+						break
+					}
 					versions = append(versions, version.ToObjectInfo(bucket, entry.name))
 				}
 			}
@@ -59,6 +59,10 @@ type listPathOptions struct {
 	// The response will be the first entry AFTER this object name.
 	Marker string
 
+	// IncludeMarker will also return the entry matching the marker.
+	// Used to resume listing within the versions of the marker object.
+	IncludeMarker bool
+
 	// Limit the number of results.
 	Limit int
 
@@ -157,7 +161,7 @@ func (o *listPathOptions) gatherResults(in <-chan metaCacheEntry) func() (metaCa
 				continue
 			}
 			o.debugln("gather got:", entry.name)
-			if o.Marker != "" && entry.name <= o.Marker {
+			if o.Marker != "" && (entry.name < o.Marker || (entry.name == o.Marker && !o.IncludeMarker)) {
 				o.debugln("pre marker")
 				continue
 			}
@@ -315,7 +319,7 @@ func (r *metacacheReader) filter(o listPathOptions) (entries metaCacheEntriesSor
 		if err != nil {
 			return entries, err
 		}
-		if next.name == o.Marker {
+		if next.name == o.Marker && !o.IncludeMarker {
 			err := r.skip(1)
 			if err != nil {
 				return entries, err
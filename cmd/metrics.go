@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/rest"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -87,6 +88,7 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 	bucketUsageMetricsPrometheus(ch)
 	networkMetricsPrometheus(ch)
 	httpMetricsPrometheus(ch)
+	requestsPoolMetricsPrometheus(ch)
 	cacheMetricsPrometheus(ch)
 	gatewayMetricsPrometheus(ch)
 	healingMetricsPrometheus(ch)
@@ -343,6 +345,72 @@ func httpMetricsPrometheus(ch chan<- prometheus.Metric) {
 	}
 }
 
+// collects requests pool metrics for MinIO server in Prometheus specific format
+// and sends to given channel
+func requestsPoolMetricsPrometheus(ch chan<- prometheus.Metric) {
+	stats := globalAPIConfig.getRequestsPoolStats()
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("s3", "requests_pool", "limit"),
+			"Maximum number of s3 requests served concurrently by current MinIO server instance",
+			nil, nil),
+		prometheus.GaugeValue,
+		float64(stats.Limit),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("s3", "requests_pool", "in_use"),
+			"Number of s3 requests being served by current MinIO server instance",
+			nil, nil),
+		prometheus.GaugeValue,
+		float64(stats.InUse),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("s3", "requests_pool", "waiting"),
+			"Number of s3 requests waiting for a free slot in current MinIO server instance",
+			nil, nil),
+		prometheus.GaugeValue,
+		float64(stats.Waiting),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("s3", "requests_pool", "served_total"),
+			"Total number of s3 requests admitted by current MinIO server instance",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(stats.Served),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("s3", "requests_pool", "rejected_total"),
+			"Total number of s3 requests rejected after waiting past the requests deadline",
+			nil, nil),
+		prometheus.CounterValue,
+		float64(stats.Rejected),
+	)
+
+	waitSum := time.Duration(atomic.LoadInt64(&globalAPIConfig.poolStats.waitSum))
+	ch <- prometheus.MustNewConstSummary(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("s3", "requests_pool", "wait_seconds"),
+			"Time s3 requests waited for a free slot in current MinIO server instance",
+			nil, nil),
+		stats.Served+stats.Rejected,
+		waitSum.Seconds(),
+		map[float64]float64{
+			0.5:  stats.WaitTime.P50.Seconds(),
+			0.9:  stats.WaitTime.P90.Seconds(),
+			0.99: stats.WaitTime.P99.Seconds(),
+		},
+	)
+}
+
 // collects network metrics for MinIO server in Prometheus specific format
 // and sends to given channel
 func networkMetricsPrometheus(ch chan<- prometheus.Metric) {
@@ -367,6 +435,29 @@ func networkMetricsPrometheus(ch chan<- prometheus.Metric) {
 		float64(connStats.TotalInputBytes),
 	)
 
+	// Internode RPC round-trip time and errors per peer node
+	for server, stats := range rest.GetRPCStats() {
+		ch <- prometheus.MustNewConstSummary(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("internode", "rpc", "round_trip_seconds"),
+				"Round-trip time of the calls to the other peer nodes by current MinIO server instance",
+				[]string{"server"}, nil),
+			stats.Calls,
+			stats.RoundTrip.Seconds(),
+			nil,
+			server,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("internode", "rpc", "errors_total"),
+				"Total number of calls to the other peer nodes which failed without a response",
+				[]string{"server"}, nil),
+			prometheus.CounterValue,
+			float64(stats.Errors),
+			server,
+		)
+	}
+
 	// Network Sent/Received Bytes (Outbound)
 	ch <- prometheus.MustNewConstMetric(
 		prometheus.NewDesc(
@@ -551,6 +642,61 @@ func storageMetricsPrometheus(ch chan<- prometheus.Metric) {
 			disk.DrivePath,
 		)
 	}
+
+	for diskPath, metrics := range globalDiskMetrics.snapshot() {
+		for op, stats := range metrics.ops() {
+			// Total number of operations on the disk
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("disk", "io", "ops_total"),
+					"Total number of operations on the disk",
+					[]string{"disk", "op"}, nil),
+				prometheus.CounterValue,
+				float64(stats.Count),
+				diskPath, op.String(),
+			)
+
+			// Total number of failed operations on the disk
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("disk", "io", "errors_total"),
+					"Total number of failed operations on the disk",
+					[]string{"disk", "op"}, nil),
+				prometheus.CounterValue,
+				float64(stats.Errors),
+				diskPath, op.String(),
+			)
+
+			// Total number of bytes read from and written to the disk
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("disk", "io", "bytes_total"),
+					"Total number of bytes read from or written to the disk",
+					[]string{"disk", "op"}, nil),
+				prometheus.CounterValue,
+				float64(stats.Bytes),
+				diskPath, op.String(),
+			)
+
+			// Latency of the operations on the disk
+			buckets := make(map[float64]uint64, len(diskLatencyBuckets))
+			var cumulative uint64
+			for i, bound := range diskLatencyBuckets {
+				cumulative += stats.Latency[i]
+				buckets[bound.Seconds()] = cumulative
+			}
+			ch <- prometheus.MustNewConstHistogram(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("disk", "io", "latency_seconds"),
+					"Histogram of the latency of the operations on the disk",
+					[]string{"disk", "op"}, nil),
+				stats.Count,
+				time.Duration(stats.LatencySum).Seconds(),
+				buckets,
+				diskPath, op.String(),
+			)
+		}
+	}
 }
 
 func metricsHandler() http.Handler {
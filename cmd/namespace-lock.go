@@ -36,6 +36,62 @@ import (
 // local lock servers
 var globalLockServer *localLocker
 
+// lock requests of this node waiting to be granted
+var globalLockWaiters = newLockWaiters()
+
+// lockWaiters keeps track of the distributed lock
+// requests of this node which are not granted yet.
+type lockWaiters struct {
+	mu sync.Mutex
+	// Waiting lock requests indexed by their UID.
+	waiters map[string]lockWaiter
+}
+
+type lockWaiter struct {
+	resources []string
+	lri       lockRequesterInfo
+}
+
+func newLockWaiters() *lockWaiters {
+	return &lockWaiters{waiters: make(map[string]lockWaiter)}
+}
+
+// add records a lock request as waiting, the returned
+// function must be called once the request is done.
+func (l *lockWaiters) add(resources []string, uid, source string, writer bool) func() {
+	l.mu.Lock()
+	l.waiters[uid] = lockWaiter{
+		resources: resources,
+		lri: lockRequesterInfo{
+			Writer:    writer,
+			UID:       uid,
+			Timestamp: UTCNow(),
+			Source:    source,
+			Owner:     GetLocalPeer(globalEndpoints),
+		},
+	}
+	l.mu.Unlock()
+	return func() {
+		l.mu.Lock()
+		delete(l.waiters, uid)
+		l.mu.Unlock()
+	}
+}
+
+// DupLockMap returns the waiting lock requests indexed by resource.
+func (l *lockWaiters) DupLockMap() map[string][]lockRequesterInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lockCopy := map[string][]lockRequesterInfo{}
+	for _, w := range l.waiters {
+		for _, resource := range w.resources {
+			lockCopy[resource] = append(lockCopy[resource], w.lri)
+		}
+	}
+	return lockCopy
+}
+
 // RWLocker - locker interface to introduce GetRLock, RUnlock.
 type RWLocker interface {
 	GetLock(ctx context.Context, timeout *dynamicTimeout) (timedOutErr error)
@@ -146,6 +202,9 @@ func (di *distLockInstance) GetLock(ctx context.Context, timeout *dynamicTimeout
 	lockSource := getSource(2)
 	start := UTCNow()
 
+	const writer = true
+	defer globalLockWaiters.add(di.rwMutex.Names, di.opsID, lockSource, writer)()
+
 	if !di.rwMutex.GetLock(ctx, di.opsID, lockSource, dsync.Options{
 		Timeout: timeout.Timeout(),
 	}) {
@@ -166,6 +225,9 @@ func (di *distLockInstance) GetRLock(ctx context.Context, timeout *dynamicTimeou
 	lockSource := getSource(2)
 	start := UTCNow()
 
+	const writer = false
+	defer globalLockWaiters.add(di.rwMutex.Names, di.opsID, lockSource, writer)()
+
 	if !di.rwMutex.GetRLock(ctx, di.opsID, lockSource, dsync.Options{
 		Timeout: timeout.Timeout(),
 	}) {
@@ -228,6 +228,19 @@ func (sys *NotificationSys) LoadGroup(group string) []NotificationPeerErr {
 	return ng.Wait()
 }
 
+// LoadIAM - reloads all users, groups and policies on all peers.
+func (sys *NotificationSys) LoadIAM() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(GlobalContext, func() error { return client.LoadIAM() }, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // DeleteServiceAccount - deletes a specific service account across all peers
 func (sys *NotificationSys) DeleteServiceAccount(accessKey string) []NotificationPeerErr {
 	ng := WithNPeers(len(sys.peerClients))
@@ -626,9 +639,14 @@ func (sys *NotificationSys) GetLocks(ctx context.Context, r *http.Request) []*Pe
 			if err != nil {
 				return err
 			}
+			serverWaitersResp, err := sys.peerClients[index].GetLockWaiters()
+			if err != nil {
+				return err
+			}
 			locksResp[index] = &PeerLocks{
-				Addr:  sys.peerClients[index].host.String(),
-				Locks: serverLocksResp,
+				Addr:    sys.peerClients[index].host.String(),
+				Locks:   serverLocksResp,
+				Waiting: serverWaitersResp,
 			}
 			return nil
 		}, index)
@@ -640,8 +658,9 @@ func (sys *NotificationSys) GetLocks(ctx context.Context, r *http.Request) []*Pe
 		logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
 	}
 	locksResp = append(locksResp, &PeerLocks{
-		Addr:  getHostName(r),
-		Locks: globalLockServer.DupLockMap(),
+		Addr:    getHostName(r),
+		Locks:   globalLockServer.DupLockMap(),
+		Waiting: globalLockWaiters.DupLockMap(),
 	})
 	return locksResp
 }
@@ -838,7 +857,45 @@ func (sys *NotificationSys) Send(args eventArgs) {
 		return
 	}
 
-	sys.targetList.Send(args.ToEvent(true), targetIDSet, sys.targetResCh)
+	ev := args.ToEvent(true)
+	globalEventJournal.add(ev)
+	sys.targetList.Send(ev, targetIDSet, sys.targetResCh)
+}
+
+// ReplayEvents - sends the journaled events of bucket between start and
+// end again to the target with the given ARN, only events routed to this
+// target by the current notification configuration of bucket are sent.
+func (sys *NotificationSys) ReplayEvents(ctx context.Context, objAPI ObjectLayer, bucket, arn string, start, end time.Time) (replayed int64, err error) {
+	var target event.Target
+	for targetID, t := range sys.targetList.TargetMap() {
+		if targetID.ToARN(globalServerRegion).String() == arn {
+			target = t
+			break
+		}
+	}
+	if target == nil {
+		return 0, &event.ErrARNNotFound{}
+	}
+
+	sys.RLock()
+	rulesMap := sys.bucketRulesMap[bucket].Clone()
+	sys.RUnlock()
+
+	err = globalEventJournal.replay(ctx, objAPI, bucket, start, end, func(ev event.Event) error {
+		objectName, err := url.QueryUnescape(ev.S3.Object.Key)
+		if err != nil {
+			return err
+		}
+		if _, ok := rulesMap.Match(ev.EventName, objectName)[target.ID()]; !ok {
+			return nil
+		}
+		if err = target.Save(ev); err != nil {
+			return err
+		}
+		replayed++
+		return nil
+	})
+	return replayed, err
 }
 
 // NetInfo - Net information
@@ -1095,6 +1152,36 @@ func (sys *NotificationSys) CPUInfo(ctx context.Context) []madmin.ServerCPUInfo
 	return reply
 }
 
+// ErasureBenchmark - runs an erasure coding benchmark on all peers.
+func (sys *NotificationSys) ErasureBenchmark(ctx context.Context, p erasureBenchmarkParams) []madmin.ErasureBenchmarkResult {
+	reply := make([]madmin.ErasureBenchmarkResult, len(sys.peerClients))
+
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reply[index], err = sys.peerClients[index].ErasureBenchmark(ctx, p)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			addr := sys.peerClients[index].host.String()
+			reqInfo := (&logger.ReqInfo{}).AppendTags("remotePeer", addr)
+			ctx := logger.SetReqInfo(GlobalContext, reqInfo)
+			logger.LogIf(ctx, err)
+			reply[index].Addr = addr
+			reply[index].Error = err.Error()
+		}
+	}
+	return reply
+}
+
 // DiskHwInfo - Disk HW information
 func (sys *NotificationSys) DiskHwInfo(ctx context.Context) []madmin.ServerDiskHwInfo {
 	reply := make([]madmin.ServerDiskHwInfo, len(sys.peerClients))
@@ -1226,6 +1313,14 @@ func (sys *NotificationSys) ServerInfo() []madmin.ServerProperties {
 		wg.Add(1)
 		go func(client *peerRESTClient, idx int) {
 			defer wg.Done()
+			if !client.IsOnline() {
+				// Peer is known to be down, do not wait for it.
+				reply[idx] = madmin.ServerProperties{
+					Endpoint: client.host.String(),
+					State:    "offline",
+				}
+				return
+			}
 			info, err := client.ServerInfo()
 			if err != nil {
 				info.Endpoint = client.host.String()
@@ -1240,6 +1335,37 @@ func (sys *NotificationSys) ServerInfo() []madmin.ServerProperties {
 	return reply
 }
 
+// RequestsPoolStats - returns the requests pool occupancy of all peers.
+func (sys *NotificationSys) RequestsPoolStats() []madmin.RequestsPoolStats {
+	reply := make([]madmin.RequestsPoolStats, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for i, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(client *peerRESTClient, idx int) {
+			defer wg.Done()
+			if !client.IsOnline() {
+				// Peer is known to be down, do not wait for it.
+				reply[idx] = madmin.RequestsPoolStats{
+					Endpoint: client.host.String(),
+					Error:    "offline",
+				}
+				return
+			}
+			stats, err := client.RequestsPoolStats()
+			if err != nil {
+				stats.Error = err.Error()
+			}
+			stats.Endpoint = client.host.String()
+			reply[idx] = stats
+		}(client, i)
+	}
+	wg.Wait()
+	return reply
+}
+
 // GetLocalDiskIDs - return disk ids of the local disks of the peers.
 func (sys *NotificationSys) GetLocalDiskIDs(ctx context.Context) (localDiskIDs [][]string) {
 	localDiskIDs = make([][]string, len(sys.peerClients))
@@ -1317,6 +1443,9 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 	if globalDeploymentID != "" {
 		respElements["x-minio-deployment-id"] = globalDeploymentID
 	}
+	if args.RespElements["requestHostId"] != "" {
+		respElements["x-amz-id-2"] = args.RespElements["requestHostId"]
+	}
 	if args.RespElements["content-length"] != "" {
 		respElements["content-length"] = args.RespElements["content-length"]
 	}
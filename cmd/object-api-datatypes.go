@@ -98,6 +98,7 @@ type BucketUsageInfo struct {
 	ReplicatedSize         uint64            `json:"objectsReplicatedTotalSize"`
 	ReplicaSize            uint64            `json:"objectReplicaTotalSize"`
 	ObjectsCount           uint64            `json:"objectsCount"`
+	VersionsCount          uint64            `json:"versionsCount"`
 	ObjectSizesHistogram   map[string]uint64 `json:"objectsSizesHistogram"`
 }
 
@@ -373,6 +373,13 @@ func (e BucketTaggingNotFound) Error() string {
 	return "No bucket tags found for bucket: " + e.Bucket
 }
 
+// BucketCorsNotFound - no bucket CORS config found
+type BucketCorsNotFound GenericError
+
+func (e BucketCorsNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket: " + e.Bucket
+}
+
 // BucketObjectLockConfigNotFound - no bucket object lock config found
 type BucketObjectLockConfigNotFound GenericError
 
@@ -387,6 +394,34 @@ func (e BucketQuotaConfigNotFound) Error() string {
 	return "No quota config found for bucket : " + e.Bucket
 }
 
+// BucketLambdaConfigNotFound - no bucket object lambda config found.
+type BucketLambdaConfigNotFound GenericError
+
+func (e BucketLambdaConfigNotFound) Error() string {
+	return "No object lambda config found for bucket : " + e.Bucket
+}
+
+// BucketPlacementConfigNotFound - no bucket placement config found.
+type BucketPlacementConfigNotFound GenericError
+
+func (e BucketPlacementConfigNotFound) Error() string {
+	return "No placement config found for bucket : " + e.Bucket
+}
+
+// BucketReadOnlyReplica - bucket is a read-only replica.
+type BucketReadOnlyReplica GenericError
+
+func (e BucketReadOnlyReplica) Error() string {
+	return "Bucket is a read-only replica, objects can only be written by replication : " + e.Bucket
+}
+
+// LambdaTransformFailed - object lambda transformation webhook failed.
+type LambdaTransformFailed GenericError
+
+func (e LambdaTransformFailed) Error() string {
+	return "Object lambda transformation failed for " + e.Bucket + "/" + e.Object + ": " + e.Err.Error()
+}
+
 // BucketQuotaExceeded - bucket quota exceeded.
 type BucketQuotaExceeded GenericError
 
@@ -597,6 +632,15 @@ func (e InvalidPart) Error() string {
 		e.PartNumber, e.ExpETag, e.GotETag)
 }
 
+// InvalidPartNumber - the object has no part with the requested part number.
+type InvalidPartNumber struct {
+	PartNumber int
+}
+
+func (e InvalidPartNumber) Error() string {
+	return fmt.Sprintf("Requested part number %d is not satisfiable", e.PartNumber)
+}
+
 // PartTooSmall - error if part size is less than 5MB.
 type PartTooSmall struct {
 	PartSize   int64
@@ -677,3 +721,24 @@ func isErrPreconditionFailed(err error) bool {
 	_, ok := err.(PreConditionFailed)
 	return ok
 }
+
+// InvalidAppendOffset - the offset of an append does not match
+// the current size of the object.
+type InvalidAppendOffset struct {
+	Bucket string
+	Object string
+	Offset int64
+	Size   int64
+}
+
+func (e InvalidAppendOffset) Error() string {
+	return fmt.Sprintf("Append offset %d does not match the size %d of object %s/%s", e.Offset, e.Size, e.Bucket, e.Object)
+}
+
+// ObjectNotAppendable - the object cannot be appended to, for example
+// because it is encrypted or compressed.
+type ObjectNotAppendable GenericError
+
+func (e ObjectNotAppendable) Error() string {
+	return "Object cannot be appended to: " + e.Bucket + SlashSeparator + e.Object
+}
@@ -1351,6 +1351,80 @@ func testListObjectVersions(obj ObjectLayer, instanceType string, t1 TestErrHand
 	}
 }
 
+// Wrapper for calling ListObjectVersions pagination tests for both Erasure multiple disks and single node setup.
+func TestListObjectVersionsPagination(t *testing.T) {
+	ExecObjectLayerTest(t, testListObjectVersionsPagination)
+}
+
+// Unit test for paginating through ListObjectVersions with version markers.
+func testListObjectVersionsPagination(obj ObjectLayer, instanceType string, t1 TestErrHandler) {
+	t, _ := t1.(*testing.T)
+	bucket := "test-bucket-list-versions-pages"
+	err := obj.MakeBucketWithLocation(context.Background(), bucket, BucketOptions{VersioningEnabled: true})
+	if err != nil {
+		if _, ok := err.(NotImplemented); ok {
+			// Skip test for FS mode.
+			return
+		}
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+
+	opts := ObjectOptions{Versioned: true}
+	expected := make(map[string]bool)
+	for _, object := range []string{"a", "b", "b", "b", "c", "d", "d"} {
+		content := "content of " + object
+		objInfo, err := obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewBufferString(content), int64(len(content)), "", ""), opts)
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err.Error())
+		}
+		expected[object+"/"+objInfo.VersionID] = true
+	}
+	// Add a few delete markers on top of "c".
+	for i := 0; i < 3; i++ {
+		objInfo, err := obj.DeleteObject(context.Background(), bucket, "c", opts)
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err.Error())
+		}
+		expected["c/"+objInfo.VersionID] = true
+	}
+
+	for _, maxKeys := range []int{1, 2, 3, 1000} {
+		var marker, versionMarker string
+		seen := make(map[string]bool)
+		for page := 0; ; page++ {
+			if page > len(expected)+1 {
+				t.Fatalf("%s: maxKeys %d: listing does not terminate", instanceType, maxKeys)
+			}
+			result, err := obj.ListObjectVersions(context.Background(), bucket, "", marker, versionMarker, "", maxKeys)
+			if err != nil {
+				t.Fatalf("%s: maxKeys %d: %s", instanceType, maxKeys, err.Error())
+			}
+			if len(result.Objects) > maxKeys {
+				t.Fatalf("%s: maxKeys %d: got %d versions", instanceType, maxKeys, len(result.Objects))
+			}
+			for _, objInfo := range result.Objects {
+				key := objInfo.Name + "/" + objInfo.VersionID
+				if seen[key] {
+					t.Errorf("%s: maxKeys %d: version %s listed twice", instanceType, maxKeys, key)
+				}
+				seen[key] = true
+			}
+			if !result.IsTruncated {
+				break
+			}
+			marker, versionMarker = result.NextMarker, result.NextVersionIDMarker
+		}
+		if len(seen) != len(expected) {
+			t.Errorf("%s: maxKeys %d: expected %d versions, got %d", instanceType, maxKeys, len(expected), len(seen))
+		}
+		for key := range expected {
+			if !seen[key] {
+				t.Errorf("%s: maxKeys %d: version %s was not listed", instanceType, maxKeys, key)
+			}
+		}
+	}
+}
+
 // Initialize FS backend for the benchmark.
 func initFSObjectsB(disk string, t *testing.B) (obj ObjectLayer) {
 	var err error
@@ -23,11 +23,14 @@ import (
 	"encoding/hex"
 	"errors"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/hash"
 )
 
@@ -313,6 +316,83 @@ func testObjectAPIPutObjectDiskNotFound(obj ObjectLayer, instanceType string, di
 }
 
 // Wrapper for calling PutObject tests for both Erasure multiple disks and single node setup.
+// Tests validate the conditional writes of PutObject and CompleteMultipartUpload.
+func TestObjectAPIPutObjectPreconditions(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectAPIPutObjectPreconditions)
+}
+
+func testObjectAPIPutObjectPreconditions(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "minio-bucket"
+	object := "minio-object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	data := []byte("hello")
+	precondOpts := func(header, value string) ObjectOptions {
+		r := httptest.NewRequest(http.MethodPut, "/"+bucket+"/"+object, nil)
+		r.Header.Set(header, value)
+		return ObjectOptions{CheckPrecondFn: getPutObjectPrecondFn(r)}
+	}
+	putObject := func(opts ObjectOptions) (ObjectInfo, error) {
+		return obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+	}
+
+	// The object doesn't exist yet.
+	if _, err := putObject(precondOpts(xhttp.IfMatch, "*")); !isErrPreconditionFailed(err) {
+		t.Fatalf("%s : expected precondition failure, got %v", instanceType, err)
+	}
+	oi, err := putObject(precondOpts(xhttp.IfNoneMatch, "*"))
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	// The current ETag of the object is used where the value is empty.
+	testCases := []struct {
+		header   string
+		value    string
+		expected error
+	}{
+		{xhttp.IfNoneMatch, "*", PreConditionFailed{}},
+		{xhttp.IfNoneMatch, "", PreConditionFailed{}},
+		{xhttp.IfNoneMatch, "ce74d846aa2fa5b24b01a8a0cdbbda5e", nil},
+		{xhttp.IfMatch, "ce74d846aa2fa5b24b01a8a0cdbbda5e", PreConditionFailed{}},
+		{xhttp.IfMatch, "", nil},
+		{xhttp.IfMatch, "*", nil},
+	}
+	for i, testCase := range testCases {
+		value := testCase.value
+		if value == "" {
+			value = `"` + oi.ETag + `"`
+		}
+		noi, err := putObject(precondOpts(testCase.header, value))
+		if err != testCase.expected {
+			t.Errorf("%s : Test %d: expected %v, got %v", instanceType, i+1, testCase.expected, err)
+		}
+		if err == nil {
+			oi = noi
+		}
+	}
+
+	// Complete a multipart upload only if the object wasn't modified meanwhile.
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	pi, err := obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	parts := []CompletePart{{PartNumber: pi.PartNumber, ETag: pi.ETag}}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, precondOpts(xhttp.IfNoneMatch, "*")); !isErrPreconditionFailed(err) {
+		t.Fatalf("%s : expected precondition failure, got %v", instanceType, err)
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, precondOpts(xhttp.IfMatch, oi.ETag)); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+}
+
 func TestObjectAPIPutObjectStaleFiles(t *testing.T) {
 	ExecObjectLayerStaleFilesTest(t, testObjectAPIPutObjectStaleFiles)
 }
@@ -25,6 +25,7 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"os"
 	"path"
 	"runtime"
 	"strconv"
@@ -410,6 +411,28 @@ func (o ObjectInfo) GetActualETag(h http.Header) string {
 	return getDecryptedETag(h, o, false)
 }
 
+// GetActualPartETag - returns the actual etag of the given part of
+// the stored object, decrypts SSE objects. An empty string is returned
+// if no etag is stored for the part, e.g. objects uploaded in a single
+// part or before part etags were recorded.
+func (o ObjectInfo) GetActualPartETag(h http.Header, partNumber int) string {
+	if partNumber < 1 || partNumber > len(o.Parts) {
+		return ""
+	}
+	etag := o.Parts[partNumber-1].ETag
+	if etag == "" || !crypto.IsEncrypted(o.UserDefined) {
+		return etag
+	}
+	if crypto.SSEC.IsEncrypted(o.UserDefined) {
+		return tryDecryptETag(nil, etag, true)
+	}
+	objectEncryptionKey, err := decryptObjectInfo(nil, o.Bucket, o.Name, o.UserDefined)
+	if err != nil {
+		return etag
+	}
+	return tryDecryptETag(objectEncryptionKey, etag, false)
+}
+
 // GetActualSize - returns the actual size of the stored object
 func (o ObjectInfo) GetActualSize() (int64, error) {
 	if o.IsCompressed() {
@@ -516,6 +539,81 @@ func partNumberToRangeSpec(oi ObjectInfo, partNumber int) *HTTPRangeSpec {
 	return &HTTPRangeSpec{Start: start, End: end}
 }
 
+// checkPartNumber returns InvalidPartNumber if the object has no part
+// with the given part number, objects uploaded in a single part only
+// have part number 1.
+func checkPartNumber(oi ObjectInfo, partNumber int) error {
+	if partNumber > 1 && partNumber > len(oi.Parts) {
+		return InvalidPartNumber{PartNumber: partNumber}
+	}
+	return nil
+}
+
+// checkWritePreconditions evaluates opts.CheckPrecondFn of a conditional
+// write against the latest version of the object returned by objInfoFn.
+// The caller must hold the object lock such that the check and the
+// following write are atomic.
+func checkWritePreconditions(opts ObjectOptions, objInfoFn func() (ObjectInfo, error)) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := objInfoFn()
+	if err != nil {
+		switch err.(type) {
+		case ObjectNotFound, VersionNotFound, MethodNotAllowed:
+			// The object doesn't exist or the latest version is a delete marker.
+			oi = ObjectInfo{}
+		default:
+			return err
+		}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
+// checkAppendObject verifies that data may be appended to the object
+// described by oi at the given offset. Only plain objects can be
+// appended to, since encrypted and compressed objects carry per-part
+// state that cannot be extended, and the offset must match the
+// current size of the object. Every append adds a part, so objects
+// stop accepting appends at the multipart upload part limit.
+func checkAppendObject(oi ObjectInfo, offset int64) error {
+	if oi.IsDir || oi.DeleteMarker || crypto.IsEncrypted(oi.UserDefined) ||
+		oi.IsCompressed() || oi.TransitionStatus == lifecycle.TransitionComplete {
+		return ObjectNotAppendable{Bucket: oi.Bucket, Object: oi.Name}
+	}
+	if len(oi.Parts) >= globalMaxPartID {
+		return ObjectNotAppendable{Bucket: oi.Bucket, Object: oi.Name}
+	}
+	if offset != oi.Size {
+		return InvalidAppendOffset{
+			Bucket: oi.Bucket,
+			Object: oi.Name,
+			Offset: offset,
+			Size:   oi.Size,
+		}
+	}
+	return nil
+}
+
+// getAppendObjectETag returns the ETag of an object after appending a
+// part with the MD5 sum partMD5 at position parts, the ETag is chained
+// from the previous ETag of the object and carries the part count as
+// suffix similar to multipart ETags.
+func getAppendObjectETag(etag, partMD5 string, parts int) string {
+	var md5Bytes []byte
+	for _, s := range []string{canonicalizeETag(etag), partMD5} {
+		b, err := hex.DecodeString(strings.SplitN(s, "-", 2)[0])
+		if err != nil {
+			b = []byte(s)
+		}
+		md5Bytes = append(md5Bytes, b...)
+	}
+	return fmt.Sprintf("%s-%d", getMD5Hash(md5Bytes), parts)
+}
+
 // Returns the compressed offset which should be skipped.
 // If encrypted offsets are adjusted for encrypted block headers/trailers.
 // Since de-compression is after decryption encryption overhead is only added to compressedOffset.
@@ -585,10 +683,6 @@ type ObjReaderFn func(inputReader io.Reader, h http.Header, pcfn CheckPreconditi
 func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cleanUpFns ...func()) (
 	fn ObjReaderFn, off, length int64, err error) {
 
-	if rs == nil && opts.PartNumber > 0 {
-		rs = partNumberToRangeSpec(oi, opts.PartNumber)
-	}
-
 	// Call the clean-up functions immediately in case of exit
 	// with error
 	defer func() {
@@ -599,6 +693,13 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 		}
 	}()
 
+	if rs == nil && opts.PartNumber > 0 {
+		if err = checkPartNumber(oi, opts.PartNumber); err != nil {
+			return nil, 0, 0, err
+		}
+		rs = partNumberToRangeSpec(oi, opts.PartNumber)
+	}
+
 	isEncrypted := crypto.IsEncrypted(oi.UserDefined)
 	isCompressed, err := oi.IsCompressedOK()
 	if err != nil {
@@ -794,6 +895,30 @@ func (g *GetObjectReader) Close() error {
 	return nil
 }
 
+// WriteTo - to implement WriterTo interface. Unencrypted and
+// uncompressed objects read from a local file are handed over to
+// w as is, allowing the response writer to copy the object with
+// sendfile instead of going through user space.
+func (g *GetObjectReader) WriteTo(w io.Writer) (n int64, err error) {
+	// Calling code may not Close() in case of error, so
+	// we ensure it.
+	defer g.Close()
+
+	if rf, ok := w.(io.ReaderFrom); ok && isFileReader(g.pReader) {
+		return rf.ReadFrom(g.pReader)
+	}
+	return io.Copy(struct{ io.Writer }{w}, g.pReader)
+}
+
+// isFileReader returns true if r reads straight from an *os.File.
+func isFileReader(r io.Reader) bool {
+	if lr, ok := r.(*io.LimitedReader); ok {
+		r = lr.R
+	}
+	_, ok := r.(*os.File)
+	return ok
+}
+
 // Read - to implement Reader interface.
 func (g *GetObjectReader) Read(p []byte) (n int, err error) {
 	n, err = g.pReader.Read(p)
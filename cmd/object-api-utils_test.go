@@ -20,7 +20,9 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"reflect"
 	"strconv"
 	"testing"
@@ -513,6 +515,59 @@ func TestGetActualSize(t *testing.T) {
 	}
 }
 
+func TestCheckPartNumber(t *testing.T) {
+	multipart := ObjectInfo{Parts: []ObjectPartInfo{{Number: 1}, {Number: 2}, {Number: 3}}}
+	testCases := []struct {
+		objInfo    ObjectInfo
+		partNumber int
+		success    bool
+	}{
+		{ObjectInfo{}, 1, true},
+		{ObjectInfo{}, 2, false},
+		{ObjectInfo{Parts: []ObjectPartInfo{{Number: 1}}}, 1, true},
+		{multipart, 1, true},
+		{multipart, 3, true},
+		{multipart, 4, false},
+	}
+	for i, test := range testCases {
+		err := checkPartNumber(test.objInfo, test.partNumber)
+		if test.success && err != nil {
+			t.Errorf("Test %d - unexpected error: %v", i+1, err)
+		}
+		if !test.success {
+			if _, ok := err.(InvalidPartNumber); !ok {
+				t.Errorf("Test %d - expected InvalidPartNumber, got %v", i+1, err)
+			}
+		}
+	}
+}
+
+func TestCheckAppendObject(t *testing.T) {
+	parts := func(n int) []ObjectPartInfo {
+		p := make([]ObjectPartInfo, n)
+		for i := range p {
+			p[i] = ObjectPartInfo{Number: i + 1, Size: 1}
+		}
+		return p
+	}
+	testCases := []struct {
+		objInfo ObjectInfo
+		offset  int64
+		err     error
+	}{
+		{ObjectInfo{Size: 5, Parts: parts(5)}, 5, nil},
+		{ObjectInfo{Size: 5, Parts: parts(5)}, 4, InvalidAppendOffset{Offset: 4, Size: 5}},
+		{ObjectInfo{Size: globalMaxPartID - 1, Parts: parts(globalMaxPartID - 1)}, globalMaxPartID - 1, nil},
+		{ObjectInfo{Size: globalMaxPartID, Parts: parts(globalMaxPartID)}, globalMaxPartID, ObjectNotAppendable{}},
+		{ObjectInfo{DeleteMarker: true}, 0, ObjectNotAppendable{}},
+	}
+	for i, test := range testCases {
+		if err := checkAppendObject(test.objInfo, test.offset); err != test.err {
+			t.Errorf("Test %d - expected %v, got %v", i+1, test.err, err)
+		}
+	}
+}
+
 func TestGetCompressedOffsets(t *testing.T) {
 	testCases := []struct {
 		objInfo           ObjectInfo
@@ -640,3 +695,59 @@ func TestS2CompressReader(t *testing.T) {
 		})
 	}
 }
+
+// readFromRecorder records the readers handed to ReadFrom.
+type readFromRecorder struct {
+	bytes.Buffer
+	readFromCalls int
+}
+
+func (r *readFromRecorder) ReadFrom(src io.Reader) (int64, error) {
+	r.readFromCalls++
+	return r.Buffer.ReadFrom(src)
+}
+
+func TestGetObjectReaderWriteTo(t *testing.T) {
+	f, err := ioutil.TempFile("", "minio-sendfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err = f.WriteString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		reader   io.Reader
+		readFrom bool
+		data     string
+	}{
+		{io.LimitReader(f, 5), true, "world"},
+		{bytes.NewReader([]byte("hello")), false, "hello"},
+	}
+	for i, testCase := range testCases {
+		var closed bool
+		gr := &GetObjectReader{
+			pReader:    testCase.reader,
+			cleanUpFns: []func(){func() { closed = true }},
+		}
+		var w readFromRecorder
+		if _, err = io.Copy(&w, gr); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if (w.readFromCalls > 0) != testCase.readFrom {
+			t.Errorf("Test %d: expected ReadFrom to be used %v, got %v", i+1, testCase.readFrom, w.readFromCalls > 0)
+		}
+		if w.String() != testCase.data {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.data, w.String())
+		}
+		if !closed {
+			t.Errorf("Test %d: expected reader to be closed", i+1)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// checksumType is the algorithm of an additional object
+// checksum, as sent in the 'x-amz-checksum-*' trailers.
+type checksumType string
+
+// List of all supported additional checksum algorithms.
+const (
+	checksumCRC32  checksumType = "CRC32"
+	checksumCRC32C checksumType = "CRC32C"
+	checksumSHA1   checksumType = "SHA1"
+	checksumSHA256 checksumType = "SHA256"
+)
+
+// supportedChecksumTypes maps the checksum trailer name to its type.
+var supportedChecksumTypes = map[string]checksumType{
+	xhttp.AmzChecksumCRC32:  checksumCRC32,
+	xhttp.AmzChecksumCRC32C: checksumCRC32C,
+	xhttp.AmzChecksumSHA1:   checksumSHA1,
+	xhttp.AmzChecksumSHA256: checksumSHA256,
+}
+
+// checksumTypeFromTrailer returns the checksum type named by
+// the 'x-amz-trailer' header value, if supported.
+func checksumTypeFromTrailer(trailer string) (checksumType, bool) {
+	c, ok := supportedChecksumTypes[http.CanonicalHeaderKey(strings.TrimSpace(trailer))]
+	return c, ok
+}
+
+// Header returns the header name carrying this checksum.
+func (c checksumType) Header() string {
+	for header, ct := range supportedChecksumTypes {
+		if ct == c {
+			return header
+		}
+	}
+	return ""
+}
+
+// Hasher returns a new hash.Hash computing this checksum.
+func (c checksumType) Hasher() hash.Hash {
+	switch c {
+	case checksumCRC32:
+		return crc32.NewIEEE()
+	case checksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case checksumSHA1:
+		return sha1.New()
+	case checksumSHA256:
+		return sha256.New()
+	}
+	return nil
+}
+
+// metadataKey returns the internal metadata key under which the
+// verified checksum is saved along with the object.
+func (c checksumType) metadataKey() string {
+	return ReservedMetadataPrefix + "checksum-" + strings.ToLower(string(c))
+}
+
+// encodeChecksum returns the base64 encoded checksum, as sent by clients.
+func encodeChecksum(sum []byte) string {
+	return base64.StdEncoding.EncodeToString(sum)
+}
+
+// getObjectChecksum returns the additional checksum saved
+// in the object metadata, if any.
+func getObjectChecksum(userDefined map[string]string) (checksumType, string, bool) {
+	for _, c := range []checksumType{checksumCRC32, checksumCRC32C, checksumSHA1, checksumSHA256} {
+		if v, ok := userDefined[c.metadataKey()]; ok {
+			return c, v, true
+		}
+	}
+	return "", "", false
+}
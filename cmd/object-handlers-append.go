@@ -0,0 +1,228 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// AppendObjectHandler - PUT Object?append
+// ----------
+// This MinIO extension appends the request body to the end of an
+// existing object, the X-Minio-Append-Offset header must match the
+// current size of the object. Appending at offset zero to an object
+// which doesn't exist creates it. Appends are only supported for
+// objects which are neither encrypted nor compressed, on buckets
+// without versioning and default encryption.
+func (api objectAPIHandlers) AppendObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AppendObject")
+
+	defer logger.AuditLog(ctx, w, r, "AppendObject", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if (globalIsGateway && globalGatewayName != NASBackendGateway) || api.CacheAPI() != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Appending encrypted content is not supported.
+	if _, ok := crypto.IsRequested(r.Header); ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := url.PathUnescape(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// X-Amz-Copy-Source shouldn't be set for this call.
+	if _, ok := r.Header[xhttp.AmzCopySource]; ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidCopySource), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if HasSuffix(object, SlashSeparator) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrObjectNotAppendable), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get(xhttp.MinIOAppendOffset), 10, 64)
+	if err != nil || offset < 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// get Content-Md5 sent by client and verify if valid
+	md5Bytes, err := checkValidMD5(r.Header)
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidDigest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	/// if Content-Length is unknown/missing, deny the request
+	size := r.ContentLength
+	rAuthType := getRequestAuthType(r)
+	if rAuthType == authTypeStreamingSigned {
+		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
+			if sizeStr[0] == "" {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			size, err = strconv.ParseInt(sizeStr[0], 10, 64)
+			if err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+	}
+	if size == -1 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	/// maximum Upload size for objects in a single operation
+	if isMaxObjectSize(size) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	metadata, err := extractMetadata(ctx, r)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	var (
+		md5hex    = hex.EncodeToString(md5Bytes)
+		sha256hex = ""
+		reader    io.Reader
+		s3Err     APIErrorCode
+	)
+	reader = r.Body
+
+	// Check if put is allowed
+	if s3Err = isPutActionAllowed(ctx, rAuthType, bucket, object, r, iampolicy.PutObjectAction); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	switch rAuthType {
+	case authTypeStreamingSigned:
+		// Initialize stream signature verifier.
+		reader, s3Err = newSignV4ChunkedReader(r, metadata)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	case authTypeSignedV2, authTypePresignedV2:
+		if s3Err = isReqAuthenticatedV2(r); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	case authTypePresigned, authTypeSigned:
+		if s3Err = reqSignatureV4Verify(r, globalServerRegion, serviceS3); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if !skipContentSha256Cksum(r) {
+			sha256hex = getContentSha256Cksum(r, serviceS3)
+		}
+	}
+
+	// Appends modify the object in place, which is not possible
+	// for immutable versions and objects encrypted by default. Once
+	// versioning is suspended the latest version may still be one of
+	// the immutable versions.
+	if globalBucketVersioningSys.Enabled(bucket) || globalBucketVersioningSys.Suspended(bucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrObjectNotAppendable), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if _, err = globalBucketSSEConfigSys.Get(bucket); globalAutoEncryption || err == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrObjectNotAppendable), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = enforceBucketQuota(ctx, bucket, size); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	pReader := NewPutObjReader(hashReader, nil, nil)
+
+	objInfo, err := objectAPI.AppendObject(ctx, bucket, object, pReader, offset, ObjectOptions{})
+	if isErrObjectNotFound(err) && offset == 0 {
+		// Appending at offset zero creates the object, unless it was
+		// created concurrently in the meantime.
+		opts := ObjectOptions{
+			UserDefined: metadata,
+			CheckPrecondFn: func(oi ObjectInfo) bool {
+				return oi.Name != ""
+			},
+		}
+		objInfo, err = objectAPI.PutObject(ctx, bucket, object, pReader, opts)
+		if isErrPreconditionFailed(err) {
+			err = InvalidAppendOffset{Bucket: bucket, Object: object, Offset: offset}
+		}
+	}
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setPutObjHeaders(w, objInfo, false)
+	w.Header().Set(xhttp.MinIOAppendOffset, strconv.FormatInt(objInfo.Size, 10))
+
+	writeSuccessResponseHeadersOnly(w)
+
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectCreatedPut,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
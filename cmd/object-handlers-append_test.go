@@ -0,0 +1,139 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/versioning"
+)
+
+func getAppendObjectURL(endPoint, bucketName, objectName string) string {
+	queryValues := url.Values{}
+	queryValues.Set("append", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValues)
+}
+
+func TestAPIAppendObjectHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIAppendObjectHandler, []string{"AppendObject"})
+}
+
+func testAPIAppendObjectHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "logs/app.log"
+
+	testCases := []struct {
+		data   []byte
+		offset string
+
+		expectedStatus int
+		expectedOffset string
+	}{
+		// Appending at offset zero creates the object.
+		{[]byte("hello "), "0", http.StatusOK, "6"},
+		{[]byte("world"), "6", http.StatusOK, "11"},
+		{bytes.Repeat([]byte("x"), 1024), "11", http.StatusOK, "1035"},
+		// Offset doesn't match the object size.
+		{[]byte("stale"), "6", http.StatusConflict, ""},
+		{[]byte("stale"), "0", http.StatusConflict, ""},
+		// Missing or malformed offset.
+		{[]byte("bad"), "", http.StatusBadRequest, ""},
+		{[]byte("bad"), "-1", http.StatusBadRequest, ""},
+	}
+	for i, testCase := range testCases {
+		headers := map[string]string{}
+		if testCase.offset != "" {
+			headers[xhttp.MinIOAppendOffset] = testCase.offset
+		}
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getAppendObjectURL("", bucketName, objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: failed to create request: %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Fatalf("Test %d: %s: expected status %d, got %d: %s", i+1, instanceType, testCase.expectedStatus, rec.Code, rec.Body.String())
+		}
+		if offset := rec.Header().Get(xhttp.MinIOAppendOffset); offset != testCase.expectedOffset {
+			t.Errorf("Test %d: %s: expected offset %q, got %q", i+1, instanceType, testCase.expectedOffset, offset)
+		}
+	}
+
+	expected := append([]byte("hello world"), bytes.Repeat([]byte("x"), 1024)...)
+	var buf bytes.Buffer
+	if err := obj.GetObject(context.Background(), bucketName, objectName, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: failed to read appended object: %v", instanceType, err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("%s: appended object has unexpected content %q", instanceType, buf.String())
+	}
+
+	// Ranged reads spanning the appended parts.
+	buf.Reset()
+	if err := obj.GetObject(context.Background(), bucketName, objectName, 3, 10, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: failed to read range of appended object: %v", instanceType, err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected[3:13]) {
+		t.Errorf("%s: expected range %q, got %q", instanceType, expected[3:13], buf.String())
+	}
+
+	oi, err := obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if oi.Size != int64(len(expected)) {
+		t.Errorf("%s: expected size %d, got %d", instanceType, len(expected), oi.Size)
+	}
+	if !HasSuffix(oi.ETag, "-3") {
+		t.Errorf("%s: expected ETag with 3 parts, got %s", instanceType, oi.ETag)
+	}
+
+	// Appends are rejected once versioning was enabled, even when it
+	// is suspended again.
+	for _, status := range []versioning.State{versioning.Enabled, versioning.Suspended} {
+		meta, err := globalBucketMetadataSys.GetConfig(bucketName)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		meta.versioningConfig = &versioning.Versioning{Status: status}
+		globalBucketMetadataSys.Set(bucketName, meta)
+
+		data := []byte("versioned")
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getAppendObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+			map[string]string{xhttp.MinIOAppendOffset: strconv.Itoa(len(expected))})
+		if err != nil {
+			t.Fatalf("%s: failed to create request: %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: versioning %s: expected status %d, got %d", instanceType, status, http.StatusBadRequest, rec.Code)
+		}
+	}
+	globalBucketMetadataSys.Set(bucketName, newBucketMetadata(bucketName))
+}
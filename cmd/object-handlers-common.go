@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	"github.com/minio/minio/pkg/event"
@@ -222,6 +223,48 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return false
 }
 
+// Validates the conditional write preconditions of PutObject and
+// CompleteMultipartUpload against the current object, objInfo is empty
+// when the object doesn't exist. Returns true if the write must be
+// rejected with 412 (precondition failed).
+func checkPutObjectPreconditions(r *http.Request, objInfo ObjectInfo) bool {
+	exists := objInfo.Name != ""
+	etag := objInfo.ETag
+	if exists && crypto.IsEncrypted(objInfo.UserDefined) {
+		etag = getDecryptedETag(r.Header, objInfo, false)
+	}
+
+	// If-Match : Write the object only if its entity tag (ETag) is the same as the
+	// one specified, '*' matches any existing object; otherwise return a 412.
+	ifMatchETagHeader := r.Header.Get(xhttp.IfMatch)
+	if ifMatchETagHeader != "" {
+		if !exists || (ifMatchETagHeader != "*" && !isETagEqual(etag, ifMatchETagHeader)) {
+			return true
+		}
+	}
+
+	// If-None-Match : Write the object only if its entity tag (ETag) is different from
+	// the one specified, '*' only allows creating a new object; otherwise return a 412.
+	ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch)
+	if ifNoneMatchETagHeader != "" && exists {
+		if ifNoneMatchETagHeader == "*" || isETagEqual(etag, ifNoneMatchETagHeader) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPutObjectPrecondFn returns the precondition function of a conditional
+// write, nil if the request has no If-Match or If-None-Match header.
+func getPutObjectPrecondFn(r *http.Request) CheckPreconditionFn {
+	if r.Header.Get(xhttp.IfMatch) == "" && r.Header.Get(xhttp.IfNoneMatch) == "" {
+		return nil
+	}
+	return func(oi ObjectInfo) bool {
+		return checkPutObjectPreconditions(r, oi)
+	}
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
@@ -252,6 +295,11 @@ func setPutObjHeaders(w http.ResponseWriter, objInfo ObjectInfo, delete bool) {
 		w.Header()[xhttp.ETag] = []string{`"` + objInfo.ETag + `"`}
 	}
 
+	// Echo the verified trailing checksum, if any.
+	if c, checksum, ok := getObjectChecksum(objInfo.UserDefined); ok && !delete {
+		w.Header().Set(c.Header(), checksum)
+	}
+
 	// Set the relevant version ID as part of the response header.
 	if objInfo.VersionID != "" {
 		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
@@ -266,6 +314,7 @@ func setPutObjHeaders(w http.ResponseWriter, objInfo ObjectInfo, delete bool) {
 			ruleID, expiryTime := lc.PredictExpiryTime(lifecycle.ObjectOpts{
 				Name:         objInfo.Name,
 				UserTags:     objInfo.UserTags,
+				Size:         lifecycleObjectSize(objInfo),
 				VersionID:    objInfo.VersionID,
 				ModTime:      objInfo.ModTime,
 				IsLatest:     objInfo.IsLatest,
@@ -0,0 +1,352 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// Zip archives are buffered in memory since their central
+// directory is at the end, tar archives are streamed.
+const maxExtractZipSize = 256 << 20
+
+// Supported archive formats for extraction.
+const (
+	extractFormatTar = iota + 1
+	extractFormatTarGz
+	extractFormatZip
+)
+
+// extractFormat returns the archive format of object, zero if the
+// object cannot be extracted.
+func extractFormat(object string) int {
+	name := strings.ToLower(object)
+	switch {
+	case strings.HasSuffix(name, ".tar"):
+		return extractFormatTar
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return extractFormatTarGz
+	case strings.HasSuffix(name, ".zip"):
+		return extractFormatZip
+	}
+	return 0
+}
+
+// extractObjectName returns the name of the object an archive
+// entry is extracted to, empty if the entry must be skipped.
+// Entries cannot escape prefix, such as with '../' elements.
+func extractObjectName(prefix, name string) string {
+	name = strings.TrimPrefix(path.Clean(SlashSeparator+name), SlashSeparator)
+	if name == "" {
+		return ""
+	}
+	return pathJoin(prefix, name)
+}
+
+// archiveExtractor stores the entries of an uploaded archive as
+// individual objects, with the metadata, encryption and compression
+// the request asks for.
+type archiveExtractor struct {
+	api       objectAPIHandlers
+	objectAPI ObjectLayer
+	r         *http.Request
+	bucket    string
+	prefix    string
+	metadata  map[string]string
+
+	extracted []ObjectInfo
+}
+
+// put stores the entry read from reader as object, the request
+// must be allowed to write object like for a regular upload of it.
+func (e *archiveExtractor) put(ctx context.Context, object string, reader io.Reader, size int64) APIError {
+	r := e.r
+	atype := getRequestAuthType(r)
+	if s3Err := isPutActionAllowed(ctx, atype, e.bucket, object, r, iampolicy.PutObjectAction); s3Err != ErrNone {
+		return errorCodes.ToAPIErr(s3Err)
+	}
+	retPerms := isPutActionAllowed(ctx, atype, e.bucket, object, r, iampolicy.PutObjectRetentionAction)
+	holdPerms := isPutActionAllowed(ctx, atype, e.bucket, object, r, iampolicy.PutObjectLegalHoldAction)
+
+	metadata := make(map[string]string, len(e.metadata))
+	for k, v := range e.metadata {
+		metadata[k] = v
+	}
+	// The content type of each object is guessed from its name.
+	delete(metadata, "content-type")
+
+	actualSize := size
+	if e.objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+		// Storing the compression metadata.
+		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
+
+		actualReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			return toAPIError(ctx, err)
+		}
+
+		s2c := newS2CompressReader(actualReader)
+		defer s2c.Close()
+		reader = s2c
+		size = -1 // Since compressed size is un-predictable.
+	}
+
+	hashReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return toAPIError(ctx, err)
+	}
+	rawReader := hashReader
+	pReader := NewPutObjReader(rawReader, nil, nil)
+
+	opts, err := putOpts(ctx, r, e.bucket, object, metadata)
+	if err != nil {
+		return toAPIError(ctx, err)
+	}
+
+	getObjectInfo := e.objectAPI.GetObjectInfo
+	putObject := e.objectAPI.PutObject
+	if e.api.CacheAPI() != nil {
+		getObjectInfo = e.api.CacheAPI().GetObjectInfo
+		putObject = e.api.CacheAPI().PutObject
+	}
+
+	retentionMode, retentionDate, legalHold, s3Err := checkPutObjectLockAllowed(ctx, r, e.bucket, object, getObjectInfo, retPerms, holdPerms)
+	if s3Err != ErrNone {
+		return errorCodes.ToAPIErr(s3Err)
+	}
+	if retentionMode.Valid() {
+		metadata[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
+		metadata[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
+	}
+	if legalHold.Status.Valid() {
+		metadata[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = string(legalHold.Status)
+	}
+	replicate := mustReplicate(ctx, r, e.bucket, object, metadata, "")
+	if replicate {
+		metadata[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	}
+
+	if e.objectAPI.IsEncryptionSupported() {
+		if _, ok := crypto.IsRequested(r.Header); ok {
+			reader, objectEncryptionKey, err := EncryptRequest(hashReader, r, e.bucket, object, metadata)
+			if err != nil {
+				return toAPIError(ctx, err)
+			}
+
+			wantSize := int64(-1)
+			if size >= 0 {
+				info := ObjectInfo{Size: size}
+				wantSize = info.EncryptedSize()
+			}
+
+			// do not try to verify encrypted content
+			hashReader, err = hash.NewReader(reader, wantSize, "", "", actualSize, globalCLIContext.StrictS3Compat)
+			if err != nil {
+				return toAPIError(ctx, err)
+			}
+			pReader = NewPutObjReader(rawReader, hashReader, &objectEncryptionKey)
+		}
+	}
+
+	// Ensure that metadata does not contain sensitive information
+	crypto.RemoveSensitiveEntries(metadata)
+
+	objInfo, err := putObject(ctx, e.bucket, object, pReader, opts)
+	if err != nil {
+		return toAPIError(ctx, err)
+	}
+	e.extracted = append(e.extracted, objInfo)
+
+	if replicate {
+		globalReplicationState.queueReplicaTask(objInfo)
+	}
+
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: e.bucket,
+		Object:     objInfo,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       handlers.GetSourceIP(r),
+	})
+	return noError
+}
+
+// extractTar stores all the regular files of the tar archive read from reader.
+func (e *archiveExtractor) extractTar(ctx context.Context, reader io.Reader) (readErr error, apiErr APIError) {
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, noError
+		}
+		if err != nil {
+			return err, noError
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		object := extractObjectName(e.prefix, hdr.Name)
+		if object == "" {
+			continue
+		}
+		if apiErr = e.put(ctx, object, tr, hdr.Size); apiErr != noError {
+			return nil, apiErr
+		}
+	}
+}
+
+// extractZip stores all the files of the zip archive data.
+func (e *archiveExtractor) extractZip(ctx context.Context, data []byte) (readErr error, apiErr APIError) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err, noError
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		object := extractObjectName(e.prefix, f.Name)
+		if object == "" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err, noError
+		}
+		apiErr = e.put(ctx, object, rc, int64(f.UncompressedSize64))
+		rc.Close()
+		if apiErr != noError {
+			return nil, apiErr
+		}
+	}
+	return nil, noError
+}
+
+// rollback removes the objects extracted so far.
+func (e *archiveExtractor) rollback(ctx context.Context) {
+	for _, objInfo := range e.extracted {
+		_, err := e.objectAPI.DeleteObject(ctx, e.bucket, objInfo.Name, ObjectOptions{VersionID: objInfo.VersionID})
+		logger.LogIf(ctx, err)
+	}
+}
+
+// putObjectExtract - PUT Object with X-Minio-Extract: true
+// ----------
+// The uploaded tar or zip archive is expanded server-side, each file
+// of the archive is stored as an object under the prefix of object,
+// preserving the paths in the archive. The archive itself is not
+// stored. If the archive is malformed or its checksum does not match,
+// the objects extracted so far are removed.
+func (api objectAPIHandlers) putObjectExtract(ctx context.Context, w http.ResponseWriter, r *http.Request, bucket, object string,
+	reader io.Reader, size int64, md5hex, sha256hex string, metadata map[string]string) {
+	objectAPI := api.ObjectAPI()
+
+	format := extractFormat(object)
+	if format == 0 || HasSuffix(object, SlashSeparator) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrExtractArchiveUnsupported), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if format == extractFormatZip && size > maxExtractZipSize {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrExtractArchiveTooLarge), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	prefix := path.Dir(object)
+	if prefix == "." {
+		prefix = ""
+	}
+	e := &archiveExtractor{
+		api:       api,
+		objectAPI: objectAPI,
+		r:         r,
+		bucket:    bucket,
+		prefix:    prefix,
+		metadata:  metadata,
+	}
+
+	var readErr error
+	apiErr := noError
+	switch format {
+	case extractFormatTar:
+		readErr, apiErr = e.extractTar(ctx, hashReader)
+	case extractFormatTarGz:
+		var gr *gzip.Reader
+		if gr, readErr = gzip.NewReader(hashReader); readErr == nil {
+			readErr, apiErr = e.extractTar(ctx, gr)
+			gr.Close()
+		}
+	case extractFormatZip:
+		var data []byte
+		if data, readErr = ioutil.ReadAll(hashReader); readErr == nil {
+			readErr, apiErr = e.extractZip(ctx, data)
+		}
+	}
+	if readErr == nil && apiErr == noError {
+		// Read the remainder of the archive, if any,
+		// which verifies the checksum of the upload.
+		_, readErr = io.Copy(ioutil.Discard, hashReader)
+	}
+
+	if readErr != nil || apiErr != noError {
+		e.rollback(ctx)
+		if readErr != nil {
+			switch readErr.(type) {
+			case hash.BadDigest, hash.SHA256Mismatch, hash.ErrSizeMismatch:
+				apiErr = toAPIError(ctx, readErr)
+			default:
+				if readErr == io.ErrUnexpectedEOF {
+					apiErr = errorCodes.ToAPIErr(ErrIncompleteBody)
+				} else {
+					apiErr = errorCodes.ToAPIErr(ErrExtractArchiveMalformed)
+				}
+			}
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	w.Header().Set(xhttp.MinIOExtractedObjects, strconv.Itoa(len(e.extracted)))
+	writeSuccessResponseHeadersOnly(w)
+}
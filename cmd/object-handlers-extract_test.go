@@ -0,0 +1,177 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestExtractObjectName(t *testing.T) {
+	testCases := []struct {
+		prefix, name string
+		expected     string
+	}{
+		{"", "a.txt", "a.txt"},
+		{"data", "dir/a.txt", "data/dir/a.txt"},
+		{"data", "./dir//a.txt", "data/dir/a.txt"},
+		{"data", "../../etc/passwd", "data/etc/passwd"},
+		{"data", "/abs/a.txt", "data/abs/a.txt"},
+		{"data", "..", ""},
+	}
+	for i, testCase := range testCases {
+		if name := extractObjectName(testCase.prefix, testCase.name); name != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, name)
+		}
+	}
+}
+
+func TestAPIPutObjectExtractHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectExtractHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectExtractHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	files := map[string][]byte{
+		"a.txt":       []byte("hello"),
+		"dir/b.txt":   []byte("world"),
+		"dir/c/d.csv": bytes.Repeat([]byte("x"), 1024),
+	}
+
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755})
+	for name, data := range files {
+		tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(data))})
+		tw.Write(data)
+	}
+	tw.Close()
+
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for name, data := range files {
+		fw, _ := zw.Create(name)
+		fw.Write(data)
+	}
+	zw.Close()
+
+	testCases := []struct {
+		objectName string
+		data       []byte
+		prefix     string
+
+		expectedStatus int
+	}{
+		{"ingest/batch.tar", tarBuf.Bytes(), "ingest/", http.StatusOK},
+		{"ingest2/batch.zip", zipBuf.Bytes(), "ingest2/", http.StatusOK},
+		{"batch.tar", tarBuf.Bytes(), "", http.StatusOK},
+		{"ingest3/batch.rar", tarBuf.Bytes(), "", http.StatusBadRequest},
+		{"ingest4/batch.zip", []byte("not a zip archive"), "", http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey,
+			map[string]string{xhttp.MinIOExtract: "true"})
+		if err != nil {
+			t.Fatalf("Test %d: %s: failed to create request: %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Fatalf("Test %d: %s: expected status %d, got %d: %s", i+1, instanceType, testCase.expectedStatus, rec.Code, rec.Body.String())
+		}
+		if _, err = obj.GetObjectInfo(context.Background(), bucketName, testCase.objectName, ObjectOptions{}); err == nil {
+			t.Errorf("Test %d: %s: the archive itself must not be stored", i+1, instanceType)
+		}
+		if testCase.expectedStatus != http.StatusOK {
+			continue
+		}
+		if n := rec.Header().Get(xhttp.MinIOExtractedObjects); n != "3" {
+			t.Errorf("Test %d: %s: expected 3 extracted objects, got %s", i+1, instanceType, n)
+		}
+		for name, data := range files {
+			var buf bytes.Buffer
+			if err = obj.GetObject(context.Background(), bucketName, testCase.prefix+name, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+				t.Fatalf("Test %d: %s: object %s not extracted: %v", i+1, instanceType, testCase.prefix+name, err)
+			}
+			if !bytes.Equal(buf.Bytes(), data) {
+				t.Errorf("Test %d: %s: object %s has unexpected content", i+1, instanceType, testCase.prefix+name)
+			}
+		}
+	}
+
+	// A checksum mismatch removes the objects extracted so far.
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, "ingest5/batch.tar"),
+		int64(tarBuf.Len()), bytes.NewReader(tarBuf.Bytes()), credentials.AccessKey, credentials.SecretKey,
+		map[string]string{xhttp.MinIOExtract: "true", xhttp.ContentMD5: "1B2M2Y8AsgTpgAmY7PhCfg=="})
+	if err != nil {
+		t.Fatalf("%s: failed to create request: %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: expected status %d, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if _, err = obj.GetObjectInfo(context.Background(), bucketName, "ingest5/a.txt", ObjectOptions{}); err == nil {
+		t.Errorf("%s: extracted objects must be removed on checksum mismatch", instanceType)
+	}
+
+	// Each extracted object must be allowed to be written, being
+	// allowed to write the archive only is not enough.
+	defer globalBucketMetadataSys.Update(bucketName, bucketPolicyConfig, nil)
+	for _, testCase := range []struct {
+		resource       string
+		expectedStatus int
+	}{
+		{"ingest6/batch.tar", http.StatusForbidden},
+		{"ingest6/*", http.StatusOK},
+	} {
+		policyData, err := json.Marshal(getAnonWriteOnlyObjectPolicy(bucketName, testCase.resource))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = globalBucketMetadataSys.Update(bucketName, bucketPolicyConfig, policyData); err != nil {
+			t.Fatalf("%s: failed to set bucket policy: %v", instanceType, err)
+		}
+
+		rec = httptest.NewRecorder()
+		req, err = newTestRequest(http.MethodPut, getPutObjectURL("", bucketName, "ingest6/batch.tar"),
+			int64(tarBuf.Len()), bytes.NewReader(tarBuf.Bytes()))
+		if err != nil {
+			t.Fatalf("%s: failed to create request: %v", instanceType, err)
+		}
+		req.Header.Set(xhttp.MinIOExtract, "true")
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Fatalf("%s: %s: expected status %d, got %d: %s", instanceType, testCase.resource, testCase.expectedStatus, rec.Code, rec.Body.String())
+		}
+		_, err = obj.GetObjectInfo(context.Background(), bucketName, "ingest6/a.txt", ObjectOptions{})
+		if extracted := err == nil; extracted != (testCase.expectedStatus == http.StatusOK) {
+			t.Errorf("%s: %s: unexpected extraction, got error %v", instanceType, testCase.resource, err)
+		}
+	}
+}
@@ -55,6 +55,32 @@ const (
 	MissingUploadID
 )
 
+// Tests validation of the response header overrides of GET and HEAD requests.
+func TestValidateHeadGetRespParams(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected APIErrorCode
+	}{
+		// No overrides requested.
+		{"", ErrNone},
+		{"versionId=null", ErrNone},
+		// Overrides on anonymous requests are not allowed.
+		{"response-content-type=text%2Fplain", ErrAnonymousResponseHeaders},
+		// Overrides on presigned requests.
+		{"X-Amz-Credential=cred&response-content-disposition=attachment%3B%20filename%3D%22a.txt%22", ErrNone},
+		{"X-Amz-Credential=cred&response-cache-control=no-cache&response-content-type=text%2Fhtml", ErrNone},
+		// Overrides with control characters.
+		{"X-Amz-Credential=cred&response-content-disposition=attachment%0D%0ASet-Cookie%3A%20a", ErrInvalidResponseHeaderValue},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost:9000/bucket/object?"+testCase.query, nil)
+		if got := validateHeadGetRespParams(req); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
 // Wrapper for calling HeadObject API handler tests for both Erasure multiple disks and FS single drive setup.
 func TestAPIHeadObjectHandler(t *testing.T) {
 	ExecObjectLayerAPITest(t, testAPIHeadObjectHandler, []string{"HeadObject"})
@@ -943,6 +969,15 @@ func testAPIGetObjectWithPartNumberHandler(obj ObjectLayer, instanceType, bucket
 			t.Fatalf("Object: %s Object Index %d: Unexpected err: %v", object, oindex, err)
 		}
 
+		// Multipart objects report the ETag of the requested part.
+		if len(oi.partLengths) > 1 && len(oi.metaData) == 0 {
+			partETag := oinfo.Parts[partNumber-1].ETag
+			if etag := rec.Header()[xhttp.ETag]; partETag == "" || len(etag) != 1 || etag[0] != "\""+partETag+"\"" {
+				t.Fatalf("(%s) Object: %s ObjectIndex %d PartNumber: %d: expected part ETag %q, got %q",
+					instanceType, object, oindex, partNumber, partETag, etag)
+			}
+		}
+
 		rs := partNumberToRangeSpec(oinfo, partNumber)
 		off, length, err := rs.GetOffsetLength(oinfo.Size)
 		if err != nil {
@@ -966,6 +1001,24 @@ func testAPIGetObjectWithPartNumberHandler(obj ObjectLayer, instanceType, bucket
 			mkGetReqWithPartNumber(idx, oi, partNum)
 		}
 	}
+
+	// Part numbers beyond the last part are not satisfiable.
+	for idx, oi := range objectInputs {
+		rec := httptest.NewRecorder()
+		queries := url.Values{}
+		queries.Add("partNumber", strconv.Itoa(len(oi.partLengths)+1))
+		targetURL := makeTestTargetURL("", bucketName, oi.objectName, queries)
+		req, err := newTestSignedRequestV4(http.MethodGet, targetURL,
+			0, nil, credentials.AccessKey, credentials.SecretKey, oi.metaData)
+		if err != nil {
+			t.Fatalf("Object: %s Object Index %d: Failed to create HTTP request for Get Object: <ERROR> %v", oi.objectName, idx, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusRequestedRangeNotSatisfiable {
+			t.Fatalf("(%s) Object: %s ObjectIndex %d: expected response status `%d`, got `%d`",
+				instanceType, oi.objectName, idx, http.StatusRequestedRangeNotSatisfiable, rec.Code)
+		}
+	}
 }
 
 // Wrapper for calling PutObject API handler tests using streaming signature v4 for both Erasure multiple disks and FS single drive setup.
@@ -0,0 +1,169 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/minio/minio/cmd/config/tracing"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/otlp"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/propagators"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// tracerName is the instrumentation name of all spans of the server.
+const tracerName = "github.com/minio/minio"
+
+// Labels set on the spans of S3 API calls and object layer operations.
+const (
+	bucketLabel = label.Key("s3.bucket")
+	objectLabel = label.Key("s3.object")
+)
+
+// globalTracerProcessor is set when tracing is enabled,
+// its pending spans are exported when the server exits.
+var (
+	globalTracingEnabled  int32
+	globalTracerProcessor *sdktrace.BatchSpanProcessor
+)
+
+// tracingErrorHandler logs the errors of the span exporter.
+type tracingErrorHandler struct{}
+
+func (tracingErrorHandler) Handle(err error) {
+	logger.LogOnceIf(GlobalContext, err, "tracing-export")
+}
+
+// initTracing registers the OpenTelemetry tracer provider
+// exporting the spans of this server to the OTLP collector.
+func initTracing(cfg tracing.Config) {
+	if !cfg.Enabled || atomic.LoadInt32(&globalTracingEnabled) == 1 {
+		return
+	}
+
+	processor := sdktrace.NewBatchSpanProcessor(
+		otlp.NewExporter(cfg.Endpoint, cfg.AuthToken, NewGatewayHTTPTransport()))
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithConfig(sdktrace.Config{
+			DefaultSampler: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio)),
+		}),
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.New(
+			semconv.ServiceNameKey.String("minio"),
+			semconv.ServiceVersionKey.String(Version),
+			semconv.ServiceInstanceIDKey.String(GetLocalPeer(globalEndpoints)),
+		)),
+	)
+
+	global.SetErrorHandler(tracingErrorHandler{})
+	global.SetTextMapPropagator(propagators.TraceContext{})
+	global.SetTracerProvider(provider)
+	globalTracerProcessor = processor
+	atomic.StoreInt32(&globalTracingEnabled, 1)
+}
+
+// shutdownTracing exports all pending spans.
+func shutdownTracing() {
+	if atomic.LoadInt32(&globalTracingEnabled) == 1 {
+		globalTracerProcessor.Shutdown()
+	}
+}
+
+// startSpan starts a span named name as the child of the span in ctx,
+// the span is not recorded unless tracing is enabled.
+func startSpan(ctx context.Context, name string, attrs ...label.KeyValue) (context.Context, trace.Span) {
+	return global.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// startObjectSpan starts the span of an object layer operation.
+func startObjectSpan(ctx context.Context, name, bucket, object string) (context.Context, trace.Span) {
+	attrs := []label.KeyValue{bucketLabel.String(bucket)}
+	if object != "" {
+		attrs = append(attrs, objectLabel.String(object))
+	}
+	return startSpan(ctx, "ObjectLayer."+name, attrs...)
+}
+
+// endSpan ends span, recording err if not nil.
+func endSpan(ctx context.Context, span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// setRequestSpanAPI names the server span of an S3 or admin
+// request after its API and labels it with the resource.
+func setRequestSpanAPI(ctx context.Context, api, bucket, object string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetName(api)
+	if bucket != "" {
+		span.SetAttributes(bucketLabel.String(bucket))
+	}
+	if object != "" {
+		span.SetAttributes(objectLabel.String(object))
+	}
+}
+
+// setTracingHandler starts a server span for each incoming request,
+// continuing the trace of the client or of the calling peer when the
+// request holds a W3C trace context. S3 API spans are renamed after
+// the API name by newContext().
+func setTracingHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&globalTracingEnabled) == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := global.TextMapPropagator().Extract(r.Context(), xhttp.HeaderCarrier(r.Header))
+
+		name := "HTTP " + r.Method
+		if strings.HasPrefix(r.URL.Path, minioReservedBucketPath+SlashSeparator) {
+			// Inter-node and admin calls are named after their path.
+			name = r.URL.Path
+		}
+		ctx, span := global.Tracer(tracerName).Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(globalMinioHost, "", r)...),
+		)
+		defer span.End()
+
+		rw := logger.NewResponseWriter(w)
+		h.ServeHTTP(rw, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(rw.StatusCode))
+		if rw.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rw.StatusCode))
+		}
+	})
+}
@@ -101,6 +101,18 @@ func (client *peerRESTClient) GetLocks() (lockMap map[string][]lockRequesterInfo
 	return lockMap, err
 }
 
+// GetLockWaiters - fetch lock requests waiting on a remote node.
+func (client *peerRESTClient) GetLockWaiters() (lockMap map[string][]lockRequesterInfo, err error) {
+	respBody, err := client.call(peerRESTMethodGetLockWaiters, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	lockMap = map[string][]lockRequesterInfo{}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&lockMap)
+	return lockMap, err
+}
+
 // ServerInfo - fetch server information for a remote node.
 func (client *peerRESTClient) ServerInfo() (info madmin.ServerProperties, err error) {
 	respBody, err := client.call(peerRESTMethodServerInfo, nil, nil, -1)
@@ -112,6 +124,17 @@ func (client *peerRESTClient) ServerInfo() (info madmin.ServerProperties, err er
 	return info, err
 }
 
+// RequestsPoolStats - fetch the requests pool occupancy of the peer.
+func (client *peerRESTClient) RequestsPoolStats() (stats madmin.RequestsPoolStats, err error) {
+	respBody, err := client.call(peerRESTMethodRequestsPoolStats, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&stats)
+	return stats, err
+}
+
 type networkOverloadedErr struct{}
 
 var networkOverloaded networkOverloadedErr
@@ -367,6 +390,17 @@ func (client *peerRESTClient) CPUInfo(ctx context.Context) (info madmin.ServerCP
 	return info, err
 }
 
+// ErasureBenchmark - runs an erasure coding benchmark on a remote node.
+func (client *peerRESTClient) ErasureBenchmark(ctx context.Context, p erasureBenchmarkParams) (result madmin.ErasureBenchmarkResult, err error) {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodErasureBenchmark, p.toValues(), nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&result)
+	return result, err
+}
+
 // DiskHwInfo - fetch Disk HW information for a remote node.
 func (client *peerRESTClient) DiskHwInfo(ctx context.Context) (info madmin.ServerDiskHwInfo, err error) {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodDiskHwInfo, nil, nil, -1)
@@ -584,6 +618,16 @@ func (client *peerRESTClient) LoadGroup(group string) error {
 	return nil
 }
 
+// LoadIAM - send reload all IAM entities command to peers.
+func (client *peerRESTClient) LoadIAM() error {
+	respBody, err := client.call(peerRESTMethodLoadIAM, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 type serverUpdateInfo struct {
 	URL       *url.URL
 	Sha256Sum []byte
@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v11"
+	peerRESTVersion       = "v12"
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -33,6 +33,7 @@ const (
 	peerRESTMethodOsInfo                 = "/osinfo"
 	peerRESTMethodMemInfo                = "/meminfo"
 	peerRESTMethodProcInfo               = "/procinfo"
+	peerRESTMethodErasureBenchmark       = "/erasurebenchmark"
 	peerRESTMethodDispatchNetInfo        = "/dispatchnetinfo"
 	peerRESTMethodDeleteBucketMetadata   = "/deletebucketmetadata"
 	peerRESTMethodLoadBucketMetadata     = "/loadbucketmetadata"
@@ -40,6 +41,7 @@ const (
 	peerRESTMethodSignalService          = "/signalservice"
 	peerRESTMethodBackgroundHealStatus   = "/backgroundhealstatus"
 	peerRESTMethodGetLocks               = "/getlocks"
+	peerRESTMethodGetLockWaiters         = "/getlockwaiters"
 	peerRESTMethodLoadUser               = "/loaduser"
 	peerRESTMethodLoadServiceAccount     = "/loadserviceaccount"
 	peerRESTMethodDeleteUser             = "/deleteuser"
@@ -48,6 +50,7 @@ const (
 	peerRESTMethodLoadPolicyMapping      = "/loadpolicymapping"
 	peerRESTMethodDeletePolicy           = "/deletepolicy"
 	peerRESTMethodLoadGroup              = "/loadgroup"
+	peerRESTMethodLoadIAM                = "/loadiam"
 	peerRESTMethodStartProfiling         = "/startprofiling"
 	peerRESTMethodDownloadProfilingData  = "/downloadprofilingdata"
 	peerRESTMethodCycleBloom             = "/cyclebloom"
@@ -58,6 +61,7 @@ const (
 	peerRESTMethodGetBandwidth           = "/bandwidth"
 	peerRESTMethodGetMetacacheListing    = "/getmetacache"
 	peerRESTMethodUpdateMetacacheListing = "/updatemetacache"
+	peerRESTMethodRequestsPoolStats      = "/requestspoolstats"
 )
 
 const (
@@ -45,6 +45,16 @@ var startsWithConds = map[string]bool{
 	"$x-amz-date":              false,
 }
 
+// postPolicyRequiredConds - form fields which affect the stored object or
+// the response sent to the browser, these must be explicitly allowed by a
+// policy condition whenever they are present in the form.
+var postPolicyRequiredConds = map[string]string{
+	"Key":                     "$key",
+	"Content-Type":            "$content-type",
+	"Success_action_redirect": "$success_action_redirect",
+	"Redirect":                "$redirect",
+}
+
 // Add policy conditionals.
 const (
 	policyCondEqual         = "eq"
@@ -185,6 +195,10 @@ func parsePostPolicyForm(policy string) (ppf PostPolicyForm, e error) {
 					return parsedPolicy, err
 				}
 
+				if min < 0 || max < min {
+					return parsedPolicy, fmt.Errorf("Invalid content-length-range [%d, %d] found in POST policy form", min, max)
+				}
+
 				parsedPolicy.Conditions.ContentLengthRange = contentLengthRange{
 					Min:   min,
 					Max:   max,
@@ -215,6 +229,21 @@ func checkPolicyCond(op string, input1, input2 string) bool {
 	return false
 }
 
+// checkContentTypeCond returns a boolean to indicate if a condition on
+// Content-Type is satisfied, a comma separated Content-Type must satisfy
+// a starts-with condition with each of its values.
+func checkContentTypeCond(op string, contentType, value string) bool {
+	if op != policyCondStartsWith {
+		return checkPolicyCond(op, contentType, value)
+	}
+	for _, v := range strings.Split(contentType, ",") {
+		if !checkPolicyCond(op, strings.TrimSpace(v), value) {
+			return false
+		}
+	}
+	return true
+}
+
 // checkPostPolicy - apply policy conditions and validate input values.
 // (http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-HTTPPOSTConstructPolicy.html)
 func checkPostPolicy(formValues http.Header, postPolicyForm PostPolicyForm) error {
@@ -224,11 +253,24 @@ func checkPostPolicy(formValues http.Header, postPolicyForm PostPolicyForm) erro
 	}
 	// map to store the metadata
 	metaMap := make(map[string]string)
+	// map to store all the policy condition keys
+	condMap := make(map[string]struct{})
 	for _, policy := range postPolicyForm.Conditions.Policies {
 		if strings.HasPrefix(policy.Key, "$x-amz-meta-") {
 			formCanonicalName := http.CanonicalHeaderKey(strings.TrimPrefix(policy.Key, "$"))
 			metaMap[formCanonicalName] = policy.Value
 		}
+		condMap[policy.Key] = struct{}{}
+	}
+	// Check if any form field affecting the object or the response
+	// is passed as input without a matching policy condition
+	for formName, condKey := range postPolicyRequiredConds {
+		if _, ok := formValues[formName]; !ok {
+			continue
+		}
+		if _, ok := condMap[condKey]; !ok {
+			return fmt.Errorf("Invalid according to Policy: Extra input fields: %s", strings.ToLower(formName))
+		}
 	}
 	// Check if any extra metadata field is passed as input
 	for key := range formValues {
@@ -256,7 +298,11 @@ func checkPostPolicy(formValues http.Header, postPolicyForm PostPolicyForm) erro
 				return fmt.Errorf("Invalid according to Policy: Policy Condition failed")
 			}
 			// Check if current policy condition is satisfied
-			condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), policy.Value)
+			if policy.Key == "$content-type" {
+				condPassed = checkContentTypeCond(op, formValues.Get(formCanonicalName), policy.Value)
+			} else {
+				condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), policy.Value)
+			}
 			if !condPassed {
 				return fmt.Errorf("Invalid according to Policy: Policy Condition failed")
 			}
@@ -105,3 +105,75 @@ func TestPostPolicyForm(t *testing.T) {
 		}
 	}
 }
+
+// Test that form fields affecting the object require a policy condition.
+func TestPostPolicyFormRequiredConds(t *testing.T) {
+	policyTemplate := `{"expiration": "%s","conditions":[["eq", "$bucket", "testbucket"], %s]}`
+	expiration := UTCNow().AddDate(0, 0, 10).Format(iso8601TimeFormat)
+
+	testCases := []struct {
+		conditions  string
+		formValues  map[string]string
+		expectedErr bool
+	}{
+		// Key with matching condition.
+		{`["starts-with", "$key", "user/"]`, map[string]string{"Key": "user/file.txt"}, false},
+		// Key without any condition.
+		{`["eq", "$x-amz-algorithm", "AWS4-HMAC-SHA256"]`, map[string]string{"Key": "user/file.txt"}, true},
+		// Content-Type without any condition.
+		{`["starts-with", "$key", "user/"]`, map[string]string{"Key": "user/file.txt", "Content-Type": "text/html"}, true},
+		// Content-Type with starts-with condition.
+		{`["starts-with", "$key", "user/"], ["starts-with", "$content-type", "image/"]`, map[string]string{"Key": "user/file.txt", "Content-Type": "image/jpeg"}, false},
+		// Comma separated Content-Type, all values must satisfy starts-with.
+		{`["starts-with", "$key", "user/"], ["starts-with", "$content-type", "image/"]`, map[string]string{"Key": "user/file.txt", "Content-Type": "image/jpeg, image/png"}, false},
+		{`["starts-with", "$key", "user/"], ["starts-with", "$content-type", "image/"]`, map[string]string{"Key": "user/file.txt", "Content-Type": "image/jpeg, text/html"}, true},
+		// success_action_redirect without any condition.
+		{`["starts-with", "$key", "user/"]`, map[string]string{"Key": "user/file.txt", "Success_action_redirect": "http://evil.com"}, true},
+		// success_action_redirect with starts-with condition.
+		{`["starts-with", "$key", "user/"], ["starts-with", "$success_action_redirect", "https://example.com/"]`, map[string]string{"Key": "user/file.txt", "Success_action_redirect": "https://example.com/done"}, false},
+		{`["starts-with", "$key", "user/"], ["starts-with", "$success_action_redirect", "https://example.com/"]`, map[string]string{"Key": "user/file.txt", "Success_action_redirect": "https://evil.com/done"}, true},
+		// Deprecated redirect without any condition.
+		{`["starts-with", "$key", "user/"]`, map[string]string{"Key": "user/file.txt", "Redirect": "http://evil.com"}, true},
+	}
+
+	for i, tt := range testCases {
+		postPolicyForm, err := parsePostPolicyForm(fmt.Sprintf(policyTemplate, expiration, tt.conditions))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		formValues := make(http.Header)
+		formValues.Set("Bucket", "testbucket")
+		for k, v := range tt.formValues {
+			formValues.Set(k, v)
+		}
+		err = checkPostPolicy(formValues, postPolicyForm)
+		if tt.expectedErr != (err != nil) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, tt.expectedErr, err)
+		}
+	}
+}
+
+// Test parsing of invalid content-length-range conditions.
+func TestPostPolicyFormContentLengthRange(t *testing.T) {
+	policyTemplate := `{"expiration": "%s","conditions":[["content-length-range", %s]]}`
+	expiration := UTCNow().AddDate(0, 0, 10).Format(iso8601TimeFormat)
+
+	testCases := []struct {
+		lengthRange string
+		expectedErr bool
+	}{
+		{`0, 1024`, false},
+		{`1024, 1024`, false},
+		{`"10", "1024"`, false},
+		{`1024, 10`, true},
+		{`-1, 10`, true},
+		{`"a", 10`, true},
+	}
+
+	for i, tt := range testCases {
+		_, err := parsePostPolicyForm(fmt.Sprintf(policyTemplate, expiration, tt.lengthRange))
+		if tt.expectedErr != (err != nil) {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, tt.expectedErr, err)
+		}
+	}
+}
@@ -25,14 +25,22 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	xnet "github.com/minio/minio/pkg/net"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/semconv"
 )
 
+// tracerName is the instrumentation name of the spans of REST calls.
+const tracerName = "github.com/minio/minio/cmd/rest"
+
 // DefaultTimeout - default REST timeout is 10 seconds.
 const DefaultTimeout = 10 * time.Second
 
@@ -42,6 +50,73 @@ const (
 	closed
 )
 
+// hostDownInterval is the duration for which all the clients of
+// a remote host fail fast, once one of them found it offline.
+const hostDownInterval = 5 * time.Second
+
+// remoteHost is shared by all the clients of a remote host, it is a
+// circuit breaker so that requests to a node which is down fail fast
+// instead of each client waiting for its own network timeouts, and
+// it keeps the round-trip statistics of the calls to the host.
+type remoteHost struct {
+	downUntil int64 // Unix time in nanoseconds until which the host is considered down.
+
+	calls     uint64 // Number of calls which received a response.
+	errors    uint64 // Number of calls which failed without a response.
+	roundTrip int64  // Total round-trip time of calls which received a response, in nanoseconds.
+}
+
+func (h *remoteHost) isDown() bool {
+	return time.Now().UnixNano() < atomic.LoadInt64(&h.downUntil)
+}
+
+func (h *remoteHost) markDown() {
+	atomic.StoreInt64(&h.downUntil, time.Now().Add(hostDownInterval).UnixNano())
+}
+
+func (h *remoteHost) markUp() {
+	atomic.StoreInt64(&h.downUntil, 0)
+}
+
+// RPCStats are the round-trip statistics of the calls to a remote host.
+type RPCStats struct {
+	Calls     uint64
+	Errors    uint64
+	RoundTrip time.Duration
+}
+
+// GetRPCStats returns the round-trip statistics of all remote hosts.
+func GetRPCStats() map[string]RPCStats {
+	remoteHostsMu.Lock()
+	defer remoteHostsMu.Unlock()
+	stats := make(map[string]RPCStats, len(remoteHosts))
+	for host, h := range remoteHosts {
+		stats[host] = RPCStats{
+			Calls:     atomic.LoadUint64(&h.calls),
+			Errors:    atomic.LoadUint64(&h.errors),
+			RoundTrip: time.Duration(atomic.LoadInt64(&h.roundTrip)),
+		}
+	}
+	return stats
+}
+
+var (
+	remoteHostsMu sync.Mutex
+	remoteHosts   = make(map[string]*remoteHost)
+)
+
+// getRemoteHost returns the shared state of the given host.
+func getRemoteHost(host string) *remoteHost {
+	remoteHostsMu.Lock()
+	defer remoteHostsMu.Unlock()
+	h, ok := remoteHosts[host]
+	if !ok {
+		h = &remoteHost{}
+		remoteHosts[host] = h
+	}
+	return h
+}
+
 // NetworkError - error type in case of errors related to http/transport
 // for ex. connection refused, connection reset, dns resolution failure etc.
 // All errors returned by storage-rest-server (ex errFileNotFound, errDiskNotFound) are not considered to be network errors.
@@ -81,10 +156,18 @@ type Client struct {
 	// This will not mark the client offline in these cases.
 	ExpectTimeouts bool
 
+	// MaxExpectedTimeouts is the number of consecutive expected
+	// timeouts after which the client is marked offline anyways,
+	// since the remote is most likely hung. Other clients of the
+	// remote host are not affected. Zero disables it.
+	MaxExpectedTimeouts int32
+
 	httpClient   *http.Client
 	url          *url.URL
 	newAuthToken func(audience string) string
 	connected    int32
+	timeouts     int32 // Consecutive expected timeouts.
+	host         *remoteHost
 }
 
 // URL query separator constants
@@ -107,25 +190,53 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	if !c.IsOnline() {
 		return nil, &NetworkError{Err: &url.Error{Op: method, URL: c.url.String(), Err: restError("remote server offline")}}
 	}
+	ctx, span := global.Tracer(tracerName).Start(ctx, c.url.Path+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.NetPeerNameKey.String(c.url.Host)))
+	defer func() {
+		if err != nil {
+			span.RecordError(ctx, err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url.String()+method+querySep+values.Encode(), body)
 	if err != nil {
 		return nil, &NetworkError{err}
 	}
+	// Propagate the trace context to the peer.
+	global.TextMapPropagator().Inject(ctx, xhttp.HeaderCarrier(req.Header))
 	req.Header.Set("Authorization", "Bearer "+c.newAuthToken(req.URL.RawQuery))
 	req.Header.Set("X-Minio-Time", time.Now().UTC().Format(time.RFC3339))
 	if length > 0 {
 		req.ContentLength = length
 	}
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		if c.HealthCheckFn != nil && xnet.IsNetworkOrHostDown(err, c.ExpectTimeouts) {
-			if c.MarkOffline() {
-				logger.LogIf(ctx, fmt.Errorf("Marking %s temporary offline; caused by %w", c.url.String(), err))
+		atomic.AddUint64(&c.host.errors, 1)
+		if c.HealthCheckFn != nil {
+			hostDown := xnet.IsNetworkOrHostDown(err, c.ExpectTimeouts)
+			if hostDown {
+				// Fail fast on all the clients of the host.
+				c.host.markDown()
+			}
+			if hostDown || c.tooManyTimeouts(err) {
+				if c.MarkOffline() {
+					logger.LogIf(ctx, fmt.Errorf("Marking %s temporary offline; caused by %w", c.url.String(), err))
+				}
 			}
 		}
 		return nil, &NetworkError{err}
 	}
 
+	atomic.AddUint64(&c.host.calls, 1)
+	atomic.AddInt64(&c.host.roundTrip, int64(time.Since(start)))
+
+	// Remote host responded, it is alive.
+	atomic.StoreInt32(&c.timeouts, 0)
+	c.host.markUp()
+
 	final := resp.Trailer.Get("FinalStatus")
 	if final != "" && final != "Success" {
 		defer xhttp.DrainBody(resp.Body)
@@ -165,6 +276,17 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	return resp.Body, nil
 }
 
+// tooManyTimeouts returns true if err is an expected timeout which
+// followed too many consecutive others. These only take the client
+// offline, not the other clients of the host, since timeouts are
+// expected by the client, e.g. under lock contention.
+func (c *Client) tooManyTimeouts(err error) bool {
+	if c.ExpectTimeouts && c.MaxExpectedTimeouts > 0 && errors.Is(err, context.DeadlineExceeded) {
+		return atomic.AddInt32(&c.timeouts, 1) >= c.MaxExpectedTimeouts
+	}
+	return false
+}
+
 // Close closes all idle connections of the underlying http client
 func (c *Client) Close() {
 	atomic.StoreInt32(&c.connected, closed)
@@ -179,14 +301,21 @@ func NewClient(url *url.URL, tr http.RoundTripper, newAuthToken func(aud string)
 		url:                 url,
 		newAuthToken:        newAuthToken,
 		connected:           online,
+		host:                getRemoteHost(url.Host),
 		MaxErrResponseSize:  4096,
+		MaxExpectedTimeouts: 3,
 		HealthCheckInterval: 200 * time.Millisecond,
 		HealthCheckTimeout:  time.Second,
 	}
 }
 
-// IsOnline returns whether the client is likely to be online.
+// IsOnline returns whether the client is likely to be online,
+// clients which keep track of health are also offline while
+// their remote host was recently found down by another client.
 func (c *Client) IsOnline() bool {
+	if c.HealthCheckFn != nil && c.host.isDown() {
+		return false
+	}
 	return atomic.LoadInt32(&c.connected) == online
 }
 
@@ -204,6 +333,7 @@ func (c *Client) MarkOffline() bool {
 					return
 				}
 				if c.HealthCheckFn() {
+					atomic.StoreInt32(&c.timeouts, 0)
 					if atomic.CompareAndSwapInt32(&c.connected, offline, online) {
 						logger.Info("Client %s online", c.url.String())
 					}
@@ -17,10 +17,14 @@
 package rest
 
 import (
+	"context"
 	"errors"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestNetworkError_Unwrap(t *testing.T) {
@@ -66,3 +70,77 @@ func TestNetworkError_Unwrap(t *testing.T) {
 		})
 	}
 }
+
+func TestClientHostHealth(t *testing.T) {
+	u1, _ := url.Parse("http://host-health:9000/path1")
+	u2, _ := url.Parse("http://host-health:9000/path2")
+	c1 := NewClient(u1, http.DefaultTransport, func(string) string { return "" })
+	c2 := NewClient(u2, http.DefaultTransport, func(string) string { return "" })
+	c1.HealthCheckFn = func() bool { return false }
+	c2.HealthCheckFn = func() bool { return true }
+	defer c1.Close()
+
+	if !c2.IsOnline() {
+		t.Fatal("expected client to be online")
+	}
+
+	// A client marking the host down takes all its clients offline.
+	c1.host.markDown()
+	if c2.IsOnline() {
+		t.Fatal("expected client to be offline while its host is down")
+	}
+	if _, err := c2.Call(context.Background(), "/method", nil, nil, -1); err == nil {
+		t.Fatal("expected call to fail fast while the host is down")
+	} else if !errors.As(err, new(*NetworkError)) {
+		t.Fatalf("expected network error, got %v", err)
+	}
+
+	c1.host.markUp()
+	if !c2.IsOnline() {
+		t.Fatal("expected client to be online once its host is up")
+	}
+}
+
+func TestClientMaxExpectedTimeouts(t *testing.T) {
+	hung := make(chan struct{})
+	defer close(hung)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hung:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	u1, _ := url.Parse(server.URL + "/lock")
+	u2, _ := url.Parse(server.URL + "/storage")
+	c1 := NewClient(u1, http.DefaultTransport, func(string) string { return "" })
+	c2 := NewClient(u2, http.DefaultTransport, func(string) string { return "" })
+	c1.ExpectTimeouts = true
+	c1.HealthCheckFn = func() bool { return false }
+	c2.HealthCheckFn = func() bool { return true }
+	defer c1.Close()
+
+	call := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := c1.Call(ctx, "/method", nil, nil, -1); err == nil {
+			t.Fatal("expected call to time out")
+		}
+	}
+	for i := int32(1); i < c1.MaxExpectedTimeouts; i++ {
+		call()
+		if !c1.IsOnline() {
+			t.Fatalf("expected timeout %d not to take the client offline", i)
+		}
+	}
+	call()
+	if c1.IsOnline() {
+		t.Fatal("expected consecutive timeouts to take the client offline")
+	}
+
+	// Expected timeouts do not take the other clients of the host offline.
+	if !c2.IsOnline() {
+		t.Fatal("expected other clients of the host to stay online")
+	}
+}
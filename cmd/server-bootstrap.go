@@ -0,0 +1,413 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/minio/minio/cmd/config/notify"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/event"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+	"gopkg.in/yaml.v2"
+)
+
+// bootstrapConfigVersion is the only supported version of the
+// declarative bootstrap config.
+const bootstrapConfigVersion = "1"
+
+// bootstrapConfig - declarative config of the buckets, policies,
+// users and groups, and notification targets created at startup.
+type bootstrapConfig struct {
+	Version  string            `yaml:"version"`
+	Config   []string          `yaml:"config"`
+	Policies []bootstrapPolicy `yaml:"policies"`
+	Users    []bootstrapUser   `yaml:"users"`
+	Groups   []bootstrapGroup  `yaml:"groups"`
+	Buckets  []bootstrapBucket `yaml:"buckets"`
+}
+
+// bootstrapPolicy - a canned IAM policy, in JSON.
+type bootstrapPolicy struct {
+	Name   string `yaml:"name"`
+	Policy string `yaml:"policy"`
+
+	policy *iampolicy.Policy
+}
+
+// bootstrapUser - a user and its comma separated policies.
+type bootstrapUser struct {
+	AccessKey string `yaml:"accessKey"`
+	SecretKey string `yaml:"secretKey"`
+	Policy    string `yaml:"policy"`
+}
+
+// bootstrapGroup - a group, its members and its comma separated policies.
+type bootstrapGroup struct {
+	Name    string   `yaml:"name"`
+	Members []string `yaml:"members"`
+	Policy  string   `yaml:"policy"`
+}
+
+// bootstrapBucket - a bucket and its configurations, unset
+// configurations are left unchanged on existing buckets.
+type bootstrapBucket struct {
+	Name          string                  `yaml:"name"`
+	ObjectLock    bool                    `yaml:"objectLock"`
+	Versioning    *bool                   `yaml:"versioning"`
+	Policy        string                  `yaml:"policy"`
+	Quota         *bootstrapQuota         `yaml:"quota"`
+	Notifications []bootstrapNotification `yaml:"notifications"`
+}
+
+// bootstrapQuota - a bucket quota of size bytes.
+type bootstrapQuota struct {
+	Size uint64           `yaml:"size"`
+	Type madmin.QuotaType `yaml:"type"`
+}
+
+// bootstrapNotification - a bucket notification sent to the
+// target arn for events on objects matching prefix and suffix.
+type bootstrapNotification struct {
+	ARN    string   `yaml:"arn"`
+	Events []string `yaml:"events"`
+	Prefix string   `yaml:"prefix"`
+	Suffix string   `yaml:"suffix"`
+}
+
+// loadBootstrapConfig reads and validates the bootstrap config at
+// configFile, configs which can only be validated against the running
+// server, such as the notification target ARNs, are validated when
+// applied.
+func loadBootstrapConfig(configFile string) (*bootstrapConfig, error) {
+	data, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	return parseBootstrapConfig(data)
+}
+
+func parseBootstrapConfig(data []byte) (*bootstrapConfig, error) {
+	cfg := &bootstrapConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Version != bootstrapConfigVersion {
+		return nil, fmt.Errorf("unsupported bootstrap config version '%s', expected '%s'", cfg.Version, bootstrapConfigVersion)
+	}
+
+	for i, p := range cfg.Policies {
+		if p.Name == "" || strings.Contains(p.Name, ",") {
+			return nil, fmt.Errorf("invalid policy name '%s'", p.Name)
+		}
+		iamPolicy, err := iampolicy.ParseConfig(strings.NewReader(p.Policy))
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy '%s': %w", p.Name, err)
+		}
+		if iamPolicy.Version == "" {
+			return nil, fmt.Errorf("invalid policy '%s': missing version", p.Name)
+		}
+		cfg.Policies[i].policy = iamPolicy
+	}
+
+	for _, u := range cfg.Users {
+		if !auth.IsAccessKeyValid(u.AccessKey) {
+			return nil, fmt.Errorf("invalid access key '%s'", u.AccessKey)
+		}
+		if !auth.IsSecretKeyValid(u.SecretKey) {
+			return nil, fmt.Errorf("invalid secret key of user '%s'", u.AccessKey)
+		}
+	}
+
+	for _, g := range cfg.Groups {
+		if g.Name == "" {
+			return nil, errors.New("missing group name")
+		}
+	}
+
+	for _, b := range cfg.Buckets {
+		if !IsValidBucketName(b.Name) || isMinioMetaBucketName(b.Name) {
+			return nil, fmt.Errorf("invalid bucket name '%s'", b.Name)
+		}
+		if b.Policy != "" {
+			if _, err := policy.ParseConfig(strings.NewReader(b.Policy), b.Name); err != nil {
+				return nil, fmt.Errorf("invalid policy of bucket '%s': %w", b.Name, err)
+			}
+		}
+		if b.Quota != nil {
+			quota := madmin.BucketQuota{Quota: b.Quota.Size, Type: b.Quota.Type}
+			if !quota.IsValid() {
+				return nil, fmt.Errorf("invalid quota of bucket '%s'", b.Name)
+			}
+		}
+		for _, n := range b.Notifications {
+			for _, name := range n.Events {
+				if _, err := event.ParseName(name); err != nil {
+					return nil, fmt.Errorf("invalid notification of bucket '%s': %w", b.Name, err)
+				}
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// notificationConfigXML returns the bucket notification config of
+// the bucket in the S3 PutBucketNotificationConfiguration format.
+func (b bootstrapBucket) notificationConfigXML() ([]byte, error) {
+	type filterRule struct {
+		Name  string `xml:"Name"`
+		Value string `xml:"Value"`
+	}
+	type queue struct {
+		ID          string       `xml:"Id"`
+		FilterRules []filterRule `xml:"Filter>S3Key>FilterRule,omitempty"`
+		Queue       string       `xml:"Queue"`
+		Events      []string     `xml:"Event"`
+	}
+	type notificationConfig struct {
+		XMLName xml.Name `xml:"NotificationConfiguration"`
+		Queues  []queue  `xml:"QueueConfiguration"`
+	}
+
+	var cfg notificationConfig
+	for i, n := range b.Notifications {
+		q := queue{
+			ID:     fmt.Sprintf("bootstrap-%d", i+1),
+			Queue:  n.ARN,
+			Events: n.Events,
+		}
+		if n.Prefix != "" {
+			q.FilterRules = append(q.FilterRules, filterRule{Name: "prefix", Value: n.Prefix})
+		}
+		if n.Suffix != "" {
+			q.FilterRules = append(q.FilterRules, filterRule{Name: "suffix", Value: n.Suffix})
+		}
+		cfg.Queues = append(cfg.Queues, q)
+	}
+	return xml.Marshal(cfg)
+}
+
+// bootstrapCluster applies the bootstrap config, all operations are
+// idempotent such that the config is applied on every startup. Users
+// are only created, such that later changes made with the admin API
+// are kept. Errors are logged and do not prevent the remaining configs
+// from being applied.
+func bootstrapCluster(ctx context.Context, objAPI ObjectLayer, cfg *bootstrapConfig) {
+	if len(cfg.Config) > 0 {
+		logger.LogIf(ctx, bootstrapServerConfig(ctx, objAPI, cfg.Config))
+	}
+
+	for _, p := range cfg.Policies {
+		if err := globalIAMSys.SetPolicy(p.Name, *p.policy); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to bootstrap policy %s: %w", p.Name, err))
+		}
+	}
+
+	for _, u := range cfg.Users {
+		if err := bootstrapIAMUser(u); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to bootstrap user %s: %w", u.AccessKey, err))
+		}
+	}
+
+	for _, g := range cfg.Groups {
+		if err := globalIAMSys.AddUsersToGroup(g.Name, g.Members); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to bootstrap group %s: %w", g.Name, err))
+			continue
+		}
+		if g.Policy != "" {
+			if err := globalIAMSys.PolicyDBSet(g.Name, g.Policy, true); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to bootstrap group %s: %w", g.Name, err))
+			}
+		}
+	}
+
+	for _, b := range cfg.Buckets {
+		if err := bootstrapBucketConfig(ctx, objAPI, b); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to bootstrap bucket %s: %w", b.Name, err))
+		}
+	}
+}
+
+// bootstrapIAMUser creates the user if it does not exist yet, existing
+// users are left untouched such that a disabled user or a rotated
+// secret key is not reverted on restart.
+func bootstrapIAMUser(u bootstrapUser) error {
+	_, err := globalIAMSys.GetUserInfo(u.AccessKey)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, errNoSuchUser) {
+		return err
+	}
+	return globalIAMSys.SetUser(u.AccessKey, madmin.UserInfo{
+		SecretKey:  u.SecretKey,
+		Status:     madmin.AccountEnabled,
+		PolicyName: u.Policy,
+	})
+}
+
+// bootstrapServerConfig sets the server config key values, the
+// notification targets configured are enabled right away.
+func bootstrapServerConfig(ctx context.Context, objAPI ObjectLayer, kvs []string) error {
+	cfg, err := readServerConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	current, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	dynamic, err := cfg.ReadConfig(strings.NewReader(strings.Join(kvs, "\n")))
+	if err != nil {
+		return err
+	}
+	if err = validateConfig(cfg, objAPI.SetDriveCount()); err != nil {
+		return err
+	}
+
+	updated, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(current, updated) {
+		return nil
+	}
+
+	if err = saveServerConfig(ctx, objAPI, cfg); err != nil {
+		return err
+	}
+	if dynamic {
+		if err = applyDynamicConfig(ctx, cfg); err != nil {
+			return err
+		}
+	}
+
+	// Enable the notification targets which are not yet known.
+	targetList, err := notify.GetNotificationTargets(ctx, cfg, NewGatewayHTTPTransport(), false)
+	if err != nil {
+		return err
+	}
+	for _, target := range targetList.Targets() {
+		if globalNotificationSys.targetList.Exists(target.ID()) {
+			target.Close()
+			continue
+		}
+		if err = globalNotificationSys.targetList.Add(target); err != nil {
+			return err
+		}
+	}
+
+	if !dynamic {
+		logger.Info("Server config updated from the bootstrap config, restart the server to apply all changes")
+	}
+	return nil
+}
+
+// bootstrapBucketConfig creates the bucket if missing and sets its
+// configurations.
+func bootstrapBucketConfig(ctx context.Context, objAPI ObjectLayer, b bootstrapBucket) error {
+	_, err := objAPI.GetBucketInfo(ctx, b.Name)
+	switch err.(type) {
+	case nil:
+		if b.ObjectLock {
+			if rcfg, _ := globalBucketObjectLockSys.Get(b.Name); !rcfg.LockEnabled {
+				return errors.New("object locking can only be enabled on bucket creation")
+			}
+		}
+	case BucketNotFound:
+		opts := BucketOptions{
+			Location:    globalServerRegion,
+			LockEnabled: b.ObjectLock,
+		}
+		if err = objAPI.MakeBucketWithLocation(ctx, b.Name, opts); err != nil {
+			if _, ok := err.(BucketExists); !ok {
+				return err
+			}
+		}
+		// Load updated bucket metadata into memory.
+		globalNotificationSys.LoadBucketMetadata(GlobalContext, b.Name)
+	default:
+		return err
+	}
+
+	if b.Versioning != nil {
+		configData := []byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Suspended</Status></VersioningConfiguration>`)
+		if *b.Versioning {
+			configData = enabledBucketVersioningConfig
+		}
+		if err = globalBucketMetadataSys.Update(b.Name, bucketVersioningConfig, configData); err != nil {
+			return err
+		}
+	}
+
+	if b.Policy != "" {
+		bucketPolicy, err := policy.ParseConfig(strings.NewReader(b.Policy), b.Name)
+		if err != nil {
+			return err
+		}
+		configData, err := json.Marshal(bucketPolicy)
+		if err != nil {
+			return err
+		}
+		if err = globalBucketMetadataSys.Update(b.Name, bucketPolicyConfig, configData); err != nil {
+			return err
+		}
+	}
+
+	if b.Quota != nil {
+		configData, err := json.Marshal(madmin.BucketQuota{Quota: b.Quota.Size, Type: b.Quota.Type})
+		if err != nil {
+			return err
+		}
+		if err = globalBucketMetadataSys.Update(b.Name, bucketQuotaConfigFile, configData); err != nil {
+			return err
+		}
+	}
+
+	if len(b.Notifications) > 0 {
+		notificationXML, err := b.notificationConfigXML()
+		if err != nil {
+			return err
+		}
+		notificationCfg, err := event.ParseConfig(bytes.NewReader(notificationXML), globalServerRegion, globalNotificationSys.targetList)
+		if err != nil {
+			return err
+		}
+		configData, err := xml.Marshal(notificationCfg)
+		if err != nil {
+			return err
+		}
+		if err = globalBucketMetadataSys.Update(b.Name, bucketNotificationConfig, configData); err != nil {
+			return err
+		}
+		globalNotificationSys.AddRulesMap(b.Name, notificationCfg.ToRulesMap())
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseBootstrapConfig(t *testing.T) {
+	testCases := []struct {
+		config  string
+		wantErr bool
+	}{
+		{config: `version: "1"`},
+		{config: `
+version: "1"
+config:
+  - notify_webhook:primary endpoint=http://localhost:8080
+policies:
+  - name: logs-read
+    policy: '{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::logs/*"]}]}'
+users:
+  - accessKey: app
+    secretKey: app-secret-key
+    policy: logs-read
+groups:
+  - name: devs
+    members: [app]
+    policy: readwrite
+buckets:
+  - name: logs
+    versioning: true
+    quota:
+      size: 1073741824
+      type: hard
+    notifications:
+      - arn: arn:minio:sqs::primary:webhook
+        events: ["s3:ObjectCreated:*"]
+        suffix: .log
+`},
+		// Unsupported version.
+		{config: `version: "2"`, wantErr: true},
+		// Unknown field.
+		{config: "version: \"1\"\nbucket:\n  - name: logs", wantErr: true},
+		// Invalid policy.
+		{config: "version: \"1\"\npolicies:\n  - name: p\n    policy: '{'", wantErr: true},
+		// Invalid secret key.
+		{config: "version: \"1\"\nusers:\n  - accessKey: app\n    secretKey: short", wantErr: true},
+		// Invalid bucket name.
+		{config: "version: \"1\"\nbuckets:\n  - name: In_Valid", wantErr: true},
+		// Invalid quota type.
+		{config: "version: \"1\"\nbuckets:\n  - name: logs\n    quota:\n      size: 1\n      type: soft", wantErr: true},
+		// Invalid event name.
+		{config: "version: \"1\"\nbuckets:\n  - name: logs\n    notifications:\n      - arn: arn:minio:sqs::1:webhook\n        events: [\"s3:Unknown\"]", wantErr: true},
+	}
+	for i, testCase := range testCases {
+		_, err := parseBootstrapConfig([]byte(testCase.config))
+		if (err != nil) != testCase.wantErr {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.wantErr, err)
+		}
+	}
+}
+
+func TestBootstrapBucketConfig(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	newAllSubsystems()
+	setObjectLayer(obj)
+
+	cfg, err := parseBootstrapConfig([]byte(`
+version: "1"
+buckets:
+  - name: logs
+    policy: '{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::logs/*"]}]}'
+    quota:
+      size: 1048576
+      type: hard
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bootstrapping is applied on every startup.
+	for i := 0; i < 2; i++ {
+		if err = bootstrapBucketConfig(context.Background(), obj, cfg.Buckets[0]); err != nil {
+			t.Fatalf("Run %d: unexpected error %v", i+1, err)
+		}
+	}
+
+	if _, err = globalPolicySys.Get("logs"); err != nil {
+		t.Fatalf("expected bucket policy to be set: %v", err)
+	}
+	quota, err := globalBucketMetadataSys.GetQuotaConfig("logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quota.Quota != 1048576 {
+		t.Fatalf("expected quota of 1048576 bytes, got %d", quota.Quota)
+	}
+}
+
+func TestBootstrapIAMUser(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	newAllSubsystems()
+	setObjectLayer(obj)
+	globalIAMSys.Init(ctx, obj)
+	defer resetGlobalIAMSys()
+
+	user := bootstrapUser{AccessKey: "app", SecretKey: "app-secret-key", Policy: "readwrite"}
+	if err := bootstrapIAMUser(user); err != nil {
+		t.Fatal(err)
+	}
+	if err := globalIAMSys.SetUserStatus(user.AccessKey, madmin.AccountDisabled); err != nil {
+		t.Fatal(err)
+	}
+
+	// A restart must not re-enable the user nor reset its secret key.
+	user.SecretKey = "new-secret-key"
+	if err := bootstrapIAMUser(user); err != nil {
+		t.Fatal(err)
+	}
+	info, err := globalIAMSys.GetUserInfo(user.AccessKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != madmin.AccountDisabled {
+		t.Fatalf("expected user to stay disabled, got %s", info.Status)
+	}
+	// Disabled users are reported as not ok, only the secret key matters.
+	cred, _ := globalIAMSys.GetUser(user.AccessKey)
+	if cred.SecretKey != "app-secret-key" {
+		t.Fatalf("expected secret key to be kept, got %s", cred.SecretKey)
+	}
+}
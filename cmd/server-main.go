@@ -50,6 +50,10 @@ var ServerFlags = []cli.Flag{
 		Value: ":" + GlobalMinioDefaultPort,
 		Usage: "bind to a specific ADDRESS:PORT, ADDRESS can be an IP or hostname",
 	},
+	cli.StringFlag{
+		Name:  "config",
+		Usage: "create buckets, policies, users and groups declared in a YAML config file at startup",
+	},
 }
 
 var serverCmd = cli.Command{
@@ -92,6 +96,9 @@ EXAMPLES:
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ROOT_PASSWORD{{.AssignmentOperator}}miniostorage
      {{.Prompt}} {{.HelpName}} http://node{1...16}.example.com/mnt/export{1...32} \
             http://node{17...64}.example.com/mnt/export{1...64}
+
+  5. Start minio server on "/home/shared" directory, creating the buckets, policies, users and groups of "cluster.yaml".
+     {{.Prompt}} {{.HelpName}} --config /etc/minio/cluster.yaml /home/shared
 `,
 }
 
@@ -117,8 +124,17 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	logger.FatalIf(CheckLocalServerAddr(globalCLIContext.Addr), "Unable to validate passed arguments")
 
 	var err error
+
+	// Load the declarative bootstrap config, if any, applied once the server is initialized.
+	if configFile := ctx.String("config"); configFile != "" {
+		globalBootstrapConfig, err = loadBootstrapConfig(configFile)
+		logger.FatalIf(err, "Unable to load the bootstrap config %s", configFile)
+	}
 	var setupType SetupType
 
+	// Obtain or renew the TLS certificate via ACME, if enabled.
+	logger.FatalIf(initACME(GlobalContext), "Unable to obtain the TLS certificate via ACME")
+
 	// Check and load TLS certificates.
 	globalPublicCerts, globalTLSCerts, globalIsTLS, err = getTLSConfig()
 	logger.FatalIf(err, "Unable to load the TLS configuration")
@@ -449,12 +465,23 @@ func serverMain(ctx *cli.Context) {
 	httpServer.BaseContext = func(listener net.Listener) context.Context {
 		return GlobalContext
 	}
+	if globalSTSTLSConfig.Enabled && httpServer.TLSConfig != nil {
+		// Client certificates are only requested, they are verified
+		// by the STS API. Clients without certificates are unaffected.
+		httpServer.TLSConfig.ClientAuth = tls.RequestClientCert
+	}
+	httpServer.EnableH2C = globalInternodeHTTP2
 	go func() {
 		globalHTTPServerErrorCh <- httpServer.Start()
 	}()
 
 	setHTTPServer(httpServer)
 
+	if globalAdminGRPCAddr != "" {
+		err = startAdminGRPCServer(GlobalContext, globalAdminGRPCAddr)
+		logger.FatalIf(err, "Unable to start the admin gRPC server")
+	}
+
 	if globalIsDistErasure && globalEndpoints.FirstLocal() {
 		for {
 			// Additionally in distributed setup, validate the setup and configuration.
@@ -483,10 +510,15 @@ func serverMain(ctx *cli.Context) {
 		initAutoHeal(GlobalContext, newObject)
 		initBackgroundReplication(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
+		initBackgroundExpiry(GlobalContext, newObject)
 	}
 
 	initDataCrawler(GlobalContext, newObject)
 
+	go globalAPIConfig.autoTuneRequestsPool(GlobalContext)
+
+	initAccessAccounting(GlobalContext, newObject)
+
 	if err = initServer(GlobalContext, newObject); err != nil {
 		var cerr config.Err
 		// For any config error, we don't need to drop into safe-mode
@@ -501,6 +533,9 @@ func serverMain(ctx *cli.Context) {
 		}
 	}
 
+	// Resume the batch jobs which were running on this node.
+	initBatchJobs(GlobalContext, newObject)
+
 	if globalCacheConfig.Enabled {
 		// initialize the new disk cache objects.
 		var cacheAPI CacheObjectLayer
@@ -511,7 +546,14 @@ func serverMain(ctx *cli.Context) {
 	}
 
 	// Initialize users credentials and policies in background right after config has initialized.
-	go globalIAMSys.Init(GlobalContext, newObject)
+	go func() {
+		globalIAMSys.Init(GlobalContext, newObject)
+
+		// Bootstrap the cluster once IAM is initialized.
+		if globalBootstrapConfig != nil {
+			bootstrapCluster(GlobalContext, newObject, globalBootstrapConfig)
+		}
+	}()
 
 	// Prints the formatted startup message, if err is not nil then it prints additional information as well.
 	printStartupMessage(getAPIEndpoints(), err)
@@ -100,6 +100,7 @@ func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.TestContentTypePersists(c)
 	suite.TestPartialContent(c)
 	suite.TestListObjectsHandler(c)
+	suite.TestListObjectsV2Metadata(c)
 	suite.TestListObjectsHandlerErrors(c)
 	suite.TestPutBucketErrors(c)
 	suite.TestGetObjectLarge10MiB(c)
@@ -1626,6 +1627,51 @@ func (s *TestSuiteCommon) TestListObjectsHandler(c *check) {
 	}
 }
 
+// TestListObjectsV2Metadata - ListObjectsV2 includes the user metadata
+// and tags of the objects only when requested with metadata=true.
+func (s *TestSuiteCommon) TestListObjectsV2Metadata(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request to create bucket.
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	buffer := bytes.NewReader([]byte("Hello World"))
+	request, err = newTestSignedRequestV4(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "object"),
+		int64(buffer.Len()), buffer, s.accessKey, s.secretKey, map[string]string{
+			"X-Amz-Meta-Color":     "blue",
+			xhttp.AmzObjectTagging: "project=alpha",
+		})
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	for _, withMetadata := range []bool{false, true} {
+		listURL := getListObjectsV2URL(s.endPoint, bucketName, "", "1000", "", "")
+		if withMetadata {
+			listURL += "&metadata=true"
+		}
+		request, err = newTestSignedRequest(http.MethodGet, listURL, 0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+
+		getContent, err := ioutil.ReadAll(response.Body)
+		c.Assert(err, nil)
+		c.Assert(strings.Contains(string(getContent), "<Key>object</Key>"), true)
+		c.Assert(strings.Contains(string(getContent), "<X-Amz-Meta-Color>blue</X-Amz-Meta-Color>"), withMetadata)
+		c.Assert(strings.Contains(string(getContent), "<UserTags>project=alpha</UserTags>"), withMetadata)
+	}
+}
+
 // TestListObjectsHandlerErrors - Setting invalid parameters to List Objects
 // and then asserting the error response with the expected one.
 func (s *TestSuiteCommon) TestListObjectsHandlerErrors(c *check) {
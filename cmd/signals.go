@@ -65,6 +65,9 @@ func handleSignals() {
 			logger.LogIf(context.Background(), oerr)
 		}
 
+		// Export the spans of the requests served until now.
+		shutdownTracing()
+
 		return (err == nil && oerr == nil)
 	}
 
@@ -88,6 +88,7 @@ func doesPolicySignatureV2Match(formValues http.Header) APIErrorCode {
 	if !compareSignatureV2(signature, calculateSignatureV2(policy, cred.SecretKey)) {
 		return ErrSignatureDoesNotMatch
 	}
+	globalAccessAccounting.recordAccessKey(cred.AccessKey)
 	return ErrNone
 }
 
@@ -180,6 +181,7 @@ func doesPresignV2SignatureMatch(r *http.Request) APIErrorCode {
 		return ErrSignatureDoesNotMatch
 	}
 
+	globalAccessAccounting.recordAccessKey(cred.AccessKey)
 	return ErrNone
 }
 
@@ -278,6 +280,7 @@ func doesSignV2Match(r *http.Request) APIErrorCode {
 	if !compareSignatureV2(v2Auth, expectedAuth) {
 		return ErrSignatureDoesNotMatch
 	}
+	globalAccessAccounting.recordAccessKey(cred.AccessKey)
 	return ErrNone
 }
 
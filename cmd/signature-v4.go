@@ -195,6 +195,8 @@ func doesPolicySignatureV4Match(formValues http.Header) APIErrorCode {
 		return ErrSignatureDoesNotMatch
 	}
 
+	globalAccessAccounting.recordAccessKey(cred.AccessKey)
+
 	// Success.
 	return ErrNone
 }
@@ -322,6 +324,8 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 	if !compareSignatureV4(req.URL.Query().Get(xhttp.AmzSignature), newSignature) {
 		return ErrSignatureDoesNotMatch
 	}
+
+	globalAccessAccounting.recordAccessKey(cred.AccessKey)
 	return ErrNone
 }
 
@@ -387,6 +391,8 @@ func doesSignatureMatch(hashedPayload string, r *http.Request, region string, st
 		return ErrSignatureDoesNotMatch
 	}
 
+	globalAccessAccounting.recordAccessKey(cred.AccessKey)
+
 	// Return error none.
 	return ErrNone
 }
@@ -38,6 +38,29 @@ type DiskInfo struct {
 	MountPath  string
 	ID         string
 	Error      string // carries the error over the network
+	Metrics    DiskMetrics
+}
+
+// DiskMetrics has the IO statistics of a drive
+// per type of operation since the server started.
+//msgp:tuple DiskMetrics
+type DiskMetrics struct {
+	Read   DiskOpMetrics
+	Write  DiskOpMetrics
+	Delete DiskOpMetrics
+	Stat   DiskOpMetrics
+}
+
+// DiskOpMetrics has the statistics of a type of drive operation.
+//msgp:tuple DiskOpMetrics
+type DiskOpMetrics struct {
+	Count  uint64
+	Errors uint64
+	Bytes  uint64
+	// Number of operations per latency bucket, see diskLatencyBuckets.
+	Latency []uint64
+	// Total latency of all operations in nanoseconds.
+	LatencySum uint64
 }
 
 // VolsInfo is a collection of volume(bucket) information
@@ -89,6 +112,10 @@ func (f *FileInfoVersions) forwardPastVersion(v string) {
 	if v == "" {
 		return
 	}
+	if v == nullVersionID {
+		// null versions are stored without a version id.
+		v = ""
+	}
 	for i, ver := range f.Versions {
 		if ver.VersionID == v {
 			f.Versions = f.Versions[i+1:]
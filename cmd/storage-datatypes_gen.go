@@ -14,8 +14,8 @@ func (z *DiskInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 		err = msgp.WrapError(err)
 		return
 	}
-	if zb0001 != 11 {
-		err = msgp.ArrayError{Wanted: 11, Got: zb0001}
+	if zb0001 != 12 {
+		err = msgp.ArrayError{Wanted: 12, Got: zb0001}
 		return
 	}
 	z.Total, err = dc.ReadUint64()
@@ -73,13 +73,18 @@ func (z *DiskInfo) DecodeMsg(dc *msgp.Reader) (err error) {
 		err = msgp.WrapError(err, "Error")
 		return
 	}
+	err = z.Metrics.DecodeMsg(dc)
+	if err != nil {
+		err = msgp.WrapError(err, "Metrics")
+		return
+	}
 	return
 }
 
 // EncodeMsg implements msgp.Encodable
 func (z *DiskInfo) EncodeMsg(en *msgp.Writer) (err error) {
-	// array header, size 11
-	err = en.Append(0x9b)
+	// array header, size 12
+	err = en.Append(0x9c)
 	if err != nil {
 		return
 	}
@@ -138,14 +143,19 @@ func (z *DiskInfo) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Error")
 		return
 	}
+	err = z.Metrics.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Metrics")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *DiskInfo) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// array header, size 11
-	o = append(o, 0x9b)
+	// array header, size 12
+	o = append(o, 0x9c)
 	o = msgp.AppendUint64(o, z.Total)
 	o = msgp.AppendUint64(o, z.Free)
 	o = msgp.AppendUint64(o, z.Used)
@@ -157,6 +167,11 @@ func (z *DiskInfo) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.AppendString(o, z.MountPath)
 	o = msgp.AppendString(o, z.ID)
 	o = msgp.AppendString(o, z.Error)
+	o, err = z.Metrics.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Metrics")
+		return
+	}
 	return
 }
 
@@ -168,8 +183,8 @@ func (z *DiskInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 		err = msgp.WrapError(err)
 		return
 	}
-	if zb0001 != 11 {
-		err = msgp.ArrayError{Wanted: 11, Got: zb0001}
+	if zb0001 != 12 {
+		err = msgp.ArrayError{Wanted: 12, Got: zb0001}
 		return
 	}
 	z.Total, bts, err = msgp.ReadUint64Bytes(bts)
@@ -227,13 +242,324 @@ func (z *DiskInfo) UnmarshalMsg(bts []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Error")
 		return
 	}
+	bts, err = z.Metrics.UnmarshalMsg(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Metrics")
+		return
+	}
 	o = bts
 	return
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *DiskInfo) Msgsize() (s int) {
-	s = 1 + msgp.Uint64Size + msgp.Uint64Size + msgp.Uint64Size + msgp.Uint64Size + msgp.StringPrefixSize + len(z.FSType) + msgp.BoolSize + msgp.BoolSize + msgp.StringPrefixSize + len(z.Endpoint) + msgp.StringPrefixSize + len(z.MountPath) + msgp.StringPrefixSize + len(z.ID) + msgp.StringPrefixSize + len(z.Error)
+	s = 1 + msgp.Uint64Size + msgp.Uint64Size + msgp.Uint64Size + msgp.Uint64Size + msgp.StringPrefixSize + len(z.FSType) + msgp.BoolSize + msgp.BoolSize + msgp.StringPrefixSize + len(z.Endpoint) + msgp.StringPrefixSize + len(z.MountPath) + msgp.StringPrefixSize + len(z.ID) + msgp.StringPrefixSize + len(z.Error) + z.Metrics.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *DiskMetrics) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0001 uint32
+	zb0001, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 4 {
+		err = msgp.ArrayError{Wanted: 4, Got: zb0001}
+		return
+	}
+	err = z.Read.DecodeMsg(dc)
+	if err != nil {
+		err = msgp.WrapError(err, "Read")
+		return
+	}
+	err = z.Write.DecodeMsg(dc)
+	if err != nil {
+		err = msgp.WrapError(err, "Write")
+		return
+	}
+	err = z.Delete.DecodeMsg(dc)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	err = z.Stat.DecodeMsg(dc)
+	if err != nil {
+		err = msgp.WrapError(err, "Stat")
+		return
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *DiskMetrics) EncodeMsg(en *msgp.Writer) (err error) {
+	// array header, size 4
+	err = en.Append(0x94)
+	if err != nil {
+		return
+	}
+	err = z.Read.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Read")
+		return
+	}
+	err = z.Write.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Write")
+		return
+	}
+	err = z.Delete.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	err = z.Stat.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Stat")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *DiskMetrics) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// array header, size 4
+	o = append(o, 0x94)
+	o, err = z.Read.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Read")
+		return
+	}
+	o, err = z.Write.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Write")
+		return
+	}
+	o, err = z.Delete.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	o, err = z.Stat.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Stat")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DiskMetrics) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 4 {
+		err = msgp.ArrayError{Wanted: 4, Got: zb0001}
+		return
+	}
+	bts, err = z.Read.UnmarshalMsg(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Read")
+		return
+	}
+	bts, err = z.Write.UnmarshalMsg(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Write")
+		return
+	}
+	bts, err = z.Delete.UnmarshalMsg(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	bts, err = z.Stat.UnmarshalMsg(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Stat")
+		return
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *DiskMetrics) Msgsize() (s int) {
+	s = 1 + z.Read.Msgsize() + z.Write.Msgsize() + z.Delete.Msgsize() + z.Stat.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *DiskOpMetrics) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0001 uint32
+	zb0001, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 5 {
+		err = msgp.ArrayError{Wanted: 5, Got: zb0001}
+		return
+	}
+	z.Count, err = dc.ReadUint64()
+	if err != nil {
+		err = msgp.WrapError(err, "Count")
+		return
+	}
+	z.Errors, err = dc.ReadUint64()
+	if err != nil {
+		err = msgp.WrapError(err, "Errors")
+		return
+	}
+	z.Bytes, err = dc.ReadUint64()
+	if err != nil {
+		err = msgp.WrapError(err, "Bytes")
+		return
+	}
+	var zb0002 uint32
+	zb0002, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err, "Latency")
+		return
+	}
+	if cap(z.Latency) >= int(zb0002) {
+		z.Latency = (z.Latency)[:zb0002]
+	} else {
+		z.Latency = make([]uint64, zb0002)
+	}
+	for za0001 := range z.Latency {
+		z.Latency[za0001], err = dc.ReadUint64()
+		if err != nil {
+			err = msgp.WrapError(err, "Latency", za0001)
+			return
+		}
+	}
+	z.LatencySum, err = dc.ReadUint64()
+	if err != nil {
+		err = msgp.WrapError(err, "LatencySum")
+		return
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *DiskOpMetrics) EncodeMsg(en *msgp.Writer) (err error) {
+	// array header, size 5
+	err = en.Append(0x95)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Count)
+	if err != nil {
+		err = msgp.WrapError(err, "Count")
+		return
+	}
+	err = en.WriteUint64(z.Errors)
+	if err != nil {
+		err = msgp.WrapError(err, "Errors")
+		return
+	}
+	err = en.WriteUint64(z.Bytes)
+	if err != nil {
+		err = msgp.WrapError(err, "Bytes")
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Latency)))
+	if err != nil {
+		err = msgp.WrapError(err, "Latency")
+		return
+	}
+	for za0001 := range z.Latency {
+		err = en.WriteUint64(z.Latency[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Latency", za0001)
+			return
+		}
+	}
+	err = en.WriteUint64(z.LatencySum)
+	if err != nil {
+		err = msgp.WrapError(err, "LatencySum")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *DiskOpMetrics) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// array header, size 5
+	o = append(o, 0x95)
+	o = msgp.AppendUint64(o, z.Count)
+	o = msgp.AppendUint64(o, z.Errors)
+	o = msgp.AppendUint64(o, z.Bytes)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Latency)))
+	for za0001 := range z.Latency {
+		o = msgp.AppendUint64(o, z.Latency[za0001])
+	}
+	o = msgp.AppendUint64(o, z.LatencySum)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DiskOpMetrics) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 5 {
+		err = msgp.ArrayError{Wanted: 5, Got: zb0001}
+		return
+	}
+	z.Count, bts, err = msgp.ReadUint64Bytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Count")
+		return
+	}
+	z.Errors, bts, err = msgp.ReadUint64Bytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Errors")
+		return
+	}
+	z.Bytes, bts, err = msgp.ReadUint64Bytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Bytes")
+		return
+	}
+	var zb0002 uint32
+	zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Latency")
+		return
+	}
+	if cap(z.Latency) >= int(zb0002) {
+		z.Latency = (z.Latency)[:zb0002]
+	} else {
+		z.Latency = make([]uint64, zb0002)
+	}
+	for za0001 := range z.Latency {
+		z.Latency[za0001], bts, err = msgp.ReadUint64Bytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err, "Latency", za0001)
+			return
+		}
+	}
+	z.LatencySum, bts, err = msgp.ReadUint64Bytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "LatencySum")
+		return
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *DiskOpMetrics) Msgsize() (s int) {
+	s = 1 + msgp.Uint64Size + msgp.Uint64Size + msgp.Uint64Size + msgp.ArrayHeaderSize + (len(z.Latency) * (msgp.Uint64Size)) + msgp.Uint64Size
 	return
 }
 
@@ -46,6 +46,9 @@ var errFaultyRemoteDisk = StorageErr("remote disk is faulty")
 // errFaultyDisk - disk is faulty.
 var errFaultyDisk = StorageErr("disk is faulty")
 
+// errDiskTimeout - disk operation did not complete within the storage deadline.
+var errDiskTimeout = StorageErr("disk operation timed out")
+
 // errDiskAccessDenied - we don't have write permissions on disk.
 var errDiskAccessDenied = StorageErr("disk access denied")
 
@@ -116,6 +119,7 @@ var baseErrs = []error{
 	errDiskNotFound,
 	errFaultyDisk,
 	errFaultyRemoteDisk,
+	errDiskTimeout,
 }
 
 var baseIgnoredErrs = baseErrs
@@ -64,6 +64,8 @@ func toStorageErr(err error) error {
 	switch err.Error() {
 	case errFaultyDisk.Error():
 		return errFaultyDisk
+	case errDiskTimeout.Error():
+		return errDiskTimeout
 	case errFileCorrupt.Error():
 		return errFileCorrupt
 	case errUnexpected.Error():
@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	storageRESTVersion       = "v23" // Add small file optimization
+	storageRESTVersion       = "v25" // Add versions count to data usage cache
 	storageRESTVersionPrefix = SlashSeparator + storageRESTVersion
 	storageRESTPrefix        = minioReservedBucketPath + "/storage"
 )
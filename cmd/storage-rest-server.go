@@ -46,7 +46,7 @@ var errDiskStale = errors.New("disk stale")
 
 // To abstract a disk over network.
 type storageRESTServer struct {
-	storage *xlStorage
+	storage *xlStorageDiskIDCheck
 }
 
 func (s *storageRESTServer) writeErrorResponse(w http.ResponseWriter, err error) {
@@ -947,9 +947,9 @@ func logFatalErrs(err error, endpoint Endpoint, exit bool) {
 	} else if errors.Is(err, errUnsupportedDisk) {
 		var hint string
 		if endpoint.URL != nil {
-			hint = fmt.Sprintf("Disk '%s' does not support O_DIRECT flags, MinIO erasure coding requires filesystems with O_DIRECT support", endpoint.Path)
+			hint = fmt.Sprintf("Disk '%s' does not support O_DIRECT flags, set MINIO_DIRECT_IO=off to use filesystems without O_DIRECT support", endpoint.Path)
 		} else {
-			hint = "Disks do not support O_DIRECT flags, MinIO erasure coding requires filesystems with O_DIRECT support"
+			hint = "Disks do not support O_DIRECT flags, set MINIO_DIRECT_IO=off to use filesystems without O_DIRECT support"
 		}
 		logger.Fatal(config.ErrUnsupportedBackend(err).Hint(hint), "Unable to initialize backend")
 	} else if errors.Is(err, errDiskNotDir) {
@@ -1011,7 +1011,10 @@ func registerStorageRESTHandlers(router *mux.Router, endpointServerPools Endpoin
 				logFatalErrs(err, endpoint, false)
 			}
 
-			server := &storageRESTServer{storage: storage}
+			server := &storageRESTServer{}
+			if storage != nil {
+				server.storage = newXLStorageDiskIDCheck(storage)
+			}
 
 			subrouter := router.PathPrefix(path.Join(storageRESTPrefix, endpoint.Path)).Subrouter()
 
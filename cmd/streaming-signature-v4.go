@@ -26,6 +26,7 @@ import (
 	"hash"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -36,12 +37,28 @@ import (
 
 // Streaming AWS Signature Version '4' constants.
 const (
-	emptySHA256              = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
-	streamingContentSHA256   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
-	signV4ChunkedAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
-	streamingContentEncoding = "aws-chunked"
+	emptySHA256                     = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	streamingContentSHA256          = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingContentSHA256Trailer   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	streamingUnsignedPayloadTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+	signV4ChunkedAlgorithm          = "AWS4-HMAC-SHA256-PAYLOAD"
+	signV4ChunkedAlgorithmTrailer   = "AWS4-HMAC-SHA256-TRAILER"
+	streamingContentEncoding        = "aws-chunked"
+
+	// Trailer carrying the signature of all the preceding trailers.
+	amzTrailerSignature = "x-amz-trailer-signature"
 )
 
+// isStreamingContentSHA256 returns true if the payload hash
+// denotes an aws-chunked payload, with or without trailers.
+func isStreamingContentSHA256(payload string) bool {
+	switch payload {
+	case streamingContentSHA256, streamingContentSHA256Trailer, streamingUnsignedPayloadTrailer:
+		return true
+	}
+	return false
+}
+
 // getChunkSignature - get chunk signature.
 func getChunkSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, hashedChunk string) string {
 	// Calculate string to sign.
@@ -61,6 +78,23 @@ func getChunkSignature(cred auth.Credentials, seedSignature string, region strin
 	return newSignature
 }
 
+// getTrailerSignature - get the signature of the trailing headers,
+// chained to the signature of the final chunk.
+func getTrailerSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, hashedTrailer string) string {
+	// Calculate string to sign.
+	stringToSign := signV4ChunkedAlgorithmTrailer + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		hashedTrailer
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+
+	// Calculate signature.
+	return getSignature(signingKey, stringToSign)
+}
+
 // calculateSeedSignature - Calculate seed signature in accordance with
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 // returns signature, error otherwise if the signature mismatches or any other
@@ -79,10 +113,11 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	}
 
 	// Payload streaming.
-	payload := streamingContentSHA256
+	payload := req.Header.Get(xhttp.AmzContentSha256)
 
 	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
-	if payload != req.Header.Get(xhttp.AmzContentSha256) {
+	// optionally followed by trailers, or 'STREAMING-UNSIGNED-PAYLOAD-TRAILER'.
+	if !isStreamingContentSHA256(payload) {
 		return cred, "", "", time.Time{}, ErrContentSHA256Mismatch
 	}
 
@@ -136,6 +171,7 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	}
 
 	// Return caculated signature.
+	globalAccessAccounting.recordAccessKey(cred.AccessKey)
 	return cred, newSignature, region, date, ErrNone
 }
 
@@ -147,27 +183,54 @@ var errLineTooLong = errors.New("header line too long")
 // Malformed encoding is generated when chunk header is wrongly formed.
 var errMalformedEncoding = errors.New("malformed chunked encoding")
 
+// Checksum mismatch is generated when the trailing checksum does not
+// match the checksum of the decoded payload.
+var errChecksumMismatch = errors.New("trailing checksum does not match payload")
+
 // newSignV4ChunkedReader returns a new s3ChunkedReader that translates the data read from r
 // out of HTTP "chunked" format before returning it.
 // The s3ChunkedReader returns io.EOF when the final 0-length chunk is read.
 //
 // NewChunkedReader is not needed by normal applications. The http package
 // automatically decodes chunking when reading response bodies.
-func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
+//
+// If the payload carries a trailing 'x-amz-checksum-*' header, it is
+// verified against the decoded payload once the final chunk is read and
+// saved in metadata, if non-nil, for the object layer to persist it.
+func newSignV4ChunkedReader(req *http.Request, metadata map[string]string) (io.ReadCloser, APIErrorCode) {
 	cred, seedSignature, region, seedDate, errCode := calculateSeedSignature(req)
 	if errCode != ErrNone {
 		return nil, errCode
 	}
 
-	return &s3ChunkedReader{
+	payload := req.Header.Get(xhttp.AmzContentSha256)
+	cr := &s3ChunkedReader{
 		reader:            bufio.NewReader(req.Body),
 		cred:              cred,
 		seedSignature:     seedSignature,
 		seedDate:          seedDate,
 		region:            region,
+		signed:            payload != streamingUnsignedPayloadTrailer,
 		chunkSHA256Writer: sha256.New(),
+		metadata:          metadata,
 		state:             readChunkHeader,
-	}, ErrNone
+	}
+
+	switch payload {
+	case streamingContentSHA256Trailer, streamingUnsignedPayloadTrailer:
+		trailer, ok := checksumTypeFromTrailer(req.Header.Get(xhttp.AmzTrailer))
+		if !ok {
+			return nil, ErrInvalidChecksumTrailer
+		}
+		cr.trailer = trailer
+		cr.checksumWriter = trailer.Hasher()
+	default:
+		if req.Header.Get(xhttp.AmzTrailer) != "" {
+			return nil, ErrInvalidChecksumTrailer
+		}
+	}
+
+	return cr, ErrNone
 }
 
 // Represents the overall state that is required for decoding a
@@ -178,11 +241,15 @@ type s3ChunkedReader struct {
 	seedSignature     string
 	seedDate          time.Time
 	region            string
+	signed            bool // Chunks and trailers carry signatures.
 	state             chunkState
 	lastChunk         bool
 	chunkSignature    string
 	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
-	n                 uint64    // Unread bytes in chunk
+	trailer           checksumType
+	checksumWriter    hash.Hash         // Calculates the trailing checksum of the payload.
+	metadata          map[string]string // Receives the verified trailing checksum.
+	n                 uint64            // Unread bytes in chunk
 	err               error
 }
 
@@ -213,6 +280,7 @@ const (
 	readChunkTrailer
 	readChunk
 	verifyChunk
+	readTrailers
 	eofChunk
 )
 
@@ -227,6 +295,8 @@ func (cs chunkState) String() string {
 		stateString = "readChunk"
 	case verifyChunk:
 		stateString = "verifyChunk"
+	case readTrailers:
+		stateString = "readTrailers"
 	case eofChunk:
 		stateString = "eofChunk"
 
@@ -247,7 +317,12 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			cr.readS3ChunkHeader()
 			// If we're at the end of a chunk.
 			if cr.n == 0 && cr.err == io.EOF {
+				// Trailing headers follow the final chunk
+				// instead of the chunk's CRLF.
 				cr.state = readChunkTrailer
+				if cr.trailer != "" {
+					cr.state = verifyChunk
+				}
 				cr.lastChunk = true
 				continue
 			}
@@ -283,7 +358,12 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			}
 
 			// Calculate sha256.
-			cr.chunkSHA256Writer.Write(rbuf[:n0])
+			if cr.signed {
+				cr.chunkSHA256Writer.Write(rbuf[:n0])
+			}
+			if cr.checksumWriter != nil {
+				cr.checksumWriter.Write(rbuf[:n0])
+			}
 			// Update the bytes read into request buffer so far.
 			n += n0
 			buf = buf[n0:]
@@ -296,30 +376,105 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 				continue
 			}
 		case verifyChunk:
-			// Calculate the hashed chunk.
-			hashedChunk := hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil))
-			// Calculate the chunk signature.
-			newSignature := getChunkSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hashedChunk)
-			if !compareSignatureV4(cr.chunkSignature, newSignature) {
-				// Chunk signature doesn't match we return signature does not match.
-				cr.err = errSignatureMismatch
-				return 0, cr.err
+			if cr.signed {
+				// Calculate the hashed chunk.
+				hashedChunk := hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil))
+				// Calculate the chunk signature.
+				newSignature := getChunkSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hashedChunk)
+				if !compareSignatureV4(cr.chunkSignature, newSignature) {
+					// Chunk signature doesn't match we return signature does not match.
+					cr.err = errSignatureMismatch
+					return 0, cr.err
+				}
+				// Newly calculated signature becomes the seed for the next chunk
+				// this follows the chaining.
+				cr.seedSignature = newSignature
+				cr.chunkSHA256Writer.Reset()
 			}
-			// Newly calculated signature becomes the seed for the next chunk
-			// this follows the chaining.
-			cr.seedSignature = newSignature
-			cr.chunkSHA256Writer.Reset()
-			if cr.lastChunk {
+			switch {
+			case cr.lastChunk && cr.trailer != "":
+				cr.state = readTrailers
+			case cr.lastChunk:
 				cr.state = eofChunk
-			} else {
+			default:
 				cr.state = readChunkHeader
 			}
+		case readTrailers:
+			if cr.err = cr.readTrailers(); cr.err != nil {
+				return 0, cr.err
+			}
+			cr.state = eofChunk
 		case eofChunk:
 			return n, io.EOF
 		}
 	}
 }
 
+// readTrailers reads the trailing headers following the final chunk,
+// verifies their signature if the payload is signed and the trailing
+// checksum against the checksum computed over the decoded payload.
+//
+//     x-amz-checksum-crc32:sOO8/Q==\r\n
+//     x-amz-trailer-signature:<signature>\r\n
+//     \r\n
+func (cr *s3ChunkedReader) readTrailers() error {
+	var checksum, signature string
+	trailerSHA256Writer := sha256.New()
+	for {
+		buf, err := cr.reader.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			} else if err == bufio.ErrBufferFull {
+				err = errLineTooLong
+			}
+			return err
+		}
+		buf = trimTrailingWhitespace(buf)
+		if len(buf) == 0 {
+			// Empty line terminates the trailers.
+			break
+		}
+		kv := bytes.SplitN(buf, []byte(":"), 2)
+		if len(kv) != 2 {
+			return errMalformedEncoding
+		}
+		key := strings.ToLower(string(bytes.TrimSpace(kv[0])))
+		value := string(bytes.TrimSpace(kv[1]))
+		switch key {
+		case amzTrailerSignature:
+			signature = value
+		case strings.ToLower(cr.trailer.Header()):
+			checksum = value
+			// Each signed trailer is hashed as 'name:value\n'.
+			trailerSHA256Writer.Write([]byte(key + ":" + value + "\n"))
+		default:
+			return errMalformedEncoding
+		}
+	}
+
+	if checksum == "" {
+		return errMalformedEncoding
+	}
+
+	if cr.signed {
+		hashedTrailer := hex.EncodeToString(trailerSHA256Writer.Sum(nil))
+		newSignature := getTrailerSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hashedTrailer)
+		if !compareSignatureV4(signature, newSignature) {
+			return errSignatureMismatch
+		}
+	}
+
+	if encodeChecksum(cr.checksumWriter.Sum(nil)) != checksum {
+		return errChecksumMismatch
+	}
+
+	if cr.metadata != nil {
+		cr.metadata[cr.trailer.metadataKey()] = checksum
+	}
+	return nil
+}
+
 // readCRLF - check if reader only has '\r\n' CRLF character.
 // returns malformed encoding if it doesn't.
 func readCRLF(reader io.Reader) error {
@@ -21,8 +21,12 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	sha256 "github.com/minio/sha256-simd"
 )
 
 // Test read chunk line.
@@ -194,3 +198,80 @@ func TestParseHexUint(t *testing.T) {
 		}
 	}
 }
+
+// Test decoding aws-chunked payloads with trailing checksums.
+func TestS3ChunkedReaderTrailer(t *testing.T) {
+	cred := auth.Credentials{AccessKey: "minio", SecretKey: "minio123"}
+	date := UTCNow()
+	region := globalMinioDefaultRegion
+	seedSignature := "seed"
+
+	data := []byte("hello world")
+	crc := checksumCRC32.Hasher()
+	crc.Write(data)
+	checksum := encodeChecksum(crc.Sum(nil))
+
+	// Signed chunks are chained to the previous signature.
+	dataSignature := getChunkSignature(cred, seedSignature, region, date, getSHA256Hash(data))
+	finalSignature := getChunkSignature(cred, dataSignature, region, date, emptySHA256)
+	trailer := "x-amz-checksum-crc32:" + checksum
+	trailerSignature := getTrailerSignature(cred, finalSignature, region, date, getSHA256Hash([]byte(trailer+"\n")))
+
+	testCases := []struct {
+		signed      bool
+		stream      string
+		expectedErr error
+	}{
+		// Test case - 1.
+		// Unsigned payload with valid checksum.
+		{false, fmt.Sprintf("%x\r\n%s\r\n0\r\n%s\r\n\r\n", len(data), data, trailer), nil},
+		// Test case - 2.
+		// Unsigned payload with mismatching checksum.
+		{false, fmt.Sprintf("%x\r\n%s\r\n0\r\nx-amz-checksum-crc32:AAAAAA==\r\n\r\n", len(data), data), errChecksumMismatch},
+		// Test case - 3.
+		// Missing checksum trailer.
+		{false, fmt.Sprintf("%x\r\n%s\r\n0\r\n\r\n", len(data), data), errMalformedEncoding},
+		// Test case - 4.
+		// Unexpected trailer.
+		{false, fmt.Sprintf("%x\r\n%s\r\n0\r\nx-amz-checksum-sha1:%s\r\n\r\n", len(data), data, checksum), errMalformedEncoding},
+		// Test case - 5.
+		// Signed payload with valid trailer signature.
+		{true, fmt.Sprintf("%x;chunk-signature=%s\r\n%s\r\n0;chunk-signature=%s\r\n%s\r\nx-amz-trailer-signature:%s\r\n\r\n",
+			len(data), dataSignature, data, finalSignature, trailer, trailerSignature), nil},
+		// Test case - 6.
+		// Signed payload with invalid trailer signature.
+		{true, fmt.Sprintf("%x;chunk-signature=%s\r\n%s\r\n0;chunk-signature=%s\r\n%s\r\nx-amz-trailer-signature:%s\r\n\r\n",
+			len(data), dataSignature, data, finalSignature, trailer, finalSignature), errSignatureMismatch},
+	}
+
+	for i, testCase := range testCases {
+		metadata := make(map[string]string)
+		cr := &s3ChunkedReader{
+			reader:            bufio.NewReader(strings.NewReader(testCase.stream)),
+			cred:              cred,
+			seedSignature:     seedSignature,
+			seedDate:          date,
+			region:            region,
+			signed:            testCase.signed,
+			chunkSHA256Writer: sha256.New(),
+			trailer:           checksumCRC32,
+			checksumWriter:    checksumCRC32.Hasher(),
+			metadata:          metadata,
+			state:             readChunkHeader,
+		}
+		got, err := ioutil.ReadAll(cr)
+		if err != testCase.expectedErr {
+			t.Errorf("Test %d: Expected error %v, got %v", i+1, testCase.expectedErr, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("Test %d: Expected payload %q, got %q", i+1, data, got)
+		}
+		if c, v, ok := getObjectChecksum(metadata); !ok || c != checksumCRC32 || v != checksum {
+			t.Errorf("Test %d: Expected checksum %s to be saved, got %s:%s", i+1, checksum, c, v)
+		}
+	}
+}
@@ -190,3 +190,19 @@ type AssumeRoleWithLDAPResponse struct {
 type LDAPIdentityResult struct {
 	Credentials auth.Credentials `xml:",omitempty"`
 }
+
+// AssumeRoleWithCertificateResponse contains the result of
+// a successful AssumeRoleWithCertificate request.
+type AssumeRoleWithCertificateResponse struct {
+	XMLName          xml.Name          `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleWithCertificateResponse" json:"-"`
+	Result           CertificateResult `xml:"AssumeRoleWithCertificateResult"`
+	ResponseMetadata struct {
+		RequestID string `xml:"RequestId,omitempty"`
+	} `xml:"ResponseMetadata,omitempty"`
+}
+
+// CertificateResult - contains credentials for a successful
+// AssumeRoleWithCertificate request.
+type CertificateResult struct {
+	Credentials auth.Credentials `xml:",omitempty"`
+}
@@ -2025,6 +2025,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "GetObject":
 			// Register GetObject handler.
 			bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(api.GetObjectHandler)
+		case "AppendObject":
+			// Register AppendObject handler.
+			bucket.Methods(http.MethodPut).Path("/{object:.+}").HandlerFunc(api.AppendObjectHandler).Queries("append", "")
 		case "PutObject":
 			// Register PutObject handler.
 			bucket.Methods(http.MethodPut).Path("/{object:.+}").HandlerFunc(api.PutObjectHandler)
@@ -2088,6 +2091,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "ListenNotification":
 			// Register ListenNotification Handler.
 			bucket.Methods(http.MethodGet).HandlerFunc(api.ListenNotificationHandler).Queries("events", "{events:.*}")
+		case "GetBucketZip":
+			// Register GetBucketZip Handler.
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketZipHandler).Queries("zip", "")
 		}
 	}
 }
@@ -0,0 +1,271 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/config/usageexport"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// Prefix under which the usage snapshots are written to the
+// configured bucket.
+const usageExportPrefix = "usage"
+
+// globalUsageExport is set when the usage export is enabled.
+var globalUsageExport *usageExporter
+
+// usageRecord is the usage of a bucket, or of a top level prefix
+// of a bucket, in a usage snapshot.
+type usageRecord struct {
+	Time     time.Time `json:"time"`
+	Bucket   string    `json:"bucket"`
+	Prefix   string    `json:"prefix,omitempty"`
+	Objects  uint64    `json:"objects"`
+	Versions uint64    `json:"versions"`
+	Size     uint64    `json:"size"`
+}
+
+// usageExporter exports usage snapshots at the end of the crawl
+// cycles, at most once per configured interval.
+type usageExporter struct {
+	cfg    usageexport.Config
+	client *http.Client
+
+	mu         sync.Mutex
+	lastExport time.Time
+}
+
+// initUsageExport enables the export of usage snapshots.
+func initUsageExport(cfg usageexport.Config) {
+	if !cfg.Enabled || globalIsGateway {
+		return
+	}
+	globalUsageExport = &usageExporter{
+		cfg:    cfg,
+		client: &http.Client{Transport: NewGatewayHTTPTransport()},
+	}
+}
+
+// crawlDone is called at the end of each crawl cycle, a snapshot
+// is exported if the last one is older than the configured interval.
+func (u *usageExporter) crawlDone(ctx context.Context, objAPI ObjectLayer) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	now := UTCNow()
+	if now.Sub(u.lastExport) < u.cfg.Interval {
+		return
+	}
+	if err := u.export(ctx, objAPI, now); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to export usage snapshot: %w", err))
+		return
+	}
+	u.lastExport = now
+}
+
+// export takes a usage snapshot and sends it to the configured
+// webhook and bucket.
+func (u *usageExporter) export(ctx context.Context, objAPI ObjectLayer, now time.Time) error {
+	records, err := collectUsageRecords(ctx, objAPI, now)
+	if err != nil {
+		return err
+	}
+	data, contentType, err := encodeUsageRecords(records, u.cfg.Format)
+	if err != nil {
+		return err
+	}
+	if u.cfg.Endpoint != "" {
+		if err = u.post(ctx, data, contentType); err != nil {
+			return err
+		}
+	}
+	if u.cfg.Bucket != "" {
+		object := path.Join(usageExportPrefix, now.Format("20060102T150405Z")+"."+u.cfg.Format)
+		r, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)), false)
+		if err != nil {
+			return err
+		}
+		_, err = objAPI.PutObject(ctx, u.cfg.Bucket, object, NewPutObjReader(r, nil, nil), ObjectOptions{
+			UserDefined: map[string]string{xhttp.ContentType: contentType},
+			Versioned:   globalBucketVersioningSys.Enabled(u.cfg.Bucket),
+		})
+		if err != nil {
+			return toObjectErr(err, u.cfg.Bucket, object)
+		}
+	}
+	return nil
+}
+
+// post sends a usage snapshot to the configured webhook.
+func (u *usageExporter) post(ctx context.Context, data []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.cfg.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(xhttp.ContentType, contentType)
+
+	// Set user-agent to indicate MinIO release
+	// version to the configured endpoint
+	req.Header.Set("User-Agent", getUserAgent(getMinioMode()))
+
+	if u.cfg.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+u.cfg.AuthToken)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	// Drain any response.
+	xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned '%s', please check your endpoint configuration",
+			u.cfg.Endpoint, resp.Status)
+	}
+	return nil
+}
+
+// dataUsageCacheStores returns the stores holding the data usage
+// caches of the buckets, one per erasure set.
+func dataUsageCacheStores(objAPI ObjectLayer) []objectIO {
+	switch z := objAPI.(type) {
+	case *erasureServerPools:
+		var stores []objectIO
+		for _, pool := range z.serverPools {
+			for _, set := range pool.sets {
+				stores = append(stores, set)
+			}
+		}
+		return stores
+	case *FSObjects:
+		return []objectIO{z}
+	}
+	return nil
+}
+
+// collectUsageRecords returns the usage of every bucket followed by
+// the usage of its top level prefixes, as of the last crawl cycle.
+func collectUsageRecords(ctx context.Context, objAPI ObjectLayer, now time.Time) ([]usageRecord, error) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+
+	stores := dataUsageCacheStores(objAPI)
+	var records []usageRecord
+	for _, bucket := range buckets {
+		total := usageRecord{Time: now, Bucket: bucket.Name}
+		prefixes := make(map[string]*usageRecord)
+		for _, store := range stores {
+			var cache dataUsageCache
+			if err = cache.load(ctx, store, pathJoin(bucket.Name, dataUsageCacheName)); err != nil {
+				return nil, err
+			}
+			e := cache.find(bucket.Name)
+			if e == nil {
+				continue
+			}
+			total.add(cache.flatten(*e))
+			for child := range e.Children {
+				ce, ok := cache.Cache[child]
+				if !ok {
+					continue
+				}
+				prefix := strings.TrimPrefix(child, bucket.Name+SlashSeparator) + SlashSeparator
+				r, ok := prefixes[prefix]
+				if !ok {
+					r = &usageRecord{Time: now, Bucket: bucket.Name, Prefix: prefix}
+					prefixes[prefix] = r
+				}
+				r.add(cache.flatten(ce))
+			}
+		}
+		records = append(records, total)
+
+		names := make([]string, 0, len(prefixes))
+		for prefix := range prefixes {
+			names = append(names, prefix)
+		}
+		sort.Strings(names)
+		for _, prefix := range names {
+			records = append(records, *prefixes[prefix])
+		}
+	}
+	return records, nil
+}
+
+// add adds a flattened data usage entry to the record.
+func (r *usageRecord) add(e dataUsageEntry) {
+	r.Objects += e.Objects
+	r.Versions += e.Versions
+	r.Size += uint64(e.Size)
+}
+
+// encodeUsageRecords encodes a usage snapshot in the given format,
+// it returns the encoded snapshot and its content type.
+func encodeUsageRecords(records []usageRecord, format string) ([]byte, string, error) {
+	switch format {
+	case usageexport.FormatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write([]string{"time", "bucket", "prefix", "objects", "versions", "size"}); err != nil {
+			return nil, "", err
+		}
+		for _, r := range records {
+			if err := w.Write([]string{
+				r.Time.Format(time.RFC3339),
+				r.Bucket,
+				r.Prefix,
+				strconv.FormatUint(r.Objects, 10),
+				strconv.FormatUint(r.Versions, 10),
+				strconv.FormatUint(r.Size, 10),
+			}); err != nil {
+				return nil, "", err
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), "text/csv", w.Error()
+	default:
+		if records == nil {
+			records = []usageRecord{}
+		}
+		data, err := json.Marshal(records)
+		return data, "application/json", err
+	}
+}
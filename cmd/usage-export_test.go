@@ -0,0 +1,101 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/cmd/config/usageexport"
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+func TestCollectUsageRecords(t *testing.T) {
+	ExecObjectLayerTest(t, testCollectUsageRecords)
+}
+
+func testCollectUsageRecords(objLayer ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	if err := objLayer.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Usage of objects at the root of the bucket and under two
+	// levels of prefixes, as saved by the crawler.
+	cache := dataUsageCache{
+		Info:  dataUsageCacheInfo{Name: "bucket"},
+		Cache: make(map[string]dataUsageEntry),
+	}
+	cache.replace("bucket", dataUsageRoot, dataUsageEntry{Size: 10, Objects: 1, Versions: 1})
+	cache.replace("bucket/logs", "bucket", dataUsageEntry{Size: 100, Objects: 2, Versions: 3})
+	cache.replace("bucket/logs/2020", "bucket/logs", dataUsageEntry{Size: 1000, Objects: 4, Versions: 4})
+	if err := cache.save(ctx, dataUsageCacheStores(objLayer)[0], pathJoin("bucket", dataUsageCacheName)); err != nil {
+		t.Fatal(err)
+	}
+
+	now := UTCNow()
+	records, err := collectUsageRecords(ctx, objLayer, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []usageRecord{
+		{Time: now, Bucket: "bucket", Objects: 7, Versions: 8, Size: 1110},
+		{Time: now, Bucket: "bucket", Prefix: "logs/", Objects: 6, Versions: 7, Size: 1100},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Fatalf("%s: expected %v, got %v", instanceType, expected, records)
+	}
+
+	data, contentType, err := encodeUsageRecords(records, usageexport.FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "text/csv" {
+		t.Fatalf("unexpected content type %s", contentType)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || lines[0] != "time,bucket,prefix,objects,versions,size" || !strings.HasSuffix(lines[2], ",bucket,logs/,6,7,1100") {
+		t.Fatalf("unexpected csv snapshot %q", data)
+	}
+}
+
+func TestUsageExportPost(t *testing.T) {
+	var auth, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		contentType = r.Header.Get(xhttp.ContentType)
+	}))
+	defer server.Close()
+
+	u := &usageExporter{
+		cfg:    usageexport.Config{Endpoint: server.URL, AuthToken: "token"},
+		client: server.Client(),
+	}
+	if err := u.post(context.Background(), []byte("[]"), "application/json"); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("expected bearer token, got %q", auth)
+	}
+	if contentType != "application/json" {
+		t.Errorf("unexpected content type %q", contentType)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -497,11 +498,44 @@ func newInternodeHTTPTransport(tlsConfig *tls.Config, dialTimeout time.Duration)
 		}
 	}
 
+	// Plain text internode calls use HTTP/2 without TLS if enabled,
+	// calls to a node are then multiplexed on a single connection
+	// instead of using a connection per concurrent call.
+	trh2c := &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return tr.DialContext(context.Background(), network, addr)
+		},
+		ReadIdleTimeout:    5 * time.Minute,
+		PingTimeout:        dialTimeout,
+		DisableCompression: true,
+	}
+
 	return func() http.RoundTripper {
-		return tr
+		return internodeTransport{tr: tr, trh2c: trh2c}
 	}
 }
 
+// internodeTransport is the http.RoundTripper used between nodes.
+type internodeTransport struct {
+	tr    *http.Transport
+	trh2c *http2.Transport
+}
+
+// RoundTrip - implements http.RoundTripper.
+func (t internodeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if globalInternodeHTTP2 && req.URL.Scheme == "http" {
+		return t.trh2c.RoundTrip(req)
+	}
+	return t.tr.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of both transports.
+func (t internodeTransport) CloseIdleConnections() {
+	t.tr.CloseIdleConnections()
+	t.trh2c.CloseIdleConnections()
+}
+
 // Used by only proxied requests, specifically only supports HTTP/1.1
 func newCustomHTTPProxyTransport(tlsConfig *tls.Config, dialTimeout time.Duration) func() *http.Transport {
 	// For more details about various values used here refer
@@ -659,6 +693,7 @@ func newContext(r *http.Request, w http.ResponseWriter, api string) context.Cont
 		BucketName:   bucket,
 		ObjectName:   object,
 	}
+	setRequestSpanAPI(r.Context(), api, bucket, object)
 	return logger.SetReqInfo(r.Context(), reqInfo)
 }
 
@@ -23,11 +23,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/minio/minio/cmd/rest"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // Tests maximum object size.
@@ -487,3 +492,34 @@ func TestGetMinioMode(t *testing.T) {
 	testMinioMode(globalMinioModeGatewayPrefix + globalGatewayName)
 
 }
+
+// Tests internode calls use HTTP/2 without TLS when enabled.
+func TestInternodeTransportH2C(t *testing.T) {
+	server := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%d", r.ProtoMajor)
+	}), &http2.Server{}))
+	defer server.Close()
+
+	defer func(enabled bool) { globalInternodeHTTP2 = enabled }(globalInternodeHTTP2)
+
+	client := &http.Client{Transport: newInternodeHTTPTransport(nil, rest.DefaultTimeout)()}
+	for _, enabled := range []bool{false, true} {
+		globalInternodeHTTP2 = enabled
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proto, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "1"
+		if enabled {
+			expected = "2"
+		}
+		if string(proto) != expected {
+			t.Errorf("expected HTTP/%s with HTTP/2 enabled %t, got HTTP/%s", expected, enabled, string(proto))
+		}
+	}
+}
@@ -227,6 +227,14 @@ func (args *PresignedGetArgs) ToKeyValue() KeyValueMap {
 	return km
 }
 
+// ToKeyValue implementation for GetObjectPreviewArgs
+func (args *GetObjectPreviewArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	km.SetObject(args.ObjectName)
+	return km
+}
+
 // newWebContext creates a context with ReqInfo values from the given
 // http request and api name.
 func newWebContext(r *http.Request, args ToKeyValuer, api string) context.Context {
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -705,6 +706,10 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 		return nil
 	}
 
+	if isBucketReadOnlyReplica(args.BucketName) {
+		return toJSONError(ctx, BucketReadOnlyReplica{Bucket: args.BucketName}, args.BucketName)
+	}
+
 	opts := ObjectOptions{
 		Versioned:        globalBucketVersioningSys.Enabled(args.BucketName),
 		VersionSuspended: globalBucketVersioningSys.Suspended(args.BucketName),
@@ -779,6 +784,7 @@ next:
 				deleteTransitionedObject(ctx, newObjectLayerFn(), args.BucketName, objectName, lifecycle.ObjectOpts{
 					Name:         objectName,
 					UserTags:     goi.UserTags,
+					Size:         lifecycleObjectSize(goi),
 					VersionID:    goi.VersionID,
 					DeleteMarker: goi.DeleteMarker,
 					IsLatest:     goi.IsLatest,
@@ -1077,7 +1083,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	// obtain the claims here if possible, for audit logging.
 	claims, owner, authErr := webRequestAuthenticate(r)
 
-	defer logger.AuditLog(w, r, "WebUpload", claims.Map())
+	defer logger.AuditLog(ctx, w, r, "WebUpload", claims.Map())
 
 	objectAPI := web.ObjectAPI()
 	if objectAPI == nil {
@@ -1093,6 +1099,11 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isBucketReadOnlyReplica(bucket) {
+		writeWebErrorResponse(w, BucketReadOnlyReplica{Bucket: bucket})
+		return
+	}
+
 	retPerms := ErrAccessDenied
 	holdPerms := ErrAccessDenied
 	replPerms := ErrAccessDenied
@@ -1313,6 +1324,115 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Content types of the objects which can be previewed in the browser,
+// active content such as SVG or HTML is deliberately not included.
+var objectPreviewTypes = map[string]string{
+	"image/bmp":       "image",
+	"image/gif":       "image",
+	"image/jpeg":      "image",
+	"image/png":       "image",
+	"image/webp":      "image",
+	"application/pdf": "pdf",
+}
+
+// objectPreviewType returns the kind of preview
+// of contentType, empty if it cannot be previewed.
+func objectPreviewType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return objectPreviewTypes[mediaType]
+}
+
+// GetObjectPreviewArgs - get object preview args.
+type GetObjectPreviewArgs struct {
+	BucketName string `json:"bucketName"`
+	ObjectName string `json:"objectName"`
+}
+
+// GetObjectPreviewRep - get object preview reply.
+type GetObjectPreviewRep struct {
+	UIVersion    string    `json:"uiVersion"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+	// Kind of preview, one of "image" or "pdf", empty if
+	// the object cannot be previewed.
+	PreviewType string `json:"previewType"`
+}
+
+// GetObjectPreview - returns the metadata required to preview an object,
+// the object is then displayed inline with /download/...?preview=true.
+func (web *webAPIHandlers) GetObjectPreview(r *http.Request, args *GetObjectPreviewArgs, reply *GetObjectPreviewRep) error {
+	ctx := newWebContext(r, args, "WebGetObjectPreview")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		if authErr != errNoAuthToken {
+			return toJSONError(ctx, authErr)
+		}
+		// Check if anonymous (non-owner) has access to the object.
+		if !globalPolicySys.IsAllowed(policy.Args{
+			Action:          policy.GetObjectAction,
+			BucketName:      args.BucketName,
+			ConditionValues: getConditionValues(r, "", "", nil),
+			IsOwner:         false,
+			ObjectName:      args.ObjectName,
+		}) {
+			return toJSONError(ctx, errAccessDenied)
+		}
+	} else if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.AccessKey,
+		Action:          iampolicy.GetObjectAction,
+		BucketName:      args.BucketName,
+		ConditionValues: getConditionValues(r, "", claims.AccessKey, claims.Map()),
+		IsOwner:         owner,
+		ObjectName:      args.ObjectName,
+		Claims:          claims.Map(),
+	}) {
+		return toJSONError(ctx, errAccessDenied)
+	}
+
+	if args.BucketName == "" || args.ObjectName == "" {
+		return toJSONError(ctx, errInvalidArgument)
+	}
+
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName, args.BucketName)
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if web.CacheAPI() != nil {
+		getObjectInfo = web.CacheAPI().GetObjectInfo
+	}
+
+	objInfo, err := getObjectInfo(ctx, args.BucketName, args.ObjectName, ObjectOptions{})
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	reply.ContentType = objInfo.ContentType
+	reply.Size = size
+	reply.LastModified = objInfo.ModTime
+	// Objects encrypted with SSE-C cannot be read by the browser.
+	if !crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+		reply.PreviewType = objectPreviewType(objInfo.ContentType)
+	}
+	return nil
+}
+
 // Download - file download handler.
 func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "WebDownload")
@@ -1320,7 +1440,7 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 
 	claims, owner, authErr := webTokenAuthenticate(r.URL.Query().Get("token"))
-	defer logger.AuditLog(w, r, "WebDownload", claims.Map())
+	defer logger.AuditLog(ctx, w, r, "WebDownload", claims.Map())
 
 	objectAPI := web.ObjectAPI()
 	if objectAPI == nil {
@@ -1467,8 +1587,14 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Add content disposition.
-	w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("attachment; filename=\"%s\"", path.Base(objInfo.Name)))
+	// Add content disposition, objects which can be previewed
+	// are displayed inline by the browser when requested.
+	disposition := "attachment"
+	if r.URL.Query().Get("preview") == "true" && objectPreviewType(objInfo.ContentType) != "" {
+		disposition = "inline"
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}
+	w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("%s; filename=\"%s\"", disposition, path.Base(objInfo.Name)))
 
 	setHeadGetRespHeaders(w, r.URL.Query())
 
@@ -1517,7 +1643,7 @@ func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 	claims, owner, authErr := webTokenAuthenticate(r.URL.Query().Get("token"))
 
 	ctx := newContext(r, w, "WebDownloadZip")
-	defer logger.AuditLog(w, r, "WebDownloadZip", claims.Map())
+	defer logger.AuditLog(ctx, w, r, "WebDownloadZip", claims.Map())
 
 	objectAPI := web.ObjectAPI()
 	if objectAPI == nil {
@@ -35,6 +35,7 @@ import (
 
 	jwtgo "github.com/dgrijalva/jwt-go"
 	humanize "github.com/dustin/go-humanize"
+	xhttp "github.com/minio/minio/cmd/http"
 	xjwt "github.com/minio/minio/cmd/jwt"
 	"github.com/minio/minio/pkg/hash"
 )
@@ -908,6 +909,111 @@ func testDownloadWebHandler(obj ObjectLayer, instanceType string, t TestErrHandl
 	}
 }
 
+// Test web.GetObjectPreview
+func TestWebHandlerGetObjectPreview(t *testing.T) {
+	ExecObjectLayerTest(t, testWebGetObjectPreviewHandler)
+}
+
+func testWebGetObjectPreviewHandler(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with Erasure/FS object layer.
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalActiveCred
+
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	err = obj.MakeBucketWithLocation(context.Background(), bucketName, BucketOptions{})
+	if err != nil {
+		// failed to create newbucket, abort.
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	content := []byte("temporary file's content")
+	objects := map[string]string{
+		"image.png": "image/png",
+		"doc.pdf":   "application/pdf",
+		"page.html": "text/html",
+		"image.svg": "image/svg+xml",
+	}
+	for objectName, contentType := range objects {
+		metadata := map[string]string{"content-type": contentType}
+		_, err = obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(content), int64(len(content)), "", ""), ObjectOptions{UserDefined: metadata})
+		if err != nil {
+			t.Fatalf("Was not able to upload an object, %v", err)
+		}
+	}
+
+	testCases := []struct {
+		objectName  string
+		previewType string
+		disposition string
+	}{
+		{"image.png", "image", "inline"},
+		{"doc.pdf", "pdf", "inline"},
+		{"page.html", "", "attachment"},
+		{"image.svg", "", "attachment"},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestWebRPCRequest("web.GetObjectPreview", authorization, GetObjectPreviewArgs{
+			BucketName: bucketName,
+			ObjectName: testCase.objectName,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: expected the response status to be 200, but instead found `%d`", i+1, rec.Code)
+		}
+		reply := &GetObjectPreviewRep{}
+		if err = getTestWebRPCResponse(rec, reply); err != nil {
+			t.Fatalf("Test %d: failed, %v", i+1, err)
+		}
+		if reply.PreviewType != testCase.previewType {
+			t.Errorf("Test %d: expected preview type %q, got %q", i+1, testCase.previewType, reply.PreviewType)
+		}
+		if reply.Size != int64(len(content)) || reply.ContentType != objects[testCase.objectName] {
+			t.Errorf("Test %d: unexpected object metadata %v", i+1, reply)
+		}
+
+		token, err := authenticateURL(credentials.AccessKey, credentials.SecretKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec = httptest.NewRecorder()
+		req, err = http.NewRequest(http.MethodGet, "/minio/download/"+bucketName+SlashSeparator+testCase.objectName+"?preview=true&token="+token, nil)
+		if err != nil {
+			t.Fatalf("Cannot create download request, %v", err)
+		}
+		req.Header.Set("User-Agent", "Mozilla")
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: expected the response status to be 200, but instead found `%d`", i+1, rec.Code)
+		}
+		if disposition := rec.Header().Get(xhttp.ContentDisposition); !strings.HasPrefix(disposition, testCase.disposition+";") {
+			t.Errorf("Test %d: expected %s content disposition, got %s", i+1, testCase.disposition, disposition)
+		}
+	}
+
+	// Unauthenticated preview should fail.
+	rec := httptest.NewRecorder()
+	req, err := newTestWebRPCRequest("web.GetObjectPreview", "", GetObjectPreviewArgs{
+		BucketName: bucketName,
+		ObjectName: "image.png",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if err = getTestWebRPCResponse(rec, &GetObjectPreviewRep{}); err == nil {
+		t.Fatalf("Expected unauthenticated preview to fail")
+	}
+}
+
 // Test web.DownloadZip
 func TestWebHandlerDownloadZip(t *testing.T) {
 	ExecObjectLayerTest(t, testWebHandlerDownloadZip)
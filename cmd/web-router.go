@@ -88,7 +88,7 @@ func registerWebRouter(router *mux.Router) error {
 			if globalHTTPTrace.NumSubscribers() > 0 {
 				globalHTTPTrace.Publish(WebTrace(ri))
 			}
-			logger.AuditLog(ri.ResponseWriter, ri.Request, ri.Method, claims.Map())
+			logger.AuditLog(ri.Request.Context(), ri.ResponseWriter, ri.Request, ri.Method, claims.Map())
 		}
 	})
 
@@ -19,12 +19,23 @@ package cmd
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // Detects change in underlying disk.
 type xlStorageDiskIDCheck struct {
 	storage *xlStorage
 	diskID  string
+
+	// IO statistics of the underlying disk.
+	metrics *diskMetrics
+}
+
+func newXLStorageDiskIDCheck(storage *xlStorage) *xlStorageDiskIDCheck {
+	return &xlStorageDiskIDCheck{
+		storage: storage,
+		metrics: globalDiskMetrics.get(storage.diskPath),
+	}
 }
 
 func (p *xlStorageDiskIDCheck) String() string {
@@ -92,11 +103,47 @@ func (p *xlStorageDiskIDCheck) checkDiskStale() error {
 	return errDiskNotFound
 }
 
+// withDeadline runs a drive read or stat operation and waits for it at
+// most the configured storage deadline, so that a hung drive is treated
+// as offline instead of stalling the whole erasure operation. The
+// operation keeps running in the background when the deadline is
+// exceeded, its results must only be used if nil is returned. Mutating
+// operations are never bounded, a timeout would report a failure for a
+// change that may still land on the drive.
+func (p *xlStorageDiskIDCheck) withDeadline(fn func() error) error {
+	return runWithDeadline(globalAPIConfig.getStorageDeadline(), fn)
+}
+
+func runWithDeadline(deadline time.Duration, fn func() error) error {
+	if deadline <= 0 {
+		return fn()
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn()
+	}()
+
+	timer := time.NewTimer(deadline)
+	defer timer.Stop()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-timer.C:
+		return errDiskTimeout
+	}
+}
+
 func (p *xlStorageDiskIDCheck) DiskInfo(ctx context.Context) (info DiskInfo, err error) {
-	info, err = p.storage.DiskInfo(ctx)
-	if err != nil {
+	var di DiskInfo
+	if err = p.withDeadline(func() (err error) {
+		di, err = p.storage.DiskInfo(ctx)
+		return err
+	}); err != nil {
 		return info, err
 	}
+	info = di
 	// check cached diskID against backend
 	// only if its non-empty.
 	if p.diskID != "" {
@@ -104,10 +151,13 @@ func (p *xlStorageDiskIDCheck) DiskInfo(ctx context.Context) (info DiskInfo, err
 			return info, errDiskNotFound
 		}
 	}
+	info.Metrics = p.metrics.snapshot()
 	return info, nil
 }
 
 func (p *xlStorageDiskIDCheck) MakeVolBulk(ctx context.Context, volumes ...string) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
@@ -115,27 +165,49 @@ func (p *xlStorageDiskIDCheck) MakeVolBulk(ctx context.Context, volumes ...strin
 }
 
 func (p *xlStorageDiskIDCheck) MakeVol(ctx context.Context, volume string) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 	return p.storage.MakeVol(ctx, volume)
 }
 
-func (p *xlStorageDiskIDCheck) ListVols(ctx context.Context) ([]VolInfo, error) {
-	if err := p.checkDiskStale(); err != nil {
+func (p *xlStorageDiskIDCheck) ListVols(ctx context.Context) (vols []VolInfo, err error) {
+	defer p.metrics.record(diskOpStat, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return nil, err
 	}
-	return p.storage.ListVols(ctx)
+	var result []VolInfo
+	if err = p.withDeadline(func() (err error) {
+		result, err = p.storage.ListVols(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 func (p *xlStorageDiskIDCheck) StatVol(ctx context.Context, volume string) (vol VolInfo, err error) {
+	defer p.metrics.record(diskOpStat, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return vol, err
 	}
-	return p.storage.StatVol(ctx, volume)
+	var result VolInfo
+	if err = p.withDeadline(func() (err error) {
+		result, err = p.storage.StatVol(ctx, volume)
+		return err
+	}); err != nil {
+		return VolInfo{}, err
+	}
+	return result, nil
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVol(ctx context.Context, volume string, forceDelete bool) (err error) {
+	defer p.metrics.record(diskOpDelete, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
@@ -149,56 +221,92 @@ func (p *xlStorageDiskIDCheck) WalkVersions(ctx context.Context, volume, dirPath
 	return p.storage.WalkVersions(ctx, volume, dirPath, marker, recursive, endWalkCh)
 }
 
-func (p *xlStorageDiskIDCheck) ListDir(ctx context.Context, volume, dirPath string, count int) ([]string, error) {
-	if err := p.checkDiskStale(); err != nil {
+func (p *xlStorageDiskIDCheck) ListDir(ctx context.Context, volume, dirPath string, count int) (entries []string, err error) {
+	defer p.metrics.record(diskOpStat, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return nil, err
 	}
 
-	return p.storage.ListDir(ctx, volume, dirPath, count)
+	var result []string
+	if err = p.withDeadline(func() (err error) {
+		result, err = p.storage.ListDir(ctx, volume, dirPath, count)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 func (p *xlStorageDiskIDCheck) ReadFile(ctx context.Context, volume string, path string, offset int64, buf []byte, verifier *BitrotVerifier) (n int64, err error) {
-	if err := p.checkDiskStale(); err != nil {
+	defer p.metrics.record(diskOpRead, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return 0, err
 	}
 
-	return p.storage.ReadFile(ctx, volume, path, offset, buf, verifier)
+	n, err = p.storage.ReadFile(ctx, volume, path, offset, buf, verifier)
+	p.metrics.addBytes(diskOpRead, n)
+	return n, err
 }
 
 func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, path string, buf []byte) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.AppendFile(ctx, volume, path, buf)
+	if err = p.storage.AppendFile(ctx, volume, path, buf); err != nil {
+		return err
+	}
+	p.metrics.addBytes(diskOpWrite, int64(len(buf)))
+	return nil
 }
 
-func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path string, size int64, reader io.Reader) error {
-	if err := p.checkDiskStale(); err != nil {
+func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path string, size int64, reader io.Reader) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.CreateFile(ctx, volume, path, size, reader)
+	if err = p.storage.CreateFile(ctx, volume, path, size, reader); err != nil {
+		return err
+	}
+	p.metrics.addBytes(diskOpWrite, size)
+	return nil
 }
 
-func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (io.ReadCloser, error) {
-	if err := p.checkDiskStale(); err != nil {
+func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (rc io.ReadCloser, err error) {
+	defer p.metrics.record(diskOpRead, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return nil, err
 	}
 
-	return p.storage.ReadFileStream(ctx, volume, path, offset, length)
+	rc, err = p.storage.ReadFileStream(ctx, volume, path, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	p.metrics.addBytes(diskOpRead, length)
+	return rc, nil
 }
 
-func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPath, dstVolume, dstPath string) error {
-	if err := p.checkDiskStale(); err != nil {
+func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPath, dstVolume, dstPath string) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
 	return p.storage.RenameFile(ctx, srcVolume, srcPath, dstVolume, dstPath)
 }
 
-func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPath, dataDir, dstVolume, dstPath string) error {
-	if err := p.checkDiskStale(); err != nil {
+func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPath, dataDir, dstVolume, dstPath string) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
@@ -206,22 +314,32 @@ func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPat
 }
 
 func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (err error) {
+	defer p.metrics.record(diskOpStat, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.CheckParts(ctx, volume, path, fi)
+	return p.withDeadline(func() error {
+		return p.storage.CheckParts(ctx, volume, path, fi)
+	})
 }
 
 func (p *xlStorageDiskIDCheck) CheckFile(ctx context.Context, volume string, path string) (err error) {
+	defer p.metrics.record(diskOpStat, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.CheckFile(ctx, volume, path)
+	return p.withDeadline(func() error {
+		return p.storage.CheckFile(ctx, volume, path)
+	})
 }
 
 func (p *xlStorageDiskIDCheck) Delete(ctx context.Context, volume string, path string, recursive bool) (err error) {
+	defer p.metrics.record(diskOpDelete, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
@@ -230,6 +348,16 @@ func (p *xlStorageDiskIDCheck) Delete(ctx context.Context, volume string, path s
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVersions(ctx context.Context, volume string, versions []FileInfo) (errs []error) {
+	defer func(start time.Time) {
+		var err error
+		for _, err = range errs {
+			if err != nil {
+				break
+			}
+		}
+		p.metrics.record(diskOpDelete, start, &err)
+	}(time.Now())
+
 	if err := p.checkDiskStale(); err != nil {
 		errs = make([]error, len(versions))
 		for i := range errs {
@@ -240,8 +368,10 @@ func (p *xlStorageDiskIDCheck) DeleteVersions(ctx context.Context, volume string
 	return p.storage.DeleteVersions(ctx, volume, versions)
 }
 
-func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error {
-	if err := p.checkDiskStale(); err != nil {
+func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (err error) {
+	defer p.metrics.record(diskOpRead, time.Now(), &err)
+
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
@@ -249,14 +379,22 @@ func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path stri
 }
 
 func (p *xlStorageDiskIDCheck) WriteAll(ctx context.Context, volume string, path string, b []byte) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.WriteAll(ctx, volume, path, b)
+	if err = p.storage.WriteAll(ctx, volume, path, b); err != nil {
+		return err
+	}
+	p.metrics.addBytes(diskOpWrite, int64(len(b)))
+	return nil
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVersion(ctx context.Context, volume, path string, fi FileInfo) (err error) {
+	defer p.metrics.record(diskOpDelete, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
@@ -265,6 +403,8 @@ func (p *xlStorageDiskIDCheck) DeleteVersion(ctx context.Context, volume, path s
 }
 
 func (p *xlStorageDiskIDCheck) WriteMetadata(ctx context.Context, volume, path string, fi FileInfo) (err error) {
+	defer p.metrics.record(diskOpWrite, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
@@ -273,17 +413,36 @@ func (p *xlStorageDiskIDCheck) WriteMetadata(ctx context.Context, volume, path s
 }
 
 func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, versionID string) (fi FileInfo, err error) {
+	defer p.metrics.record(diskOpRead, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return fi, err
 	}
 
-	return p.storage.ReadVersion(ctx, volume, path, versionID)
+	var result FileInfo
+	if err = p.withDeadline(func() (err error) {
+		result, err = p.storage.ReadVersion(ctx, volume, path, versionID)
+		return err
+	}); err != nil {
+		return FileInfo{}, err
+	}
+	return result, nil
 }
 
 func (p *xlStorageDiskIDCheck) ReadAll(ctx context.Context, volume string, path string) (buf []byte, err error) {
+	defer p.metrics.record(diskOpRead, time.Now(), &err)
+
 	if err = p.checkDiskStale(); err != nil {
 		return nil, err
 	}
 
-	return p.storage.ReadAll(ctx, volume, path)
+	var data []byte
+	if err = p.withDeadline(func() (err error) {
+		data, err = p.storage.ReadAll(ctx, volume, path)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	p.metrics.addBytes(diskOpRead, int64(len(data)))
+	return data, nil
 }
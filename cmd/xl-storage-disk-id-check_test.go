@@ -0,0 +1,51 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunWithDeadline(t *testing.T) {
+	// Operations are not bounded without a deadline.
+	if err := runWithDeadline(0, func() error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := runWithDeadline(time.Second, func() error {
+		return errFileNotFound
+	}); err != errFileNotFound {
+		t.Fatalf("expected %v, got %v", errFileNotFound, err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	start := time.Now()
+	if err := runWithDeadline(50*time.Millisecond, func() error {
+		<-release
+		return nil
+	}); err != errDiskTimeout {
+		t.Fatalf("expected %v, got %v", errDiskTimeout, err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("expected the hung operation to be abandoned at the deadline")
+	}
+}
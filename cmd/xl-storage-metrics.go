@@ -0,0 +1,248 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// diskOp is the type of a drive operation.
+type diskOp int
+
+// All types of drive operations for which statistics are kept.
+const (
+	diskOpRead diskOp = iota
+	diskOpWrite
+	diskOpDelete
+	diskOpStat
+
+	diskOpLast
+)
+
+func (op diskOp) String() string {
+	switch op {
+	case diskOpRead:
+		return "read"
+	case diskOpWrite:
+		return "write"
+	case diskOpDelete:
+		return "delete"
+	case diskOpStat:
+		return "stat"
+	}
+	return "unknown"
+}
+
+// diskLatencyBuckets are the upper bounds of the drive operation
+// latency buckets, an additional last bucket has no upper bound.
+var diskLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Errors which are part of the normal operation of a
+// drive, these are not accounted as drive errors.
+var diskMetricsIgnoredErrs = []error{
+	errFileNotFound,
+	errFileVersionNotFound,
+	errVolumeNotFound,
+	errVolumeExists,
+	errVolumeNotEmpty,
+	errFileAccessDenied,
+	errIsNotRegular,
+}
+
+type diskOpMetrics struct {
+	count      uint64
+	errors     uint64
+	bytes      uint64
+	latencySum uint64
+	latency    []uint64
+}
+
+// diskMetrics keeps the operation counts, bytes and
+// latencies of a drive, updated atomically.
+type diskMetrics struct {
+	ops [diskOpLast]diskOpMetrics
+}
+
+func newDiskMetrics() *diskMetrics {
+	m := &diskMetrics{}
+	for i := range m.ops {
+		m.ops[i].latency = make([]uint64, len(diskLatencyBuckets)+1)
+	}
+	return m
+}
+
+// record records an operation of type op, started at start
+// and which failed with *err if not nil.
+func (m *diskMetrics) record(op diskOp, start time.Time, err *error) {
+	if m == nil {
+		return
+	}
+	duration := time.Since(start)
+	o := &m.ops[op]
+	atomic.AddUint64(&o.count, 1)
+	if err != nil && *err != nil && !IsErrIgnored(*err, diskMetricsIgnoredErrs...) {
+		atomic.AddUint64(&o.errors, 1)
+	}
+	bucket := sort.Search(len(diskLatencyBuckets), func(i int) bool {
+		return duration <= diskLatencyBuckets[i]
+	})
+	atomic.AddUint64(&o.latency[bucket], 1)
+	atomic.AddUint64(&o.latencySum, uint64(duration))
+}
+
+// addBytes accounts n bytes read or written by an operation of type op.
+func (m *diskMetrics) addBytes(op diskOp, n int64) {
+	if m == nil || n <= 0 {
+		return
+	}
+	atomic.AddUint64(&m.ops[op].bytes, uint64(n))
+}
+
+// snapshot returns a copy of the current drive statistics.
+func (m *diskMetrics) snapshot() DiskMetrics {
+	if m == nil {
+		return DiskMetrics{}
+	}
+	load := func(op diskOp) DiskOpMetrics {
+		o := &m.ops[op]
+		s := DiskOpMetrics{
+			Count:      atomic.LoadUint64(&o.count),
+			Errors:     atomic.LoadUint64(&o.errors),
+			Bytes:      atomic.LoadUint64(&o.bytes),
+			Latency:    make([]uint64, len(o.latency)),
+			LatencySum: atomic.LoadUint64(&o.latencySum),
+		}
+		for i := range o.latency {
+			s.Latency[i] = atomic.LoadUint64(&o.latency[i])
+		}
+		return s
+	}
+	return DiskMetrics{
+		Read:   load(diskOpRead),
+		Write:  load(diskOpWrite),
+		Delete: load(diskOpDelete),
+		Stat:   load(diskOpStat),
+	}
+}
+
+// diskMetricsMap holds the statistics of all local drives, the
+// statistics of a drive are shared by all its storage instances.
+type diskMetricsMap struct {
+	sync.Mutex
+	disks map[string]*diskMetrics
+}
+
+// get returns the statistics of the drive at diskPath.
+func (d *diskMetricsMap) get(diskPath string) *diskMetrics {
+	d.Lock()
+	defer d.Unlock()
+	if d.disks == nil {
+		d.disks = make(map[string]*diskMetrics)
+	}
+	m, ok := d.disks[diskPath]
+	if !ok {
+		m = newDiskMetrics()
+		d.disks[diskPath] = m
+	}
+	return m
+}
+
+// snapshot returns a copy of the statistics of all local drives.
+func (d *diskMetricsMap) snapshot() map[string]DiskMetrics {
+	d.Lock()
+	defer d.Unlock()
+	metrics := make(map[string]DiskMetrics, len(d.disks))
+	for diskPath, m := range d.disks {
+		metrics[diskPath] = m.snapshot()
+	}
+	return metrics
+}
+
+var globalDiskMetrics diskMetricsMap
+
+// ops returns the statistics of each type of drive operation.
+func (m DiskMetrics) ops() map[diskOp]DiskOpMetrics {
+	return map[diskOp]DiskOpMetrics{
+		diskOpRead:   m.Read,
+		diskOpWrite:  m.Write,
+		diskOpDelete: m.Delete,
+		diskOpStat:   m.Stat,
+	}
+}
+
+// percentile returns the upper bound of the latency bucket in which
+// the given percentile of the operations fall, operations slower
+// than the last bucket bound are reported with the last bound.
+func (m DiskOpMetrics) percentile(p float64) time.Duration {
+	var total uint64
+	for _, n := range m.Latency {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(p * float64(total)))
+	var cumulative uint64
+	for i, n := range m.Latency {
+		cumulative += n
+		if cumulative >= rank {
+			if i >= len(diskLatencyBuckets) {
+				break
+			}
+			return diskLatencyBuckets[i]
+		}
+	}
+	return diskLatencyBuckets[len(diskLatencyBuckets)-1]
+}
+
+func (m DiskOpMetrics) toAdmin() madmin.DiskIOStats {
+	return madmin.DiskIOStats{
+		Count:      m.Count,
+		Errors:     m.Errors,
+		Bytes:      m.Bytes,
+		LatencyP50: m.percentile(0.50),
+		LatencyP90: m.percentile(0.90),
+		LatencyP99: m.percentile(0.99),
+	}
+}
+
+// toAdmin converts the drive statistics to the admin API format.
+func (m DiskMetrics) toAdmin() *madmin.DiskMetrics {
+	return &madmin.DiskMetrics{
+		Read:   m.Read.toAdmin(),
+		Write:  m.Write.toAdmin(),
+		Delete: m.Delete.toAdmin(),
+		Stat:   m.Stat.toAdmin(),
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDiskMetrics(t *testing.T) {
+	m := newDiskMetrics()
+
+	now := time.Now()
+	for i := 0; i < 9; i++ {
+		m.record(diskOpRead, now, nil)
+	}
+	err := errors.New("slow disk")
+	m.record(diskOpRead, now.Add(-2*time.Second), &err)
+	m.addBytes(diskOpRead, 4096)
+
+	err = errFileNotFound
+	m.record(diskOpStat, now, &err)
+
+	s := m.snapshot()
+	if s.Read.Count != 10 || s.Read.Errors != 1 || s.Read.Bytes != 4096 {
+		t.Errorf("unexpected read statistics %+v", s.Read)
+	}
+	if s.Stat.Count != 1 || s.Stat.Errors != 0 {
+		t.Errorf("expected ignored errors not to be accounted, got %+v", s.Stat)
+	}
+	if s.Write.Count != 0 || s.Delete.Count != 0 {
+		t.Errorf("expected no write and delete operations, got %+v %+v", s.Write, s.Delete)
+	}
+
+	if p := s.Read.percentile(0.5); p != time.Millisecond {
+		t.Errorf("expected p50 latency %v, got %v", time.Millisecond, p)
+	}
+	if p := s.Read.percentile(0.99); p != 5*time.Second {
+		t.Errorf("expected p99 latency %v, got %v", 5*time.Second, p)
+	}
+	if p := s.Write.percentile(0.99); p != 0 {
+		t.Errorf("expected no latency without operations, got %v", p)
+	}
+
+	// A nil metrics is a no-op.
+	var nm *diskMetrics
+	nm.record(diskOpRead, now, nil)
+	nm.addBytes(diskOpRead, 1)
+	if s := nm.snapshot(); s.Read.Count != 0 {
+		t.Errorf("expected empty statistics, got %+v", s.Read)
+	}
+}
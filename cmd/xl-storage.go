@@ -239,7 +239,7 @@ func newLocalXLStorage(path string) (*xlStorage, error) {
 func newXLStorage(ep Endpoint) (*xlStorage, error) {
 	path := ep.Path
 	var err error
-	if path, err = getValidPath(path, true); err != nil {
+	if path, err = getValidPath(path, globalDirectIO); err != nil {
 		return nil, err
 	}
 
@@ -390,17 +390,22 @@ func (s *xlStorage) CrawlAndGetDataUsage(ctx context.Context, cache dataUsageCac
 		var totalSize int64
 		var numVersions = len(fivs.Versions)
 
-		sizeS := sizeSummary{}
+		sizeS := sizeSummary{versions: uint64(numVersions)}
 		for i, version := range fivs.Versions {
 			var successorModTime time.Time
+			var newerNoncurrent int
 			if i > 0 {
 				successorModTime = fivs.Versions[i-1].ModTime
+				// Versions are sorted latest first, all the versions
+				// between the latest one and this one are noncurrent.
+				newerNoncurrent = i - 1
 			}
 			oi := version.ToObjectInfo(item.bucket, item.objectPath())
 			if objAPI != nil {
 				totalSize += item.applyActions(ctx, objAPI, actionMeta{
 					numVersions:      numVersions,
 					successorModTime: successorModTime,
+					newerNoncurrent:  newerNoncurrent,
 					oi:               oi,
 					bitRotScan:       healOpts.Bitrot,
 				})
@@ -1338,6 +1343,13 @@ func (s *xlStorage) openFile(volume, path string, mode int) (f *os.File, err err
 	return w, nil
 }
 
+// useDirectIO returns true if a file of the given size, or
+// of unknown size if negative, must be read or written with
+// O_DIRECT, avoiding caching large files in the page cache.
+func useDirectIO(size int64) bool {
+	return globalDirectIO && (size < 0 || size >= globalStorageClass.GetDMAThreshold())
+}
+
 // To support O_DIRECT reads for erasure backends.
 type odirectReader struct {
 	f         *os.File
@@ -1405,7 +1417,7 @@ func (s *xlStorage) ReadFileStream(ctx context.Context, volume, path string, off
 		return nil, err
 	}
 
-	if offset == 0 && globalStorageClass.GetDMA() == storageclass.DMAReadWrite {
+	if offset == 0 && globalStorageClass.GetDMA() == storageclass.DMAReadWrite && useDirectIO(length) {
 		file, err := disk.OpenFileDirectIO(filePath, os.O_RDONLY, 0666)
 		if err != nil {
 			switch {
@@ -1551,7 +1563,12 @@ func (s *xlStorage) CreateFile(ctx context.Context, volume, path string, fileSiz
 		return err
 	}
 
-	w, err := disk.OpenFileDirectIO(filePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	var w *os.File
+	if useDirectIO(fileSize) {
+		w, err = disk.OpenFileDirectIO(filePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	} else {
+		w, err = os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	}
 	if err != nil {
 		switch {
 		case osIsPermission(err):
@@ -84,3 +84,36 @@ func (m *MonitoredReader) Close() error {
 	}
 	return nil
 }
+
+// Throttle is a bandwidth limit shared by a set of readers.
+type Throttle struct {
+	throttle *throttle
+}
+
+// NewThrottle returns a bandwidth limit of bytesPerSecond shared by all
+// readers created from it, set bytesPerSecond to 0 for no limit.
+func NewThrottle(ctx context.Context, bytesPerSecond int64) *Throttle {
+	return &Throttle{throttle: newThrottle(ctx, bytesPerSecond, bytesPerSecond)}
+}
+
+// ThrottledReader limits the rate at which data is read, independent
+// of the bandwidth measured by the monitor.
+type ThrottledReader struct {
+	reader   io.Reader
+	throttle *throttle
+}
+
+// NewReader returns a reader reading from reader within the bandwidth limit.
+func (t *Throttle) NewReader(reader io.Reader) *ThrottledReader {
+	return &ThrottledReader{reader: reader, throttle: t.throttle}
+}
+
+// Read wraps the read reader
+func (t *ThrottledReader) Read(p []byte) (n int, err error) {
+	p = p[:t.throttle.GetLimitForBytes(int64(len(p)))]
+	n, err = t.reader.Read(p)
+	if unused := len(p) - n; unused > 0 {
+		t.throttle.ReleaseUnusedBandwidth(int64(unused))
+	}
+	return
+}
@@ -0,0 +1,69 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestThrottledReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	data := bytes.Repeat([]byte("a"), int(oneMiB))
+
+	// No limit.
+	start := time.Now()
+	n, err := io.Copy(ioutil.Discard, NewThrottle(ctx, 0).NewReader(bytes.NewReader(data)))
+	if err != nil || n != int64(len(data)) {
+		t.Fatalf("expected %d bytes, got %d: %v", len(data), n, err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("unlimited read took %v", time.Since(start))
+	}
+
+	// 1 MiB at 2 MiB/s takes at least one throttle interval.
+	throttle := NewThrottle(ctx, int64(2*oneMiB))
+	start = time.Now()
+	n, err = io.Copy(ioutil.Discard, throttle.NewReader(bytes.NewReader(data)))
+	if err != nil || n != int64(len(data)) {
+		t.Fatalf("expected %d bytes, got %d: %v", len(data), n, err)
+	}
+	if elapsed := time.Since(start); elapsed < throttleInternal {
+		t.Errorf("throttled read took %v, expected at least %v", elapsed, throttleInternal)
+	}
+}
+
+func TestThrottleMinimumInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Limits below one byte per interval must not turn into no limit.
+	throttle := NewThrottle(ctx, 1)
+	defer throttle.throttle.generateTicker.Stop()
+	if bpi := throttle.throttle.bytesPerInterval; bpi != 1 {
+		t.Fatalf("expected 1 byte per interval, got %d", bpi)
+	}
+	if n := throttle.throttle.GetLimitForBytes(1024); n != 1 {
+		t.Errorf("expected 1 byte to be allowed, got %d", n)
+	}
+}
@@ -90,6 +90,10 @@ func (t *throttle) GetLimitForBytes(want int64) int64 {
 // SetBandwidth sets a new bandwidth limit in bytes per second.
 func (t *throttle) SetBandwidth(bandwidthBiPS int64, clusterBandwidth int64) {
 	bpi := int64(throttleInternal) * bandwidthBiPS / int64(time.Second)
+	if bpi == 0 && bandwidthBiPS > 0 {
+		// A zero interval is no limit, allow at least one byte.
+		bpi = 1
+	}
 	atomic.StoreInt64(&t.bytesPerInterval, bpi)
 }
 
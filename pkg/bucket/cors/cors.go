@@ -0,0 +1,164 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// Maximum number of rules of a CORS configuration.
+const maxRules = 100
+
+// Methods which may be allowed by a CORS rule.
+var allowedMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodPut:    {},
+	http.MethodHead:   {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+}
+
+// Rule - a CORS rule, cross-origin requests are allowed if they
+// match the origins, methods and headers of a rule.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Config - CORS configuration of a bucket.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"CORSConfiguration"`
+	Rules   []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS rule.
+func (r Rule) Validate() error {
+	if len(r.ID) > 255 {
+		return Errorf("ID must be less than 255 characters")
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return Errorf("at least one AllowedOrigin must be specified")
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return Errorf("AllowedOrigin '%s' can not have more than one wildcard", origin)
+		}
+	}
+	if len(r.AllowedMethods) == 0 {
+		return Errorf("at least one AllowedMethod must be specified")
+	}
+	for _, method := range r.AllowedMethods {
+		if _, ok := allowedMethods[method]; !ok {
+			return Errorf("found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return Errorf("AllowedHeader '%s' can not have more than one wildcard", header)
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return Errorf("MaxAgeSeconds must not be negative")
+	}
+	return nil
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return Errorf("at least one CORSRule must be specified")
+	}
+	if len(c.Rules) > maxRules {
+		return Errorf("CORS configuration can not have more than %d rules", maxRules)
+	}
+	for _, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchAny returns true if value matches any of the patterns,
+// patterns may contain a '*' wildcard.
+func matchAny(patterns []string, value string, caseInsensitive bool) bool {
+	if caseInsensitive {
+		value = strings.ToLower(value)
+	}
+	for _, pattern := range patterns {
+		if caseInsensitive {
+			pattern = strings.ToLower(pattern)
+		}
+		if wildcard.MatchSimple(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match - returns the first rule allowing a cross-origin request
+// from origin with method and the given request headers, nil is
+// returned if the request is not allowed.
+func (c Config) Match(origin, method string, headers []string) *Rule {
+	for i, rule := range c.Rules {
+		if !matchAny(rule.AllowedOrigins, origin, false) {
+			continue
+		}
+		allowed := false
+		for _, m := range rule.AllowedMethods {
+			if m == method {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			continue
+		}
+		for _, header := range headers {
+			if !matchAny(rule.AllowedHeaders, header, true) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return &c.Rules[i]
+		}
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to CORSConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
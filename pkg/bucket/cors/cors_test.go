@@ -0,0 +1,103 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		expectErr bool
+	}{
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+		},
+		// No rules.
+		{
+			input:     `<CORSConfiguration></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// No origin.
+		{
+			input:     `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Unsupported method.
+		{
+			input:     `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Several wildcards in origin.
+		{
+			input:     `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Malformed XML.
+		{
+			input:     `<CORSConfiguration><CORSRule>`,
+			expectErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.input))
+		if testCase.expectErr && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+		if !testCase.expectErr && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<CORSConfiguration>
+<CORSRule><ID>read</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>HEAD</AllowedMethod></CORSRule>
+<CORSRule><ID>write</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>Content-*</AllowedHeader><AllowedHeader>x-amz-date</AllowedHeader></CORSRule>
+</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+	}{
+		{origin: "https://any.org", method: "GET", expectedID: "read"},
+		{origin: "https://any.org", method: "PUT"},
+		{origin: "https://www.example.com", method: "PUT", headers: []string{"content-type", "X-Amz-Date"}, expectedID: "write"},
+		{origin: "https://www.example.com", method: "PUT", headers: []string{"authorization"}},
+		{origin: "http://www.example.com", method: "PUT"},
+		{origin: "https://www.example.com", method: "DELETE"},
+	}
+
+	for i, testCase := range testCases {
+		rule := config.Match(testCase.origin, testCase.method, testCase.headers)
+		var id string
+		if rule != nil {
+			id = rule.ID
+		}
+		if id != testCase.expectedID {
+			t.Errorf("Test %d: expected rule %q, got %q", i+1, testCase.expectedID, id)
+		}
+	}
+}
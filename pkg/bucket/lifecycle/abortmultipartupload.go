@@ -0,0 +1,48 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+)
+
+var (
+	errAbortIncompleteMultipartUploadTags = Errorf("AbortIncompleteMultipartUpload cannot be specified with Tags.")
+	errAbortIncompleteMultipartUploadSize = Errorf("AbortIncompleteMultipartUpload cannot be specified with an object size filter.")
+)
+
+// AbortIncompleteMultipartUpload - an action for lifecycle configuration rule
+// removing the multipart uploads not completed within the given number of
+// days after they were initiated.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name       `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation ExpirationDays `xml:"DaysAfterInitiation,omitempty"`
+}
+
+// MarshalXML if days after initiation is set to non zero value
+func (a AbortIncompleteMultipartUpload) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.IsDaysNull() {
+		return nil
+	}
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	return e.EncodeElement(abortIncompleteMultipartUploadWrapper(a), start)
+}
+
+// IsDaysNull returns true if days field is null
+func (a AbortIncompleteMultipartUpload) IsDaysNull() bool {
+	return a.DaysAfterInitiation == ExpirationDays(0)
+}
@@ -20,18 +20,22 @@ import (
 	"encoding/xml"
 )
 
-// And - a tag to combine a prefix and multiple tags for lifecycle configuration rule.
+// And - a tag to combine a prefix, multiple tags and an object size range
+// for lifecycle configuration rule.
 type And struct {
-	XMLName xml.Name `xml:"And"`
-	Prefix  string   `xml:"Prefix,omitempty"`
-	Tags    []Tag    `xml:"Tag,omitempty"`
+	XMLName               xml.Name `xml:"And"`
+	Prefix                string   `xml:"Prefix,omitempty"`
+	Tags                  []Tag    `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan int64    `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64    `xml:"ObjectSizeLessThan,omitempty"`
 }
 
 var errDuplicateTagKey = Errorf("Duplicate Tag Keys are not allowed")
 
-// isEmpty returns true if Tags field is null
+// isEmpty returns true if none of the And fields are set
 func (a And) isEmpty() bool {
-	return len(a.Tags) == 0 && a.Prefix == ""
+	return len(a.Tags) == 0 && a.Prefix == "" &&
+		a.ObjectSizeGreaterThan == 0 && a.ObjectSizeLessThan == 0
 }
 
 // Validate - validates the And field
@@ -44,7 +48,7 @@ func (a And) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return validateObjectSize(a.ObjectSizeGreaterThan, a.ObjectSizeLessThan)
 }
 
 // ContainsDuplicateTag - returns true if duplicate keys are present in And
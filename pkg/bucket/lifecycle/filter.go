@@ -21,7 +21,8 @@ import (
 )
 
 var (
-	errInvalidFilter = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errInvalidFilter     = Errorf("Filter must have exactly one of Prefix, Tag, ObjectSizeGreaterThan, ObjectSizeLessThan or And specified")
+	errInvalidObjectSize = Errorf("ObjectSizeGreaterThan and ObjectSizeLessThan must be positive and ObjectSizeGreaterThan must be less than ObjectSizeLessThan")
 )
 
 // Filter - a filter for a lifecycle configuration Rule.
@@ -31,12 +32,16 @@ type Filter struct {
 	And     And
 	Tag     Tag
 
+	ObjectSizeGreaterThan int64 `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64 `xml:"ObjectSizeLessThan,omitempty"`
+
 	// Caching tags, only once
 	cachedTags []string
 }
 
 // MarshalXML - produces the xml representation of the Filter struct
-// only one of Prefix, And and Tag should be present in the output.
+// only one of Prefix, And, Tag and the object size elements should be
+// present in the output.
 func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start); err != nil {
 		return err
@@ -51,8 +56,16 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
 			return err
 		}
+	case f.ObjectSizeGreaterThan != 0:
+		if err := e.EncodeElement(f.ObjectSizeGreaterThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeGreaterThan"}}); err != nil {
+			return err
+		}
+	case f.ObjectSizeLessThan != 0:
+		if err := e.EncodeElement(f.ObjectSizeLessThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeLessThan"}}); err != nil {
+			return err
+		}
 	default:
-		// Always print Prefix field when both And & Tag are empty
+		// Always print Prefix field when And, Tag and the object size elements are empty
 		if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
 			return err
 		}
@@ -63,28 +76,45 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 
 // Validate - validates the filter element
 func (f Filter) Validate() error {
-	// A Filter must have exactly one of Prefix, Tag, or And specified.
-	if !f.And.isEmpty() {
-		if f.Prefix != "" {
-			return errInvalidFilter
-		}
-		if !f.Tag.IsEmpty() {
-			return errInvalidFilter
+	// A Filter must have exactly one of Prefix, Tag, ObjectSizeGreaterThan,
+	// ObjectSizeLessThan or And specified.
+	var n int
+	for _, set := range []bool{
+		f.Prefix != "",
+		!f.Tag.IsEmpty(),
+		f.ObjectSizeGreaterThan != 0,
+		f.ObjectSizeLessThan != 0,
+		!f.And.isEmpty(),
+	} {
+		if set {
+			n++
 		}
+	}
+	if n > 1 {
+		return errInvalidFilter
+	}
+	if !f.And.isEmpty() {
 		if err := f.And.Validate(); err != nil {
 			return err
 		}
 	}
-	if f.Prefix != "" {
-		if !f.Tag.IsEmpty() {
-			return errInvalidFilter
-		}
-	}
 	if !f.Tag.IsEmpty() {
 		if err := f.Tag.Validate(); err != nil {
 			return err
 		}
 	}
+	return validateObjectSize(f.ObjectSizeGreaterThan, f.ObjectSizeLessThan)
+}
+
+// validateObjectSize validates the object size range of a filter, a zero
+// value means the corresponding bound is not set.
+func validateObjectSize(greaterThan, lessThan int64) error {
+	if greaterThan < 0 || lessThan < 0 {
+		return errInvalidObjectSize
+	}
+	if greaterThan > 0 && lessThan > 0 && greaterThan >= lessThan {
+		return errInvalidObjectSize
+	}
 	return nil
 }
 
@@ -114,3 +144,26 @@ func (f Filter) TestTags(tags []string) bool {
 	}
 	return true
 }
+
+// TestSize tests if the object size satisfies the Filter object size
+// requirements, it returns true if there is no size limit in the
+// underlying Filter.
+func (f Filter) TestSize(size int64) bool {
+	greaterThan, lessThan := f.ObjectSizeGreaterThan, f.ObjectSizeLessThan
+	if !f.And.isEmpty() {
+		greaterThan, lessThan = f.And.ObjectSizeGreaterThan, f.And.ObjectSizeLessThan
+	}
+	if greaterThan > 0 && size <= greaterThan {
+		return false
+	}
+	if lessThan > 0 && size >= lessThan {
+		return false
+	}
+	return true
+}
+
+// hasSize returns true if the filter limits the size of the objects.
+func (f Filter) hasSize() bool {
+	return f.ObjectSizeGreaterThan != 0 || f.ObjectSizeLessThan != 0 ||
+		f.And.ObjectSizeGreaterThan != 0 || f.And.ObjectSizeLessThan != 0
+}
@@ -107,6 +107,49 @@ func TestUnsupportedFilters(t *testing.T) {
 						</Filter>`,
 			expectedErr: errInvalidFilter,
 		},
+		{ // Filter with ObjectSizeGreaterThan tag
+			inputXML: ` <Filter>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter without And, Prefix & ObjectSizeLessThan tags
+			inputXML: ` <Filter>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeLessThan>1024</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidFilter,
+		},
+		{ // Filter with And, Prefix, Tag & object size range tags
+			inputXML: ` <Filter>
+							<And>
+							<Prefix>key-prefix</Prefix>
+							<Tag>
+								<Key>key1</Key>
+								<Value>value1</Value>
+							</Tag>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>4096</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with And and an empty object size range
+			inputXML: ` <Filter>
+							<And>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeGreaterThan>4096</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>1024</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: errInvalidObjectSize,
+		},
+		{ // Filter with a negative ObjectSizeLessThan
+			inputXML: ` <Filter>
+							<ObjectSizeLessThan>-1</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidObjectSize,
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
@@ -94,7 +94,7 @@ func (lc Lifecycle) HasActiveRules(prefix string, recursive bool) bool {
 			}
 		}
 
-		if rule.NoncurrentVersionExpiration.NoncurrentDays > 0 {
+		if !rule.NoncurrentVersionExpiration.IsNull() {
 			return true
 		}
 		if rule.NoncurrentVersionTransition.NoncurrentDays > 0 {
@@ -157,7 +157,7 @@ func (lc Lifecycle) Validate() error {
 }
 
 // FilterActionableRules returns the rules actions that need to be executed
-// after evaluating prefix/tag filtering
+// after evaluating prefix/tag/object size filtering
 func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 	if obj.Name == "" {
 		return nil
@@ -179,27 +179,15 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 			rules = append(rules, rule)
 			continue
 		}
-		// The NoncurrentVersionExpiration action requests MinIO to expire
-		// noncurrent versions of objects x days after the objects become
-		// noncurrent.
-		if !rule.NoncurrentVersionExpiration.IsDaysNull() {
-			rules = append(rules, rule)
+		// Tag and object size filters apply to all the remaining
+		// actions, current and noncurrent versions alike.
+		if !rule.Filter.TestTags(strings.Split(obj.UserTags, "&")) {
 			continue
 		}
-		// The NoncurrentVersionTransition action requests MinIO to transition
-		// noncurrent versions of objects x days after the objects become
-		// noncurrent.
-		if !rule.NoncurrentVersionTransition.IsDaysNull() {
-			rules = append(rules, rule)
+		if !rule.Filter.TestSize(obj.Size) {
 			continue
 		}
-
-		if rule.Filter.TestTags(strings.Split(obj.UserTags, "&")) {
-			rules = append(rules, rule)
-		}
-		if !rule.Transition.IsNull() {
-			rules = append(rules, rule)
-		}
+		rules = append(rules, rule)
 	}
 	return rules
 }
@@ -209,6 +197,7 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 type ObjectOpts struct {
 	Name             string
 	UserTags         string
+	Size             int64
 	ModTime          time.Time
 	VersionID        string
 	IsLatest         bool
@@ -218,6 +207,10 @@ type ObjectOpts struct {
 	TransitionStatus string
 	RestoreOngoing   bool
 	RestoreExpires   time.Time
+
+	// NewerNoncurrentVersions is the number of non current
+	// versions of the object more recent than this version.
+	NewerNoncurrentVersions int
 }
 
 // ComputeAction returns the action to perform by evaluating all lifecycle rules
@@ -237,11 +230,12 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 			return DeleteVersionAction
 		}
 
-		if !rule.NoncurrentVersionExpiration.IsDaysNull() {
+		if !rule.NoncurrentVersionExpiration.IsNull() {
 			if obj.VersionID != "" && !obj.IsLatest && !obj.SuccessorModTime.IsZero() {
 				// Non current versions should be deleted if their age exceeds non current days configuration
+				// and they are not among the newer non current versions to retain
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
-				if time.Now().After(ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionExpiration.NoncurrentDays))) {
+				if rule.NoncurrentVersionExpiration.expired(obj.SuccessorModTime, obj.NewerNoncurrentVersions) {
 					return DeleteVersionAction
 				}
 			}
@@ -294,6 +288,27 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 	return action
 }
 
+// AbortIncompleteUpload returns true if the multipart upload of objName
+// initiated at the given time has to be aborted as per the
+// AbortIncompleteMultipartUpload action of the lifecycle rules.
+func (lc Lifecycle) AbortIncompleteUpload(objName string, initiated time.Time) bool {
+	if objName == "" || initiated.IsZero() {
+		return false
+	}
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled || rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+			continue
+		}
+		if !strings.HasPrefix(objName, rule.Prefix()) {
+			continue
+		}
+		if time.Now().UTC().After(ExpectedExpiryTime(initiated, int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation))) {
+			return true
+		}
+	}
+	return false
+}
+
 // ExpectedExpiryTime calculates the expiry, transition or restore date/time based on a object modtime.
 // The expected transition or restore time is always a midnight time following the the object
 // modification time plus the number of transition/restore days.
@@ -260,6 +260,7 @@ func TestComputeActions(t *testing.T) {
 		inputConfig    string
 		objectName     string
 		objectTags     string
+		objectSize     int64
 		objectModTime  time.Time
 		expectedAction Action
 	}{
@@ -388,6 +389,49 @@ func TestComputeActions(t *testing.T) {
 			objectModTime:  time.Now().UTC().Add(-48 * time.Hour), // Created 2 day ago
 			expectedAction: DeleteAction,
 		},
+		// Should remove - prefix and tags match, object is larger than ObjectSizeGreaterThan
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Prefix>logs/</Prefix><Tag><Key>class</Key><Value>temp</Value></Tag><ObjectSizeGreaterThan>1073741824</ObjectSizeGreaterThan></And></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "logs/app.log",
+			objectTags:     "class=temp",
+			objectSize:     2 << 30,
+			objectModTime:  time.Now().UTC().Add(-48 * time.Hour), // Created 2 day ago
+			expectedAction: DeleteAction,
+		},
+		// Should not remove - prefix and tags match, object is not larger than ObjectSizeGreaterThan
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Prefix>logs/</Prefix><Tag><Key>class</Key><Value>temp</Value></Tag><ObjectSizeGreaterThan>1073741824</ObjectSizeGreaterThan></And></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "logs/app.log",
+			objectTags:     "class=temp",
+			objectSize:     1 << 30,
+			objectModTime:  time.Now().UTC().Add(-48 * time.Hour), // Created 2 day ago
+			expectedAction: NoneAction,
+		},
+		// Should not remove - object size matches but tags don't
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Tag><Key>class</Key><Value>temp</Value></Tag><ObjectSizeGreaterThan>1073741824</ObjectSizeGreaterThan></And></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "logs/app.log",
+			objectTags:     "class=keep",
+			objectSize:     2 << 30,
+			objectModTime:  time.Now().UTC().Add(-48 * time.Hour), // Created 2 day ago
+			expectedAction: NoneAction,
+		},
+		// Should remove - object is smaller than ObjectSizeLessThan
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><ObjectSizeLessThan>1024</ObjectSizeLessThan></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectSize:     512,
+			objectModTime:  time.Now().UTC().Add(-48 * time.Hour), // Created 2 day ago
+			expectedAction: DeleteAction,
+		},
+		// Should not transition - tags don't match
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Tag><Key>class</Key><Value>temp</Value></Tag></Filter><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectTags:     "class=keep",
+			objectModTime:  time.Now().UTC().Add(-48 * time.Hour), // Created 2 day ago
+			expectedAction: NoneAction,
+		},
 		// Should remove, the second rule has expiration kicked in
 		{
 			inputConfig:    `<LifecycleConfiguration><Rule><Status>Enabled</Status><Expiration><Date>` + time.Now().Truncate(24*time.Hour).UTC().Add(24*time.Hour).Format(time.RFC3339) + `</Date></Expiration></Rule><Rule><Filter><Prefix>foxdir/</Prefix></Filter><Status>Enabled</Status><Expiration><Date>` + time.Now().Truncate(24*time.Hour).UTC().Add(-24*time.Hour).Format(time.RFC3339) + `</Date></Expiration></Rule></LifecycleConfiguration>`,
@@ -407,6 +451,7 @@ func TestComputeActions(t *testing.T) {
 			if resultAction := lc.ComputeAction(ObjectOpts{
 				Name:     tc.objectName,
 				UserTags: tc.objectTags,
+				Size:     tc.objectSize,
 				ModTime:  tc.objectModTime,
 				IsLatest: true,
 			}); resultAction != tc.expectedAction {
@@ -474,3 +519,115 @@ func TestHasActiveRules(t *testing.T) {
 
 	}
 }
+
+func TestAbortIncompleteUpload(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		objectName  string
+		initiated   time.Time
+		expected    bool
+	}{
+		{
+			inputConfig: `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:  "foodir/fooobject",
+			initiated:   time.Now().UTC().Add(-10 * 24 * time.Hour),
+			expected:    true,
+		},
+		{ // upload initiated too recently
+			inputConfig: `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:  "foodir/fooobject",
+			initiated:   time.Now().UTC().Add(-2 * 24 * time.Hour),
+			expected:    false,
+		},
+		{ // prefix not matching
+			inputConfig: `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:  "zdir/fooobject",
+			initiated:   time.Now().UTC().Add(-10 * 24 * time.Hour),
+			expected:    false,
+		},
+		{ // disabled rule
+			inputConfig: `<LifecycleConfiguration><Rule><Filter><Prefix></Prefix></Filter><Status>Disabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>5</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`,
+			objectName:  "foodir/fooobject",
+			initiated:   time.Now().UTC().Add(-10 * 24 * time.Hour),
+			expected:    false,
+		},
+		{ // rule without the action
+			inputConfig: `<LifecycleConfiguration><Rule><Filter><Prefix></Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:  "foodir/fooobject",
+			initiated:   time.Now().UTC().Add(-10 * 24 * time.Hour),
+			expected:    false,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("Test_%d", i+1), func(t *testing.T) {
+			lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if err = lc.Validate(); err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if got := lc.AbortIncompleteUpload(tc.objectName, tc.initiated); got != tc.expected {
+				t.Fatalf("Expected result: `%v`, got: `%v`", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewerNoncurrentVersions(t *testing.T) {
+	testCases := []struct {
+		inputConfig     string
+		newerNoncurrent int
+		successorTime   time.Time
+		expectedAction  Action
+	}{
+		{ // version among the newer noncurrent versions to retain
+			inputConfig:     `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NewerNoncurrentVersions>3</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newerNoncurrent: 2,
+			successorTime:   time.Now().UTC().Add(-time.Hour),
+			expectedAction:  NoneAction,
+		},
+		{ // version beyond the newer noncurrent versions to retain
+			inputConfig:     `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NewerNoncurrentVersions>3</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newerNoncurrent: 3,
+			successorTime:   time.Now().UTC().Add(-time.Hour),
+			expectedAction:  DeleteVersionAction,
+		},
+		{ // version beyond the count but not noncurrent for long enough
+			inputConfig:     `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays><NewerNoncurrentVersions>3</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newerNoncurrent: 4,
+			successorTime:   time.Now().UTC().Add(-2 * 24 * time.Hour),
+			expectedAction:  NoneAction,
+		},
+		{ // version beyond the count and noncurrent for long enough
+			inputConfig:     `<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays><NewerNoncurrentVersions>3</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`,
+			newerNoncurrent: 4,
+			successorTime:   time.Now().UTC().Add(-10 * 24 * time.Hour),
+			expectedAction:  DeleteVersionAction,
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("Test_%d", i+1), func(t *testing.T) {
+			lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if err = lc.Validate(); err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			if resultAction := lc.ComputeAction(ObjectOpts{
+				Name:                    "foodir/fooobject",
+				ModTime:                 tc.successorTime.Add(-time.Hour),
+				VersionID:               "5cc4d1c6-07ad-4e69-a2b4-6a4efc4ed56c",
+				SuccessorModTime:        tc.successorTime,
+				NewerNoncurrentVersions: tc.newerNoncurrent,
+			}); resultAction != tc.expectedAction {
+				t.Fatalf("Expected action: `%v`, got: `%v`", tc.expectedAction, resultAction)
+			}
+		})
+	}
+}
@@ -18,12 +18,18 @@ package lifecycle
 
 import (
 	"encoding/xml"
+	"time"
 )
 
+var errInvalidNewerNoncurrentVersions = Errorf("NewerNoncurrentVersions must be a positive integer")
+
 // NoncurrentVersionExpiration - an action for lifecycle configuration rule.
+// NewerNoncurrentVersions, when set, keeps that many of the most recent
+// noncurrent versions of an object from expiring.
 type NoncurrentVersionExpiration struct {
-	XMLName        xml.Name       `xml:"NoncurrentVersionExpiration"`
-	NoncurrentDays ExpirationDays `xml:"NoncurrentDays,omitempty"`
+	XMLName                 xml.Name       `xml:"NoncurrentVersionExpiration"`
+	NoncurrentDays          ExpirationDays `xml:"NoncurrentDays,omitempty"`
+	NewerNoncurrentVersions int            `xml:"NewerNoncurrentVersions,omitempty"`
 }
 
 // NoncurrentVersionTransition - an action for lifecycle configuration rule.
@@ -32,9 +38,9 @@ type NoncurrentVersionTransition struct {
 	StorageClass   string         `xml:"StorageClass"`
 }
 
-// MarshalXML if non-current days not set to non zero value
+// MarshalXML if non-current days or newer non-current versions not set to non zero value
 func (n NoncurrentVersionExpiration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	if n.IsDaysNull() {
+	if n.IsNull() {
 		return nil
 	}
 	type noncurrentVersionExpirationWrapper NoncurrentVersionExpiration
@@ -46,6 +52,32 @@ func (n NoncurrentVersionExpiration) IsDaysNull() bool {
 	return n.NoncurrentDays == ExpirationDays(0)
 }
 
+// IsNull returns true if neither days nor newer non-current versions are set
+func (n NoncurrentVersionExpiration) IsNull() bool {
+	return n.IsDaysNull() && n.NewerNoncurrentVersions == 0
+}
+
+// Validate - validates the NoncurrentVersionExpiration element
+func (n NoncurrentVersionExpiration) Validate() error {
+	if n.NewerNoncurrentVersions < 0 {
+		return errInvalidNewerNoncurrentVersions
+	}
+	return nil
+}
+
+// expired returns true if a non-current version, which became non-current
+// at successorModTime and has newerNoncurrent non-current versions more
+// recent than itself, is to be expired.
+func (n NoncurrentVersionExpiration) expired(successorModTime time.Time, newerNoncurrent int) bool {
+	if n.IsNull() {
+		return false
+	}
+	if n.NewerNoncurrentVersions > 0 && newerNoncurrent < n.NewerNoncurrentVersions {
+		return false
+	}
+	return n.IsDaysNull() || time.Now().After(ExpectedExpiryTime(successorModTime, int(n.NoncurrentDays)))
+}
+
 // MarshalXML is extended to leave out
 // <NoncurrentVersionTransition></NoncurrentVersionTransition> tags
 func (n NoncurrentVersionTransition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
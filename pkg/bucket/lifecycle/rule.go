@@ -34,15 +34,15 @@ const (
 
 // Rule - a rule for lifecycle configuration.
 type Rule struct {
-	XMLName    xml.Name   `xml:"Rule"`
-	ID         string     `xml:"ID,omitempty"`
-	Status     Status     `xml:"Status"`
-	Filter     Filter     `xml:"Filter,omitempty"`
-	Expiration Expiration `xml:"Expiration,omitempty"`
-	Transition Transition `xml:"Transition,omitempty"`
-	// FIXME: add a type to catch unsupported AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
-	NoncurrentVersionExpiration NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
-	NoncurrentVersionTransition NoncurrentVersionTransition `xml:"NoncurrentVersionTransition,omitempty"`
+	XMLName                        xml.Name                       `xml:"Rule"`
+	ID                             string                         `xml:"ID,omitempty"`
+	Status                         Status                         `xml:"Status"`
+	Filter                         Filter                         `xml:"Filter,omitempty"`
+	Expiration                     Expiration                     `xml:"Expiration,omitempty"`
+	Transition                     Transition                     `xml:"Transition,omitempty"`
+	AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	NoncurrentVersionExpiration    NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransition    NoncurrentVersionTransition    `xml:"NoncurrentVersionTransition,omitempty"`
 }
 
 var (
@@ -104,6 +104,18 @@ func (r Rule) validateTransition() error {
 	return r.Transition.Validate()
 }
 
+// validateAbortIncompleteMultipartUpload - multipart uploads carry no
+// tags, the action can only be filtered by prefix.
+func (r Rule) validateAbortIncompleteMultipartUpload() error {
+	if !r.AbortIncompleteMultipartUpload.IsDaysNull() && r.Tags() != "" {
+		return errAbortIncompleteMultipartUploadTags
+	}
+	if !r.AbortIncompleteMultipartUpload.IsDaysNull() && r.Filter.hasSize() {
+		return errAbortIncompleteMultipartUploadSize
+	}
+	return nil
+}
+
 // Prefix - a rule can either have prefix under <filter></filter> or under
 // <filter><and></and></filter>. This method returns the prefix from the
 // location where it is available
@@ -154,5 +166,11 @@ func (r Rule) Validate() error {
 	if err := r.validateTransition(); err != nil {
 		return err
 	}
+	if err := r.validateAbortIncompleteMultipartUpload(); err != nil {
+		return err
+	}
+	if err := r.NoncurrentVersionExpiration.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
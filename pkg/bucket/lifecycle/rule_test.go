@@ -59,6 +59,41 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule aborting incomplete multipart uploads filtered by tags
+			inputXML: ` <Rule>
+			                  <ID>abort incomplete uploads with tags</ID>
+			                  <Filter><Tag><Key>key</Key><Value>value</Value></Tag></Filter>
+			                  <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortIncompleteMultipartUploadTags,
+		},
+		{ // Rule aborting incomplete multipart uploads filtered by object size
+			inputXML: ` <Rule>
+			                  <ID>abort incomplete uploads with object size</ID>
+			                  <Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>
+			                  <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortIncompleteMultipartUploadSize,
+		},
+		{ // Rule aborting incomplete multipart uploads filtered by prefix
+			inputXML: ` <Rule>
+			                  <ID>abort incomplete uploads</ID>
+			                  <Filter><Prefix>prefix/</Prefix></Filter>
+			                  <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule expiring noncurrent versions beyond a negative count
+			inputXML: ` <Rule>
+			                  <ID>negative newer noncurrent versions</ID>
+			                  <NoncurrentVersionExpiration><NewerNoncurrentVersions>-1</NewerNoncurrentVersions></NoncurrentVersionExpiration>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errInvalidNewerNoncurrentVersions,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
@@ -121,6 +121,11 @@ const (
 	// PutBucketTaggingAction - PutTagging Rest API action
 	PutBucketTaggingAction = "s3:PutBucketTagging"
 
+	// GetBucketCorsAction - GetBucketCors Rest API action
+	GetBucketCorsAction = "s3:GetBucketCORS"
+	// PutBucketCorsAction - PutBucketCors and DeleteBucketCors Rest API action
+	PutBucketCorsAction = "s3:PutBucketCORS"
+
 	// GetObjectTaggingAction - Get Object Tags API action
 	GetObjectTaggingAction = "s3:GetObjectTagging"
 	// PutObjectTaggingAction - Put Object Tags API action
@@ -172,6 +177,14 @@ const (
 
 	// RestoreObjectAction - RestoreObject REST API action
 	RestoreObjectAction = "s3:RestoreObject"
+
+	// GetObjectLambdaAction - GetObject with object lambda transformation.
+	// This is MinIO extension.
+	GetObjectLambdaAction = "s3:GetObjectLambda"
+
+	// GetObjectZipAction - download all the objects under a prefix
+	// as a single zip archive. This is MinIO extension.
+	GetObjectZipAction = "s3:GetObjectZip"
 )
 
 // List of all supported object actions.
@@ -199,6 +212,8 @@ var supportedObjectActions = map[Action]struct{}{
 	ReplicateTagsAction:                  {},
 	GetObjectVersionForReplicationAction: {},
 	RestoreObjectAction:                  {},
+	GetObjectLambdaAction:                {},
+	GetObjectZipAction:                   {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -240,6 +255,8 @@ var supportedActions = map[Action]struct{}{
 	GetBucketObjectLockConfigurationAction: {},
 	PutBucketTaggingAction:                 {},
 	GetBucketTaggingAction:                 {},
+	GetBucketCorsAction:                    {},
+	PutBucketCorsAction:                    {},
 	GetObjectVersionAction:                 {},
 	GetObjectVersionTaggingAction:          {},
 	DeleteObjectVersionAction:              {},
@@ -260,6 +277,8 @@ var supportedActions = map[Action]struct{}{
 	ReplicateTagsAction:                    {},
 	GetObjectVersionForReplicationAction:   {},
 	RestoreObjectAction:                    {},
+	GetObjectLambdaAction:                  {},
+	GetObjectZipAction:                     {},
 }
 
 // IsValid - checks if action is valid or not.
@@ -388,6 +407,8 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	PutBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
 	GetBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	PutBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
+	GetBucketCorsAction:                    condition.NewKeySet(condition.CommonKeys...),
+	PutBucketCorsAction:                    condition.NewKeySet(condition.CommonKeys...),
 	PutObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	GetObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
@@ -416,4 +437,6 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	ReplicateTagsAction:                  condition.NewKeySet(condition.CommonKeys...),
 	GetObjectVersionForReplicationAction: condition.NewKeySet(condition.CommonKeys...),
 	RestoreObjectAction:                  condition.NewKeySet(condition.CommonKeys...),
+	GetObjectLambdaAction:                condition.NewKeySet(condition.CommonKeys...),
+	GetObjectZipAction:                   condition.NewKeySet(condition.CommonKeys...),
 }
@@ -34,23 +34,35 @@ func GetRootCAs(certsCAsDir string) (*x509.CertPool, error) {
 		rootCAs = x509.NewCertPool()
 	}
 
+	return rootCAs, appendCertsFromDir(rootCAs, certsCAsDir)
+}
+
+// GetClientCAs - returns only the CAs at the input certsCADir,
+// without the system CAs, to verify client certificates.
+func GetClientCAs(certsCAsDir string) (*x509.CertPool, error) {
+	clientCAs := x509.NewCertPool()
+	return clientCAs, appendCertsFromDir(clientCAs, certsCAsDir)
+}
+
+// appendCertsFromDir adds all the CAs at the input certsCAsDir to pool.
+func appendCertsFromDir(pool *x509.CertPool, certsCAsDir string) error {
 	fis, err := ioutil.ReadDir(certsCAsDir)
 	if err != nil {
 		if os.IsNotExist(err) || os.IsPermission(err) {
 			// Return success if CA's directory is missing or permission denied.
-			return rootCAs, nil
+			return nil
 		}
-		return rootCAs, err
+		return err
 	}
 
 	// Load all custom CA files.
 	for _, fi := range fis {
 		caCert, err := ioutil.ReadFile(path.Join(certsCAsDir, fi.Name()))
 		if err == nil {
-			rootCAs.AppendCertsFromPEM(caCert)
+			pool.AppendCertsFromPEM(caCert)
 		}
 		// ignore files which are not readable.
 	}
 
-	return rootCAs, nil
+	return nil
 }
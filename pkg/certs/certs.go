@@ -129,19 +129,10 @@ func (m *Manager) AddCertificate(certFile, keyFile string) (err error) {
 		return fmt.Errorf("certs: '%s' is a symlink but '%s' is a regular file", keyFile, certFile)
 	}
 
-	certificate, err := m.loadX509KeyPair(certFile, keyFile)
+	certificate, err := m.loadCertificate(certFile, keyFile)
 	if err != nil {
 		return err
 	}
-	// We set the certificate leaf to the actual certificate such that
-	// we don't have to do the parsing (multiple times) when matching the
-	// certificate to the client hello. This a performance optimisation.
-	if certificate.Leaf == nil {
-		certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
-		if err != nil {
-			return err
-		}
-	}
 
 	p := pair{
 		CertFile: certFile,
@@ -157,10 +148,17 @@ func (m *Manager) AddCertificate(certFile, keyFile string) (err error) {
 	// host name and clients will not set the SNI to an IP address.
 	// Allowing multiple certificates with IP SANs lead to errors that confuses users - like:
 	// "It works for `https://instance.minio.local` but not for `https://10.0.2.1`"
-	if len(m.certificates) > 0 && len(certificate.Leaf.IPAddresses) > 0 {
+	if len(m.certificates) > 0 && len(certificate.Leaf.IPAddresses) > 0 && p != m.defaultCert {
 		return errors.New("cert: certificate must not contain any IP SANs: only the default certificate may contain IP SANs")
 	}
-	m.certificates[p] = &certificate
+
+	// The certificate files of an already added pair are watched
+	// already, so the certificate is just replaced.
+	_, watched := m.certificates[p]
+	m.certificates[p] = certificate
+	if watched {
+		return nil
+	}
 
 	if certFileIsLink && keyFileIsLink {
 		go m.watchSymlinks(certFile, keyFile)
@@ -179,32 +177,74 @@ func (m *Manager) AddCertificate(certFile, keyFile string) (err error) {
 	return nil
 }
 
-// watchSymlinks starts an endless loop reloading the
-// certFile and keyFile periodically.
+// loadCertificate loads the TLS certificate in certFile resp. keyFile.
+func (m *Manager) loadCertificate(certFile, keyFile string) (*tls.Certificate, error) {
+	certificate, err := m.loadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	// We set the certificate leaf to the actual certificate such that
+	// we don't have to do the parsing (multiple times) when matching the
+	// certificate to the client hello. This a performance optimisation.
+	if certificate.Leaf == nil {
+		certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &certificate, nil
+}
+
+// reload reloads the TLS certificate of the given pair, the
+// currently served certificate is kept if loading fails.
+func (m *Manager) reload(p pair) error {
+	certificate, err := m.loadCertificate(p.CertFile, p.KeyFile)
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	m.certificates[p] = certificate
+	m.lock.Unlock()
+	return nil
+}
+
+// symlinkReloadInterval is the interval at which certificates
+// added via symlinks are checked for changes.
+const symlinkReloadInterval = time.Minute
+
+// watchSymlinks starts an endless loop reloading the certFile
+// and keyFile once the files they point to have changed. This is
+// the case when a tool like certbot renews a certificate, since
+// changes of the link targets don't cause any file system event
+// on the links themselves.
 func (m *Manager) watchSymlinks(certFile, keyFile string) {
+	certStat, _ := os.Stat(certFile)
+	keyStat, _ := os.Stat(keyFile)
+
+	ticker := time.NewTicker(symlinkReloadInterval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-m.ctx.Done():
 			return // Once stopped exits this routine.
-		case <-time.After(24 * time.Hour):
-			certificate, err := m.loadX509KeyPair(certFile, keyFile)
+		case <-ticker.C:
+			newCertStat, err := os.Stat(certFile)
 			if err != nil {
 				continue
 			}
-			if certificate.Leaf == nil { // This is a performance optimisation
-				certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
-				if err != nil {
-					continue
-				}
+			newKeyStat, err := os.Stat(keyFile)
+			if err != nil {
+				continue
 			}
-
-			p := pair{
-				CertFile: certFile,
-				KeyFile:  keyFile,
+			if !isChanged(certStat, newCertStat) && !isChanged(keyStat, newKeyStat) {
+				continue
+			}
+			if err = m.reload(pair{CertFile: certFile, KeyFile: keyFile}); err != nil {
+				// The pair may be in the middle of being replaced,
+				// retry on the next tick.
+				continue
 			}
-			m.lock.Lock()
-			m.certificates[p] = &certificate
-			m.lock.Unlock()
+			certStat, keyStat = newCertStat, newKeyStat
 		}
 	}
 }
@@ -222,23 +262,18 @@ func (m *Manager) watchFileEvents() {
 				continue
 			}
 
-			for pair := range m.certificates {
-				if p := event.Path(); pair.KeyFile == p || pair.CertFile == p {
-					certificate, err := m.loadX509KeyPair(pair.CertFile, pair.KeyFile)
-					if err != nil {
-						continue
-					}
-					if certificate.Leaf == nil { // This is performance optimisation
-						certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
-						if err != nil {
-							continue
-						}
-					}
-					m.lock.Lock()
-					m.certificates[pair] = &certificate
-					m.lock.Unlock()
+			var changed []pair
+			m.lock.RLock()
+			for p := range m.certificates {
+				if path := event.Path(); p.KeyFile == path || p.CertFile == path {
+					changed = append(changed, p)
 				}
 			}
+			m.lock.RUnlock()
+
+			for _, p := range changed {
+				m.reload(p)
+			}
 		}
 	}
 }
@@ -335,6 +370,16 @@ func (m *Manager) GetClientCertificate(reqInfo *tls.CertificateRequestInfo) (*tl
 	return nil, errors.New("certs: no client certificate is supported by peer")
 }
 
+// isChanged returns true if the file described by
+// newStat is not the same file as, or has been modified
+// since, the file described by oldStat.
+func isChanged(oldStat, newStat os.FileInfo) bool {
+	if oldStat == nil {
+		return true
+	}
+	return !os.SameFile(oldStat, newStat) || !oldStat.ModTime().Equal(newStat.ModTime())
+}
+
 // isSymlink returns true if the given file
 // is a symbolic link.
 func isSymlink(file string) (bool, error) {
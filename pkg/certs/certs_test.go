@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"io"
+	"io/ioutil"
 	"os"
 	"reflect"
 	"testing"
@@ -107,3 +108,45 @@ func TestValidPairAfterWrite(t *testing.T) {
 		t.Error("client certificate doesn't match expected certificate")
 	}
 }
+
+func TestValidPairAfterRename(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+	expectedCert, err := tls.LoadX509KeyPair("new-public.crt", "new-private.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := certs.NewManager(ctx, "public.crt", "private.key", tls.LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the pair atomically, like most certificate renewal tools do.
+	replace := func(src, dst string) {
+		data, err := ioutil.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(dst+".tmp", data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.Rename(dst+".tmp", dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+	replace("new-private.key", "private.key")
+	replace("new-public.crt", "public.crt")
+	defer updateCerts("original-public.crt", "original-private.key")
+
+	// Wait for the rename events..
+	time.Sleep(200 * time.Millisecond)
+
+	gcert, err := c.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gcert.Certificate, expectedCert.Certificate) {
+		t.Error("certificate doesn't match expected certificate")
+	}
+}
@@ -22,5 +22,5 @@ import "github.com/rjeczalik/notify"
 
 var (
 	// eventWrite contains the notify events that will cause a write
-	eventWrite = []notify.Event{notify.InCloseWrite}
+	eventWrite = []notify.Event{notify.InCloseWrite, notify.InMovedTo}
 )
@@ -45,13 +45,20 @@ func log(format string, data ...interface{}) {
 const DRWMutexAcquireTimeout = 1 * time.Second // 1 second.
 const drwMutexInfinite = 1<<63 - 1
 
+// DRWMutexRefreshInterval - interval at which the lease of a held lock
+// is refreshed on the lockers, lockers expire locks which are not
+// refreshed for a few intervals, i.e locks of crashed nodes.
+const DRWMutexRefreshInterval = 10 * time.Second
+
 // A DRWMutex is a distributed mutual exclusion lock.
 type DRWMutex struct {
-	Names        []string
-	writeLocks   []string   // Array of nodes that granted a write lock
-	readersLocks [][]string // Array of array of nodes that granted reader locks
-	m            sync.Mutex // Mutex to prevent multiple simultaneous locks from this node
-	clnt         *Dsync
+	Names          []string
+	writeLocks     []string             // Array of nodes that granted a write lock
+	readersLocks   [][]string           // Array of array of nodes that granted reader locks
+	writeRefresh   context.CancelFunc   // Stops refreshing the write lock
+	readersRefresh []context.CancelFunc // Stops refreshing the reader locks, in readersLocks order
+	m              sync.Mutex           // Mutex to prevent multiple simultaneous locks from this node
+	clnt           *Dsync
 }
 
 // Granted - represents a structure of a granted lock.
@@ -174,23 +181,43 @@ func (dm *DRWMutex) lockBlocking(ctx context.Context, id, source string, isReadL
 
 	tolerance = len(restClnts) - quorum
 
+	// Lockers order waiting write lock requests by their deadline.
+	deadline, _ := ctx.Deadline()
+
 	for {
 		select {
 		case <-ctx.Done():
+			if !isReadLock {
+				// Attempts which failed quorum are still waiting on
+				// the lockers which refused them, withdraw them all.
+				withdrawWriter(dm.clnt, locks, id, dm.Names...)
+			}
 			return false
 		default:
 			// Try to acquire the lock.
-			if locked = lock(ctx, dm.clnt, &locks, id, source, isReadLock, tolerance, quorum, dm.Names...); locked {
+			if locked = lock(ctx, dm.clnt, &locks, id, source, isReadLock, tolerance, quorum, deadline, dm.Names...); locked {
+				if !isReadLock {
+					// The minority of lockers which refused the
+					// lock must not keep it waiting.
+					go withdrawWriter(dm.clnt, append([]string(nil), locks...), id, dm.Names...)
+				}
+
 				dm.m.Lock()
 
+				// Keep the lease of the lock alive until it is released.
+				refreshCtx, stopRefresh := context.WithCancel(context.Background())
+				go refreshLock(refreshCtx, dm.clnt, append([]string(nil), locks...), id, source, quorum, dm.Names...)
+
 				// If success, copy array to object
 				if isReadLock {
 					// Append new array of strings at the end
 					dm.readersLocks = append(dm.readersLocks, make([]string, len(restClnts)))
 					// and copy stack array into last spot
 					copy(dm.readersLocks[len(dm.readersLocks)-1], locks[:])
+					dm.readersRefresh = append(dm.readersRefresh, stopRefresh)
 				} else {
 					copy(dm.writeLocks, locks[:])
+					dm.writeRefresh = stopRefresh
 				}
 
 				dm.m.Unlock()
@@ -203,8 +230,78 @@ func (dm *DRWMutex) lockBlocking(ctx context.Context, id, source string, isReadL
 	}
 }
 
+// withdrawWriter withdraws the write lock request id from the waiting
+// writers of all the lockers which did not grant it in locks, such that
+// readers are not held back by a writer which is not waiting anymore.
+func withdrawWriter(ds *Dsync, locks []string, id string, lockNames ...string) {
+	restClnts, owner := ds.GetLockers()
+
+	var wg sync.WaitGroup
+	for index, c := range restClnts {
+		if c == nil || (index < len(locks) && isLocked(locks[index])) {
+			continue
+		}
+		wg.Add(1)
+		go func(c NetLocker) {
+			defer wg.Done()
+			args := LockArgs{
+				Owner:     owner,
+				UID:       id,
+				Resources: lockNames,
+			}
+			if _, err := c.Withdraw(args); err != nil {
+				log("dsync: Unable to call Withdraw failed with %s for %#v at %s\n", err, args, c)
+			}
+		}(c)
+	}
+	wg.Wait()
+}
+
+// refreshLock periodically refreshes the lease of a granted lock on
+// the lockers which granted it, until the context is canceled.
+func refreshLock(ctx context.Context, ds *Dsync, locks []string, id, source string, quorum int, lockNames ...string) {
+	ticker := time.NewTicker(DRWMutexRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			restClnts, owner := ds.GetLockers()
+			args := LockArgs{
+				Owner:     owner,
+				UID:       id,
+				Resources: lockNames,
+				Source:    source,
+				Quorum:    quorum,
+			}
+
+			var refreshed int
+			for index, c := range restClnts {
+				if c == nil || index >= len(locks) || !isLocked(locks[index]) {
+					continue
+				}
+				rctx, cancel := context.WithTimeout(ctx, DRWMutexAcquireTimeout)
+				ok, err := c.Refresh(rctx, args)
+				cancel()
+				if err != nil {
+					log("dsync: Unable to call Refresh failed with %s for %#v at %s\n", err, args, c)
+					continue
+				}
+				if ok {
+					refreshed++
+				}
+			}
+			if refreshed < quorum {
+				log("dsync: lock %#v refreshed on %d lockers only, lock may have expired\n", args, refreshed)
+			}
+		}
+	}
+}
+
 // lock tries to acquire the distributed lock, returning true or false.
-func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, isReadLock bool, tolerance, quorum int, lockNames ...string) bool {
+func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, isReadLock bool, tolerance, quorum int, deadline time.Time, lockNames ...string) bool {
 	for i := range *locks {
 		(*locks)[i] = ""
 	}
@@ -237,6 +334,7 @@ func lock(ctx context.Context, ds *Dsync, locks *[]string, id, source string, is
 				Resources: lockNames,
 				Source:    source,
 				Quorum:    quorum,
+				Deadline:  deadline,
 			}
 
 			var locked bool
@@ -410,6 +508,11 @@ func (dm *DRWMutex) Unlock() {
 
 		// Copy write locks to stack array
 		copy(locks, dm.writeLocks[:])
+
+		if dm.writeRefresh != nil {
+			dm.writeRefresh()
+			dm.writeRefresh = nil
+		}
 	}
 
 	// Tolerance is not set, defaults to half of the locker clients.
@@ -441,6 +544,11 @@ func (dm *DRWMutex) RUnlock() {
 		copy(locks, dm.readersLocks[0][:])
 		// Drop first element from array
 		dm.readersLocks = dm.readersLocks[1:]
+
+		if len(dm.readersRefresh) > 0 {
+			dm.readersRefresh[0]()
+			dm.readersRefresh = dm.readersRefresh[1:]
+		}
 	}
 
 	// Tolerance is not set, defaults to half of the locker clients.
@@ -91,6 +91,19 @@ func (l *lockServer) RUnlock(args *LockArgs, reply *bool) error {
 	return nil
 }
 
+func (l *lockServer) Refresh(args *LockArgs, reply *bool) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	_, *reply = l.lockMap[args.Resources[0]]
+	return nil
+}
+
+func (l *lockServer) Withdraw(args *LockArgs, reply *bool) error {
+	// Write lock requests are not queued.
+	*reply = false
+	return nil
+}
+
 func (l *lockServer) ForceUnlock(args *LockArgs, reply *bool) error {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
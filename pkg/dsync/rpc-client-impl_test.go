@@ -119,6 +119,16 @@ func (rpcClient *ReconnectRPCClient) Expired(ctx context.Context, args LockArgs)
 	return expired, err
 }
 
+func (rpcClient *ReconnectRPCClient) Refresh(ctx context.Context, args LockArgs) (refreshed bool, err error) {
+	err = rpcClient.Call("Dsync.Refresh", &args, &refreshed)
+	return refreshed, err
+}
+
+func (rpcClient *ReconnectRPCClient) Withdraw(args LockArgs) (withdrawn bool, err error) {
+	err = rpcClient.Call("Dsync.Withdraw", &args, &withdrawn)
+	return withdrawn, err
+}
+
 func (rpcClient *ReconnectRPCClient) String() string {
 	return "http://" + rpcClient.addr + "/" + rpcClient.endpoint
 }
@@ -16,7 +16,10 @@
 
 package dsync
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // LockArgs is minimal required values for any dsync compatible lock operation.
 type LockArgs struct {
@@ -36,6 +39,10 @@ type LockArgs struct {
 
 	// Quorum represents the expected quorum for this lock type.
 	Quorum int
+
+	// Deadline after which the lock request is abandoned, lockers
+	// serve waiting write lock requests in deadline order.
+	Deadline time.Time
 }
 
 // NetLocker is dsync compatible locker interface.
@@ -63,6 +70,17 @@ type NetLocker interface {
 	// Expired returns if current lock args has expired.
 	Expired(ctx context.Context, args LockArgs) (bool, error)
 
+	// Refresh the lease of the lock for given LockArgs. It should return
+	// * a boolean to indicate if the lock is still held
+	// * an error on failure of refresh request operation.
+	Refresh(ctx context.Context, args LockArgs) (bool, error)
+
+	// Withdraw the waiting write lock request for given LockArgs, which
+	// was not granted. It should return
+	// * a boolean to indicate if the request was waiting
+	// * an error on failure of withdraw request operation.
+	Withdraw(args LockArgs) (bool, error)
+
 	// Returns underlying endpoint of this lock client instance.
 	String() string
 
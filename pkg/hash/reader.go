@@ -28,16 +28,27 @@ import (
 	sha256 "github.com/minio/sha256-simd"
 )
 
+// checksum is a stage of the hashing pipeline of a Reader. All content
+// read is written to its hash, the expected sum, if set, is verified at
+// io.EOF.
+type checksum struct {
+	hash hash.Hash
+	sum  []byte // Byte value of the client sent sum.
+}
+
 // Reader writes what it reads from an io.Reader to an MD5 and SHA256 hash.Hash.
 // Reader verifies that the content of the io.Reader matches the expected checksums.
+//
+// The hashes computed are kept in a single pipeline, each chunk read is
+// written to all of them in turn.
 type Reader struct {
 	src        io.Reader
 	size       int64
 	actualSize int64
 	bytesRead  int64
 
-	md5sum, sha256sum   []byte // Byte values of md5sum, sha256sum of client sent values.
-	md5Hash, sha256Hash hash.Hash
+	md5, sha256 *checksum
+	pipeline    []hash.Hash // All hashes computed, in the order of the stages.
 }
 
 // NewReader returns a new hash Reader which computes the MD5 sum and
@@ -56,11 +67,8 @@ func NewReader(src io.Reader, size int64, md5Hex, sha256Hex string, actualSize i
 func (r *Reader) Read(p []byte) (n int, err error) {
 	n, err = r.src.Read(p)
 	if n > 0 {
-		if r.md5Hash != nil {
-			r.md5Hash.Write(p[:n])
-		}
-		if r.sha256Hash != nil {
-			r.sha256Hash.Write(p[:n])
+		for _, h := range r.pipeline {
+			h.Write(p[:n])
 		}
 	}
 	r.bytesRead += int64(n)
@@ -86,7 +94,10 @@ func (r *Reader) ActualSize() int64 { return r.actualSize }
 
 // MD5 - returns byte md5 value
 func (r *Reader) MD5() []byte {
-	return r.md5sum
+	if r.md5 != nil {
+		return r.md5.sum
+	}
+	return nil
 }
 
 // MD5Current - returns byte md5 value of the current state
@@ -94,48 +105,57 @@ func (r *Reader) MD5() []byte {
 // NOTE: Calling this function multiple times might yield
 // different results if they are intermixed with Reader.
 func (r *Reader) MD5Current() []byte {
-	if r.md5Hash != nil {
-		return r.md5Hash.Sum(nil)
+	if r.md5 != nil {
+		return r.md5.hash.Sum(nil)
 	}
 	return nil
 }
 
 // SHA256 - returns byte sha256 value
 func (r *Reader) SHA256() []byte {
-	return r.sha256sum
+	if r.sha256 != nil {
+		return r.sha256.sum
+	}
+	return nil
 }
 
 // MD5HexString returns hex md5 value.
 func (r *Reader) MD5HexString() string {
-	return hex.EncodeToString(r.md5sum)
+	return hex.EncodeToString(r.MD5())
 }
 
 // MD5Base64String returns base64 encoded MD5sum value.
 func (r *Reader) MD5Base64String() string {
-	return base64.StdEncoding.EncodeToString(r.md5sum)
+	return base64.StdEncoding.EncodeToString(r.MD5())
 }
 
 // SHA256HexString returns hex sha256 value.
 func (r *Reader) SHA256HexString() string {
-	return hex.EncodeToString(r.sha256sum)
+	return hex.EncodeToString(r.SHA256())
 }
 
 // verify verifies if the computed MD5 sum and SHA256 sum are
 // equal to the ones specified when creating the Reader.
 func (r *Reader) verify() error {
-	if r.sha256Hash != nil && len(r.sha256sum) > 0 {
-		if sum := r.sha256Hash.Sum(nil); !bytes.Equal(r.sha256sum, sum) {
-			return SHA256Mismatch{hex.EncodeToString(r.sha256sum), hex.EncodeToString(sum)}
+	if r.sha256 != nil && len(r.sha256.sum) > 0 {
+		if sum := r.sha256.hash.Sum(nil); !bytes.Equal(r.sha256.sum, sum) {
+			return SHA256Mismatch{hex.EncodeToString(r.sha256.sum), hex.EncodeToString(sum)}
 		}
 	}
-	if r.md5Hash != nil && len(r.md5sum) > 0 {
-		if sum := r.md5Hash.Sum(nil); !bytes.Equal(r.md5sum, sum) {
-			return BadDigest{hex.EncodeToString(r.md5sum), hex.EncodeToString(sum)}
+	if r.md5 != nil && len(r.md5.sum) > 0 {
+		if sum := r.md5.hash.Sum(nil); !bytes.Equal(r.md5.sum, sum) {
+			return BadDigest{hex.EncodeToString(r.md5.sum), hex.EncodeToString(sum)}
 		}
 	}
 	return nil
 }
 
+// addChecksum adds a new stage to the hashing pipeline.
+func (r *Reader) addChecksum(h hash.Hash, sum []byte) *checksum {
+	r.pipeline = append(r.pipeline, h)
+	return &checksum{hash: h, sum: sum}
+}
+
 // merge another hash into this one.
 // There cannot be conflicting information given.
 func (r *Reader) merge(size int64, md5Hex, sha256Hex string, actualSize int64, strictCompat bool) (*Reader, error) {
@@ -164,13 +184,12 @@ func (r *Reader) merge(size int64, md5Hex, sha256Hex string, actualSize int64, s
 	}
 
 	// If both are set, they must be the same.
-	if r.sha256Hash != nil && len(sha256sum) > 0 {
-		if !bytes.Equal(r.sha256sum, sha256sum) {
+	if r.sha256 != nil && len(sha256sum) > 0 {
+		if !bytes.Equal(r.sha256.sum, sha256sum) {
 			return nil, SHA256Mismatch{}
 		}
 	} else if len(sha256sum) > 0 {
-		r.sha256Hash = sha256.New()
-		r.sha256sum = sha256sum
+		r.sha256 = r.addChecksum(sha256.New(), sha256sum)
 	}
 
 	// Merge MD5 Sum.
@@ -179,13 +198,12 @@ func (r *Reader) merge(size int64, md5Hex, sha256Hex string, actualSize int64, s
 		return nil, BadDigest{}
 	}
 	// If both are set, they must expect the same.
-	if r.md5Hash != nil && len(md5sum) > 0 {
-		if !bytes.Equal(r.md5sum, md5sum) {
+	if r.md5 != nil && len(md5sum) > 0 {
+		if !bytes.Equal(r.md5.sum, md5sum) {
 			return nil, BadDigest{}
 		}
-	} else if len(md5sum) > 0 || (r.md5Hash == nil && strictCompat) {
-		r.md5Hash = md5.New()
-		r.md5sum = md5sum
+	} else if r.md5 == nil && (len(md5sum) > 0 || strictCompat) {
+		r.md5 = r.addChecksum(md5.New(), md5sum)
 	}
 	return r, nil
 }
@@ -210,6 +210,35 @@ func mustReader(t *testing.T, src io.Reader, size int64, md5Hex, sha256Hex strin
 	return r
 }
 
+// Tests that only the hashes needed are computed.
+func TestHashReaderPipeline(t *testing.T) {
+	testCases := []struct {
+		md5hex, sha256hex string
+		strict            bool
+		stages            int
+	}{
+		{"", "", false, 0},
+		{"", "", true, 1},
+		{"e2fc714c4727ee9395f324cd2e7f331f", "", false, 1},
+		{"", "88d4266fd4e6338d13b845fcf289579d209c897823b9217da3e161936f031589", false, 1},
+		{"e2fc714c4727ee9395f324cd2e7f331f", "88d4266fd4e6338d13b845fcf289579d209c897823b9217da3e161936f031589", true, 2},
+	}
+	for i, testCase := range testCases {
+		r := mustReader(t, bytes.NewReader([]byte("abcd")), 4, testCase.md5hex, testCase.sha256hex, 4, testCase.strict)
+		// Merging the same expectations must not add stages.
+		r = mustReader(t, r, 4, testCase.md5hex, testCase.sha256hex, 4, testCase.strict)
+		if len(r.pipeline) != testCase.stages {
+			t.Errorf("Test %d: expected %d hashes, got %d", i+1, testCase.stages, len(r.pipeline))
+		}
+		if _, err := ioutil.ReadAll(r); err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if md5sum := r.MD5Current(); (md5sum != nil) != (testCase.md5hex != "" || testCase.strict) {
+			t.Errorf("Test %d: unexpected MD5 %x", i+1, md5sum)
+		}
+	}
+}
+
 // Tests NewReader() constructor with invalid arguments.
 func TestHashReaderInvalidArguments(t *testing.T) {
 	testCases := []struct {
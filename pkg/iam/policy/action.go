@@ -141,6 +141,12 @@ const (
 	// PutBucketTaggingAction - PutBucketTagging Rest API action
 	PutBucketTaggingAction = "s3:PutBucketTagging"
 
+	// GetBucketCorsAction - GetBucketCors Rest API action
+	GetBucketCorsAction = "s3:GetBucketCORS"
+
+	// PutBucketCorsAction - PutBucketCors and DeleteBucketCors Rest API action
+	PutBucketCorsAction = "s3:PutBucketCORS"
+
 	// GetObjectTaggingAction - Get Object Tags API action
 	GetObjectTaggingAction = "s3:GetObjectTagging"
 
@@ -178,6 +184,14 @@ const (
 	// GetObjectVersionForReplicationAction  - GetObjectVersionForReplication REST API action
 	GetObjectVersionForReplicationAction = "s3:GetObjectVersionForReplication"
 
+	// GetObjectLambdaAction - GetObject with object lambda transformation.
+	// This is MinIO extension.
+	GetObjectLambdaAction = "s3:GetObjectLambda"
+
+	// GetObjectZipAction - download all the objects under a prefix
+	// as a single zip archive. This is MinIO extension.
+	GetObjectZipAction = "s3:GetObjectZip"
+
 	// AllActions - all API actions
 	AllActions = "s3:*"
 )
@@ -216,6 +230,8 @@ var supportedActions = map[Action]struct{}{
 	PutBucketObjectLockConfigurationAction: {},
 	GetBucketTaggingAction:                 {},
 	PutBucketTaggingAction:                 {},
+	GetBucketCorsAction:                    {},
+	PutBucketCorsAction:                    {},
 	GetObjectVersionAction:                 {},
 	GetObjectVersionTaggingAction:          {},
 	DeleteObjectVersionAction:              {},
@@ -234,6 +250,8 @@ var supportedActions = map[Action]struct{}{
 	ReplicateDeleteAction:                  {},
 	ReplicateTagsAction:                    {},
 	GetObjectVersionForReplicationAction:   {},
+	GetObjectLambdaAction:                  {},
+	GetObjectZipAction:                     {},
 	AllActions:                             {},
 }
 
@@ -262,6 +280,8 @@ var supportedObjectActions = map[Action]struct{}{
 	ReplicateDeleteAction:                {},
 	ReplicateTagsAction:                  {},
 	GetObjectVersionForReplicationAction: {},
+	GetObjectLambdaAction:                {},
+	GetObjectZipAction:                   {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -376,6 +396,8 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	PutBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
 	GetBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	PutBucketTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
+	GetBucketCorsAction:                    condition.NewKeySet(condition.CommonKeys...),
+	PutBucketCorsAction:                    condition.NewKeySet(condition.CommonKeys...),
 	PutObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	GetObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
 	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
@@ -403,4 +425,6 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	ReplicateDeleteAction:                condition.NewKeySet(condition.CommonKeys...),
 	ReplicateTagsAction:                  condition.NewKeySet(condition.CommonKeys...),
 	GetObjectVersionForReplicationAction: condition.NewKeySet(condition.CommonKeys...),
+	GetObjectLambdaAction:                condition.NewKeySet(condition.CommonKeys...),
+	GetObjectZipAction:                   condition.NewKeySet(condition.CommonKeys...),
 }
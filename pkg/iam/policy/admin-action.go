@@ -33,6 +33,8 @@ const (
 	StorageInfoAdminAction = "admin:StorageInfo"
 	// DataUsageInfoAdminAction - allow listing data usage info
 	DataUsageInfoAdminAction = "admin:DataUsageInfo"
+	// AccountingInfoAdminAction - allow listing access key and anonymous access accounting
+	AccountingInfoAdminAction = "admin:AccountingInfo"
 	// TopLocksAdminAction - allow listing top locks
 	TopLocksAdminAction = "admin:TopLocksInfo"
 	// ProfilingAdminAction - allow profiling
@@ -74,6 +76,8 @@ const (
 	DisableUserAdminAction = "admin:DisableUser"
 	// GetUserAdminAction - allows GET permission on user info
 	GetUserAdminAction = "admin:GetUser"
+	// RefreshIAMAdminAction - allow reloading all IAM entities from the backend
+	RefreshIAMAdminAction = "admin:RefreshIAM"
 
 	// Group Actions
 
@@ -117,6 +121,53 @@ const (
 	// GetBucketTargetAction - allow getting bucket targets
 	GetBucketTargetAction = "admin:GetBucketTarget"
 
+	// Bucket object lambda admin Actions
+
+	// SetBucketLambdaAdminAction - allow setting bucket object lambda
+	SetBucketLambdaAdminAction = "admin:SetBucketLambda"
+	// GetBucketLambdaAdminAction - allow getting bucket object lambda
+	GetBucketLambdaAdminAction = "admin:GetBucketLambda"
+
+	// Bucket placement admin Actions
+
+	// SetBucketPlacementAdminAction - allow setting bucket placement
+	SetBucketPlacementAdminAction = "admin:SetBucketPlacement"
+	// GetBucketPlacementAdminAction - allow getting bucket placement
+	GetBucketPlacementAdminAction = "admin:GetBucketPlacement"
+
+	// PurgeObjectVersionsAdminAction - allow permanently removing all
+	// versions of the objects under a prefix
+	PurgeObjectVersionsAdminAction = "admin:PurgeObjectVersions"
+
+	// ReplayEventsAdminAction - allow replaying the journaled
+	// events of a bucket to a notification target
+	ReplayEventsAdminAction = "admin:ReplayEvents"
+
+	// Batch job admin Actions
+
+	// StartBatchJobAdminAction - allow starting batch jobs
+	StartBatchJobAdminAction = "admin:StartBatchJob"
+	// ListBatchJobsAdminAction - allow listing batch jobs and their status
+	ListBatchJobsAdminAction = "admin:ListBatchJobs"
+	// CancelBatchJobAdminAction - allow canceling batch jobs
+	CancelBatchJobAdminAction = "admin:CancelBatchJob"
+
+	// Bucket metadata bundle admin Actions
+
+	// ExportBucketMetadataAction - allow exporting bucket metadata
+	ExportBucketMetadataAction = "admin:ExportBucketMetadata"
+	// ImportBucketMetadataAction - allow importing bucket metadata
+	ImportBucketMetadataAction = "admin:ImportBucketMetadata"
+
+	// Bucket replica admin Actions
+
+	// SetBucketReplicaAdminAction - allow setting the replica mode of a bucket
+	SetBucketReplicaAdminAction = "admin:SetBucketReplica"
+	// GetBucketReplicaAdminAction - allow getting the replica mode of a bucket
+	GetBucketReplicaAdminAction = "admin:GetBucketReplica"
+	// FailoverBucketAdminAction - allow failing over a replica bucket
+	FailoverBucketAdminAction = "admin:FailoverBucket"
+
 	// AllAdminActions - provides all admin permissions
 	AllAdminActions = "admin:*"
 )
@@ -126,6 +177,7 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	HealAdminAction:                {},
 	StorageInfoAdminAction:         {},
 	DataUsageInfoAdminAction:       {},
+	AccountingInfoAdminAction:      {},
 	TopLocksAdminAction:            {},
 	ProfilingAdminAction:           {},
 	TraceAdminAction:               {},
@@ -144,6 +196,7 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	EnableUserAdminAction:          {},
 	DisableUserAdminAction:         {},
 	GetUserAdminAction:             {},
+	RefreshIAMAdminAction:          {},
 	AddUserToGroupAdminAction:      {},
 	RemoveUserFromGroupAdminAction: {},
 	GetGroupAdminAction:            {},
@@ -159,6 +212,20 @@ var supportedAdminActions = map[AdminAction]struct{}{
 	GetBucketQuotaAdminAction:      {},
 	SetBucketTargetAction:          {},
 	GetBucketTargetAction:          {},
+	SetBucketLambdaAdminAction:     {},
+	GetBucketLambdaAdminAction:     {},
+	SetBucketPlacementAdminAction:  {},
+	GetBucketPlacementAdminAction:  {},
+	PurgeObjectVersionsAdminAction: {},
+	ReplayEventsAdminAction:        {},
+	StartBatchJobAdminAction:       {},
+	ListBatchJobsAdminAction:       {},
+	CancelBatchJobAdminAction:      {},
+	ExportBucketMetadataAction:     {},
+	ImportBucketMetadataAction:     {},
+	SetBucketReplicaAdminAction:    {},
+	GetBucketReplicaAdminAction:    {},
+	FailoverBucketAdminAction:      {},
 	AllAdminActions:                {},
 }
 
@@ -175,6 +242,7 @@ var adminActionConditionKeyMap = map[Action]condition.KeySet{
 	StorageInfoAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ServerInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	DataUsageInfoAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AccountingInfoAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	HealthInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	BandwidthMonitorAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	TopLocksAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
@@ -192,6 +260,7 @@ var adminActionConditionKeyMap = map[Action]condition.KeySet{
 	EnableUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	DisableUserAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	GetUserAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RefreshIAMAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	AddUserToGroupAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	RemoveUserFromGroupAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	ListGroupsAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
@@ -206,4 +275,18 @@ var adminActionConditionKeyMap = map[Action]condition.KeySet{
 	GetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	SetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
 	GetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketLambdaAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketLambdaAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketPlacementAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketPlacementAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	PurgeObjectVersionsAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ReplayEventsAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	StartBatchJobAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListBatchJobsAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CancelBatchJobAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ExportBucketMetadataAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ImportBucketMetadataAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketReplicaAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketReplicaAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	FailoverBucketAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
 }
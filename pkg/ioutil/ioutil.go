@@ -41,6 +41,20 @@ func (w *WriteOnCloser) Write(p []byte) (int, error) {
 	return w.Writer.Write(p)
 }
 
+// ReadFrom calls the underlying ReadFrom, if any, so that
+// the data can be copied without going through user space.
+func (w *WriteOnCloser) ReadFrom(r io.Reader) (int64, error) {
+	rf, ok := w.Writer.(io.ReaderFrom)
+	if !ok {
+		return io.Copy(struct{ io.Writer }{w}, r)
+	}
+	n, err := rf.ReadFrom(r)
+	if n > 0 {
+		w.hasWritten = true
+	}
+	return n, err
+}
+
 // Close closes the WriteOnCloser. It behaves like io.Closer.
 func (w *WriteOnCloser) Close() error {
 	if !w.hasWritten {
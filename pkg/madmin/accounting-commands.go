@@ -0,0 +1,61 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// AccountingInfo represents the access accounting of the cluster.
+type AccountingInfo struct {
+	// Last time each access key was used, zero if never used.
+	AccessKeys map[string]time.Time `json:"accessKeys"`
+	// Number of anonymous requests allowed per bucket.
+	AnonymousRequests map[string]uint64 `json:"anonymousRequests"`
+}
+
+// AccountingInfo - returns the last used time of every access key
+// and the number of anonymous requests allowed on every bucket.
+func (adm *AdminClient) AccountingInfo(ctx context.Context) (AccountingInfo, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{relPath: adminAPIPrefix + "/accounting"})
+	defer closeResponse(resp)
+	if err != nil {
+		return AccountingInfo{}, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return AccountingInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return AccountingInfo{}, err
+	}
+
+	var accountingInfo AccountingInfo
+	if err = json.Unmarshal(respBytes, &accountingInfo); err != nil {
+		return AccountingInfo{}, err
+	}
+
+	return accountingInfo, nil
+}
@@ -0,0 +1,24 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package adminpb holds the protobuf definitions and the generated gRPC
+// client and server code of the MinIO admin API.
+package adminpb
+
+// The etcd client pins google.golang.org/grpc to v1.26, the service
+// code must be generated for grpc.SupportPackageIsVersion4 until then.
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. admin.proto
@@ -0,0 +1,227 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BatchJobType - type of a batch job.
+type BatchJobType string
+
+// BatchJobCopyType - copies the objects of a bucket to another
+// bucket of this or of a remote cluster.
+const BatchJobCopyType BatchJobType = "copy"
+
+// BatchJobStatus - status of a batch job.
+type BatchJobStatus string
+
+// Batch job status values.
+const (
+	BatchJobRunning  BatchJobStatus = "running"
+	BatchJobComplete BatchJobStatus = "complete"
+	BatchJobFailed   BatchJobStatus = "failed"
+	BatchJobCanceled BatchJobStatus = "canceled"
+)
+
+// BatchJobCopySource - bucket and prefix of the objects to copy.
+type BatchJobCopySource struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// BatchJobCopyTarget - bucket and prefix the objects are copied to,
+// the endpoint and credentials are only needed for a remote cluster.
+type BatchJobCopyTarget struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+
+	// Endpoint of the remote cluster, for example "https://minio2:9000",
+	// empty to copy the objects within this cluster.
+	Endpoint  string `json:"endpoint,omitempty"`
+	AccessKey string `json:"accessKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty"`
+	Region    string `json:"region,omitempty"`
+}
+
+// BatchJobCopy - describes a job copying the latest version of all
+// objects under a prefix along with their metadata, tags and encryption.
+type BatchJobCopy struct {
+	Source BatchJobCopySource `json:"source"`
+	Target BatchJobCopyTarget `json:"target"`
+
+	// Bandwidth limit of the job in bytes per second, 0 for no limit.
+	BandwidthLimit int64 `json:"bandwidthLimit,omitempty"`
+}
+
+// BatchJobInfo - progress of a batch job, the progress is saved
+// periodically such that the job resumes after a restart.
+type BatchJobInfo struct {
+	ID     string         `json:"id"`
+	Type   BatchJobType   `json:"type"`
+	Status BatchJobStatus `json:"status"`
+
+	// Node running the job.
+	Node      string    `json:"node"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime,omitempty"`
+
+	// Number and size of the objects copied so far, and the number of
+	// objects which could not be copied.
+	Objects int64 `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+	Failed  int64 `json:"failed"`
+
+	// Name of the last object processed before the first failed
+	// object, the job resumes after it.
+	LastObject string `json:"lastObject,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	// Copy job description, without the secret key of the target.
+	Copy *BatchJobCopy `json:"copy,omitempty"`
+}
+
+// StartBatchCopyJob - starts a job copying the objects described by job
+// in the background, returns the ID of the job.
+func (adm *AdminClient) StartBatchCopyJob(ctx context.Context, job BatchJobCopy) (string, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+	// The job carries the credentials of the target.
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return "", err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("type", string(BatchJobCopyType))
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/start-batch-job",
+		queryValues: queryValues,
+		content:     econfigBytes,
+	}
+
+	// Execute POST on /minio/admin/v3/start-batch-job
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	var info BatchJobInfo
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", err
+	}
+	return info.ID, nil
+}
+
+// BatchJobStatus - returns the progress of the batch job with the given ID.
+func (adm *AdminClient) BatchJobStatus(ctx context.Context, id string) (info BatchJobInfo, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/batch-job-status",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/batch-job-status
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+	if err = json.Unmarshal(b, &info); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// ListBatchJobs - returns the progress of all batch jobs.
+func (adm *AdminClient) ListBatchJobs(ctx context.Context) (jobs []BatchJobInfo, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/list-batch-jobs",
+	}
+
+	// Execute GET on /minio/admin/v3/list-batch-jobs
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CancelBatchJob - cancels the running batch job with the given ID, the
+// objects copied so far are kept.
+func (adm *AdminClient) CancelBatchJob(ctx context.Context, id string) error {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/cancel-batch-job",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/cancel-batch-job
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ExportBucketMetadata - exports the configurations of bucket, or
+// of all buckets if bucket is empty, as a zip bundle with a
+// '<bucket>/<config file>' entry for each configuration.
+func (adm *AdminClient) ExportBucketMetadata(ctx context.Context, bucket string) ([]byte, error) {
+	queryValues := url.Values{}
+	if bucket != "" {
+		queryValues.Set("bucket", bucket)
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/export-bucket-metadata",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/export-bucket-metadata
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	return DecryptData(adm.getSecretKey(), resp.Body)
+}
+
+// ImportBucketMetadata - imports a zip bundle created by
+// ExportBucketMetadata, missing buckets are created.
+func (adm *AdminClient) ImportBucketMetadata(ctx context.Context, bundle io.Reader) error {
+	data, err := ioutil.ReadAll(bundle)
+	if err != nil {
+		return err
+	}
+
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/import-bucket-metadata",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/import-bucket-metadata
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
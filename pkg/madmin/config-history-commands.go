@@ -125,3 +125,48 @@ func (adm *AdminClient) ListConfigHistoryKV(ctx context.Context, count int) ([]C
 
 	return chEntries, nil
 }
+
+// ConfigKVDiff - a config key whose value differs between
+// two versions of the server config.
+type ConfigKVDiff struct {
+	SubSys string `json:"subSys"`
+	Target string `json:"target,omitempty"`
+	Key    string `json:"key"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// DiffConfigHistoryKV - returns the changes restoring the config
+// set history entry represented by restoreID would make to the
+// current server config.
+func (adm *AdminClient) DiffConfigHistoryKV(ctx context.Context, restoreID string) ([]ConfigKVDiff, error) {
+	v := url.Values{}
+	v.Set("restoreId", restoreID)
+
+	// Execute GET on /minio/admin/v3/diff-config-history-kv
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/diff-config-history-kv",
+			queryValues: v,
+		})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	data, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []ConfigKVDiff
+	if err = json.Unmarshal(data, &diffs); err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
@@ -19,6 +19,7 @@ package madmin
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
 )
@@ -110,3 +111,144 @@ func (adm *AdminClient) GetConfigKV(ctx context.Context, key string) ([]byte, er
 
 	return DecryptData(adm.getSecretKey(), resp.Body)
 }
+
+// SetConfigKVStaged - stage key value config on the server, staged
+// config is only applied once the server is restarted.
+func (adm *AdminClient) SetConfigKVStaged(ctx context.Context, kv string) (err error) {
+	econfigBytes, err := EncryptData(adm.getSecretKey(), []byte(kv))
+	if err != nil {
+		return err
+	}
+
+	v := url.Values{}
+	v.Set("staged", "true")
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-config-kv",
+		queryValues: v,
+		content:     econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-config-kv?staged=true to stage config key/value.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetStagedConfigKV - returns the changes the staged config
+// makes to the current server config.
+func (adm *AdminClient) GetStagedConfigKV(ctx context.Context) ([]ConfigKVDiff, error) {
+	// Execute GET on /minio/admin/v3/staged-config-kv
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath: adminAPIPrefix + "/staged-config-kv",
+		})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	data, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []ConfigKVDiff
+	if err = json.Unmarshal(data, &diffs); err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
+
+// DiscardStagedConfigKV - discards the staged config.
+func (adm *AdminClient) DiscardStagedConfigKV(ctx context.Context) error {
+	// Execute DELETE on /minio/admin/v3/staged-config-kv
+	resp, err := adm.executeMethod(ctx,
+		http.MethodDelete,
+		requestData{
+			relPath: adminAPIPrefix + "/staged-config-kv",
+		})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// Origins of the values of a resolved config key.
+const (
+	ConfigOriginDefault = "default"
+	ConfigOriginConfig  = "config"
+	ConfigOriginEnv     = "env"
+)
+
+// ResolvedConfigKV - a config key along with the value in effect
+// and where the value comes from.
+type ResolvedConfigKV struct {
+	SubSys string `json:"subSys"`
+	Target string `json:"target"`
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Origin string `json:"origin"`
+	// Environment variable overriding the key, if any.
+	EnvVar string `json:"envVar,omitempty"`
+	// Set when the stored value is not yet used by the server.
+	PendingRestart bool `json:"pendingRestart,omitempty"`
+}
+
+// ResolvedConfig - the fully resolved config of the server.
+type ResolvedConfig struct {
+	KVS []ResolvedConfigKV `json:"kvs"`
+	// Set when some stored values are not yet used by the server.
+	RestartPending bool `json:"restartPending"`
+	// Set when there are staged config changes.
+	Staged bool `json:"staged"`
+}
+
+// GetResolvedConfigKV - returns the fully resolved config of the server,
+// with the origin of each key, to find out which settings are in effect.
+func (adm *AdminClient) GetResolvedConfigKV(ctx context.Context) (ResolvedConfig, error) {
+	// Execute GET on /minio/admin/v3/resolved-config-kv
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath: adminAPIPrefix + "/resolved-config-kv",
+		})
+	defer closeResponse(resp)
+	if err != nil {
+		return ResolvedConfig{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ResolvedConfig{}, httpRespToErrorResponse(resp)
+	}
+
+	data, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return ResolvedConfig{}, err
+	}
+
+	var cfg ResolvedConfig
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return ResolvedConfig{}, err
+	}
+	return cfg, nil
+}
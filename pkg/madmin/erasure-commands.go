@@ -0,0 +1,90 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErasureBenchmarkOpts - options of an erasure coding benchmark,
+// zero values select the server defaults.
+type ErasureBenchmarkOpts struct {
+	DataBlocks   int
+	ParityBlocks int
+	BlockSize    int64
+	Duration     time.Duration
+}
+
+// ErasureBenchmarkResult - erasure coding throughput of a node.
+type ErasureBenchmarkResult struct {
+	Addr string `json:"addr"`
+
+	// SIMD instruction set used for Reed-Solomon coding.
+	Acceleration string `json:"acceleration"`
+
+	DataBlocks   int   `json:"dataBlocks"`
+	ParityBlocks int   `json:"parityBlocks"`
+	BlockSize    int64 `json:"blockSize"`
+
+	// Throughput in bytes of object data per second.
+	EncodeThroughput float64 `json:"encodeThroughput"`
+	DecodeThroughput float64 `json:"decodeThroughput"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// ErasureBenchmark - runs an erasure coding benchmark on all nodes for the
+// given duration and returns the encode and decode throughput per node.
+func (adm *AdminClient) ErasureBenchmark(ctx context.Context, opts ErasureBenchmarkOpts) ([]ErasureBenchmarkResult, error) {
+	v := url.Values{}
+	if opts.DataBlocks > 0 {
+		v.Set("data", strconv.Itoa(opts.DataBlocks))
+	}
+	if opts.ParityBlocks > 0 {
+		v.Set("parity", strconv.Itoa(opts.ParityBlocks))
+	}
+	if opts.BlockSize > 0 {
+		v.Set("blockSize", strconv.FormatInt(opts.BlockSize, 10))
+	}
+	if opts.Duration > 0 {
+		v.Set("duration", opts.Duration.String())
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		relPath:     adminAPIPrefix + "/erasure-benchmark",
+		queryValues: v,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var results []ErasureBenchmarkResult
+	if err = json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -294,6 +294,29 @@ type Disk struct {
 	ReadLatency     float64 `json:"readlatency,omitempty"`
 	WriteLatency    float64 `json:"writelatency,omitempty"`
 	Utilization     float64 `json:"utilization,omitempty"`
+
+	// IO statistics of the drive since the server started.
+	Metrics *DiskMetrics `json:"metrics,omitempty"`
+}
+
+// DiskMetrics has the IO statistics of a drive per type of operation.
+type DiskMetrics struct {
+	Read   DiskIOStats `json:"read"`
+	Write  DiskIOStats `json:"write"`
+	Delete DiskIOStats `json:"delete"`
+	Stat   DiskIOStats `json:"stat"`
+}
+
+// DiskIOStats has the statistics of a type of drive operation,
+// latency percentiles are the upper bounds of the latency
+// ranges in which these percentiles of operations fall.
+type DiskIOStats struct {
+	Count      uint64        `json:"count"`
+	Errors     uint64        `json:"errors"`
+	Bytes      uint64        `json:"bytes,omitempty"`
+	LatencyP50 time.Duration `json:"latencyP50"`
+	LatencyP90 time.Duration `json:"latencyP90"`
+	LatencyP99 time.Duration `json:"latencyP99"`
 }
 
 // ServerInfo - Connect to a minio server and call Server Admin Info Management API
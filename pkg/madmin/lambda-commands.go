@@ -0,0 +1,135 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BucketLambda holds the object lambda configuration of a bucket,
+// every GET with the `lambda` query parameter on this bucket streams
+// the object to Endpoint and returns the transformed response.
+type BucketLambda struct {
+	// Endpoint is the transformation webhook, empty means disabled.
+	Endpoint string `json:"endpoint"`
+	// AuthToken is sent as 'Authorization' header to the webhook.
+	AuthToken string `json:"authToken,omitempty"`
+	// Timeout in seconds for a single transformation, zero picks
+	// the server default.
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// IsValid returns false if the lambda configuration is invalid,
+// empty configs are always valid.
+func (l BucketLambda) IsValid() bool {
+	if l.Endpoint == "" {
+		return true
+	}
+	u, err := url.Parse(l.Endpoint)
+	if err != nil {
+		return false
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	return u.Host != "" && l.Timeout >= 0
+}
+
+// Enabled returns true if a transformation endpoint is configured.
+func (l BucketLambda) Enabled() bool {
+	return l.Endpoint != ""
+}
+
+// TimeoutDuration returns the configured timeout, falls back
+// to def when timeout is not configured.
+func (l BucketLambda) TimeoutDuration(def time.Duration) time.Duration {
+	if l.Timeout <= 0 {
+		return def
+	}
+	return time.Duration(l.Timeout) * time.Second
+}
+
+// GetBucketLambda - get the object lambda configuration of a bucket.
+func (adm *AdminClient) GetBucketLambda(ctx context.Context, bucket string) (l BucketLambda, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-lambda",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-lambda
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return l, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return l, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return l, err
+	}
+	if err = json.Unmarshal(b, &l); err != nil {
+		return l, err
+	}
+
+	return l, nil
+}
+
+// SetBucketLambda - sets a bucket's object lambda configuration, if
+// endpoint is set to empty the transformation is disabled.
+func (adm *AdminClient) SetBucketLambda(ctx context.Context, bucket string, lambda *BucketLambda) error {
+	data, err := json.Marshal(lambda)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-lambda",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-lambda to set lambda for a bucket.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// BucketPlacement holds the placement policy of a bucket, new objects
+// matching one of the rules are written to the server pool of the rule
+// instead of the server pool with the most available space.
+type BucketPlacement struct {
+	Rules []PlacementRule `json:"rules"`
+}
+
+// PlacementRule pins objects under Prefix and of at least MinSize
+// bytes to a server pool.
+type PlacementRule struct {
+	// Prefix of the objects the rule applies to, empty matches all objects.
+	Prefix string `json:"prefix,omitempty"`
+	// MinSize in bytes of the objects the rule applies to. Objects of
+	// unknown size never match a rule with a MinSize, multipart uploads
+	// are only of known size if initiated with the X-Minio-Object-Size
+	// header, otherwise they are placed by the rules without a MinSize
+	// or on the server pool with the most available space.
+	MinSize int64 `json:"minSize,omitempty"`
+	// Pool is the index of the server pool, in the order the pools
+	// were given on the command line.
+	Pool int `json:"pool"`
+}
+
+// IsValid returns false if the placement configuration is invalid,
+// empty configs are always valid.
+func (p BucketPlacement) IsValid() bool {
+	for _, rule := range p.Rules {
+		if rule.Pool < 0 || rule.MinSize < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Enabled returns true if at least one placement rule is configured.
+func (p BucketPlacement) Enabled() bool {
+	return len(p.Rules) > 0
+}
+
+// Pool returns the server pool of the first rule matching the object
+// name and size, a negative size stands for an unknown size.
+func (p BucketPlacement) Pool(object string, size int64) (pool int, ok bool) {
+	for _, rule := range p.Rules {
+		if !strings.HasPrefix(object, rule.Prefix) {
+			continue
+		}
+		if rule.MinSize > 0 && size < rule.MinSize {
+			continue
+		}
+		return rule.Pool, true
+	}
+	return -1, false
+}
+
+// GetBucketPlacement - get the placement policy of a bucket.
+func (adm *AdminClient) GetBucketPlacement(ctx context.Context, bucket string) (p BucketPlacement, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-placement",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-placement
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return p, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return p, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return p, err
+	}
+	if err = json.Unmarshal(b, &p); err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+// SetBucketPlacement - sets a bucket's placement policy, if no rules
+// are set new objects are placed on any server pool.
+func (adm *AdminClient) SetBucketPlacement(ctx context.Context, bucket string, placement *BucketPlacement) error {
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-placement",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-placement to set placement for a bucket.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // ProfilerType represents the profiler type
@@ -110,3 +111,32 @@ func (adm *AdminClient) DownloadProfilingData(ctx context.Context) (io.ReadClose
 
 	return resp.Body, nil
 }
+
+// Profile makes an admin call to profile a standalone server or the whole
+// cluster in case of a distributed setup for the given duration, the
+// returned stream is the zipped profiling data of all nodes.
+func (adm *AdminClient) Profile(ctx context.Context, profiler ProfilerType, duration time.Duration) (io.ReadCloser, error) {
+	v := url.Values{}
+	v.Set("profilerType", string(profiler))
+	v.Set("duration", duration.String())
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPost, requestData{
+			relPath:     adminAPIPrefix + "/profile",
+			queryValues: v,
+		},
+	)
+	if err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	if resp.Body == nil {
+		return nil, errors.New("body is nil")
+	}
+
+	return resp.Body, nil
+}
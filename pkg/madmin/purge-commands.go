@@ -0,0 +1,90 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PurgeVersionsOpts - options of a versions purge.
+type PurgeVersionsOpts struct {
+	// BypassGovernance allows removing versions under governance
+	// mode retention, requires the s3:BypassGovernanceRetention
+	// permission on the bucket.
+	BypassGovernance bool
+	// DryRun only reports the versions which would be removed.
+	DryRun bool
+}
+
+// PurgeVersionsResult - result of a versions purge.
+type PurgeVersionsResult struct {
+	// Number of versions and delete markers removed, or which
+	// would be removed when run with DryRun.
+	Deleted int64 `json:"deleted"`
+	// Number of versions skipped because of an active legal
+	// hold or retention.
+	Locked int64 `json:"locked"`
+	// Number of versions which could not be removed.
+	Failed int64 `json:"failed"`
+}
+
+// PurgeObjectVersions - permanently removes all versions and delete
+// markers of the objects under prefix in bucket, versions protected
+// by object locking are kept and reported as locked.
+func (adm *AdminClient) PurgeObjectVersions(ctx context.Context, bucket, prefix string, opts PurgeVersionsOpts) (result PurgeVersionsResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("prefix", prefix)
+	if opts.BypassGovernance {
+		queryValues.Set("bypass-governance", strconv.FormatBool(opts.BypassGovernance))
+	}
+	if opts.DryRun {
+		queryValues.Set("dry-run", strconv.FormatBool(opts.DryRun))
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/purge-versions",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/purge-versions
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+	if err = json.Unmarshal(b, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ReplayEventsResult - result of an events replay.
+type ReplayEventsResult struct {
+	// Number of events sent to the target.
+	Replayed int64 `json:"replayed"`
+}
+
+// ReplayEvents - sends the journaled events of bucket between start and
+// end again to the notification target with the given ARN, only events
+// routed to this target by the notification configuration of the bucket
+// are sent. Requires the event journal to be enabled on the server.
+func (adm *AdminClient) ReplayEvents(ctx context.Context, bucket, arn string, start, end time.Time) (result ReplayEventsResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("arn", arn)
+	queryValues.Set("start", start.UTC().Format(time.RFC3339Nano))
+	queryValues.Set("end", end.UTC().Format(time.RFC3339Nano))
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/replay-events",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/replay-events
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+	if err = json.Unmarshal(b, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
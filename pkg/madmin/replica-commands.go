@@ -0,0 +1,147 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// BucketReplicaConfig holds the replica mode of a bucket. A read-only
+// replica, such as the bucket of a warm standby cluster, rejects all
+// object writes except those sent by replication.
+type BucketReplicaConfig struct {
+	ReadOnly bool `json:"readOnly"`
+}
+
+// GetBucketReplicaConfig - get the replica mode of a bucket.
+func (adm *AdminClient) GetBucketReplicaConfig(ctx context.Context, bucket string) (c BucketReplicaConfig, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-replica",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-replica
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return c, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return c, err
+	}
+	if err = json.Unmarshal(b, &c); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// SetBucketReplicaConfig - sets the replica mode of a bucket.
+func (adm *AdminClient) SetBucketReplicaConfig(ctx context.Context, bucket string, config BucketReplicaConfig) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-replica",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-replica to set the replica mode of a bucket.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// FailoverBucket - makes a read-only replica bucket writable. If target
+// is not nil, it is the bucket of the former primary cluster, it is added
+// as a remote target of the bucket along with a replication configuration
+// replicating all objects to it, which reverses the replication direction.
+// The ARN of the new target is returned.
+func (adm *AdminClient) FailoverBucket(ctx context.Context, bucket string, target *BucketTarget) (string, error) {
+	var encData []byte
+	if target != nil {
+		data, err := json.Marshal(target)
+		if err != nil {
+			return "", err
+		}
+		encData, err = EncryptData(adm.getSecretKey(), data)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/failover-bucket",
+		queryValues: queryValues,
+		content:     encData,
+	}
+
+	// Execute POST on /minio/admin/v3/failover-bucket to make the replica bucket writable.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var arn string
+	if err = json.Unmarshal(b, &arn); err != nil {
+		return "", err
+	}
+	return arn, nil
+}
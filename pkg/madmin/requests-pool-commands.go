@@ -0,0 +1,97 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// RequestsPoolWaitTime holds the percentiles of the time S3 API
+// requests waited for a slot of the requests pool.
+type RequestsPoolWaitTime struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// RequestsPoolStats holds the occupancy of the requests pool of a
+// server, the pool limits the number of S3 API requests served
+// concurrently by the server.
+type RequestsPoolStats struct {
+	Endpoint string `json:"endpoint"`
+	Error    string `json:"error,omitempty"`
+
+	// Limit is the number of requests served concurrently,
+	// zero when the requests are not limited.
+	Limit int `json:"limit"`
+	// AutoTune is set when the limit is tuned by the server
+	// based on the memory usage and the GC pauses.
+	AutoTune bool `json:"autoTune"`
+
+	InUse    int64  `json:"inUse"`
+	Waiting  int64  `json:"waiting"`
+	Served   uint64 `json:"served"`
+	Rejected uint64 `json:"rejected"`
+
+	// WaitTime is computed over the most recent requests.
+	WaitTime RequestsPoolWaitTime `json:"waitTime"`
+
+	// Memory used by the server and available to the server
+	// along with the longest GC pause seen by the last tuning.
+	MemUsed  uint64        `json:"memUsed"`
+	MemTotal uint64        `json:"memTotal"`
+	GCPause  time.Duration `json:"gcPause"`
+}
+
+// RequestsPoolInfo holds the requests pool stats of all servers.
+type RequestsPoolInfo struct {
+	Servers []RequestsPoolStats `json:"servers"`
+}
+
+// RequestsPoolInfo - returns the occupancy and the limit of the
+// requests pool of all servers.
+func (adm *AdminClient) RequestsPoolInfo(ctx context.Context) (RequestsPoolInfo, error) {
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{relPath: adminAPIPrefix + "/requests-pool"},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return RequestsPoolInfo{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return RequestsPoolInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return RequestsPoolInfo{}, err
+	}
+
+	var info RequestsPoolInfo
+	if err = json.Unmarshal(respBytes, &info); err != nil {
+		return RequestsPoolInfo{}, err
+	}
+	return info, nil
+}
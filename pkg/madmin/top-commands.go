@@ -40,6 +40,10 @@ type LockEntry struct {
 	ID         string    `json:"id"`         // UID to uniquely identify request of client.
 	// Represents quorum number of servers required to hold this lock, used to look for stale locks.
 	Quorum int `json:"quorum"`
+	// Elapsed time since the lock was granted, or requested if queued.
+	Elapsed time.Duration `json:"elapsed"`
+	// Queued is set if the lock request is still waiting to be granted.
+	Queued bool `json:"queued,omitempty"`
 }
 
 // LockEntries - To sort the locks
@@ -59,12 +63,14 @@ func (l LockEntries) Swap(i, j int) {
 
 // TopLockOpts top lock options
 type TopLockOpts struct {
-	Count int
-	Stale bool
+	Count  int
+	Stale  bool
+	Queued bool
 }
 
 // TopLocksWithOpts - returns the count number of oldest locks currently active on the server.
-// additionally we can also enable `stale` to get stale locks currently present on server.
+// additionally we can also enable `stale` to get stale locks currently present on server
+// and `queued` to get lock requests waiting to be granted.
 func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts) (LockEntries, error) {
 	// Execute GET on /minio/admin/v3/top/locks?count=10
 	// to get the 'count' number of oldest locks currently
@@ -72,6 +78,7 @@ func (adm *AdminClient) TopLocksWithOpts(ctx context.Context, opts TopLockOpts)
 	queryVals := make(url.Values)
 	queryVals.Set("count", strconv.Itoa(opts.Count))
 	queryVals.Set("stale", strconv.FormatBool(opts.Stale))
+	queryVals.Set("queued", strconv.FormatBool(opts.Queued))
 	resp, err := adm.executeMethod(ctx,
 		http.MethodGet,
 		requestData{
@@ -387,3 +387,39 @@ func (adm *AdminClient) DeleteServiceAccount(ctx context.Context, serviceAccount
 
 	return nil
 }
+
+// RefreshIAMNodeResult - result of reloading IAM on a single node.
+type RefreshIAMNodeResult struct {
+	Host  string `json:"host"`
+	Error string `json:"error,omitempty"`
+}
+
+// RefreshIAMResult - result of reloading IAM on all the nodes.
+type RefreshIAMResult struct {
+	Nodes []RefreshIAMNodeResult `json:"nodes"`
+}
+
+// RefreshIAM - reloads all users, groups, policies and policy mappings
+// from the IAM backend (disks or etcd) on all the nodes.
+func (adm *AdminClient) RefreshIAM(ctx context.Context) (RefreshIAMResult, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/refresh-iam",
+	}
+
+	// Execute POST on /minio/admin/v3/refresh-iam
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return RefreshIAMResult{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return RefreshIAMResult{}, httpRespToErrorResponse(resp)
+	}
+
+	var result RefreshIAMResult
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return RefreshIAMResult{}, err
+	}
+	return result, nil
+}
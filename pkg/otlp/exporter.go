@@ -0,0 +1,259 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package otlp implements an OpenTelemetry span exporter sending
+// spans to a collector with the OTLP/HTTP protocol, JSON encoded.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+)
+
+// Exporter - exports spans to an OTLP/HTTP traces endpoint.
+type Exporter struct {
+	endpoint  string
+	authToken string
+	client    *http.Client
+}
+
+// NewExporter - returns a new exporter sending spans to endpoint,
+// authToken if not empty is sent as the bearer token of each request.
+func NewExporter(endpoint, authToken string, transport http.RoundTripper) *Exporter {
+	return &Exporter{
+		endpoint:  endpoint,
+		authToken: authToken,
+		client:    &http.Client{Transport: transport},
+	}
+}
+
+// ExportSpans - implements export.SpanExporter interface.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []*export.SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(newTracesData(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+e.authToken)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s returned '%s', please check the collector configuration", e.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// Shutdown - implements export.SpanExporter interface.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// The types below follow the JSON mapping of the OTLP trace protobuf
+// messages, 64 bit integers are encoded as strings and ids as hex.
+
+type tracesData struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Events            []event    `json:"events,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type event struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// OTLP status codes.
+const (
+	statusCodeOk    = 1
+	statusCodeError = 2
+)
+
+// newTracesData groups spans by resource and instrumentation library.
+func newTracesData(spans []*export.SpanData) tracesData {
+	var td tracesData
+	resources := make(map[label.Distinct]int)
+	scopes := make(map[label.Distinct]map[string]int)
+	for _, sd := range spans {
+		rkey := sd.Resource.Equivalent()
+		ri, ok := resources[rkey]
+		if !ok {
+			ri = len(td.ResourceSpans)
+			resources[rkey] = ri
+			scopes[rkey] = make(map[string]int)
+			td.ResourceSpans = append(td.ResourceSpans, resourceSpans{
+				Resource: resource{Attributes: newKeyValues(sd.Resource.Attributes())},
+			})
+		}
+		rs := &td.ResourceSpans[ri]
+		skey := sd.InstrumentationLibrary.Name + "@" + sd.InstrumentationLibrary.Version
+		si, ok := scopes[rkey][skey]
+		if !ok {
+			si = len(rs.ScopeSpans)
+			scopes[rkey][skey] = si
+			rs.ScopeSpans = append(rs.ScopeSpans, scopeSpans{
+				Scope: scope{
+					Name:    sd.InstrumentationLibrary.Name,
+					Version: sd.InstrumentationLibrary.Version,
+				},
+			})
+		}
+		rs.ScopeSpans[si].Spans = append(rs.ScopeSpans[si].Spans, newSpan(sd))
+	}
+	return td
+}
+
+func newSpan(sd *export.SpanData) span {
+	s := span{
+		TraceID:           sd.SpanContext.TraceID.String(),
+		SpanID:            sd.SpanContext.SpanID.String(),
+		Name:              sd.Name,
+		Kind:              int(sd.SpanKind),
+		StartTimeUnixNano: strconv.FormatInt(sd.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(sd.EndTime.UnixNano(), 10),
+		Attributes:        newKeyValues(sd.Attributes),
+		Status:            status{Message: sd.StatusMessage},
+	}
+	if sd.ParentSpanID.IsValid() {
+		s.ParentSpanID = sd.ParentSpanID.String()
+	}
+	switch sd.StatusCode {
+	case codes.Ok:
+		s.Status.Code = statusCodeOk
+	case codes.Error:
+		s.Status.Code = statusCodeError
+	}
+	for _, ev := range sd.MessageEvents {
+		s.Events = append(s.Events, event{
+			TimeUnixNano: strconv.FormatInt(ev.Time.UnixNano(), 10),
+			Name:         ev.Name,
+			Attributes:   newKeyValues(ev.Attributes),
+		})
+	}
+	return s
+}
+
+func newKeyValues(kvs []label.KeyValue) []keyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+	values := make([]keyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		values = append(values, keyValue{Key: string(kv.Key), Value: newAnyValue(kv.Value)})
+	}
+	return values
+}
+
+func newAnyValue(v label.Value) (av anyValue) {
+	switch v.Type() {
+	case label.BOOL:
+		b := v.AsBool()
+		av.BoolValue = &b
+	case label.INT32:
+		i := strconv.FormatInt(int64(v.AsInt32()), 10)
+		av.IntValue = &i
+	case label.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		av.IntValue = &i
+	case label.UINT32:
+		i := strconv.FormatUint(uint64(v.AsUint32()), 10)
+		av.IntValue = &i
+	case label.UINT64:
+		i := strconv.FormatUint(v.AsUint64(), 10)
+		av.IntValue = &i
+	case label.FLOAT32:
+		f := float64(v.AsFloat32())
+		av.DoubleValue = &f
+	case label.FLOAT64:
+		f := v.AsFloat64()
+		av.DoubleValue = &f
+	default:
+		s := v.Emit()
+		av.StringValue = &s
+	}
+	return av
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestExportSpans(t *testing.T) {
+	var (
+		received tracesData
+		auth     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	res := sdkresource.New(label.String("service.name", "minio"))
+	lib := instrumentation.Library{Name: "github.com/minio/minio"}
+	start := time.Unix(1600000000, 0)
+	spans := []*export.SpanData{
+		{
+			SpanContext: apitrace.SpanContext{
+				TraceID: apitrace.ID{1},
+				SpanID:  apitrace.SpanID{1},
+			},
+			SpanKind:               apitrace.SpanKindServer,
+			Name:                   "PutObject",
+			StartTime:              start,
+			EndTime:                start.Add(time.Second),
+			Attributes:             []label.KeyValue{label.String("s3.bucket", "bucket"), label.Int("http.status_code", 200)},
+			Resource:               res,
+			InstrumentationLibrary: lib,
+		},
+		{
+			SpanContext: apitrace.SpanContext{
+				TraceID: apitrace.ID{1},
+				SpanID:  apitrace.SpanID{2},
+			},
+			ParentSpanID:           apitrace.SpanID{1},
+			SpanKind:               apitrace.SpanKindInternal,
+			Name:                   "ObjectLayer.PutObject",
+			StartTime:              start,
+			EndTime:                start.Add(time.Second),
+			StatusCode:             codes.Error,
+			StatusMessage:          "disk not found",
+			Resource:               res,
+			InstrumentationLibrary: lib,
+		},
+	}
+
+	exporter := NewExporter(server.URL+"/v1/traces", "token", http.DefaultTransport)
+	if err := exporter.ExportSpans(context.Background(), spans); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bearer token" {
+		t.Fatalf("expected bearer token, got %q", auth)
+	}
+	if len(received.ResourceSpans) != 1 || len(received.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("expected spans grouped by resource and library, got %+v", received)
+	}
+	got := received.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(got) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(got))
+	}
+	if got[0].TraceID != "01000000000000000000000000000000" || got[0].SpanID != "0100000000000000" || got[0].ParentSpanID != "" {
+		t.Fatalf("unexpected ids %+v", got[0])
+	}
+	if got[0].Kind != int(apitrace.SpanKindServer) || got[0].StartTimeUnixNano != "1600000000000000000" {
+		t.Fatalf("unexpected span %+v", got[0])
+	}
+	if v := got[0].Attributes[1].Value.IntValue; v == nil || *v != "200" {
+		t.Fatalf("expected int attribute, got %+v", got[0].Attributes[1])
+	}
+	if got[1].ParentSpanID != "0100000000000000" || got[1].Status.Code != statusCodeError {
+		t.Fatalf("unexpected span %+v", got[1])
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	if err := exporter.ExportSpans(context.Background(), spans); err == nil {
+		t.Fatal("expected export to fail")
+	}
+}
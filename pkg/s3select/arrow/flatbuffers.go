@@ -0,0 +1,198 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arrow
+
+import (
+	"encoding/binary"
+)
+
+// The Arrow IPC metadata is serialized with flatbuffers, this file
+// implements the small subset of the flatbuffers encoding needed to
+// write the schema and record batch messages. Objects are written
+// front to back, every object referenced by an offset is written
+// after the referencing table, as flatbuffers offsets are unsigned.
+
+// fbObject is a flatbuffers object written out of line and
+// referenced by an offset.
+type fbObject interface {
+	// write appends the object and returns its position.
+	write(b *fbBuilder) int
+}
+
+// fbField is a table field, a field of zero size is absent.
+type fbField struct {
+	size   int
+	scalar uint64
+	ref    fbObject
+}
+
+func fbBool(v bool) fbField {
+	if v {
+		return fbField{size: 1, scalar: 1}
+	}
+	return fbField{size: 1}
+}
+
+func fbUint8(v uint8) fbField       { return fbField{size: 1, scalar: uint64(v)} }
+func fbInt16(v int16) fbField       { return fbField{size: 2, scalar: uint64(uint16(v))} }
+func fbInt32(v int32) fbField       { return fbField{size: 4, scalar: uint64(uint32(v))} }
+func fbInt64(v int64) fbField       { return fbField{size: 8, scalar: uint64(v)} }
+func fbRef(v fbObject) fbField      { return fbField{size: 4, ref: v} }
+func fbStr(v string) fbField        { return fbRef(fbString(v)) }
+func fbAbsent() (f fbField)         { return f }
+func fbTables(v ...fbTable) fbField { return fbRef(fbTableVector(v)) }
+
+// fbTable is a table, indexed by field id.
+type fbTable []fbField
+
+func (t fbTable) write(b *fbBuilder) int {
+	// Lay out the inline fields after the vtable offset.
+	offsets := make([]int, len(t))
+	size, align := 4, 4
+	for i, f := range t {
+		if f.size == 0 {
+			continue
+		}
+		for size%f.size != 0 {
+			size++
+		}
+		offsets[i] = size
+		size += f.size
+		if f.size > align {
+			align = f.size
+		}
+	}
+
+	b.pad(2)
+	vtable := len(b.buf)
+	b.putUint16(uint16(4 + 2*len(t)))
+	b.putUint16(uint16(size))
+	for _, offset := range offsets {
+		b.putUint16(uint16(offset))
+	}
+
+	b.pad(align)
+	start := len(b.buf)
+	b.buf = append(b.buf, make([]byte, size)...)
+	binary.LittleEndian.PutUint32(b.buf[start:], uint32(int32(start-vtable)))
+	for i, f := range t {
+		pos := start + offsets[i]
+		switch f.size {
+		case 1:
+			b.buf[pos] = byte(f.scalar)
+		case 2:
+			binary.LittleEndian.PutUint16(b.buf[pos:], uint16(f.scalar))
+		case 4:
+			binary.LittleEndian.PutUint32(b.buf[pos:], uint32(f.scalar))
+		case 8:
+			binary.LittleEndian.PutUint64(b.buf[pos:], f.scalar)
+		}
+	}
+	for i, f := range t {
+		if f.ref != nil {
+			b.patch(start+offsets[i], f.ref.write(b))
+		}
+	}
+	return start
+}
+
+// fbString is a null terminated string.
+type fbString string
+
+func (s fbString) write(b *fbBuilder) int {
+	b.pad(4)
+	start := len(b.buf)
+	b.putUint32(uint32(len(s)))
+	b.buf = append(b.buf, s...)
+	b.buf = append(b.buf, 0)
+	return start
+}
+
+// fbTableVector is a vector of tables.
+type fbTableVector []fbTable
+
+func (v fbTableVector) write(b *fbBuilder) int {
+	b.pad(4)
+	start := len(b.buf)
+	b.putUint32(uint32(len(v)))
+	b.buf = append(b.buf, make([]byte, 4*len(v))...)
+	for i, t := range v {
+		b.patch(start+4+4*i, t.write(b))
+	}
+	return start
+}
+
+// fbStructVector is a vector of structs made of 64 bit
+// integers, which are thus aligned on 8 bytes.
+type fbStructVector struct {
+	count  int
+	values []int64
+}
+
+func (v fbStructVector) write(b *fbBuilder) int {
+	// The vector length precedes the 8 bytes aligned elements.
+	b.pad(4)
+	if (len(b.buf)+4)%8 != 0 {
+		b.buf = append(b.buf, 0, 0, 0, 0)
+	}
+	start := len(b.buf)
+	b.putUint32(uint32(v.count))
+	for _, value := range v.values {
+		b.putUint64(uint64(value))
+	}
+	return start
+}
+
+type fbBuilder struct {
+	buf []byte
+}
+
+func (b *fbBuilder) pad(align int) {
+	for len(b.buf)%align != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+func (b *fbBuilder) putUint16(v uint16) {
+	b.buf = append(b.buf, byte(v), byte(v>>8))
+}
+
+func (b *fbBuilder) putUint32(v uint32) {
+	var tmp [4]byte
+	binary.LittleEndian.PutUint32(tmp[:], v)
+	b.buf = append(b.buf, tmp[:]...)
+}
+
+func (b *fbBuilder) putUint64(v uint64) {
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], v)
+	b.buf = append(b.buf, tmp[:]...)
+}
+
+// patch sets the offset at pos to point to target.
+func (b *fbBuilder) patch(pos, target int) {
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(target-pos))
+}
+
+// fbFinish serializes the root table, the returned
+// buffer is padded to a multiple of 8 bytes.
+func fbFinish(root fbTable) []byte {
+	b := &fbBuilder{buf: make([]byte, 4, 512)}
+	b.patch(0, root.write(b))
+	b.pad(8)
+	return b.buf
+}
@@ -0,0 +1,326 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arrow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/bcicen/jstream"
+)
+
+// Arrow IPC format constants as defined in Schema.fbs and Message.fbs.
+const (
+	metadataV5 = 4
+
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeUtf8          = 5
+	typeBool          = 6
+
+	precisionDouble = 2
+
+	// Marker preceding every encapsulated message.
+	continuationMarker = 0xFFFFFFFF
+)
+
+type columnType int
+
+const (
+	columnUtf8 columnType = iota
+	columnInt64
+	columnFloat64
+	columnBool
+)
+
+type column struct {
+	name string
+	typ  columnType
+}
+
+// Writer - encodes records as an Arrow IPC stream.
+//
+// Arrow requires a schema upfront, it is inferred from the first
+// record written: integer, float and boolean values map to the
+// corresponding Arrow types, all other values are written as UTF-8
+// strings. Values of later records which do not match the type of
+// their column are written as null, as are missing columns.
+// Columns which are not part of the first record are dropped.
+type Writer struct {
+	columns    []column
+	index      map[string]int
+	schemaSent bool
+}
+
+// NewWriter - creates new Arrow IPC stream writer.
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+// WriteBatch - appends a record batch holding rows to buf, the first
+// batch is preceded by the stream schema.
+func (w *Writer) WriteBatch(buf *bytes.Buffer, rows []jstream.KVS) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if !w.schemaSent {
+		w.inferSchema(rows[0])
+		w.writeSchema(buf)
+	}
+
+	// Gather the values of each column.
+	values := make([][]interface{}, len(w.columns))
+	for i := range values {
+		values[i] = make([]interface{}, len(rows))
+	}
+	for r, row := range rows {
+		for _, kv := range row {
+			if i, ok := w.index[kv.Key]; ok && values[i][r] == nil {
+				values[i][r] = kv.Value
+			}
+		}
+	}
+
+	var b recordBatchBody
+	nodes := make([]int64, 0, 2*len(w.columns))
+	for i, c := range w.columns {
+		nullCount, err := b.addColumn(c.typ, values[i])
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, int64(len(rows)), int64(nullCount))
+	}
+
+	recordBatch := fbTable{
+		fbInt64(int64(len(rows))),
+		fbRef(fbStructVector{count: len(w.columns), values: nodes}),
+		fbRef(fbStructVector{count: len(b.buffers) / 2, values: b.buffers}),
+	}
+	writeMessage(buf, headerRecordBatch, recordBatch, b.data)
+	return nil
+}
+
+// Close - appends the end of stream marker to buf, preceded by an
+// empty schema if no batch was written.
+func (w *Writer) Close(buf *bytes.Buffer) {
+	if !w.schemaSent {
+		w.writeSchema(buf)
+	}
+	var eos [8]byte
+	binary.LittleEndian.PutUint32(eos[:], continuationMarker)
+	buf.Write(eos[:])
+}
+
+func (w *Writer) inferSchema(row jstream.KVS) {
+	w.index = make(map[string]int, len(row))
+	for _, kv := range row {
+		if _, ok := w.index[kv.Key]; ok {
+			continue
+		}
+		typ := columnUtf8
+		switch kv.Value.(type) {
+		case int64:
+			typ = columnInt64
+		case float64:
+			typ = columnFloat64
+		case bool:
+			typ = columnBool
+		}
+		w.index[kv.Key] = len(w.columns)
+		w.columns = append(w.columns, column{name: kv.Key, typ: typ})
+	}
+}
+
+func (w *Writer) writeSchema(buf *bytes.Buffer) {
+	fields := make([]fbTable, 0, len(w.columns))
+	for _, c := range w.columns {
+		var typeType uint8
+		var typ fbTable
+		switch c.typ {
+		case columnInt64:
+			typeType, typ = typeInt, fbTable{fbInt32(64), fbBool(true)}
+		case columnFloat64:
+			typeType, typ = typeFloatingPoint, fbTable{fbInt16(precisionDouble)}
+		case columnBool:
+			typeType, typ = typeBool, fbTable{}
+		default:
+			typeType, typ = typeUtf8, fbTable{}
+		}
+		fields = append(fields, fbTable{
+			fbStr(c.name),     // name
+			fbBool(true),      // nullable
+			fbUint8(typeType), // type_type
+			fbRef(typ),        // type
+			fbAbsent(),        // dictionary
+			fbTables(),        // children
+		})
+	}
+	schema := fbTable{
+		fbInt16(0), // little endian
+		fbTables(fields...),
+	}
+	writeMessage(buf, headerSchema, schema, nil)
+	w.schemaSent = true
+}
+
+// writeMessage appends an encapsulated message to buf, body
+// must be padded to a multiple of 8 bytes.
+func writeMessage(buf *bytes.Buffer, headerType uint8, header fbTable, body []byte) {
+	metadata := fbFinish(fbTable{
+		fbInt16(metadataV5),
+		fbUint8(headerType),
+		fbRef(header),
+		fbInt64(int64(len(body))),
+	})
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:4], continuationMarker)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(metadata)))
+	buf.Write(prefix[:])
+	buf.Write(metadata)
+	buf.Write(body)
+}
+
+// recordBatchBody holds the buffers of a record batch, along
+// with their offset and length pairs.
+type recordBatchBody struct {
+	data    []byte
+	buffers []int64
+}
+
+func (b *recordBatchBody) add(p []byte) {
+	b.buffers = append(b.buffers, int64(len(b.data)), int64(len(p)))
+	b.data = append(b.data, p...)
+	for len(b.data)%8 != 0 {
+		b.data = append(b.data, 0)
+	}
+}
+
+// addColumn adds the validity and value buffers of a column,
+// returning its number of null values.
+func (b *recordBatchBody) addColumn(typ columnType, values []interface{}) (int, error) {
+	n := len(values)
+	validity := make([]byte, (n+7)/8)
+	nullCount := 0
+	setValid := func(i int) {
+		validity[i/8] |= 1 << uint(i%8)
+	}
+
+	var data, offsets []byte
+	switch typ {
+	case columnInt64, columnFloat64:
+		data = make([]byte, 8*n)
+	case columnBool:
+		data = make([]byte, (n+7)/8)
+	case columnUtf8:
+		offsets = make([]byte, 4*(n+1))
+	}
+
+	for i, value := range values {
+		switch typ {
+		case columnInt64:
+			v, ok := toInt64(value)
+			if !ok {
+				nullCount++
+				continue
+			}
+			binary.LittleEndian.PutUint64(data[8*i:], uint64(v))
+		case columnFloat64:
+			v, ok := toFloat64(value)
+			if !ok {
+				nullCount++
+				continue
+			}
+			binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(v))
+		case columnBool:
+			v, ok := value.(bool)
+			if !ok {
+				nullCount++
+				continue
+			}
+			if v {
+				data[i/8] |= 1 << uint(i%8)
+			}
+		case columnUtf8:
+			// Null values are empty strings.
+			if value == nil {
+				binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+				nullCount++
+				continue
+			}
+			s, err := toString(value)
+			if err != nil {
+				return 0, err
+			}
+			data = append(data, s...)
+			binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+		}
+		setValid(i)
+	}
+
+	b.add(validity)
+	if typ == columnUtf8 {
+		b.add(offsets)
+	}
+	b.add(data)
+	return nullCount, nil
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			return int64(v), true
+		}
+	}
+	return 0, false
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func toString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,269 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arrow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/bcicen/jstream"
+)
+
+// fbTableReader reads the fields of a flatbuffers table.
+type fbTableReader struct {
+	buf []byte
+	pos int
+}
+
+func fbRootReader(buf []byte) fbTableReader {
+	return fbTableReader{buf, int(binary.LittleEndian.Uint32(buf))}
+}
+
+func (t fbTableReader) field(id int) int {
+	vtable := t.pos - int(int32(binary.LittleEndian.Uint32(t.buf[t.pos:])))
+	if 4+2*id >= int(binary.LittleEndian.Uint16(t.buf[vtable:])) {
+		return 0
+	}
+	offset := int(binary.LittleEndian.Uint16(t.buf[vtable+4+2*id:]))
+	if offset == 0 {
+		return 0
+	}
+	return t.pos + offset
+}
+
+func (t fbTableReader) scalar(id, size int) uint64 {
+	pos := t.field(id)
+	if pos == 0 {
+		return 0
+	}
+	switch size {
+	case 1:
+		return uint64(t.buf[pos])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(t.buf[pos:]))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(t.buf[pos:]))
+	}
+	return binary.LittleEndian.Uint64(t.buf[pos:])
+}
+
+func (t fbTableReader) ref(id int) int {
+	pos := t.field(id)
+	if pos == 0 {
+		return 0
+	}
+	return pos + int(binary.LittleEndian.Uint32(t.buf[pos:]))
+}
+
+func (t fbTableReader) table(id int) fbTableReader {
+	return fbTableReader{t.buf, t.ref(id)}
+}
+
+func (t fbTableReader) str(id int) string {
+	pos := t.ref(id)
+	n := int(binary.LittleEndian.Uint32(t.buf[pos:]))
+	return string(t.buf[pos+4 : pos+4+n])
+}
+
+func (t fbTableReader) tables(id int) []fbTableReader {
+	pos := t.ref(id)
+	n := int(binary.LittleEndian.Uint32(t.buf[pos:]))
+	tables := make([]fbTableReader, n)
+	for i := range tables {
+		elem := pos + 4 + 4*i
+		tables[i] = fbTableReader{t.buf, elem + int(binary.LittleEndian.Uint32(t.buf[elem:]))}
+	}
+	return tables
+}
+
+func (t fbTableReader) int64s(id int) []int64 {
+	pos := t.ref(id)
+	if (pos+4)%8 != 0 {
+		panic("misaligned struct vector")
+	}
+	n := int(binary.LittleEndian.Uint32(t.buf[pos:]))
+	var values []int64
+	for i := 0; i < 2*n; i++ {
+		values = append(values, int64(binary.LittleEndian.Uint64(t.buf[pos+4+8*i:])))
+	}
+	return values
+}
+
+type testMessage struct {
+	header fbTableReader
+	typ    uint8
+	body   []byte
+}
+
+func readTestStream(t *testing.T, stream []byte) []testMessage {
+	var messages []testMessage
+	for {
+		if len(stream) < 8 || binary.LittleEndian.Uint32(stream) != continuationMarker {
+			t.Fatalf("missing continuation marker")
+		}
+		size := int(binary.LittleEndian.Uint32(stream[4:]))
+		if size == 0 {
+			if len(stream) != 8 {
+				t.Fatalf("unexpected data after end of stream")
+			}
+			return messages
+		}
+		if size%8 != 0 {
+			t.Fatalf("metadata size %d not padded", size)
+		}
+		message := fbRootReader(stream[8 : 8+size])
+		if v := message.scalar(0, 2); v != metadataV5 {
+			t.Fatalf("unexpected metadata version %d", v)
+		}
+		bodyLength := int(message.scalar(3, 8))
+		messages = append(messages, testMessage{
+			header: message.table(2),
+			typ:    uint8(message.scalar(1, 1)),
+			body:   stream[8+size : 8+size+bodyLength],
+		})
+		stream = stream[8+size+bodyLength:]
+	}
+}
+
+// decodeTestBatch decodes a record batch into rows of column values.
+func decodeTestBatch(t *testing.T, schema fbTableReader, m testMessage) [][]interface{} {
+	fields := schema.tables(1)
+	length := int(m.header.scalar(0, 8))
+	nodes := m.header.int64s(1)
+	buffers := m.header.int64s(2)
+	buffer := func(i int) []byte {
+		return m.body[buffers[2*i] : buffers[2*i]+buffers[2*i+1]]
+	}
+	rows := make([][]interface{}, length)
+	for r := range rows {
+		rows[r] = make([]interface{}, len(fields))
+	}
+	b := 0
+	for c, field := range fields {
+		if int(nodes[2*c]) != length {
+			t.Fatalf("unexpected node length %d", nodes[2*c])
+		}
+		validity := buffer(b)
+		b++
+		var offsets []byte
+		if field.scalar(2, 1) == typeUtf8 {
+			offsets = buffer(b)
+			b++
+		}
+		data := buffer(b)
+		b++
+		for r := 0; r < length; r++ {
+			if validity[r/8]&(1<<uint(r%8)) == 0 {
+				continue
+			}
+			switch field.scalar(2, 1) {
+			case typeInt:
+				rows[r][c] = int64(binary.LittleEndian.Uint64(data[8*r:]))
+			case typeFloatingPoint:
+				rows[r][c] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*r:]))
+			case typeBool:
+				rows[r][c] = data[r/8]&(1<<uint(r%8)) != 0
+			case typeUtf8:
+				start := binary.LittleEndian.Uint32(offsets[4*r:])
+				end := binary.LittleEndian.Uint32(offsets[4*(r+1):])
+				rows[r][c] = string(data[start:end])
+			}
+		}
+	}
+	return rows
+}
+
+func TestWriter(t *testing.T) {
+	w := NewWriter()
+	var buf bytes.Buffer
+	batches := [][]jstream.KVS{
+		{
+			{{Key: "id", Value: int64(1)}, {Key: "price", Value: 1.5}, {Key: "name", Value: "a"}, {Key: "ok", Value: true}},
+			{{Key: "name", Value: "bb"}, {Key: "id", Value: int64(2)}, {Key: "price", Value: int64(3)}, {Key: "ok", Value: false}},
+			{{Key: "id", Value: "x"}, {Key: "price", Value: nil}, {Key: "name", Value: int64(7)}, {Key: "extra", Value: 1.0}},
+		},
+		{
+			{{Key: "id", Value: float64(4)}, {Key: "name", Value: []interface{}{"c", 1.0}}},
+		},
+	}
+	for _, batch := range batches {
+		if err := w.WriteBatch(&buf, batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close(&buf)
+
+	messages := readTestStream(t, buf.Bytes())
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(messages))
+	}
+	if messages[0].typ != headerSchema {
+		t.Fatalf("expected schema message, got %d", messages[0].typ)
+	}
+	schema := messages[0].header
+	var names []string
+	var types []uint64
+	for _, field := range schema.tables(1) {
+		names = append(names, field.str(0))
+		types = append(types, field.scalar(2, 1))
+		if len(field.tables(5)) != 0 {
+			t.Fatalf("unexpected children")
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"id", "price", "name", "ok"}) {
+		t.Fatalf("unexpected fields %v", names)
+	}
+	if !reflect.DeepEqual(types, []uint64{typeInt, typeFloatingPoint, typeUtf8, typeBool}) {
+		t.Fatalf("unexpected types %v", types)
+	}
+
+	var rows [][]interface{}
+	for _, m := range messages[1:] {
+		if m.typ != headerRecordBatch {
+			t.Fatalf("expected record batch message, got %d", m.typ)
+		}
+		rows = append(rows, decodeTestBatch(t, schema, m)...)
+	}
+	expected := [][]interface{}{
+		{int64(1), 1.5, "a", true},
+		{int64(2), 3.0, "bb", false},
+		{nil, nil, "7", nil},
+		{int64(4), nil, `["c",1]`, nil},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	w := NewWriter()
+	var buf bytes.Buffer
+	if err := w.WriteBatch(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	w.Close(&buf)
+	messages := readTestStream(t, buf.Bytes())
+	if len(messages) != 1 || messages[0].typ != headerSchema {
+		t.Fatalf("expected a single schema message, got %v", messages)
+	}
+	if n := len(messages[0].header.tables(1)); n != 0 {
+		t.Fatalf("expected no fields, got %d", n)
+	}
+}
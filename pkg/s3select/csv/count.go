@@ -0,0 +1,138 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// CountReader - counts the records of a CSV object without parsing
+// them, for queries which only aggregate the number of records. No
+// records are returned by Read(), the count is returned by Stats().
+type CountReader struct {
+	args       *ReaderArgs
+	readCloser io.ReadCloser
+	buf        *bufio.Reader
+}
+
+// Read - returns io.EOF, records are only counted.
+func (r *CountReader) Read(dst sql.Record) (sql.Record, error) {
+	return nil, io.EOF
+}
+
+// Stats - counts the records of the object. As in Reader, records are
+// delimited by newlines, empty lines and comments are not counted.
+// Quoted fields may span lines, so from the first line containing the
+// quote character on, records are counted by the full CSV parser.
+func (r *CountReader) Stats() (sql.ObjectStats, error) {
+	var stats sql.ObjectStats
+	comment := []byte(string([]rune(r.args.CommentCharacter)[0]))
+	quote := []byte(r.args.QuoteCharacter)
+	skipHeader := r.args.FileHeaderInfo != none
+	for {
+		line, err := r.buf.ReadSlice('\n')
+		empty := len(line) == 0
+		blank := bytes.Equal(line, []byte{'\n'}) || bytes.Equal(line, []byte{'\r', '\n'})
+		commented := bytes.HasPrefix(line, comment)
+		if len(quote) > 0 && !blank && !commented &&
+			(bytes.Contains(line, quote) || err == bufio.ErrBufferFull) {
+			// Lines longer than the buffer can't be inspected whole
+			// for quotes, leave them to the parser as well.
+			n, err := r.countRecords(line, skipHeader)
+			stats.Rows += n
+			return stats, err
+		}
+		// Lines longer than the buffer are only inspected by their start.
+		for err == bufio.ErrBufferFull {
+			_, err = r.buf.ReadSlice('\n')
+		}
+		if err != nil && err != io.EOF {
+			return stats, errCSVParsingError(err)
+		}
+
+		switch {
+		case empty:
+		case skipHeader:
+			skipHeader = false
+		case blank, commented:
+		default:
+			stats.Rows++
+		}
+
+		if err == io.EOF {
+			return stats, nil
+		}
+	}
+}
+
+// countRecords - counts the remaining records with the full CSV parser,
+// starting with line which was already read from the buffer.
+func (r *CountReader) countRecords(line []byte, skipHeader bool) (n int64, err error) {
+	args := *r.args
+	// The record delimiter was already replaced with newlines.
+	args.RecordDelimiter = "\n"
+	if !skipHeader {
+		args.FileHeaderInfo = none
+	}
+	rest := io.MultiReader(bytes.NewReader(append([]byte(nil), line...)), r.buf)
+	reader, err := NewReader(ioutil.NopCloser(rest), &args)
+	if err != nil {
+		if err == io.EOF {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer reader.Close()
+
+	var record sql.Record
+	for {
+		if record, err = reader.Read(record); err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+		n++
+	}
+}
+
+// Close - closes underlying reader.
+func (r *CountReader) Close() error {
+	return r.readCloser.Close()
+}
+
+// NewCountReader - creates new CSV record counter using readCloser.
+func NewCountReader(readCloser io.ReadCloser, args *ReaderArgs) *CountReader {
+	csvIn := io.Reader(readCloser)
+	if args.RecordDelimiter != "\n" {
+		csvIn = &recordTransform{
+			reader:          readCloser,
+			recordDelimiter: []byte(args.RecordDelimiter),
+			oneByte:         make([]byte, len(args.RecordDelimiter)-1),
+		}
+	}
+	return &CountReader{
+		args:       args,
+		readCloser: readCloser,
+		buf:        bufio.NewReaderSize(csvIn, csvSplitSize),
+	}
+}
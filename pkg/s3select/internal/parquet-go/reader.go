@@ -116,6 +116,16 @@ func NewReader(getReaderFunc GetReaderFunc, columnNames set.StringSet) (*Reader,
 	}, nil
 }
 
+// RowGroups - returns the row groups metadata of the parquet file.
+func (reader *Reader) RowGroups() []*parquet.RowGroup {
+	return reader.rowGroups
+}
+
+// SchemaElements - returns the schema elements of the parquet file.
+func (reader *Reader) SchemaElements() []*parquet.SchemaElement {
+	return reader.schemaElements
+}
+
 // Read - reads single record.
 func (reader *Reader) Read() (record *Record, err error) {
 	if reader.rowGroupIndex >= len(reader.rowGroups) {
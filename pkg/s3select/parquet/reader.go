@@ -17,8 +17,10 @@
 package parquet
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/bcicen/jstream"
 	parquetgo "github.com/minio/minio/pkg/s3select/internal/parquet-go"
@@ -93,6 +95,158 @@ func (r *Reader) Read(dst sql.Record) (rec sql.Record, rerr error) {
 	return dstRec, nil
 }
 
+// Stats - returns the statistics of the records from the file metadata,
+// the minimum and maximum values are only known for numeric columns.
+func (r *Reader) Stats() (stats sql.ObjectStats, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic reading parquet metadata: %v", rec)
+		}
+	}()
+
+	// Only top level columns are reported, they follow the root element.
+	elements := r.reader.SchemaElements()
+	topLevel := make(map[string]*parquetgen.SchemaElement)
+	for i := 1; i < len(elements); i += schemaSize(elements, i) {
+		topLevel[elements[i].GetName()] = elements[i]
+	}
+
+	columns := make(map[string]*columnStats)
+	rowGroups := r.reader.RowGroups()
+	for _, rowGroup := range rowGroups {
+		stats.Rows += rowGroup.GetNumRows()
+		for _, chunk := range rowGroup.GetColumns() {
+			meta := chunk.GetMetaData()
+			if meta == nil || len(meta.GetPathInSchema()) != 1 {
+				continue
+			}
+			name := meta.GetPathInSchema()[0]
+			element, ok := topLevel[name]
+			if !ok || element.GetNumChildren() > 0 || element.GetRepetitionType() == parquetgen.FieldRepetitionType_REPEATED {
+				continue
+			}
+			c, ok := columns[name]
+			if !ok {
+				c = &columnStats{hasMinMax: true}
+				columns[name] = c
+			}
+			c.merge(element, meta, rowGroup.GetNumRows())
+		}
+	}
+
+	stats.Columns = make(map[string]sql.ColumnStats, len(columns))
+	for name, c := range columns {
+		// Columns missing from a row group are not reported.
+		if c.chunks != len(rowGroups) {
+			continue
+		}
+		stats.Columns[name] = c.toSQL()
+	}
+	return stats, nil
+}
+
+// schemaSize returns the number of elements of the schema subtree at i.
+func schemaSize(elements []*parquetgen.SchemaElement, i int) int {
+	n := 1
+	for c := int32(0); c < elements[i].GetNumChildren() && i+n < len(elements); c++ {
+		n += schemaSize(elements, i+n)
+	}
+	return n
+}
+
+// columnStats accumulates the statistics of the chunks of a column.
+type columnStats struct {
+	chunks    int
+	nullCount int64
+
+	// Minimum and maximum values, either int64 or float64.
+	hasMinMax bool
+	min, max  interface{}
+}
+
+func (c *columnStats) merge(element *parquetgen.SchemaElement, meta *parquetgen.ColumnMetaData, rows int64) {
+	c.chunks++
+	s := meta.GetStatistics()
+	if s == nil || s.NullCount == nil {
+		c.nullCount = -1
+		c.hasMinMax = false
+		return
+	}
+	if c.nullCount >= 0 {
+		c.nullCount += s.GetNullCount()
+	}
+
+	// A chunk of null values has no minimum and maximum.
+	if s.GetNullCount() >= rows {
+		return
+	}
+	min, max := statsValue(element, meta.GetType(), s.MinValue, s.Min), statsValue(element, meta.GetType(), s.MaxValue, s.Max)
+	if min == nil || max == nil {
+		c.hasMinMax = false
+		return
+	}
+	if c.min == nil || statsLess(min, c.min) {
+		c.min = min
+	}
+	if c.max == nil || statsLess(c.max, max) {
+		c.max = max
+	}
+}
+
+func (c *columnStats) toSQL() sql.ColumnStats {
+	s := sql.ColumnStats{NullCount: c.nullCount}
+	if !c.hasMinMax || c.min == nil {
+		return s
+	}
+	switch min := c.min.(type) {
+	case int64:
+		s.Min, s.Max = sql.FromInt(min), sql.FromInt(c.max.(int64))
+	case float64:
+		s.Min, s.Max = sql.FromFloat(min), sql.FromFloat(c.max.(float64))
+	}
+	return s
+}
+
+// statsValue decodes a minimum or maximum statistics value, the
+// deprecated value is used when value is not set. Unsigned integers
+// are not decoded as their deprecated statistics use signed order.
+func statsValue(element *parquetgen.SchemaElement, typ parquetgen.Type, value, deprecated []byte) interface{} {
+	if value == nil {
+		value = deprecated
+	}
+	switch element.GetConvertedType() {
+	case parquetgen.ConvertedType_UINT_8, parquetgen.ConvertedType_UINT_16,
+		parquetgen.ConvertedType_UINT_32, parquetgen.ConvertedType_UINT_64:
+		return nil
+	}
+	switch {
+	case typ == parquetgen.Type_INT32 && len(value) == 4:
+		return int64(int32(binary.LittleEndian.Uint32(value)))
+	case typ == parquetgen.Type_INT64 && len(value) == 8:
+		return int64(binary.LittleEndian.Uint64(value))
+	case typ == parquetgen.Type_FLOAT && len(value) == 4:
+		if f := float64(math.Float32frombits(binary.LittleEndian.Uint32(value))); !math.IsNaN(f) {
+			return f
+		}
+	case typ == parquetgen.Type_DOUBLE && len(value) == 8:
+		if f := math.Float64frombits(binary.LittleEndian.Uint64(value)); !math.IsNaN(f) {
+			return f
+		}
+	}
+	return nil
+}
+
+// statsLess returns whether a is less than b, both of the same type.
+func statsLess(a, b interface{}) bool {
+	switch a := a.(type) {
+	case int64:
+		return a < b.(int64)
+	case float64:
+		return a < b.(float64)
+	}
+	return false
+}
+
 // Close - closes underlying readers.
 func (r *Reader) Close() error {
 	return r.reader.Close()
@@ -30,6 +30,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/bcicen/jstream"
+	"github.com/minio/minio/pkg/s3select/arrow"
 	"github.com/minio/minio/pkg/s3select/csv"
 	"github.com/minio/minio/pkg/s3select/json"
 	"github.com/minio/minio/pkg/s3select/parquet"
@@ -45,10 +47,18 @@ type recordReader interface {
 	Close() error
 }
 
+// statsReader is implemented by record readers which know the
+// statistics of the records without reading them, aggregations
+// are then computed from these statistics when possible.
+type statsReader interface {
+	Stats() (sql.ObjectStats, error)
+}
+
 const (
 	csvFormat     = "csv"
 	jsonFormat    = "json"
 	parquetFormat = "parquet"
+	arrowFormat   = "arrow"
 )
 
 // CompressionType - represents value inside <CompressionType/> in request XML.
@@ -159,8 +169,9 @@ func (input *InputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartEle
 
 // OutputSerialization - represents elements inside <OutputSerialization/> in request XML.
 type OutputSerialization struct {
-	CSVArgs     csv.WriterArgs  `xml:"CSV"`
-	JSONArgs    json.WriterArgs `xml:"JSON"`
+	CSVArgs     csv.WriterArgs   `xml:"CSV"`
+	JSONArgs    json.WriterArgs  `xml:"JSON"`
+	ArrowArgs   arrow.WriterArgs `xml:"Arrow"`
 	unmarshaled bool
 	format      string
 }
@@ -188,8 +199,12 @@ func (output *OutputSerialization) UnmarshalXML(d *xml.Decoder, start xml.StartE
 		parsedOutput.format = jsonFormat
 		found++
 	}
+	if !parsedOutput.ArrowArgs.IsEmpty() {
+		parsedOutput.format = arrowFormat
+		found++
+	}
 	if found != 1 {
-		return errObjectSerializationConflict(fmt.Errorf("either CSV or JSON should be present in OutputSerialization"))
+		return errObjectSerializationConflict(fmt.Errorf("either CSV, JSON or Arrow should be present in OutputSerialization"))
 	}
 
 	*output = OutputSerialization(parsedOutput)
@@ -271,7 +286,7 @@ func (s3Select *S3Select) outputRecord() sql.Record {
 	switch s3Select.Output.format {
 	case csvFormat:
 		return csv.NewRecord()
-	case jsonFormat:
+	case jsonFormat, arrowFormat:
 		return json.NewRecord(sql.SelectFmtJSON)
 	}
 
@@ -302,6 +317,12 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 			return err
 		}
 
+		// Records only need to be counted, not parsed.
+		if s3Select.statement.IsRowCount() {
+			s3Select.recordReader = csv.NewCountReader(s3Select.progressReader, &s3Select.Input.CSVArgs)
+			return nil
+		}
+
 		s3Select.recordReader, err = csv.NewReader(s3Select.progressReader, &s3Select.Input.CSVArgs)
 		if err != nil {
 			rc.Close()
@@ -394,6 +415,50 @@ func (s3Select *S3Select) marshal(buf *bytes.Buffer, record sql.Record) error {
 	panic(fmt.Errorf("unknown output format '%v'", s3Select.Output.format))
 }
 
+// recordKVS returns the columns of an output record, in order.
+func recordKVS(record sql.Record) (jstream.KVS, error) {
+	switch r := record.(type) {
+	case *json.Record:
+		return r.KVS, nil
+	case *simdj.Record:
+		dst, err := r.CloneTo(nil)
+		if err != nil {
+			return nil, err
+		}
+		return dst.(*json.Record).KVS, nil
+	}
+
+	// Fall back to decoding the JSON representation of the record.
+	var buf bytes.Buffer
+	if err := record.WriteJSON(&buf); err != nil {
+		return nil, err
+	}
+	d := jstream.NewDecoder(&buf, 0).ObjectAsKVS()
+	var kvs jstream.KVS
+	for v := range d.Stream() {
+		if v.ValueType == jstream.Object {
+			kvs, _ = v.Value.(jstream.KVS)
+		}
+	}
+	return kvs, d.Err()
+}
+
+// marshalArrow encodes records as a single Arrow record batch.
+func marshalArrow(buf *bytes.Buffer, w *arrow.Writer, records []sql.Record) error {
+	rows := make([]jstream.KVS, 0, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		kvs, err := recordKVS(record)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, kvs)
+	}
+	return w.WriteBatch(buf, rows)
+}
+
 // Evaluate - filters and sends records read from opened reader as per select statement to http response writer.
 func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 	getProgressFunc := s3Select.getProgress
@@ -410,24 +475,42 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 	} else {
 		outputQueue = make([]sql.Record, 0, 100)
 	}
+
+	// Arrow output is a single IPC stream spanning all the records
+	// messages, each batch of output records is a record batch.
+	var arrowWriter *arrow.Writer
+	if s3Select.Output.format == arrowFormat {
+		arrowWriter = arrow.NewWriter()
+	}
+
 	var err error
-	sendRecord := func() bool {
+	sendRecord := func(last bool) bool {
 		buf := bufPool.Get().(*bytes.Buffer)
 		buf.Reset()
 
-		for _, outputRecord := range outputQueue {
-			if outputRecord == nil {
-				continue
-			}
-			before := buf.Len()
-			if err = s3Select.marshal(buf, outputRecord); err != nil {
+		if arrowWriter != nil {
+			if err = marshalArrow(buf, arrowWriter, outputQueue); err != nil {
 				bufPool.Put(buf)
 				return false
 			}
-			if buf.Len()-before > maxRecordSize {
-				writer.FinishWithError("OverMaxRecordSize", "The length of a record in the input or result is greater than maxCharsPerRecord of 1 MB.")
-				bufPool.Put(buf)
-				return false
+			if last {
+				arrowWriter.Close(buf)
+			}
+		} else {
+			for _, outputRecord := range outputQueue {
+				if outputRecord == nil {
+					continue
+				}
+				before := buf.Len()
+				if err = s3Select.marshal(buf, outputRecord); err != nil {
+					bufPool.Put(buf)
+					return false
+				}
+				if buf.Len()-before > maxRecordSize {
+					writer.FinishWithError("OverMaxRecordSize", "The length of a record in the input or result is greater than maxCharsPerRecord of 1 MB.")
+					bufPool.Put(buf)
+					return false
+				}
 			}
 		}
 
@@ -441,11 +524,23 @@ func (s3Select *S3Select) Evaluate(w http.ResponseWriter) {
 		return true
 	}
 
+	// Aggregations computed from the statistics of the
+	// records do not need the records to be read.
+	var statsAggregated bool
+	if r, ok := s3Select.recordReader.(statsReader); ok && s3Select.statement.IsAggregated() {
+		var stats sql.ObjectStats
+		if stats, err = r.Stats(); err != nil {
+			_ = writer.FinishWithError("InternalError", err.Error())
+			return
+		}
+		statsAggregated = s3Select.statement.AggregateStats(stats)
+	}
+
 	var rec sql.Record
 OuterLoop:
 	for {
 		if s3Select.statement.LimitReached() {
-			if !sendRecord() {
+			if !sendRecord(true) {
 				break
 			}
 			if err = writer.Finish(s3Select.getProgress()); err != nil {
@@ -455,7 +550,12 @@ OuterLoop:
 			break
 		}
 
-		if rec, err = s3Select.recordReader.Read(rec); err != nil {
+		if statsAggregated {
+			err = io.EOF
+		} else {
+			rec, err = s3Select.recordReader.Read(rec)
+		}
+		if err != nil {
 			if err != io.EOF {
 				break
 			}
@@ -468,7 +568,7 @@ OuterLoop:
 				outputQueue = append(outputQueue, outputRecord)
 			}
 
-			if !sendRecord() {
+			if !sendRecord(true) {
 				break
 			}
 
@@ -485,6 +585,10 @@ OuterLoop:
 		}
 
 		for _, inputRecord := range inputRecords {
+			// A single record may expand to more records than the limit.
+			if s3Select.statement.LimitReached() {
+				continue OuterLoop
+			}
 			if s3Select.statement.IsAggregated() {
 				if err = s3Select.statement.AggregateRow(*inputRecord); err != nil {
 					break OuterLoop
@@ -520,7 +624,7 @@ OuterLoop:
 					continue
 				}
 
-				if !sendRecord() {
+				if !sendRecord(false) {
 					break OuterLoop
 				}
 			}
@@ -19,6 +19,7 @@ package s3select
 import (
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -672,6 +673,96 @@ func TestCSVQueries2(t *testing.T) {
 	}
 }
 
+func TestArrowOutput(t *testing.T) {
+	input := `id,num,text
+1,7867786,"a text, with comma"
+2,-5,
+`
+	var testTable = []struct {
+		name    string
+		query   string
+		columns []string
+	}{
+		{
+			name:    "select-all",
+			query:   `SELECT * from s3object s`,
+			columns: []string{"id", "num", "text"},
+		},
+		{
+			name:    "select-cast",
+			query:   `SELECT CAST(num AS INT) AS n, text from s3object s WHERE id = 2`,
+			columns: []string{"n", "text"},
+		},
+		{
+			name:  "select-none",
+			query: `SELECT id from s3object s WHERE id = 3`,
+		},
+	}
+
+	defRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        <CSV>
+        	<FileHeaderInfo>USE</FileHeaderInfo>
+        </CSV>
+    </InputSerialization>
+    <OutputSerialization>
+        <Arrow/>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>`
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			s3Select, err := NewS3Select(bytes.NewReader([]byte(fmt.Sprintf(defRequest, testCase.query))))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = s3Select.Open(func(offset, length int64) (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewBufferString(input)), nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			w := &testResponseWriter{}
+			s3Select.Evaluate(w)
+			s3Select.Close()
+			resp := http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+				ContentLength: int64(len(w.response)),
+			}
+			res, err := minio.NewSelectResults(&resp, "testbucket")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The stream starts with the schema message and ends with the end of stream marker.
+			if !bytes.HasPrefix(got, []byte{0xff, 0xff, 0xff, 0xff}) {
+				t.Fatalf("missing continuation marker: %x", got)
+			}
+			if !bytes.HasSuffix(got, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}) {
+				t.Fatalf("missing end of stream marker: %x", got)
+			}
+			for _, column := range testCase.columns {
+				if !bytes.Contains(got, []byte(column+"\x00")) {
+					t.Errorf("column %s not found in schema", column)
+				}
+			}
+		})
+	}
+}
+
 func TestCSVInput(t *testing.T) {
 	var testTable = []struct {
 		requestXML     []byte
@@ -1059,3 +1150,118 @@ func TestParquetInput(t *testing.T) {
 		})
 	}
 }
+
+func TestScanShortCircuit(t *testing.T) {
+	os.Setenv("MINIO_API_SELECT_PARQUET", "on")
+	defer os.Setenv("MINIO_API_SELECT_PARQUET", "off")
+
+	csvInput := "id,value\n1,a\n\n2,b\r\n# comment\n3,c"
+	jsonInput := `{"a":[{"x":1},{"x":2},{"x":3}]}`
+
+	var testTable = []struct {
+		name       string
+		input      string
+		format     string
+		query      string
+		wantResult string
+	}{
+		{
+			name:       "csv-count",
+			input:      csvInput,
+			format:     `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`,
+			query:      `SELECT COUNT(*) FROM S3Object`,
+			wantResult: "3",
+		},
+		{
+			name:       "csv-count-where",
+			input:      csvInput,
+			format:     `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`,
+			query:      `SELECT COUNT(*) FROM S3Object s WHERE s.id > 1`,
+			wantResult: "2",
+		},
+		{
+			name:       "csv-count-quoted-newline",
+			input:      "id,value\n1,\"line one\nline two\"\n2,\"say \"\"hi\"\"\"\n\n3,c",
+			format:     `<CSV><FileHeaderInfo>USE</FileHeaderInfo></CSV>`,
+			query:      `SELECT COUNT(*) FROM S3Object`,
+			wantResult: "3",
+		},
+		{
+			name:       "parquet-stats",
+			format:     `<Parquet/>`,
+			query:      `SELECT COUNT(*), COUNT(s.one), MIN(one), MAX(s.one) FROM S3Object s`,
+			wantResult: "3,2,-1,2.5",
+		},
+		{
+			name:       "json-limit",
+			input:      jsonInput,
+			format:     `<JSON><Type>DOCUMENT</Type></JSON>`,
+			query:      `SELECT s.x FROM S3Object[*].a[*] s LIMIT 2`,
+			wantResult: "1\n2",
+		},
+	}
+
+	defRequest := `<?xml version="1.0" encoding="UTF-8"?>
+<SelectObjectContentRequest>
+    <Expression>%s</Expression>
+    <ExpressionType>SQL</ExpressionType>
+    <InputSerialization>
+        <CompressionType>NONE</CompressionType>
+        %s
+    </InputSerialization>
+    <OutputSerialization>
+        <CSV/>
+    </OutputSerialization>
+    <RequestProgress>
+        <Enabled>FALSE</Enabled>
+    </RequestProgress>
+</SelectObjectContentRequest>`
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			getReader := func(offset, length int64) (io.ReadCloser, error) {
+				if testCase.input != "" {
+					return ioutil.NopCloser(bytes.NewBufferString(testCase.input)), nil
+				}
+				// Only the parquet footer is expected to be read.
+				if offset >= 0 {
+					return nil, errors.New("unexpected parquet data read")
+				}
+				data, err := ioutil.ReadFile("testdata.parquet")
+				if err != nil {
+					return nil, err
+				}
+				offset += int64(len(data))
+				return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+			}
+
+			s3Select, err := NewS3Select(bytes.NewReader([]byte(fmt.Sprintf(defRequest, testCase.query, testCase.format))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = s3Select.Open(getReader); err != nil {
+				t.Fatal(err)
+			}
+
+			w := &testResponseWriter{}
+			s3Select.Evaluate(w)
+			s3Select.Close()
+			resp := http.Response{
+				StatusCode:    http.StatusOK,
+				Body:          ioutil.NopCloser(bytes.NewReader(w.response)),
+				ContentLength: int64(len(w.response)),
+			}
+			res, err := minio.NewSelectResults(&resp, "testbucket")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotS := strings.TrimSpace(string(got)); gotS != testCase.wantResult {
+				t.Errorf("Query: %s\ngot: %q\nwant: %q", testCase.query, gotS, testCase.wantResult)
+			}
+		})
+	}
+}
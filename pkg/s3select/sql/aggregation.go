@@ -286,6 +286,71 @@ func (e *FuncExpr) aggregateRow(r Record) error {
 	return nil
 }
 
+// statsColumn returns the statistics of the column aggregated by
+// e, if the aggregation can be computed from stats.
+func (e *FuncExpr) statsColumn(stats ObjectStats) (ColumnStats, bool) {
+	var arg *Expression
+	switch e.getFunctionName() {
+	case aggFnCount:
+		arg = e.Count.ExprArg
+	case aggFnMin, aggFnMax:
+		if len(e.SFunc.ArgsList) != 1 {
+			return ColumnStats{}, false
+		}
+		arg = e.SFunc.ArgsList[0]
+	default:
+		return ColumnStats{}, false
+	}
+	name, ok := getColumnName(arg)
+	if !ok {
+		return ColumnStats{}, false
+	}
+	c, ok := stats.Columns[name]
+	return c, ok
+}
+
+// canAggregateStats returns whether the aggregation can be computed
+// from the object statistics.
+func (e *FuncExpr) canAggregateStats(stats ObjectStats) bool {
+	if e.Count != nil && e.Count.StarArg {
+		return true
+	}
+	c, ok := e.statsColumn(stats)
+	if !ok {
+		return false
+	}
+	if e.getFunctionName() == aggFnCount {
+		return c.NullCount >= 0
+	}
+	return (c.Min != nil && c.Max != nil) || c.NullCount == stats.Rows
+}
+
+// aggregateStats computes the aggregation from the object statistics,
+// canAggregateStats must have returned true.
+func (e *FuncExpr) aggregateStats(stats ObjectStats) {
+	if e.Count != nil && e.Count.StarArg {
+		e.aggregate.runningCount = stats.Rows
+		return
+	}
+	c, _ := e.statsColumn(stats)
+	switch e.getFunctionName() {
+	case aggFnCount:
+		e.aggregate.runningCount = stats.Rows - c.NullCount
+	case aggFnMin:
+		if c.Min != nil {
+			v := *c.Min
+			e.aggregate.runningMin = &v
+			e.aggregate.seen = true
+		}
+	case aggFnMax:
+		if c.Max != nil {
+			v := *c.Max
+			e.aggregate.runningMax = &v
+			e.aggregate.seen = true
+		}
+	}
+}
+
 // getAggregate() implementation for each AST node follows. This is
 // called after calling aggregateRow() on each input row, to calculate
 // the final aggregate result.
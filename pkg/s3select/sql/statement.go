@@ -242,6 +242,62 @@ func (e *SelectStatement) AggregateResult(output Record) error {
 	return nil
 }
 
+// ColumnStats - statistics of the values of a column.
+type ColumnStats struct {
+	// Minimum and maximum of the non-null values, nil if unknown.
+	Min, Max *Value
+
+	// Number of null values, -1 if unknown.
+	NullCount int64
+}
+
+// ObjectStats - statistics of the records of an object, as known from
+// the object metadata without reading the records.
+type ObjectStats struct {
+	Rows    int64
+	Columns map[string]ColumnStats
+}
+
+// AggregateStats - computes the aggregations from the statistics of
+// the object instead of its records. This is only possible for queries
+// without a WHERE clause, whose expressions are all COUNT(*), or COUNT,
+// MIN or MAX of a column with known statistics. Returns false when the
+// records must be aggregated.
+func (e *SelectStatement) AggregateStats(stats ObjectStats) bool {
+	funcs := e.statsAggregations(stats)
+	if funcs == nil {
+		return false
+	}
+	for _, fn := range funcs {
+		fn.aggregateStats(stats)
+	}
+	return true
+}
+
+// IsRowCount - returns whether the statement only aggregates the
+// number of records, e.g. "SELECT COUNT(*) FROM S3Object".
+func (e *SelectStatement) IsRowCount() bool {
+	return e.statsAggregations(ObjectStats{}) != nil
+}
+
+// statsAggregations returns the aggregations of the statement if they
+// can all be computed from stats, nil otherwise.
+func (e *SelectStatement) statsAggregations(stats ObjectStats) []*FuncExpr {
+	if !e.IsAggregated() || e.selectAST.Where != nil || e.selectAST.From.HasKeypath() {
+		return nil
+	}
+
+	funcs := make([]*FuncExpr, 0, len(e.selectAST.Expression.Expressions))
+	for _, expr := range e.selectAST.Expression.Expressions {
+		primary := getPrimaryTerm(expr.Expression)
+		if primary == nil || primary.FuncCall == nil || !primary.FuncCall.canAggregateStats(stats) {
+			return nil
+		}
+		funcs = append(funcs, primary.FuncCall)
+	}
+	return funcs
+}
+
 func (e *SelectStatement) isPassingWhereClause(input Record) (bool, error) {
 	if e.selectAST.Where == nil {
 		return true, nil
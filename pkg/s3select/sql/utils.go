@@ -105,6 +105,42 @@ func getLastKeypathComponent(e *Expression) (string, bool) {
 	return ps, true
 }
 
+// getPrimaryTerm returns the primary term of the given expression, if
+// the expression is made of a single term without operators.
+func getPrimaryTerm(e *Expression) *PrimaryTerm {
+	if len(e.And) != 1 ||
+		len(e.And[0].Condition) != 1 ||
+		e.And[0].Condition[0].Not != nil ||
+		e.And[0].Condition[0].Operand.ConditionRHS != nil {
+		return nil
+	}
+
+	operand := e.And[0].Condition[0].Operand.Operand
+	if operand.Right != nil ||
+		operand.Left.Right != nil ||
+		operand.Left.Left.Negated != nil {
+		return nil
+	}
+	return operand.Left.Left.Primary
+}
+
+// getColumnName returns the column name of the given expression, if
+// the expression is a column with an optional table alias.
+func getColumnName(e *Expression) (string, bool) {
+	primary := getPrimaryTerm(e)
+	if primary == nil || primary.JPathExpr == nil {
+		return "", false
+	}
+	jpath := primary.JPathExpr
+	switch {
+	case len(jpath.PathExpr) == 0:
+		return jpath.BaseKey.String(), true
+	case len(jpath.PathExpr) == 1 && jpath.PathExpr[0].Key != nil:
+		return jpath.PathExpr[0].Key.keyString(), true
+	}
+	return "", false
+}
+
 // HasKeypath returns if the from clause has a key path -
 // e.g. S3object[*].id
 func (from *TableExpression) HasKeypath() bool {